@@ -0,0 +1,296 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/audit"
+	"github.com/the20100/gads-cli/internal/config"
+	"github.com/the20100/gads-cli/internal/gaql"
+	"github.com/the20100/gads-cli/internal/output"
+)
+
+var accountsLinkCmd = &cobra.Command{
+	Use:   "link",
+	Short: "Invite client accounts to the MCC and manage the invitations",
+	Long: `Invite client accounts to be managed by the configured Manager Account (MCC),
+list the links and invitations, and cancel pending invitations. The client
+accepts an invitation in the Google Ads web interface.`,
+}
+
+// linkKeyRe matches the <clientId>~<managerLinkId> key of a customer client
+// link.
+var linkKeyRe = regexp.MustCompile(`^\d+~\d+$`)
+
+// managerAccount returns the manager account the link commands act from.
+func managerAccount() (string, error) {
+	creds, err := config.Load()
+	if err != nil {
+		return "", fmt.Errorf("loading credentials: %w", err)
+	}
+	mccID, err := loginCustomerID(creds)
+	if err != nil {
+		return "", err
+	}
+	if mccID == "" {
+		return "", fmt.Errorf("manager account not set — run: gads-cli auth login --manager-account=<id>")
+	}
+	return mccID, nil
+}
+
+// linkErrorMessages explain the customer client link errors a manager can
+// run into, by error code without its category.
+var linkErrorMessages = map[string]string{
+	"CLIENT_ALREADY_INVITED_BY_THIS_MANAGER":    "account %s already has a pending invitation from manager %s",
+	"ALREADY_INVITED_BY_THIS_MANAGER":           "account %s already has a pending invitation from manager %s",
+	"CLIENT_ALREADY_MANAGED_IN_HIERARCHY":       "account %s is already managed by manager %s or one of its sub-managers",
+	"ALREADY_MANAGED_BY_THIS_MANAGER":           "account %s is already managed by manager %s",
+	"ALREADY_MANAGED_IN_HIERARCHY":              "account %s is already managed by manager %s or one of its sub-managers",
+	"CYCLIC_LINK_NOT_ALLOWED":                   "account %s manages manager %s, directly or not: the link would make a cycle",
+	"CLIENT_HAS_TOO_MANY_INVITATIONS":           "account %s has too many pending invitations; cancel some before inviting it from manager %s",
+	"CLIENT_HAS_TOO_MANY_MANAGERS":              "account %s already has the most managers allowed, so manager %s cannot be added",
+	"CUSTOMER_HAS_TOO_MANY_ACCOUNTS_AT_MANAGER": "manager %[2]s cannot take account %[1]s: it has the most client accounts allowed",
+	"CUSTOMER_HAS_TOO_MANY_ACCOUNTS":            "manager %[2]s cannot take account %[1]s: it has the most client accounts allowed",
+}
+
+// describeLinkError explains a customer client link error between client
+// and manager in plain words, keeping the API error (and its exit code).
+func describeLinkError(err error, client, manager string) error {
+	var gErr *api.GoogleAdsError
+	if !errors.As(err, &gErr) {
+		return err
+	}
+	_, reason, _ := strings.Cut(gErr.Code, ".")
+	if msg, ok := linkErrorMessages[reason]; ok {
+		return fmt.Errorf(msg+": %w", client, manager, err)
+	}
+	return err
+}
+
+// ---- accounts link send ----
+
+func newAccountsLinkSendCmd() *cobra.Command {
+	var client string
+	c := &cobra.Command{
+		Use:   "send",
+		Short: "Invite a client account to be managed by the MCC",
+		Long: `Send an invitation from the configured Manager Account (MCC) to manage a
+client account. The link is PENDING until the client accepts it in the
+Google Ads web interface; see accounts link list.
+
+Examples:
+  gads-cli accounts link send --client=1234567890
+  gads-cli accounts link send --client=123-456-7890 --dry-run`,
+		Annotations: mutatingCommand,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mccID, err := managerAccount()
+			if err != nil {
+				return err
+			}
+			clientID, err := resolveAccount(client, accountAliases)
+			if err != nil {
+				return fmt.Errorf("--client: %w", err)
+			}
+			if clientID == mccID {
+				return fmt.Errorf("--client is the manager account itself")
+			}
+
+			change := fmt.Sprintf("Manager %s: invite account %s", mccID, clientID)
+			if ok, err := confirmChange("Send this invitation?", change); !ok {
+				return err
+			}
+			recordUndo("customerClientLinks", audit.NotUndoable("cancel the invitation with accounts link cancel"))
+			resourceName, err := apiClient.MutateCustomerClientLink(mccID, map[string]any{
+				"create": map[string]any{
+					"clientCustomer": "customers/" + clientID,
+					"status":         "PENDING",
+				},
+			})
+			if err != nil {
+				return describeLinkError(err, clientID, mccID)
+			}
+			if apiClient.ValidateOnly() {
+				fmt.Println("DRY RUN — would have applied 1 operation(s)")
+				return nil
+			}
+			if jsonResult() {
+				res := newMutateResult()
+				res.add(resourceName)
+				return writeMutateResult(res)
+			}
+			fmt.Printf("Invitation sent to account %s (link: %s).\n", clientID, api.ResourceID(resourceName))
+			return nil
+		},
+	}
+	c.Flags().StringVar(&client, "client", "", "Client account to invite, as an ID or an alias (required)")
+	c.MarkFlagRequired("client")
+	return c
+}
+
+// ---- accounts link list ----
+
+func newAccountsLinkListCmd() *cobra.Command {
+	var pending bool
+	c := &cobra.Command{
+		Use:   "list",
+		Short: "List the MCC's client links and invitations",
+		Long: `List the links between the configured Manager Account (MCC) and its client
+accounts, with their status:
+  PENDING   invitation sent, not answered yet
+  ACTIVE    the client accepted: the MCC manages the account
+  REFUSED   the client declined the invitation
+  CANCELED  the invitation was canceled by the manager
+  INACTIVE  the link was ended
+
+LINK is the <clientId>~<managerLinkId> key that accounts link cancel takes.
+
+Examples:
+  gads-cli accounts link list
+  gads-cli accounts link list --pending --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mccID, err := managerAccount()
+			if err != nil {
+				return err
+			}
+			query := gaql.Select(
+				"customer_client_link.resource_name", "customer_client_link.client_customer",
+				"customer_client_link.manager_link_id", "customer_client_link.status",
+				"customer_client_link.hidden").
+				From("customer_client_link").
+				WhereIf(pending, "customer_client_link.status = 'PENDING'").
+				String()
+			rows, err := apiClient.Search(mccID, query)
+			if err != nil {
+				return err
+			}
+			links := []api.CustomerClientLink{}
+			for _, raw := range rows {
+				var row api.CustomerClientLinkRow
+				if err := json.Unmarshal(raw, &row); err != nil {
+					continue
+				}
+				links = append(links, row.CustomerClientLink)
+			}
+
+			if output.IsQuiet() {
+				ids := make([]string, len(links))
+				for i, l := range links {
+					ids[i] = api.ResourceID(l.ResourceName)
+				}
+				return output.PrintIDs(ids)
+			}
+			if output.IsJSON(cmd) {
+				return output.PrintJSON(links, output.IsPretty(cmd))
+			}
+			if len(links) == 0 {
+				if pending {
+					fmt.Println("No pending invitations.")
+				} else {
+					fmt.Println("No client links found.")
+				}
+				return nil
+			}
+
+			headers := []string{"CLIENT", "STATUS", "LINK", "HIDDEN"}
+			tableRows := make([][]string, len(links))
+			for i, l := range links {
+				hidden := ""
+				if l.Hidden {
+					hidden = "yes"
+				}
+				tableRows[i] = []string{
+					api.ResourceID(l.ClientCustomer),
+					output.Colorize(l.Status),
+					api.ResourceID(l.ResourceName),
+					hidden,
+				}
+			}
+			return output.PrintTable(headers, tableRows)
+		},
+	}
+	c.Flags().BoolVar(&pending, "pending", false, "Only list invitations not answered yet")
+	return c
+}
+
+// ---- accounts link cancel ----
+
+func newAccountsLinkCancelCmd() *cobra.Command {
+	var link string
+	c := &cobra.Command{
+		Use:   "cancel",
+		Short: "Cancel a pending invitation",
+		Long: `Cancel an invitation that the client account has not answered yet. --link
+is the LINK key of accounts link list (<clientId>~<managerLinkId>) or the
+link's resource name.
+
+Examples:
+  gads-cli accounts link cancel --link=1234567890~555666777`,
+		Annotations: mutatingCommand,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mccID, err := managerAccount()
+			if err != nil {
+				return err
+			}
+			key := link
+			if strings.Contains(link, "/") {
+				key = api.ResourceID(link)
+			}
+			if !linkKeyRe.MatchString(key) {
+				return fmt.Errorf("--link must be <clientId>~<managerLinkId> or a customer client link resource name")
+			}
+			clientID, managerLinkID, _ := strings.Cut(key, "~")
+			resourceName := api.CustomerClientLinkResourceName(mccID, clientID, managerLinkID)
+
+			rows, err := apiClient.Search(mccID, gaql.Select("customer_client_link.resource_name", "customer_client_link.status").
+				From("customer_client_link").
+				Where("customer_client_link.resource_name = "+gaql.Quote(resourceName)).
+				String())
+			if err != nil {
+				return err
+			}
+			if len(rows) == 0 {
+				return fmt.Errorf("no link %s under manager %s — see: gads-cli accounts link list", key, mccID)
+			}
+			var row api.CustomerClientLinkRow
+			if err := json.Unmarshal(rows[0], &row); err != nil {
+				return fmt.Errorf("parsing response: %w", err)
+			}
+			if status := row.CustomerClientLink.Status; status != "PENDING" {
+				return fmt.Errorf("the invitation to account %s is %s, not PENDING: only pending invitations can be canceled", clientID, status)
+			}
+
+			change := fmt.Sprintf("Manager %s: invitation to account %s PENDING → CANCELED", mccID, clientID)
+			if ok, err := confirmChange("Cancel this invitation?", change); !ok {
+				return err
+			}
+			recordUndo("customerClientLinks", audit.NotUndoable("send a new invitation with accounts link send"))
+			if _, err := apiClient.MutateCustomerClientLink(mccID, updateOperation(resourceName, map[string]any{"status": "CANCELED"})); err != nil {
+				return describeLinkError(err, clientID, mccID)
+			}
+			if apiClient.ValidateOnly() {
+				fmt.Println("DRY RUN — would have applied 1 operation(s)")
+				return nil
+			}
+			if jsonResult() {
+				res := newMutateResult()
+				res.add(resourceName)
+				return writeMutateResult(res)
+			}
+			fmt.Printf("Invitation to account %s canceled.\n", clientID)
+			return nil
+		},
+	}
+	c.Flags().StringVar(&link, "link", "", "Link to cancel: <clientId>~<managerLinkId> or its resource name (required)")
+	c.MarkFlagRequired("link")
+	return c
+}
+
+func init() {
+	accountsLinkCmd.AddCommand(newAccountsLinkSendCmd(), newAccountsLinkListCmd(), newAccountsLinkCancelCmd())
+	accountsCmd.AddCommand(accountsLinkCmd)
+}
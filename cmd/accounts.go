@@ -1,12 +1,18 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/the20100/gads-cli/internal/api"
 	"github.com/the20100/gads-cli/internal/config"
+	"github.com/the20100/gads-cli/internal/gaql"
 	"github.com/the20100/gads-cli/internal/output"
 )
 
@@ -15,27 +21,49 @@ var accountsCmd = &cobra.Command{
 	Short: "Manage Google Ads accounts",
 }
 
-var accountsVerbose bool
+var (
+	accountsVerbose   bool
+	accountsWithSpend bool
+	accountsSpendDays int
+
+	accountsManagerOnly     bool
+	accountsClientsOnly     bool
+	accountsIncludeManagers bool
+	accountsIncludeHidden   bool
+	accountsName            string
+)
 
 var accountsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List accessible customer accounts under the MCC",
 	Long: `List all customer accounts accessible under the configured Manager Account (MCC).
 
+Client accounts are listed; sub-manager accounts are excluded unless
+--include-managers (or --manager-only) is set, and hidden accounts unless
+--include-hidden is set. Filters compose and apply to table and JSON output.
+
 Examples:
   gads-cli accounts list
   gads-cli accounts list --json
-  gads-cli accounts list --verbose`,
+  gads-cli accounts list --verbose
+  gads-cli accounts list --name=acme
+  gads-cli accounts list --include-managers
+  gads-cli accounts list --manager-only --include-hidden
+  gads-cli accounts list --with-spend
+  gads-cli accounts list --with-spend --days=7`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		from, err := apiClient.ListAccessibleCustomers()
 		if err != nil {
 			return err
 		}
 
-		creds, _ := config.Load()
-		mccID := ""
-		if creds != nil {
-			mccID = api.CleanCustomerID(creds.ManagerCustomerID)
+		creds, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("loading credentials: %w", err)
+		}
+		mccID, err := loginCustomerID(creds)
+		if err != nil {
+			return err
 		}
 
 		if accountsVerbose {
@@ -50,16 +78,16 @@ Examples:
 		var accounts []api.CustomerClient
 
 		// Strategy 1: customer_client GAQL query (efficient, single call)
-		if mccID != "" {
-			query := `SELECT customer_client.id, customer_client.descriptive_name,
-				customer_client.currency_code, customer_client.time_zone,
-				customer_client.manager, customer_client.level, customer_client.hidden,
-				customer_client.test_account
-			FROM customer_client
-			ORDER BY customer_client.id`
-
-			rows, searchErr := apiClient.Search(mccID, query)
+		ccQuery := customerClientQuery()
+		mccFailed := mccID == ""
+		if mccID == "" {
+			fmt.Fprintln(os.Stderr, "Warning: no manager account configured — listing accessible accounts individually.")
+		} else {
+			rows, searchErr := apiClient.Search(mccID, ccQuery)
 			if searchErr != nil {
+				mccFailed = true
+				fmt.Fprintf(os.Stderr, "Warning: could not list accounts under manager %s: %s\n", mccID, describeMCCError(searchErr))
+				fmt.Fprintln(os.Stderr, "Falling back to querying each accessible account individually.")
 				if accountsVerbose {
 					fmt.Printf("[strategy 1] customer_client query failed: %v\n\n", searchErr)
 				}
@@ -80,7 +108,7 @@ Examples:
 							i, row.CustomerClient.ID, row.CustomerClient.DescriptiveName,
 							row.CustomerClient.Manager, row.CustomerClient.Level)
 					}
-					if !row.CustomerClient.Manager {
+					if clientMatchesFilters(row.CustomerClient) {
 						accounts = append(accounts, row.CustomerClient)
 					}
 				}
@@ -93,7 +121,7 @@ Examples:
 		// Strategy 2: query each accessible customer individually (fallback).
 		// For top-level accounts not under the configured MCC, retry using
 		// the account's own ID as the login-customer-id.
-		if len(accounts) == 0 && len(from) > 0 {
+		if mccFailed && len(from) > 0 {
 			if accountsVerbose {
 				fmt.Println("[strategy 2] falling back to per-customer queries")
 			}
@@ -106,7 +134,11 @@ Examples:
 					continue // skip the MCC itself
 				}
 
-				q := `SELECT customer.id, customer.descriptive_name, customer.currency_code, customer.time_zone, customer.manager, customer.test_account FROM customer`
+				q := gaql.Select(
+					"customer.id", "customer.descriptive_name", "customer.currency_code",
+					"customer.time_zone", "customer.manager", "customer.test_account").
+					From("customer").
+					String()
 
 				rows, qErr := apiClient.Search(custID, q)
 				if qErr != nil {
@@ -116,6 +148,7 @@ Examples:
 					// Retry using the account's own ID as login-customer-id
 					rows, qErr = apiClient.WithLoginID(custID).Search(custID, q)
 					if qErr != nil {
+						fmt.Fprintf(os.Stderr, "Warning: skipping account %s: %s\n", custID, describeAccountError(qErr))
 						if accountsVerbose {
 							fmt.Printf("  %s: self-login also failed: %v\n", custID, qErr)
 						}
@@ -125,12 +158,6 @@ Examples:
 						fmt.Printf("  %s: self-login succeeded, trying customer_client query\n", custID)
 					}
 					// This account is accessible — try customer_client to find sub-accounts
-					ccQuery := `SELECT customer_client.id, customer_client.descriptive_name,
-						customer_client.currency_code, customer_client.time_zone,
-						customer_client.manager, customer_client.level, customer_client.hidden,
-						customer_client.test_account
-					FROM customer_client
-					ORDER BY customer_client.id`
 					ccRows, ccErr := apiClient.WithLoginID(custID).Search(custID, ccQuery)
 					if ccErr == nil && len(ccRows) > 0 {
 						if accountsVerbose {
@@ -149,7 +176,7 @@ Examples:
 									i, row.CustomerClient.ID, row.CustomerClient.DescriptiveName,
 									row.CustomerClient.Manager, row.CustomerClient.Level)
 							}
-							if !row.CustomerClient.Manager {
+							if clientMatchesFilters(row.CustomerClient) {
 								accounts = append(accounts, row.CustomerClient)
 							}
 						}
@@ -160,13 +187,24 @@ Examples:
 					var row struct {
 						Customer api.CustomerClient `json:"customer"`
 					}
-					if json.Unmarshal(raw, &row) == nil && !row.Customer.Manager {
+					if json.Unmarshal(raw, &row) == nil && accountMatchesFilters(row.Customer) {
 						accounts = append(accounts, row.Customer)
 					}
 				}
 			}
 		}
 
+		if output.IsQuiet() {
+			ids := make([]string, len(accounts))
+			for i, a := range accounts {
+				ids[i] = a.ID
+			}
+			return output.PrintIDs(ids)
+		}
+		if accountsWithSpend {
+			return printAccountsWithSpend(cmd, accounts)
+		}
+
 		if output.IsJSON(cmd) {
 			return output.PrintJSON(accounts, output.IsPretty(cmd))
 		}
@@ -175,28 +213,439 @@ Examples:
 			return nil
 		}
 
-		headers := []string{"ID", "NAME", "CURRENCY", "TIMEZONE", "TEST"}
+		// Accounts with an alias (config alias add) show it next to the ID.
+		aliases := aliasesByID(creds.Aliases)
+		withAlias := false
+		for _, a := range accounts {
+			withAlias = withAlias || aliases[a.ID] != ""
+		}
+		headers := []string{"ID", "NAME", "CURRENCY", "TIMEZONE"}
+		if listsManagers() {
+			headers = append(headers, "MANAGER")
+		}
+		headers = append(headers, "TEST")
+		if withAlias {
+			headers = append([]string{"ID", "ALIAS"}, headers[1:]...)
+		}
+		if accountsIncludeHidden {
+			headers = append(headers, "HIDDEN")
+		}
 		rows2 := make([][]string, len(accounts))
 		for i, a := range accounts {
 			testStr := ""
 			if a.TestAccount {
 				testStr = "yes"
 			}
+			managerStr := ""
+			if a.Manager {
+				managerStr = "yes"
+			}
 			rows2[i] = []string{
 				a.ID,
-				output.Truncate(a.DescriptiveName, 40),
+				a.DescriptiveName,
 				a.CurrencyCode,
-				output.Truncate(a.TimeZone, 30),
-				testStr,
+				a.TimeZone,
+			}
+			if listsManagers() {
+				rows2[i] = append(rows2[i], managerStr)
+			}
+			rows2[i] = append(rows2[i], testStr)
+			if withAlias {
+				rows2[i] = append([]string{a.ID, aliases[a.ID]}, rows2[i][1:]...)
+			}
+			if accountsIncludeHidden {
+				hiddenStr := ""
+				if a.Hidden {
+					hiddenStr = "yes"
+				}
+				rows2[i] = append(rows2[i], hiddenStr)
+			}
+		}
+		return output.PrintTable(headers, rows2)
+	},
+}
+
+// ---- accounts get ----
+
+var accountsGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Show the details of an account",
+	Long: `Show the details of an account: name, currency, time zone, whether it is
+a manager or test account, and its optimization score, Google's estimate of
+how well the account is set to perform ("-" for manager accounts).
+
+Examples:
+  gads-cli accounts get --account=1234567890
+  gads-cli accounts get --account=1234567890 --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, err := accountID()
+		if err != nil {
+			return err
+		}
+		query := gaql.Select(
+			"customer.id", "customer.descriptive_name", "customer.currency_code",
+			"customer.time_zone", "customer.manager", "customer.test_account",
+			"customer.optimization_score").
+			From("customer").
+			String()
+		rows, err := apiClient.Search(cid, query)
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return fmt.Errorf("account %s not found", cid)
+		}
+		var row api.CustomerRow
+		if err := json.Unmarshal(rows[0], &row); err != nil {
+			return fmt.Errorf("parsing response: %w", err)
+		}
+
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(row.Customer, output.IsPretty(cmd))
+		}
+		a := row.Customer
+		return output.PrintKeyValue([][]string{
+			{"ID", a.ID},
+			{"Name", a.DescriptiveName},
+			{"Currency", a.CurrencyCode},
+			{"Time Zone", a.TimeZone},
+			{"Manager", yesNo(a.Manager)},
+			{"Test", yesNo(a.TestAccount)},
+			{"Optimization Score", formatOptimizationScore(a.OptimizationScore)},
+		})
+	},
+}
+
+// describeMCCError explains an account listing failure based on the API status.
+func describeMCCError(err error) string {
+	var gErr *api.GoogleAdsError
+	if !errors.As(err, &gErr) {
+		return err.Error()
+	}
+	switch gErr.StatusCode {
+	case 401:
+		return fmt.Sprintf("authentication failed (%s) — run: gads-cli auth login", gErr.Body)
+	case 403:
+		return fmt.Sprintf("permission denied (%s) — check that your login has access to this account", gErr.Body)
+	case 400, 404:
+		return fmt.Sprintf("invalid or unknown customer ID (%s) — check manager_customer_id in %s", gErr.Body, config.Path())
+	}
+	return gErr.Error()
+}
+
+// describeAccountError explains why one account could not be read when
+// accounts are listed individually.
+func describeAccountError(err error) string {
+	var gErr *api.GoogleAdsError
+	if !errors.As(err, &gErr) {
+		return err.Error()
+	}
+	switch gErr.StatusCode {
+	case 401:
+		return fmt.Sprintf("authentication failed (%s) — run: gads-cli auth login", gErr.Body)
+	case 403:
+		return fmt.Sprintf("permission denied (%s) — the account may be canceled, or your login no longer has access to it", gErr.Body)
+	case 400, 404:
+		return fmt.Sprintf("account not found or not enabled (%s)", gErr.Body)
+	}
+	return gErr.Error()
+}
+
+// customerClientQuery builds the customer_client GAQL query, pushing the
+// server-side-expressible filters (manager, hidden) into the WHERE clause.
+func customerClientQuery() string {
+	return gaql.Select(
+		"customer_client.id", "customer_client.descriptive_name",
+		"customer_client.currency_code", "customer_client.time_zone",
+		"customer_client.manager", "customer_client.level", "customer_client.hidden",
+		"customer_client.test_account").
+		From("customer_client").
+		WhereIf(accountsManagerOnly, "customer_client.manager = true").
+		WhereIf(!listsManagers(), "customer_client.manager = false").
+		WhereIf(!accountsIncludeHidden, "customer_client.hidden = false").
+		OrderBy("customer_client.id", false).
+		String()
+}
+
+// clientAccounts returns the enabled, visible client (non-manager) accounts
+// under the manager account, for commands that run against every account.
+func clientAccounts() ([]api.CustomerClient, error) {
+	creds, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("loading credentials: %w", err)
+	}
+	mccID, err := loginCustomerID(creds)
+	if err != nil {
+		return nil, err
+	}
+	if mccID == "" {
+		return nil, fmt.Errorf("no manager account configured — pass --login-customer-id or set manager_customer_id in %s", config.Path())
+	}
+	query := gaql.Select("customer_client.id", "customer_client.descriptive_name",
+		"customer_client.currency_code", "customer_client.manager", "customer_client.level").
+		From("customer_client").
+		Where("customer_client.manager = false").
+		Where("customer_client.hidden = false").
+		Where("customer_client.status = 'ENABLED'").
+		OrderBy("customer_client.id", false).
+		String()
+	rows, err := apiClient.Search(mccID, query)
+	if err != nil {
+		return nil, fmt.Errorf("listing accounts under manager %s: %s", mccID, describeMCCError(err))
+	}
+	var accounts []api.CustomerClient
+	for _, raw := range rows {
+		var row api.CustomerClientRow
+		if json.Unmarshal(raw, &row) == nil {
+			accounts = append(accounts, row.CustomerClient)
+		}
+	}
+	return accounts, nil
+}
+
+// listsManagers reports whether accounts list shows sub-manager accounts,
+// which it leaves out unless --include-managers or --manager-only is set.
+func listsManagers() bool {
+	return (accountsIncludeManagers || accountsManagerOnly) && !accountsClientsOnly
+}
+
+// accountMatchesFilters applies the accounts list filters client-side.
+func accountMatchesFilters(c api.CustomerClient) bool {
+	if accountsManagerOnly && !c.Manager {
+		return false
+	}
+	if c.Manager && !listsManagers() {
+		return false
+	}
+	if !accountsIncludeHidden && c.Hidden {
+		return false
+	}
+	if accountsName != "" && !strings.Contains(strings.ToLower(c.DescriptiveName), strings.ToLower(accountsName)) {
+		return false
+	}
+	return true
+}
+
+// clientMatchesFilters is accountMatchesFilters for customer_client rows. It
+// also drops the root manager row (level 0) that customer_client returns for
+// the manager queried; customer rows have no level, so it does not apply to
+// them.
+func clientMatchesFilters(c api.CustomerClient) bool {
+	return !(c.Manager && c.Level == 0) && accountMatchesFilters(c)
+}
+
+// accountSpend is a client account together with its aggregated metrics for
+// the --with-spend window. Metrics is nil when the account could not be queried.
+type accountSpend struct {
+	api.CustomerClient
+	Metrics           *api.Metrics `json:"metrics,omitempty"`
+	OptimizationScore *float64     `json:"optimizationScore,omitempty"`
+	Error             string       `json:"error,omitempty"`
+}
+
+// fetchAccountSpend queries customer-level metrics for each non-manager account
+// in parallel (see --concurrency). Per-account failures are recorded, not returned.
+func fetchAccountSpend(ctx context.Context, accounts []api.CustomerClient, days int) []accountSpend {
+	results := make([]accountSpend, len(accounts))
+	query := gaql.Select("customer.optimization_score",
+		"metrics.cost_micros", "metrics.clicks", "metrics.conversions").
+		From("customer").
+		Where(buildDateRange("", days, "", "")).
+		String()
+
+	var ids []string
+	for i, a := range accounts {
+		results[i].CustomerClient = a
+		if !a.Manager {
+			ids = append(ids, a.ID)
+		}
+	}
+	rowsByID, errs := apiClient.SearchMany(ctx, ids, query, concurrencyFlag)
+
+	for i, a := range accounts {
+		if a.Manager {
+			continue
+		}
+		custID := api.CleanCustomerID(a.ID)
+		if err, ok := errs[custID]; ok {
+			results[i].Error = err.Error()
+			continue
+		}
+		var m api.Metrics
+		for _, raw := range rowsByID[custID] {
+			var row api.CustomerMetricsRow
+			if err := json.Unmarshal(raw, &row); err != nil {
+				continue
 			}
+			m = row.Metrics
+			results[i].OptimizationScore = row.Customer.OptimizationScore
+		}
+		results[i].Metrics = &m
+	}
+	return results
+}
+
+// costMicrosValue returns the account cost in micros, treating missing metrics as -1
+// so that accounts without data sort below accounts with zero spend.
+func costMicrosValue(m *api.Metrics) int64 {
+	if m == nil {
+		return -1
+	}
+	return int64(m.CostMicros)
+}
+
+func printAccountsWithSpend(cmd *cobra.Command, accounts []api.CustomerClient) error {
+	results := fetchAccountSpend(cmd.Context(), accounts, accountsSpendDays)
+	sort.SliceStable(results, func(i, j int) bool {
+		return costMicrosValue(results[i].Metrics) > costMicrosValue(results[j].Metrics)
+	})
+	for _, r := range results {
+		if r.Error != "" {
+			fmt.Fprintf(os.Stderr, "Warning: could not fetch spend for %s: %s\n", r.ID, r.Error)
+		}
+	}
+
+	if output.IsJSON(cmd) {
+		return output.PrintJSON(results, output.IsPretty(cmd))
+	}
+	if len(results) == 0 {
+		fmt.Println("No client accounts found.")
+		return nil
+	}
+
+	headers := []string{"ID", "NAME", "CURRENCY", "SPEND", "CLICKS", "CONV", "OPT SCORE"}
+	rows := make([][]string, len(results))
+	for i, r := range results {
+		spend, clicks, conv := "-", "-", "-"
+		if r.Metrics != nil {
+			spend = api.FormatMoney(float64(r.Metrics.CostMicros), r.CurrencyCode)
+			clicks = api.FormatMetricInt(int64(r.Metrics.Clicks))
+			conv = fmt.Sprintf("%.1f", r.Metrics.Conversions)
+		}
+		rows[i] = []string{
+			r.ID,
+			r.DescriptiveName,
+			r.CurrencyCode,
+			spend,
+			clicks,
+			conv,
+			formatOptimizationScore(r.OptimizationScore),
+		}
+	}
+	return output.PrintTable(headers, rows)
+}
+
+// ---- accounts create ----
+
+var (
+	accountsCreateName     string
+	accountsCreateCurrency string
+	accountsCreateTimezone string
+)
+
+// validCurrencyCodes is a small allowlist of ISO 4217 codes accepted by --currency.
+var validCurrencyCodes = map[string]bool{
+	"USD": true, "EUR": true, "GBP": true, "CAD": true, "AUD": true, "NZD": true,
+	"CHF": true, "SEK": true, "NOK": true, "DKK": true, "PLN": true, "CZK": true,
+	"JPY": true, "CNY": true, "HKD": true, "SGD": true, "INR": true, "KRW": true,
+	"BRL": true, "MXN": true, "ZAR": true, "AED": true, "ILS": true, "TRY": true,
+}
+
+// validTimeZones is a small allowlist of IANA time zones accepted by --timezone.
+var validTimeZones = map[string]bool{
+	"America/New_York": true, "America/Chicago": true, "America/Denver": true,
+	"America/Los_Angeles": true, "America/Phoenix": true, "America/Anchorage": true,
+	"America/Toronto": true, "America/Vancouver": true, "America/Mexico_City": true,
+	"America/Sao_Paulo": true, "America/Buenos_Aires": true,
+	"Europe/London": true, "Europe/Dublin": true, "Europe/Paris": true, "Europe/Berlin": true,
+	"Europe/Madrid": true, "Europe/Rome": true, "Europe/Amsterdam": true, "Europe/Brussels": true,
+	"Europe/Zurich": true, "Europe/Stockholm": true, "Europe/Oslo": true, "Europe/Copenhagen": true,
+	"Europe/Warsaw": true, "Europe/Prague": true, "Europe/Istanbul": true,
+	"Asia/Tokyo": true, "Asia/Shanghai": true, "Asia/Hong_Kong": true, "Asia/Singapore": true,
+	"Asia/Kolkata": true, "Asia/Seoul": true, "Asia/Dubai": true, "Asia/Jerusalem": true,
+	"Australia/Sydney": true, "Australia/Melbourne": true, "Australia/Perth": true,
+	"Pacific/Auckland": true, "Africa/Johannesburg": true, "UTC": true,
+}
+
+var accountsCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new client account under the MCC",
+	Long: `Create a new client account under the configured Manager Account (MCC).
+
+The currency and time zone cannot be changed after creation.
+
+Examples:
+  gads-cli accounts create --name="Client X" --currency=USD --timezone=America/New_York
+  gads-cli accounts create --name="Client Y" --currency=EUR --timezone=Europe/Paris --json`,
+	Annotations: mutatingCommand,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if accountsCreateName == "" {
+			return fmt.Errorf("--name is required")
+		}
+		currency := strings.ToUpper(accountsCreateCurrency)
+		if !validCurrencyCodes[currency] {
+			return fmt.Errorf("--currency %q is not a supported currency code (e.g. USD, EUR, GBP)", accountsCreateCurrency)
+		}
+		if !validTimeZones[accountsCreateTimezone] {
+			return fmt.Errorf("--timezone %q is not a supported time zone (e.g. America/New_York, Europe/Paris)", accountsCreateTimezone)
+		}
+
+		creds, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("loading credentials: %w", err)
+		}
+		mccID, err := loginCustomerID(creds)
+		if err != nil {
+			return err
+		}
+		if mccID == "" {
+			return fmt.Errorf("manager account not set — run: gads-cli auth login --manager-account=<id>")
 		}
-		output.PrintTable(headers, rows2)
+
+		change := fmt.Sprintf("Manager %s: create account %q (%s, %s) — currency and time zone are permanent",
+			mccID, accountsCreateName, currency, accountsCreateTimezone)
+		if ok, err := confirmChange("Create this account?", change); !ok {
+			return err
+		}
+
+		resp, err := apiClient.CreateCustomerClient(mccID, map[string]any{
+			"descriptiveName": accountsCreateName,
+			"currencyCode":    currency,
+			"timeZone":        accountsCreateTimezone,
+		})
+		if err != nil {
+			return err
+		}
+		if apiClient.ValidateOnly() {
+			fmt.Println("DRY RUN — would have applied 1 operation(s)")
+			return nil
+		}
+
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(resp, output.IsPretty(cmd))
+		}
+		fmt.Printf("Account created: %s (ID: %s)\n", accountsCreateName, api.ResourceID(resp.ResourceName))
+		fmt.Printf("Resource: %s\n", resp.ResourceName)
 		return nil
 	},
 }
 
 func init() {
-	accountsListCmd.Flags().BoolVar(&accountsVerbose, "verbose", false, "Show diagnostic info (accessible customers, strategy errors)")
-	accountsCmd.AddCommand(accountsListCmd)
+	accountsListCmd.Flags().BoolVar(&accountsVerbose, "verbose", false, "Show diagnostic info (accessible customers, strategy errors, API request count)")
+	accountsListCmd.Flags().BoolVar(&accountsWithSpend, "with-spend", false, "Add SPEND/CLICKS/CONV columns per account, sorted by spend")
+	accountsListCmd.Flags().IntVar(&accountsSpendDays, "days", 30, "Look-back window in days for --with-spend")
+	accountsListCmd.Flags().BoolVar(&accountsManagerOnly, "manager-only", false, "Only show manager (MCC) accounts")
+	accountsListCmd.Flags().BoolVar(&accountsClientsOnly, "clients-only", false, "Only show client (non-manager) accounts; the default, kept as an alias")
+	accountsListCmd.Flags().BoolVar(&accountsIncludeManagers, "include-managers", false, "Also show sub-manager accounts (adds a MANAGER column)")
+	accountsListCmd.Flags().BoolVar(&accountsIncludeHidden, "include-hidden", false, "Include hidden accounts (adds a HIDDEN column)")
+	accountsListCmd.Flags().StringVar(&accountsName, "name", "", "Filter by account name substring (case-insensitive)")
+	accountsListCmd.MarkFlagsMutuallyExclusive("manager-only", "clients-only")
+	accountsListCmd.MarkFlagsMutuallyExclusive("include-managers", "clients-only")
+
+	accountsCreateCmd.Flags().StringVar(&accountsCreateName, "name", "", "Descriptive name of the new account (required)")
+	accountsCreateCmd.Flags().StringVar(&accountsCreateCurrency, "currency", "", "ISO 4217 currency code, e.g. USD (required)")
+	accountsCreateCmd.Flags().StringVar(&accountsCreateTimezone, "timezone", "", "IANA time zone, e.g. America/New_York (required)")
+
+	accountsCmd.AddCommand(accountsListCmd, accountsGetCmd, accountsCreateCmd)
 	rootCmd.AddCommand(accountsCmd)
 }
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/the20100/gads-cli/internal/api"
+)
+
+// setAccountsFilters sets the accounts list filter flags for one test and
+// restores them when it ends.
+func setAccountsFilters(t *testing.T, managerOnly, clientsOnly, includeManagers, includeHidden bool, name string) {
+	t.Helper()
+	saved := []any{accountsManagerOnly, accountsClientsOnly, accountsIncludeManagers, accountsIncludeHidden, accountsName}
+	t.Cleanup(func() {
+		accountsManagerOnly = saved[0].(bool)
+		accountsClientsOnly = saved[1].(bool)
+		accountsIncludeManagers = saved[2].(bool)
+		accountsIncludeHidden = saved[3].(bool)
+		accountsName = saved[4].(string)
+	})
+	accountsManagerOnly, accountsClientsOnly = managerOnly, clientsOnly
+	accountsIncludeManagers, accountsIncludeHidden = includeManagers, includeHidden
+	accountsName = name
+}
+
+func TestClientMatchesFilters(t *testing.T) {
+	root := api.CustomerClient{ID: "1", Manager: true, Level: 0}
+	subManager := api.CustomerClient{ID: "2", DescriptiveName: "Acme MCC", Manager: true, Level: 1}
+	client := api.CustomerClient{ID: "3", DescriptiveName: "Acme Shoes", Level: 1}
+	hidden := api.CustomerClient{ID: "4", DescriptiveName: "Old", Level: 1, Hidden: true}
+
+	tests := []struct {
+		name                                                     string
+		managerOnly, clientsOnly, includeManagers, includeHidden bool
+		filter                                                   string
+		want                                                     []string
+	}{
+		{name: "default lists visible clients only", want: []string{"3"}},
+		{name: "include managers", includeManagers: true, want: []string{"2", "3"}},
+		{name: "manager only", managerOnly: true, want: []string{"2"}},
+		{name: "clients only", clientsOnly: true, want: []string{"3"}},
+		{name: "include hidden", includeHidden: true, want: []string{"3", "4"}},
+		{name: "name is case-insensitive", includeManagers: true, filter: "acme s", want: []string{"3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setAccountsFilters(t, tt.managerOnly, tt.clientsOnly, tt.includeManagers, tt.includeHidden, tt.filter)
+			var got []string
+			for _, c := range []api.CustomerClient{root, subManager, client, hidden} {
+				if clientMatchesFilters(c) {
+					got = append(got, c.ID)
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("listed %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCustomerClientQueryExcludesManagersByDefault(t *testing.T) {
+	tests := []struct {
+		name                              string
+		managerOnly, includeManagers      bool
+		wantClientsOnly, wantManagersOnly bool
+	}{
+		{name: "default", wantClientsOnly: true},
+		{name: "include managers", includeManagers: true},
+		{name: "manager only", managerOnly: true, wantManagersOnly: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setAccountsFilters(t, tt.managerOnly, false, tt.includeManagers, false, "")
+			q := customerClientQuery()
+			if got := strings.Contains(q, "customer_client.manager = false"); got != tt.wantClientsOnly {
+				t.Errorf("query filters out managers = %v, want %v:\n%s", got, tt.wantClientsOnly, q)
+			}
+			if got := strings.Contains(q, "customer_client.manager = true"); got != tt.wantManagersOnly {
+				t.Errorf("query keeps only managers = %v, want %v:\n%s", got, tt.wantManagersOnly, q)
+			}
+		})
+	}
+}
+
+func TestAccountsListEmptyManagerDoesNotFallBack(t *testing.T) {
+	var searched []string
+	useTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ":listAccessibleCustomers") {
+			fmt.Fprint(w, `{"resourceNames":["customers/999","customers/111"]}`)
+			return
+		}
+		searched = append(searched, r.URL.Path)
+		fmt.Fprint(w, `{}`)
+	})
+	setAccountsFilters(t, false, false, false, false, "")
+	loginIDFlag = "999"
+
+	_, stderr, err := runOutput(t, func() error { return accountsListCmd.RunE(accountsListCmd, nil) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(searched) != 1 || !strings.Contains(searched[0], "/customers/999/") {
+		t.Errorf("searched %v, want only the manager account", searched)
+	}
+	if stderr != "" {
+		t.Errorf("unexpected warning: %s", stderr)
+	}
+}
+
+func TestAccountsListFallbackWarnsPerAccount(t *testing.T) {
+	useTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, ":listAccessibleCustomers"):
+			fmt.Fprint(w, `{"resourceNames":["customers/999","customers/111","customers/222"]}`)
+		case strings.Contains(r.URL.Path, "/customers/999/"):
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"error":{"code":403,"message":"The caller does not have permission","status":"PERMISSION_DENIED"}}`)
+		case strings.Contains(r.URL.Path, "/customers/111/"):
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"code":404,"message":"Not found","status":"NOT_FOUND"}}`)
+		default:
+			fmt.Fprint(w, `{"results":[{"customer":{"id":"222","descriptiveName":"Acme","manager":false}}]}`)
+		}
+	})
+	setAccountsFilters(t, false, false, false, false, "")
+	loginIDFlag = "999"
+
+	stdout, stderr, err := runOutput(t, func() error { return accountsListCmd.RunE(accountsListCmd, nil) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stderr, "could not list accounts under manager 999") {
+		t.Errorf("no manager warning in:\n%s", stderr)
+	}
+	if !strings.Contains(stderr, "skipping account 111: account not found") {
+		t.Errorf("no per-account warning in:\n%s", stderr)
+	}
+	if strings.Contains(stderr, "manager_customer_id") {
+		t.Errorf("per-account warning blames the manager ID:\n%s", stderr)
+	}
+	if !strings.Contains(stdout, `"id":"222"`) {
+		t.Errorf("account 222 not listed:\n%s", stdout)
+	}
+}
+
+func TestAccountsListFallbackListsManagers(t *testing.T) {
+	useTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, ":listAccessibleCustomers"):
+			fmt.Fprint(w, `{"resourceNames":["customers/999","customers/222","customers/333"]}`)
+		case strings.Contains(r.URL.Path, "/customers/999/"):
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"error":{"code":403,"message":"The caller does not have permission","status":"PERMISSION_DENIED"}}`)
+		case strings.Contains(r.URL.Path, "/customers/222/"):
+			fmt.Fprint(w, `{"results":[{"customer":{"id":"222","descriptiveName":"Acme MCC","manager":true}}]}`)
+		default:
+			fmt.Fprint(w, `{"results":[{"customer":{"id":"333","descriptiveName":"Acme Shoes","manager":false}}]}`)
+		}
+	})
+	loginIDFlag = "999"
+
+	tests := []struct {
+		name                                      string
+		managerOnly, clientsOnly, includeManagers bool
+		want                                      []string
+	}{
+		{name: "default", want: []string{"333"}},
+		{name: "clients only", clientsOnly: true, want: []string{"333"}},
+		{name: "include managers", includeManagers: true, want: []string{"222", "333"}},
+		{name: "manager only", managerOnly: true, want: []string{"222"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setAccountsFilters(t, tt.managerOnly, tt.clientsOnly, tt.includeManagers, false, "")
+			stdout, _, err := runOutput(t, func() error { return accountsListCmd.RunE(accountsListCmd, nil) })
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, id := range []string{"222", "333"} {
+				if strings.Contains(stdout, fmt.Sprintf(`"id":"%s"`, id)) {
+					got = append(got, id)
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("listed %v, want %v:\n%s", got, tt.want, stdout)
+			}
+		})
+	}
+}
@@ -3,9 +3,13 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/audit"
+	"github.com/the20100/gads-cli/internal/gaql"
 	"github.com/the20100/gads-cli/internal/output"
 )
 
@@ -15,9 +19,9 @@ var adgroupsCmd = &cobra.Command{
 }
 
 var (
-	adgroupAccount    string
 	adgroupCampaignID string
 	adgroupID         string
+	adgroupRotation   string
 )
 
 // ---- adgroups list ----
@@ -25,27 +29,38 @@ var (
 var adgroupsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List ad groups in a campaign",
-	Long: `List all ad groups in a campaign.
+	Long: `List all ad groups in a campaign. SERVING is the ad group's primary
+status, with the reasons it does not (fully) serve.
 
 Examples:
   gads-cli adgroups list --account=1234567890 --campaign=111222333
-  gads-cli adgroups list --account=1234567890 --campaign=111222333 --json`,
+  gads-cli adgroups list --account=1234567890 --campaign=111222333 --json
+  gads-cli adgroups list --account=1234567890 --campaign=111222333 --include-removed`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if adgroupAccount == "" {
-			return fmt.Errorf("--account is required")
+		cid, err := accountID()
+		if err != nil {
+			return err
 		}
 		if adgroupCampaignID == "" {
 			return fmt.Errorf("--campaign is required")
 		}
-		cid := api.CleanCustomerID(adgroupAccount)
+		if !api.IsNumericID(adgroupCampaignID) {
+			return fmt.Errorf("--campaign must be a numeric ID")
+		}
 
-		query := fmt.Sprintf(`SELECT ad_group.id, ad_group.name, ad_group.status, ad_group.type,
-			ad_group.cpc_bid_micros, campaign.id, campaign.name
-		FROM ad_group
-		WHERE ad_group.status != 'REMOVED'
-		  AND campaign.id = '%s'
-		ORDER BY ad_group.id`, adgroupCampaignID)
+		query := gaql.Select(
+			"ad_group.id", "ad_group.name", "ad_group.status", "ad_group.type",
+			"ad_group.primary_status", "ad_group.primary_status_reasons",
+			"ad_group.cpc_bid_micros", "campaign.id", "campaign.name").
+			From("ad_group").
+			Where(notRemoved("ad_group.status")).
+			Where("campaign.id = "+gaql.Quote(adgroupCampaignID)).
+			OrderBy("ad_group.id", false).
+			String()
 
+		if streamRows() {
+			return streamJSONL[api.AdGroupRow](cid, query)
+		}
 		rows, err := apiClient.Search(cid, query)
 		if err != nil {
 			return err
@@ -60,6 +75,13 @@ Examples:
 			adgroups = append(adgroups, row)
 		}
 
+		if output.IsQuiet() {
+			ids := make([]string, len(adgroups))
+			for i, r := range adgroups {
+				ids[i] = r.AdGroup.ID
+			}
+			return output.PrintIDs(ids)
+		}
 		if output.IsJSON(cmd) {
 			return output.PrintJSON(adgroups, output.IsPretty(cmd))
 		}
@@ -68,18 +90,169 @@ Examples:
 			return nil
 		}
 
-		headers := []string{"ID", "NAME", "STATUS", "TYPE", "DEFAULT BID"}
+		headers := []string{"ID", "NAME", "STATUS", "SERVING", "TYPE", "DEFAULT BID"}
 		tableRows := make([][]string, len(adgroups))
 		for i, r := range adgroups {
-			tableRows[i] = []string{
+			tableRows[i] = dimRemoved(r.AdGroup.Status, []string{
 				r.AdGroup.ID,
-				output.Truncate(r.AdGroup.Name, 40),
-				r.AdGroup.Status,
+				r.AdGroup.Name,
+				output.Colorize(r.AdGroup.Status),
+				servingStatus(r.AdGroup.PrimaryStatus, r.AdGroup.PrimaryStatusReasons),
 				formatChannelType(r.AdGroup.Type),
-				api.MicrosToCurrency(r.AdGroup.CpcBidMicros),
-			}
+				money(cid, int64(r.AdGroup.CpcBidMicros)),
+			})
+		}
+		return output.PrintTable(headers, tableRows)
+	},
+}
+
+// ---- adgroups get ----
+
+var adgroupsGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Get full details of an ad group",
+	Long: `Get detailed information about an ad group: status and serving status,
+default bid, ad rotation and, for Display and Demand Gen ad groups,
+optimized targeting.
+
+Examples:
+  gads-cli adgroups get --account=1234567890 --adgroup=444555666
+  gads-cli adgroups get --account=1234567890 --adgroup=444555666 --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, err := accountID()
+		if err != nil {
+			return err
+		}
+		if !api.IsNumericID(adgroupID) {
+			return fmt.Errorf("--adgroup must be a numeric ID")
 		}
-		output.PrintTable(headers, tableRows)
+
+		query := gaql.Select(
+			"ad_group.id", "ad_group.name", "ad_group.status", "ad_group.type",
+			"ad_group.primary_status", "ad_group.primary_status_reasons",
+			"ad_group.cpc_bid_micros", "ad_group.ad_rotation_mode",
+			"ad_group.optimized_targeting_enabled",
+			"campaign.id", "campaign.name").
+			From("ad_group").
+			Where("ad_group.id = " + gaql.Quote(adgroupID)).
+			String()
+		rows, err := apiClient.Search(cid, query)
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return fmt.Errorf("ad group %s not found", adgroupID)
+		}
+		var row api.AdGroupRow
+		if err := json.Unmarshal(rows[0], &row); err != nil {
+			return fmt.Errorf("parsing response: %w", err)
+		}
+
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(row, output.IsPretty(cmd))
+		}
+
+		ag := row.AdGroup
+		kv := [][]string{
+			{"ID", ag.ID},
+			{"Name", ag.Name},
+			{"Campaign", fmt.Sprintf("%s (%s)", row.Campaign.Name, row.Campaign.ID)},
+			{"Status", output.Colorize(ag.Status)},
+			{"Serving", orDash(output.Colorize(ag.PrimaryStatus))},
+			{"Serving Reasons", servingReasons(ag.PrimaryStatus, ag.PrimaryStatusReasons)},
+			{"Type", formatChannelType(ag.Type)},
+			{"Default Bid", money(cid, int64(ag.CpcBidMicros))},
+			{"Ad Rotation", orDash(ag.AdRotationMode)},
+		}
+		if hasOptimizedTargeting(ag.Type) {
+			kv = append(kv, []string{"Optimized Targeting", onOff(ag.OptimizedTargetingEnabled)})
+		}
+		return output.PrintKeyValue(append(kv, []string{"Resource", ag.ResourceName}))
+	},
+}
+
+// hasOptimizedTargeting reports whether ad groups of type t can use
+// optimized targeting, which only applies to Display and Demand Gen.
+func hasOptimizedTargeting(t string) bool {
+	return strings.HasPrefix(t, "DISPLAY_") || strings.HasPrefix(t, "DEMAND_GEN")
+}
+
+// ---- adgroups set ----
+
+// adRotationModes are the values adgroups set --rotation takes.
+var adRotationModes = []string{"OPTIMIZE", "ROTATE_FOREVER"}
+
+var adgroupsSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Change ad group settings",
+	Long: `Change settings of an ad group, after confirmation.
+
+--rotation sets how the ads of the ad group are rotated: OPTIMIZE favours
+the ads expected to perform best, ROTATE_FOREVER rotates them evenly for an
+indefinite period.
+
+Examples:
+  gads-cli adgroups set --account=1234567890 --adgroup=444555666 --rotation=ROTATE_FOREVER
+  gads-cli adgroups set --account=1234567890 --adgroup=444555666 --rotation=optimize`,
+	Annotations: mutatingCommand,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, err := accountID()
+		if err != nil {
+			return err
+		}
+		if !api.IsNumericID(adgroupID) {
+			return fmt.Errorf("--adgroup must be a numeric ID")
+		}
+		if adgroupRotation == "" {
+			return fmt.Errorf("nothing to change: pass --rotation")
+		}
+		rotation := strings.ToUpper(adgroupRotation)
+		if !slices.Contains(adRotationModes, rotation) {
+			return fmt.Errorf("invalid --rotation %q: must be one of %s", adgroupRotation, strings.Join(adRotationModes, ", "))
+		}
+
+		rows, err := apiClient.Search(cid, gaql.Select("ad_group.id", "ad_group.name", "ad_group.ad_rotation_mode").
+			From("ad_group").
+			Where("ad_group.id = "+gaql.Quote(adgroupID)).
+			String())
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return fmt.Errorf("ad group %s not found", adgroupID)
+		}
+		var row api.AdGroupRow
+		if err := json.Unmarshal(rows[0], &row); err != nil {
+			return fmt.Errorf("parsing response: %w", err)
+		}
+		previous := row.AdGroup.AdRotationMode
+		if previous == "" || previous == "UNSPECIFIED" {
+			// Unset rotation behaves as OPTIMIZE, which the undo restores.
+			previous = "OPTIMIZE"
+		}
+		if previous == rotation {
+			fmt.Printf("Ad group %s already rotates ads with %s.\n", adgroupID, rotation)
+			return nil
+		}
+		change := fmt.Sprintf("Ad group %s %q: ad rotation %s → %s", adgroupID, row.AdGroup.Name, previous, rotation)
+		if ok, err := confirmChange("Apply this change?", change); !ok {
+			return err
+		}
+
+		resourceName := fmt.Sprintf("customers/%s/adGroups/%s", cid, adgroupID)
+		ops := []map[string]any{updateOperation(resourceName, map[string]any{"adRotationMode": rotation})}
+		recordUndo("adGroups", audit.Restore([]map[string]any{updateOperation(resourceName, map[string]any{"adRotationMode": previous})}))
+		resp, err := apiClient.MutateAdGroups(cid, ops)
+		if err != nil {
+			return err
+		}
+		if apiClient.ValidateOnly() {
+			return reportDryRun(resp, len(ops))
+		}
+		if jsonResult() {
+			return printMutateResult(len(ops), resp)
+		}
+		fmt.Printf("Ad group %s ad rotation set to %s.\n", adgroupID, rotation)
 		return nil
 	},
 }
@@ -93,8 +266,9 @@ var adgroupsPauseCmd = &cobra.Command{
 
 Examples:
   gads-cli adgroups pause --account=1234567890 --adgroup=444555666`,
+	Annotations: mutatingCommand,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return setAdGroupStatus(adgroupAccount, adgroupID, "PAUSED")
+		return setAdGroupStatus(adgroupID, "PAUSED")
 	},
 }
 
@@ -107,21 +281,44 @@ var adgroupsEnableCmd = &cobra.Command{
 
 Examples:
   gads-cli adgroups enable --account=1234567890 --adgroup=444555666`,
+	Annotations: mutatingCommand,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return setAdGroupStatus(adgroupAccount, adgroupID, "ENABLED")
+		return setAdGroupStatus(adgroupID, "ENABLED")
 	},
 }
 
-func setAdGroupStatus(account, agID, status string) error {
-	if account == "" {
-		return fmt.Errorf("--account is required")
+func setAdGroupStatus(agID, status string) error {
+	cid, err := accountID()
+	if err != nil {
+		return err
 	}
 	if agID == "" {
 		return fmt.Errorf("--adgroup is required")
 	}
-	cid := api.CleanCustomerID(account)
+	if !api.IsNumericID(agID) {
+		return fmt.Errorf("--adgroup must be a numeric ID")
+	}
 	resourceName := fmt.Sprintf("customers/%s/adGroups/%s", cid, agID)
 
+	rows, err := apiClient.Search(cid, gaql.Select("ad_group.id", "ad_group.name", "ad_group.status").
+		From("ad_group").
+		Where("ad_group.id = "+gaql.Quote(agID)).
+		String())
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return fmt.Errorf("ad group %s not found", agID)
+	}
+	var row api.AdGroupRow
+	if err := json.Unmarshal(rows[0], &row); err != nil {
+		return fmt.Errorf("parsing response: %w", err)
+	}
+	change := fmt.Sprintf("Ad group %s %q: %s → %s", agID, row.AdGroup.Name, row.AdGroup.Status, status)
+	if ok, err := confirmChange("Apply this change?", change); !ok {
+		return err
+	}
+
 	ops := []map[string]any{
 		{
 			"updateMask": "status",
@@ -131,22 +328,33 @@ func setAdGroupStatus(account, agID, status string) error {
 			},
 		},
 	}
-	if _, err := apiClient.MutateAdGroups(cid, ops); err != nil {
+	recordUndo("adGroups", audit.Restore([]map[string]any{undoUpdate(resourceName, "status", row.AdGroup.Status)}))
+	resp, err := apiClient.MutateAdGroups(cid, ops)
+	if err != nil {
 		return err
 	}
+	if apiClient.ValidateOnly() {
+		return reportDryRun(resp, len(ops))
+	}
+	if jsonResult() {
+		return printMutateResult(len(ops), resp)
+	}
 	fmt.Printf("Ad group %s status set to %s.\n", agID, status)
 	return nil
 }
 
 func init() {
-	adgroupsListCmd.Flags().StringVar(&adgroupAccount, "account", "", "Customer account ID (required)")
 	adgroupsListCmd.Flags().StringVar(&adgroupCampaignID, "campaign", "", "Campaign ID (required)")
 
-	for _, c := range []*cobra.Command{adgroupsPauseCmd, adgroupsEnableCmd} {
-		c.Flags().StringVar(&adgroupAccount, "account", "", "Customer account ID (required)")
+	for _, c := range []*cobra.Command{adgroupsGetCmd, adgroupsSetCmd, adgroupsPauseCmd, adgroupsEnableCmd} {
 		c.Flags().StringVar(&adgroupID, "adgroup", "", "Ad group ID (required)")
 	}
+	adgroupsSetCmd.Flags().StringVar(&adgroupRotation, "rotation", "", "Ad rotation: "+strings.Join(adRotationModes, " or "))
+	adgroupsSetCmd.RegisterFlagCompletionFunc("rotation", cobra.FixedCompletions(adRotationModes, cobra.ShellCompDirectiveNoFileComp))
+
+	addStreamFlag(adgroupsListCmd)
+	addIncludeRemovedFlag(adgroupsListCmd)
 
-	adgroupsCmd.AddCommand(adgroupsListCmd, adgroupsPauseCmd, adgroupsEnableCmd)
+	adgroupsCmd.AddCommand(adgroupsListCmd, adgroupsGetCmd, adgroupsSetCmd, adgroupsPauseCmd, adgroupsEnableCmd)
 	rootCmd.AddCommand(adgroupsCmd)
 }
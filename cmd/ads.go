@@ -7,6 +7,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/gaql"
 	"github.com/the20100/gads-cli/internal/output"
 )
 
@@ -16,8 +17,7 @@ var adsCmd = &cobra.Command{
 }
 
 var (
-	adsAccount    string
-	adsAdGroupID  string
+	adsAdGroupID string
 )
 
 // ---- ads list ----
@@ -26,30 +26,42 @@ var adsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List responsive search ads in an ad group",
 	Long: `List responsive search ads (RSAs) with their headlines, descriptions, and status.
+Serving is the ad's primary status, with the reasons it does not (fully)
+serve, e.g. NOT_ELIGIBLE (AD_GROUP_AD_DISAPPROVED).
 
 Examples:
   gads-cli ads list --account=1234567890 --adgroup=444555666
-  gads-cli ads list --account=1234567890 --adgroup=444555666 --json`,
+  gads-cli ads list --account=1234567890 --adgroup=444555666 --json
+  gads-cli ads list --account=1234567890 --adgroup=444555666 --include-removed`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if adsAccount == "" {
-			return fmt.Errorf("--account is required")
+		cid, err := accountID()
+		if err != nil {
+			return err
 		}
 		if adsAdGroupID == "" {
 			return fmt.Errorf("--adgroup is required")
 		}
-		cid := api.CleanCustomerID(adsAccount)
-
-		query := fmt.Sprintf(`SELECT ad_group_ad.ad.id, ad_group_ad.ad.type,
-			ad_group_ad.ad.responsive_search_ad.headlines,
-			ad_group_ad.ad.responsive_search_ad.descriptions,
-			ad_group_ad.ad.final_urls, ad_group_ad.status,
-			ad_group.id, campaign.id
-		FROM ad_group_ad
-		WHERE ad_group_ad.ad.type = 'RESPONSIVE_SEARCH_AD'
-		  AND ad_group_ad.status != 'REMOVED'
-		  AND ad_group.id = '%s'
-		ORDER BY ad_group_ad.ad.id`, adsAdGroupID)
+		if !api.IsNumericID(adsAdGroupID) {
+			return fmt.Errorf("--adgroup must be a numeric ID")
+		}
+
+		query := gaql.Select(
+			"ad_group_ad.ad.id", "ad_group_ad.ad.type",
+			"ad_group_ad.ad.responsive_search_ad.headlines",
+			"ad_group_ad.ad.responsive_search_ad.descriptions",
+			"ad_group_ad.ad.final_urls", "ad_group_ad.status",
+			"ad_group_ad.primary_status", "ad_group_ad.primary_status_reasons",
+			"ad_group.id", "campaign.id").
+			From("ad_group_ad").
+			Where("ad_group_ad.ad.type = 'RESPONSIVE_SEARCH_AD'").
+			Where(notRemoved("ad_group_ad.status")).
+			Where("ad_group.id = "+gaql.Quote(adsAdGroupID)).
+			OrderBy("ad_group_ad.ad.id", false).
+			String()
 
+		if streamRows() {
+			return streamJSONL[api.AdRow](cid, query)
+		}
 		rows, err := apiClient.Search(cid, query)
 		if err != nil {
 			return err
@@ -64,6 +76,13 @@ Examples:
 			ads = append(ads, row)
 		}
 
+		if output.IsQuiet() {
+			ids := make([]string, len(ads))
+			for i, r := range ads {
+				ids[i] = r.AdGroupAd.Ad.ID
+			}
+			return output.PrintIDs(ids)
+		}
 		if output.IsJSON(cmd) {
 			return output.PrintJSON(ads, output.IsPretty(cmd))
 		}
@@ -71,9 +90,17 @@ Examples:
 			fmt.Println("No responsive search ads found.")
 			return nil
 		}
+		if output.IsCSV() || output.IsMarkdown() {
+			return printAdsTable(ads)
+		}
 
 		for _, r := range ads {
-			fmt.Printf("Ad ID: %s  Status: %s\n", r.AdGroupAd.Ad.ID, r.AdGroupAd.Status)
+			header := fmt.Sprintf("Ad ID: %s  Status: %s  Serving: %s", r.AdGroupAd.Ad.ID,
+				output.Colorize(r.AdGroupAd.Status), servingStatus(r.AdGroupAd.PrimaryStatus, r.AdGroupAd.PrimaryStatusReasons))
+			if r.AdGroupAd.Status == "REMOVED" {
+				header = output.Dim(header)
+			}
+			fmt.Println(header)
 			// Show up to 3 headlines
 			headlines := r.AdGroupAd.Ad.ResponsiveSearchAd.Headlines
 			if len(headlines) > 0 {
@@ -113,10 +140,44 @@ Examples:
 	},
 }
 
+// printAdsTable prints one row per ad with every headline and description,
+// for --csv and --markdown, since the grouped text layout of ads list has no
+// columns.
+func printAdsTable(ads []api.AdRow) error {
+	headers := []string{"AD ID", "STATUS", "SERVING", "HEADLINES", "DESCRIPTIONS", "FINAL URL"}
+	rows := make([][]string, len(ads))
+	for i, r := range ads {
+		rsa := r.AdGroupAd.Ad.ResponsiveSearchAd
+		headlines := make([]string, len(rsa.Headlines))
+		for j, h := range rsa.Headlines {
+			headlines[j] = h.Text
+		}
+		descs := make([]string, len(rsa.Descriptions))
+		for j, d := range rsa.Descriptions {
+			descs[j] = d.Text
+		}
+		finalURL := ""
+		if len(r.AdGroupAd.Ad.FinalUrls) > 0 {
+			finalURL = r.AdGroupAd.Ad.FinalUrls[0]
+		}
+		rows[i] = []string{
+			r.AdGroupAd.Ad.ID,
+			r.AdGroupAd.Status,
+			servingStatus(r.AdGroupAd.PrimaryStatus, r.AdGroupAd.PrimaryStatusReasons),
+			strings.Join(headlines, " | "),
+			strings.Join(descs, " | "),
+			finalURL,
+		}
+	}
+	return output.PrintTable(headers, rows)
+}
+
 func init() {
-	adsListCmd.Flags().StringVar(&adsAccount, "account", "", "Customer account ID (required)")
 	adsListCmd.Flags().StringVar(&adsAdGroupID, "adgroup", "", "Ad group ID (required)")
 
+	addStreamFlag(adsListCmd)
+	addIncludeRemovedFlag(adsListCmd)
+
 	adsCmd.AddCommand(adsListCmd)
 	rootCmd.AddCommand(adsCmd)
 }
@@ -0,0 +1,385 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/audit"
+	"github.com/the20100/gads-cli/internal/changes"
+	"github.com/the20100/gads-cli/internal/gaql"
+	"github.com/the20100/gads-cli/internal/output"
+)
+
+var applyFile string
+
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Apply a CSV or JSON file of changes (pause, bids, budgets, ...)",
+	Long: `Apply a file of changes, one per row, e.g. a weekly optimization sheet.
+
+A CSV file has a header row with the columns entity, id, action and value
+(any order; value only where the action takes one). A JSON file is an array
+of objects with the same keys. Rows are reported by CSV line, or by position
+in the JSON array.
+
+  entity     id                        actions
+  campaign   campaign ID               pause, enable, remove, set-status, set-budget
+  adgroup    ad group ID               pause, enable, remove, set-status, set-bid
+  keyword    <adGroupId>~<criterionId> pause, enable, remove, set-status, set-bid
+  budget     budget ID                 set-budget
+
+set-status takes ENABLED or PAUSED; set-bid (max CPC) and set-budget (daily
+amount) take an amount in the account's currency, e.g. 1.50, with a point for
+decimals (quote amounts with thousands commas in CSV: "5,000"). set-budget on
+a campaign changes the budget it uses, which may be shared.
+
+Every row is validated before anything is sent. The changes are then grouped
+by resource type and sent with partial failure, so valid rows are applied
+even when others fail, and each row's outcome is listed with its line.
+
+With --dry-run nothing is sent: the exact mutate operations are printed.
+
+Examples:
+  gads-cli apply --account=1234567890 --file=changes.csv --dry-run
+  gads-cli apply --account=1234567890 --file=changes.csv
+  gads-cli apply --account=1234567890 --file=changes.json --json`,
+	Annotations: mutatingCommand,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, err := accountID()
+		if err != nil {
+			return err
+		}
+		rows, err := changes.Read(applyFile)
+		if err != nil {
+			return err
+		}
+		ops, err := changes.Translate(cid, rows, &campaignBudgets{cid: cid})
+		if err != nil {
+			return fmt.Errorf("%s has invalid rows, nothing was sent:\n%w", applyFile, err)
+		}
+		if apiClient.ValidateOnly() {
+			return printApplyPlan(cmd, cid, ops)
+		}
+		question := fmt.Sprintf("Apply %d change(s) to account %s?", len(ops), cid)
+		if ok, err := confirmChange(question, applySummary(ops)...); !ok {
+			return err
+		}
+
+		if !noAuditFlag {
+			recordApplyUndo(cid, ops)
+		}
+		results := sendChanges(cid, ops)
+		failed := 0
+		for _, r := range results {
+			if r.Status != "applied" {
+				failed++
+			}
+		}
+		if output.IsJSON(cmd) {
+			if err := output.PrintJSON(results, output.IsPretty(cmd)); err != nil {
+				return err
+			}
+		} else {
+			headers := []string{"LINE", "ENTITY", "ID", "ACTION", "VALUE", "RESULT"}
+			tableRows := make([][]string, len(results))
+			for i, r := range results {
+				result := r.Status
+				if r.Error != "" {
+					result += ": " + r.Error
+				}
+				tableRows[i] = []string{strconv.Itoa(r.Line), r.Entity, r.ID, r.Action, orDash(r.Value), result}
+			}
+			if err := output.PrintTable(headers, tableRows); err != nil {
+				return err
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d change(s) failed", failed, len(results))
+		}
+		if !output.IsJSON(cmd) {
+			fmt.Fprintf(os.Stderr, "\n%d change(s) applied.\n", len(results))
+		}
+		return nil
+	},
+}
+
+// applyResult is the outcome of one row of a changes file.
+type applyResult struct {
+	changes.Row
+	Status       string `json:"status"` // applied or failed
+	ResourceName string `json:"resourceName,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// sendChanges sends ops in one mutate request per service, in
+// changes.Services order, and returns the outcome of each in file order.
+func sendChanges(cid string, ops []changes.Operation) []applyResult {
+	results := make([]applyResult, len(ops))
+	for _, service := range changes.Services {
+		var idx []int
+		var payload []map[string]any
+		for i, op := range ops {
+			if op.Service == service {
+				idx = append(idx, i)
+				payload = append(payload, op.Op)
+			}
+		}
+		if len(payload) == 0 {
+			continue
+		}
+		for _, i := range idx {
+			results[i] = applyResult{Row: ops[i].Row, Status: "failed"}
+		}
+		resp, err := mutateService(cid, service, payload)
+		if err != nil {
+			for _, i := range idx {
+				results[i].Error = err.Error()
+			}
+			continue
+		}
+		for n, i := range idx {
+			if n < len(resp.Results) && resp.Results[n].ResourceName != "" {
+				results[i].Status = "applied"
+				results[i].ResourceName = resp.Results[n].ResourceName
+			}
+		}
+		for _, e := range resp.OperationErrors() {
+			reason := e.Message
+			if e.Code != "" {
+				reason = e.Code + ": " + e.Message
+			}
+			if e.Index >= 0 && e.Index < len(idx) {
+				results[idx[e.Index]].Error = reason
+				continue
+			}
+			// Not tied to an operation: it explains every row that failed.
+			for _, i := range idx {
+				if results[i].Status == "failed" && results[i].Error == "" {
+					results[i].Error = reason
+				}
+			}
+		}
+	}
+	return results
+}
+
+// mutateService sends operations to the mutate method of service.
+func mutateService(cid, service string, ops []map[string]any) (*api.MutateResponse, error) {
+	switch service {
+	case "campaignBudgets":
+		return apiClient.MutateCampaignBudgets(cid, ops)
+	case "campaigns":
+		return apiClient.MutateCampaigns(cid, ops)
+	case "adGroups":
+		return apiClient.MutateAdGroups(cid, ops)
+	case "adGroupCriteria":
+		return apiClient.MutateAdGroupCriteria(cid, ops)
+	}
+	return nil, fmt.Errorf("unsupported service %q", service)
+}
+
+// applySummary counts changes by entity and action for the confirmation,
+// e.g. "12 × keyword pause", in file order.
+func applySummary(ops []changes.Operation) []string {
+	var keys []string
+	counts := make(map[string]int)
+	for _, op := range ops {
+		key := op.Row.Entity + " " + op.Row.Action
+		if counts[key] == 0 {
+			keys = append(keys, key)
+		}
+		counts[key]++
+	}
+	lines := make([]string, len(keys))
+	for i, key := range keys {
+		lines[i] = fmt.Sprintf("%d × %s", counts[key], key)
+	}
+	return lines
+}
+
+// applyPlanRequest is one mutate request apply would send, for --dry-run.
+type applyPlanRequest struct {
+	Service    string           `json:"service"`
+	Lines      []int            `json:"lines"`
+	Operations []map[string]any `json:"operations"`
+}
+
+// printApplyPlan prints the mutate requests ops would be sent as, without
+// sending them.
+func printApplyPlan(cmd *cobra.Command, cid string, ops []changes.Operation) error {
+	var plan []applyPlanRequest
+	for _, service := range changes.Services {
+		req := applyPlanRequest{Service: service}
+		for _, op := range ops {
+			if op.Service == service {
+				req.Lines = append(req.Lines, op.Row.Line)
+				req.Operations = append(req.Operations, op.Op)
+			}
+		}
+		if len(req.Operations) > 0 {
+			plan = append(plan, req)
+		}
+	}
+	if output.IsJSON(cmd) {
+		return output.PrintJSON(map[string]any{"dryRun": true, "requests": plan}, output.IsPretty(cmd))
+	}
+	for _, req := range plan {
+		fmt.Printf("POST customers/%s/%s:mutate (lines %s)\n", cid, req.Service, joinInts(req.Lines))
+		body, err := json.MarshalIndent(map[string]any{"operations": req.Operations}, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n\n", body)
+	}
+	fmt.Printf("DRY RUN — nothing sent; %d operation(s) in %d request(s)\n", len(ops), len(plan))
+	return nil
+}
+
+func joinInts(ns []int) string {
+	s := ""
+	for i, n := range ns {
+		if i > 0 {
+			s += ", "
+		}
+		s += strconv.Itoa(n)
+	}
+	return s
+}
+
+// applyResources maps each service to the GAQL resource and the JSON key
+// of its rows, to read the values apply changes.
+var applyResources = map[string][2]string{
+	"campaignBudgets": {"campaign_budget", "campaignBudget"},
+	"campaigns":       {"campaign", "campaign"},
+	"adGroups":        {"ad_group", "adGroup"},
+	"adGroupCriteria": {"ad_group_criterion", "adGroupCriterion"},
+}
+
+// applyColumns maps the fields apply changes to their GAQL columns.
+var applyColumns = map[string]string{
+	"status":       "status",
+	"cpcBidMicros": "cpc_bid_micros",
+	"amountMicros": "amount_micros",
+}
+
+// recordApplyUndo reads the current value of everything ops change and
+// records, per service, the operations restoring them. A service with a
+// remove, or whose values cannot be read, is recorded as not undoable.
+func recordApplyUndo(cid string, ops []changes.Operation) {
+	for _, service := range changes.Services {
+		var mine []changes.Operation
+		for _, op := range ops {
+			if op.Service == service {
+				mine = append(mine, op)
+			}
+		}
+		if len(mine) > 0 {
+			recordUndo(service, applyUndo(cid, service, mine))
+		}
+	}
+}
+
+func applyUndo(cid, service string, ops []changes.Operation) *audit.Undo {
+	res := applyResources[service]
+	columns := []string{res[0] + ".resource_name"}
+	seen := map[string]bool{}
+	var names []string
+	for _, op := range ops {
+		if _, ok := op.Op["remove"]; ok {
+			return audit.NotUndoable(fmt.Sprintf("line %d removes %s %s, which cannot be restored", op.Row.Line, op.Row.Entity, op.Row.ID))
+		}
+		field, _ := op.Op["updateMask"].(string)
+		if !seen[field] {
+			seen[field] = true
+			columns = append(columns, res[0]+"."+applyColumns[field])
+		}
+		names = append(names, gaql.Quote(op.Resource))
+	}
+
+	previous := map[string]map[string]any{}
+	const chunk = 500
+	for start := 0; start < len(names); start += chunk {
+		end := min(start+chunk, len(names))
+		query := gaql.Select(columns...).
+			From(res[0]).
+			Where(res[0] + ".resource_name IN (" + strings.Join(names[start:end], ", ") + ")").
+			String()
+		rows, err := apiClient.Search(cid, query)
+		if err != nil {
+			return audit.NotUndoable(fmt.Sprintf("the previous values could not be read: %v", err))
+		}
+		for _, raw := range rows {
+			var row map[string]map[string]any
+			if json.Unmarshal(raw, &row) == nil {
+				if name, ok := row[res[1]]["resourceName"].(string); ok {
+					previous[name] = row[res[1]]
+				}
+			}
+		}
+	}
+
+	undo := make([]map[string]any, len(ops))
+	for i, op := range ops {
+		values, ok := previous[op.Resource]
+		if !ok {
+			return audit.NotUndoable(fmt.Sprintf("the previous values of %s %s (line %d) could not be read", op.Row.Entity, op.Row.ID, op.Row.Line))
+		}
+		field, _ := op.Op["updateMask"].(string)
+		undo[i] = undoUpdate(op.Resource, field, values[field])
+		if values[field] == nil {
+			// Unset before (e.g. a keyword using its ad group's bid): the
+			// masked field without a value clears it again.
+			delete(undo[i]["update"].(map[string]any), field)
+		}
+	}
+	return audit.Restore(undo)
+}
+
+// campaignBudgets resolves the budget of a campaign for set-budget rows. All
+// campaigns' budgets are read in one query, on first use.
+type campaignBudgets struct {
+	cid        string
+	byCampaign map[string]string
+	err        error
+}
+
+// CampaignBudget implements changes.Resolver.
+func (b *campaignBudgets) CampaignBudget(campaignID string) (string, error) {
+	if b.byCampaign == nil && b.err == nil {
+		query := gaql.Select("campaign.id", "campaign.campaign_budget").
+			From("campaign").
+			Where("campaign.status != 'REMOVED'").
+			String()
+		rows, err := apiClient.Search(b.cid, query)
+		if err != nil {
+			b.err = fmt.Errorf("looking up campaign budgets: %w", err)
+			return "", b.err
+		}
+		b.byCampaign = make(map[string]string, len(rows))
+		for _, raw := range rows {
+			var row api.CampaignRow
+			if json.Unmarshal(raw, &row) == nil {
+				b.byCampaign[row.Campaign.ID] = row.Campaign.CampaignBudget
+			}
+		}
+	}
+	if b.err != nil {
+		return "", b.err
+	}
+	budget, ok := b.byCampaign[campaignID]
+	if !ok || budget == "" {
+		return "", fmt.Errorf("campaign %s not found, or has no budget", campaignID)
+	}
+	return budget, nil
+}
+
+func init() {
+	applyCmd.Flags().StringVar(&applyFile, "file", "", "CSV or JSON changes file, or - for stdin (required)")
+	applyCmd.MarkFlagRequired("file")
+	rootCmd.AddCommand(applyCmd)
+}
@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image"
+	_ "image/gif" // register decoders for image.DecodeConfig
+	_ "image/jpeg"
+	_ "image/png"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/gaql"
+	"github.com/the20100/gads-cli/internal/output"
+)
+
+var assetsCmd = &cobra.Command{
+	Use:   "assets",
+	Short: "Manage text and image assets",
+}
+
+var (
+	assetType string
+	assetText string
+	assetFile string
+	assetName string
+)
+
+// maxImageAssetBytes is the largest image file the API accepts (5120 KB).
+const maxImageAssetBytes = 5120 * 1024
+
+// imageAssetTypes are the image formats the API accepts.
+var imageAssetTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// ---- assets list ----
+
+var assetsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List assets in an account",
+	Long: `List assets with their ID, type, and a preview: the text of text, sitelink and
+callout assets, or the dimensions of image assets.
+
+Examples:
+  gads-cli assets list --account=1234567890
+  gads-cli assets list --account=1234567890 --type=IMAGE
+  gads-cli assets list --account=1234567890 --type=SITELINK --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, err := accountID()
+		if err != nil {
+			return err
+		}
+
+		query := gaql.Select(
+			"asset.id", "asset.name", "asset.type", "asset.text_asset.text",
+			"asset.image_asset.mime_type", "asset.image_asset.file_size",
+			"asset.image_asset.full_size.width_pixels", "asset.image_asset.full_size.height_pixels",
+			"asset.image_asset.full_size.url",
+			"asset.sitelink_asset.link_text", "asset.sitelink_asset.description1", "asset.sitelink_asset.description2",
+			"asset.callout_asset.callout_text").
+			From("asset").
+			WhereIf(assetType != "", "asset.type = "+gaql.Quote(strings.ToUpper(assetType))).
+			OrderBy("asset.id", false).
+			String()
+
+		rows, err := apiClient.Search(cid, query)
+		if err != nil {
+			return err
+		}
+
+		var assets []api.Asset
+		for _, raw := range rows {
+			var row api.AssetRow
+			if err := json.Unmarshal(raw, &row); err != nil {
+				continue
+			}
+			assets = append(assets, row.Asset)
+		}
+
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(assets, output.IsPretty(cmd))
+		}
+		if len(assets) == 0 {
+			fmt.Println("No assets found.")
+			return nil
+		}
+
+		headers := []string{"ID", "TYPE", "NAME", "PREVIEW"}
+		tableRows := make([][]string, len(assets))
+		for i, a := range assets {
+			tableRows[i] = []string{
+				a.ID,
+				a.Type,
+				a.Name,
+				assetPreview(a),
+			}
+		}
+		return output.PrintTable(headers, tableRows)
+	},
+}
+
+// assetPreview summarizes an asset's content for the list table.
+func assetPreview(a api.Asset) string {
+	switch a.Type {
+	case "TEXT":
+		return a.TextAsset.Text
+	case "IMAGE":
+		img := a.ImageAsset
+		return fmt.Sprintf("%dx%d %s (%d KB)", img.FullSize.WidthPixels, img.FullSize.HeightPixels, img.MimeType, img.FileSize/1024)
+	case "SITELINK":
+		return a.SitelinkAsset.LinkText
+	case "CALLOUT":
+		return a.CalloutAsset.CalloutText
+	}
+	return "-"
+}
+
+// ---- assets create-text ----
+
+var assetsCreateTextCmd = &cobra.Command{
+	Use:   "create-text",
+	Short: "Create a text asset",
+	Long: `Create a text asset, e.g. a headline or description to reuse across ads.
+
+Examples:
+  gads-cli assets create-text --account=1234567890 --text="Free shipping on all orders"`,
+	Annotations: mutatingCommand,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, err := accountID()
+		if err != nil {
+			return err
+		}
+		if assetText == "" {
+			return fmt.Errorf("--text is required")
+		}
+
+		asset := map[string]any{"textAsset": map[string]any{"text": assetText}}
+		if assetName != "" {
+			asset["name"] = assetName
+		}
+		return createAsset(cid, asset, fmt.Sprintf("text asset %q", assetText))
+	},
+}
+
+// ---- assets create-image ----
+
+var assetsCreateImageCmd = &cobra.Command{
+	Use:   "create-image",
+	Short: "Upload an image asset",
+	Long: `Upload a JPEG, PNG or GIF file (at most 5120 KB) as an image asset. The asset
+name defaults to the file name.
+
+Examples:
+  gads-cli assets create-image --account=1234567890 --file=logo.png
+  gads-cli assets create-image --account=1234567890 --file=banner.jpg --name="Spring banner"`,
+	Annotations: mutatingCommand,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, err := accountID()
+		if err != nil {
+			return err
+		}
+		if assetFile == "" {
+			return fmt.Errorf("--file is required")
+		}
+
+		data, err := readImageAsset(assetFile)
+		if err != nil {
+			return err
+		}
+		name := assetName
+		if name == "" {
+			name = filepath.Base(assetFile)
+		}
+		asset := map[string]any{
+			"name":       name,
+			"imageAsset": map[string]any{"data": base64.StdEncoding.EncodeToString(data)},
+		}
+		return createAsset(cid, asset, fmt.Sprintf("image asset %q (%d KB)", name, len(data)/1024))
+	},
+}
+
+// readImageAsset reads an image file and checks it against the API's size and
+// format limits before anything is uploaded.
+func readImageAsset(path string) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	if info.Size() > maxImageAssetBytes {
+		return nil, fmt.Errorf("%s is %d KB; image assets are limited to %d KB", path, info.Size()/1024, maxImageAssetBytes/1024)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%s is empty", path)
+	}
+	if mime := http.DetectContentType(data); !imageAssetTypes[mime] {
+		return nil, fmt.Errorf("%s is %s; image assets must be JPEG, PNG or GIF", path, mime)
+	}
+	if _, _, err := image.DecodeConfig(bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("%s is not a valid image: %w", path, err)
+	}
+	return data, nil
+}
+
+// createAsset sends a single asset create operation, described as what in
+// the confirmation, and prints the result.
+func createAsset(cid string, asset map[string]any, what string) error {
+	if ok, err := confirmChange("Create this asset?", fmt.Sprintf("Account %s: create %s", cid, what)); !ok {
+		return err
+	}
+	ops := []map[string]any{{"create": asset}}
+	resp, err := apiClient.MutateAssets(cid, ops)
+	if err != nil {
+		return err
+	}
+	if apiClient.ValidateOnly() {
+		return reportDryRun(resp, len(ops))
+	}
+	if jsonResult() {
+		return printMutateResult(len(ops), resp)
+	}
+	if len(resp.Results) > 0 {
+		fmt.Printf("Asset created: %s\n", resp.Results[0].ResourceName)
+	}
+	return nil
+}
+
+func init() {
+	assetsListCmd.Flags().StringVar(&assetType, "type", "", "Only list this asset type: TEXT, IMAGE, SITELINK, CALLOUT, ...")
+	assetsCreateTextCmd.Flags().StringVar(&assetText, "text", "", "Asset text (required)")
+	assetsCreateImageCmd.Flags().StringVar(&assetFile, "file", "", "JPEG, PNG or GIF file (required)")
+	for _, c := range []*cobra.Command{assetsCreateTextCmd, assetsCreateImageCmd} {
+		c.Flags().StringVar(&assetName, "name", "", "Asset name")
+	}
+
+	assetsCmd.AddCommand(assetsListCmd, assetsCreateTextCmd, assetsCreateImageCmd)
+	rootCmd.AddCommand(assetsCmd)
+}
@@ -0,0 +1,383 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/gaql"
+	"github.com/the20100/gads-cli/internal/output"
+)
+
+var audiencesCmd = &cobra.Command{
+	Use:   "audiences",
+	Short: "List audiences (user lists)",
+}
+
+var adgroupsAudienceCmd = &cobra.Command{
+	Use:   "audience",
+	Short: "Manage ad group audiences",
+}
+
+var campaignsAudienceCmd = &cobra.Command{
+	Use:   "audience",
+	Short: "Manage campaign audiences",
+}
+
+var (
+	audAdGroup     string
+	audCampaign    string
+	audUserList    string
+	audBidModifier float64
+	audObservation bool
+	audTargeting   bool
+)
+
+// Minimum active list sizes before a user list serves.
+const (
+	minUserListSizeSearch  = 1000
+	minUserListSizeDisplay = 100
+)
+
+// ---- audiences list ----
+
+var audiencesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List user lists with their size",
+	Long: `List user lists with their type, membership status and estimated size on the
+Search and Display networks. Lists below the minimum size to serve (1000
+users for Search, 100 for Display) are flagged with "!".
+
+Examples:
+  gads-cli audiences list --account=1234567890
+  gads-cli audiences list --account=1234567890 --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, err := accountID()
+		if err != nil {
+			return err
+		}
+
+		query := gaql.Select(
+			"user_list.id", "user_list.name", "user_list.type", "user_list.membership_status",
+			"user_list.size_for_search", "user_list.size_for_display",
+			"user_list.eligible_for_search", "user_list.eligible_for_display").
+			From("user_list").
+			OrderBy("user_list.name", false).
+			String()
+
+		rows, err := apiClient.Search(cid, query)
+		if err != nil {
+			return err
+		}
+
+		var lists []api.UserList
+		for _, raw := range rows {
+			var row api.UserListRow
+			if err := json.Unmarshal(raw, &row); err != nil {
+				continue
+			}
+			lists = append(lists, row.UserList)
+		}
+
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(lists, output.IsPretty(cmd))
+		}
+		if len(lists) == 0 {
+			fmt.Println("No user lists found.")
+			return nil
+		}
+
+		headers := []string{"ID", "NAME", "TYPE", "STATUS", "SEARCH SIZE", "DISPLAY SIZE"}
+		tableRows := make([][]string, len(lists))
+		for i, l := range lists {
+			search := api.FormatMetricInt(int64(l.SizeForSearch))
+			if l.SizeForSearch < minUserListSizeSearch {
+				search += " !"
+			}
+			display := api.FormatMetricInt(int64(l.SizeForDisplay))
+			if l.SizeForDisplay < minUserListSizeDisplay {
+				display += " !"
+			}
+			tableRows[i] = []string{
+				l.ID,
+				l.Name,
+				l.Type,
+				output.Colorize(l.MembershipStatus),
+				search,
+				display,
+			}
+		}
+		return output.PrintTable(headers, tableRows)
+	},
+}
+
+// ---- adgroups audience attach ----
+
+var adgroupsAudienceAttachCmd = &cobra.Command{
+	Use:   "attach",
+	Short: "Attach a user list to an ad group",
+	Long: `Attach a user list to an ad group, optionally with a bid modifier.
+
+--observation makes all audiences on the ad group adjust bids only;
+--targeting makes them also restrict who sees the ads. Without either, the
+ad group's current setting is left unchanged.
+
+Examples:
+  gads-cli adgroups audience attach --account=1234567890 --adgroup=444555666 --user-list=777888999
+  gads-cli adgroups audience attach --account=1234567890 --adgroup=444555666 --user-list=777888999 --bid-modifier=1.2 --observation`,
+	Annotations: mutatingCommand,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if audAdGroup == "" {
+			return fmt.Errorf("--adgroup is required")
+		}
+		if !api.IsNumericID(audAdGroup) {
+			return fmt.Errorf("--adgroup must be a numeric ID")
+		}
+		cid, criterion, err := userListCriterion()
+		if err != nil {
+			return err
+		}
+		adGroupName := fmt.Sprintf("customers/%s/adGroups/%s", cid, audAdGroup)
+		criterion["adGroup"] = adGroupName
+		if ok, err := confirmChange("Attach this audience?", audienceChanges("Ad group", audAdGroup)...); !ok {
+			return err
+		}
+
+		ops := []map[string]any{{"create": criterion}}
+		resp, err := apiClient.MutateAdGroupCriteria(cid, ops)
+		if err != nil {
+			return err
+		}
+		if !jsonResult() {
+			if err := reportAudienceAttach(resp, len(ops), "ad group", audAdGroup); err != nil {
+				return err
+			}
+		}
+
+		if !audObservation && !audTargeting {
+			if jsonResult() {
+				return printMutateResult(len(ops), resp)
+			}
+			return nil
+		}
+		query := gaql.Select("ad_group.targeting_setting.target_restrictions").
+			From("ad_group").
+			Where("ad_group.id = " + gaql.Quote(audAdGroup)).
+			String()
+		rows, err := apiClient.Search(cid, query)
+		if err != nil {
+			return err
+		}
+		var current *api.TargetingSetting
+		if len(rows) > 0 {
+			var row api.AdGroupRow
+			if err := json.Unmarshal(rows[0], &row); err == nil {
+				current = row.AdGroup.TargetingSetting
+			}
+		}
+		settingOps := []map[string]any{audienceTargetingUpdate(adGroupName, current)}
+		settingResp, err := apiClient.MutateAdGroups(cid, settingOps)
+		if err != nil {
+			return fmt.Errorf("updating the ad group's audience setting: %w", err)
+		}
+		return reportAudienceSetting(resp, settingResp, len(ops), len(settingOps), "ad group", audAdGroup)
+	},
+}
+
+// ---- campaigns audience attach ----
+
+var campaignsAudienceAttachCmd = &cobra.Command{
+	Use:   "attach",
+	Short: "Attach a user list to a campaign",
+	Long: `Attach a user list to a campaign, optionally with a bid modifier.
+
+--observation makes all audiences on the campaign adjust bids only;
+--targeting makes them also restrict who sees the ads. Without either, the
+campaign's current setting is left unchanged.
+
+Examples:
+  gads-cli campaigns audience attach --account=1234567890 --campaign=111222333 --user-list=777888999
+  gads-cli campaigns audience attach --account=1234567890 --campaign=111222333 --user-list=777888999 --bid-modifier=1.5 --targeting`,
+	Annotations: mutatingCommand,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if audCampaign == "" {
+			return fmt.Errorf("--campaign is required")
+		}
+		if !api.IsNumericID(audCampaign) {
+			return fmt.Errorf("--campaign must be a numeric ID")
+		}
+		cid, criterion, err := userListCriterion()
+		if err != nil {
+			return err
+		}
+		campaignName := fmt.Sprintf("customers/%s/campaigns/%s", cid, audCampaign)
+		criterion["campaign"] = campaignName
+		if ok, err := confirmChange("Attach this audience?", audienceChanges("Campaign", audCampaign)...); !ok {
+			return err
+		}
+
+		ops := []map[string]any{{"create": criterion}}
+		resp, err := apiClient.MutateCampaignCriteria(cid, ops)
+		if err != nil {
+			return err
+		}
+		if !jsonResult() {
+			if err := reportAudienceAttach(resp, len(ops), "campaign", audCampaign); err != nil {
+				return err
+			}
+		}
+
+		if !audObservation && !audTargeting {
+			if jsonResult() {
+				return printMutateResult(len(ops), resp)
+			}
+			return nil
+		}
+		query := gaql.Select("campaign.targeting_setting.target_restrictions").
+			From("campaign").
+			Where("campaign.id = " + gaql.Quote(audCampaign)).
+			String()
+		rows, err := apiClient.Search(cid, query)
+		if err != nil {
+			return err
+		}
+		var current *api.TargetingSetting
+		if len(rows) > 0 {
+			var row api.CampaignRow
+			if err := json.Unmarshal(rows[0], &row); err == nil {
+				current = row.Campaign.TargetingSetting
+			}
+		}
+		settingOps := []map[string]any{audienceTargetingUpdate(campaignName, current)}
+		settingResp, err := apiClient.MutateCampaigns(cid, settingOps)
+		if err != nil {
+			return fmt.Errorf("updating the campaign's audience setting: %w", err)
+		}
+		return reportAudienceSetting(resp, settingResp, len(ops), len(settingOps), "campaign", audCampaign)
+	},
+}
+
+// userListCriterion validates the shared attach flags and returns the
+// customer ID and a criterion payload for --user-list. Callers add the
+// adGroup or campaign it belongs to.
+func userListCriterion() (string, map[string]any, error) {
+	cid, err := accountID()
+	if err != nil {
+		return "", nil, err
+	}
+	if audUserList == "" {
+		return "", nil, fmt.Errorf("--user-list is required")
+	}
+	if !api.IsNumericID(audUserList) {
+		return "", nil, fmt.Errorf("--user-list must be a numeric ID")
+	}
+	if audBidModifier != 0 && (audBidModifier < 0.1 || audBidModifier > 10) {
+		return "", nil, fmt.Errorf("--bid-modifier must be between 0.1 and 10")
+	}
+	if audObservation && audTargeting {
+		return "", nil, fmt.Errorf("--observation and --targeting are mutually exclusive")
+	}
+
+	criterion := map[string]any{
+		"userList": map[string]any{
+			"userList": fmt.Sprintf("customers/%s/userLists/%s", cid, audUserList),
+		},
+	}
+	if audBidModifier != 0 {
+		criterion["bidModifier"] = audBidModifier
+	}
+	return cid, criterion, nil
+}
+
+// audienceChanges describes an attach for its confirmation.
+func audienceChanges(kind, id string) []string {
+	attach := fmt.Sprintf("%s %s: attach user list %s", kind, id, audUserList)
+	if audBidModifier != 0 {
+		attach += fmt.Sprintf(" (bid modifier %g)", audBidModifier)
+	}
+	changes := []string{attach}
+	switch {
+	case audObservation:
+		changes = append(changes, fmt.Sprintf("%s %s: audience setting → observation", kind, id))
+	case audTargeting:
+		changes = append(changes, fmt.Sprintf("%s %s: audience setting → targeting", kind, id))
+	}
+	return changes
+}
+
+// audienceTargetingUpdate returns an update operation for resourceName that
+// sets the AUDIENCE target restriction per --observation/--targeting. The API
+// replaces the whole list, so restrictions on other dimensions are kept.
+func audienceTargetingUpdate(resourceName string, current *api.TargetingSetting) map[string]any {
+	restrictions := []map[string]any{{
+		"targetingDimension": "AUDIENCE",
+		"bidOnly":            audObservation,
+	}}
+	if current != nil {
+		for _, r := range current.TargetRestrictions {
+			if r.TargetingDimension == "AUDIENCE" {
+				continue
+			}
+			restrictions = append(restrictions, map[string]any{
+				"targetingDimension": r.TargetingDimension,
+				"bidOnly":            r.BidOnly,
+			})
+		}
+	}
+	return map[string]any{
+		"updateMask": "targetingSetting.targetRestrictions",
+		"update": map[string]any{
+			"resourceName":     resourceName,
+			"targetingSetting": map[string]any{"targetRestrictions": restrictions},
+		},
+	}
+}
+
+// reportAudienceAttach prints the outcome of attaching --user-list to the
+// kind (ad group or campaign) id.
+func reportAudienceAttach(resp *api.MutateResponse, n int, kind, id string) error {
+	if apiClient.ValidateOnly() {
+		return reportDryRun(resp, n)
+	}
+	fmt.Printf("User list %s attached to %s %s.\n", audUserList, kind, id)
+	if len(resp.Results) > 0 {
+		fmt.Printf("Resource: %s\n", resp.Results[0].ResourceName)
+	}
+	return nil
+}
+
+// reportAudienceSetting prints the outcome of an audienceTargetingUpdate.
+// With --json it reports the attach mutate too, as one result.
+func reportAudienceSetting(attachResp, resp *api.MutateResponse, attachN, n int, kind, id string) error {
+	if jsonResult() {
+		return printMutateResult(attachN+n, attachResp, resp)
+	}
+	if apiClient.ValidateOnly() {
+		return reportDryRun(resp, n)
+	}
+	mode := "targeting"
+	if audObservation {
+		mode = "observation"
+	}
+	fmt.Printf("Audiences on %s %s set to %s.\n", kind, id, mode)
+	return nil
+}
+
+func init() {
+	adgroupsAudienceAttachCmd.Flags().StringVar(&audAdGroup, "adgroup", "", "Ad group ID (required)")
+	campaignsAudienceAttachCmd.Flags().StringVar(&audCampaign, "campaign", "", "Campaign ID (required)")
+	for _, c := range []*cobra.Command{adgroupsAudienceAttachCmd, campaignsAudienceAttachCmd} {
+		c.Flags().StringVar(&audUserList, "user-list", "", "User list ID (required)")
+		c.Flags().Float64Var(&audBidModifier, "bid-modifier", 0, "Bid modifier, 0.1–10 (e.g. 1.2 = +20%)")
+		c.Flags().BoolVar(&audObservation, "observation", false, "Audiences adjust bids only (observation)")
+		c.Flags().BoolVar(&audTargeting, "targeting", false, "Audiences also restrict reach (targeting)")
+	}
+
+	audiencesCmd.AddCommand(audiencesListCmd)
+	adgroupsAudienceCmd.AddCommand(adgroupsAudienceAttachCmd)
+	campaignsAudienceCmd.AddCommand(campaignsAudienceAttachCmd)
+	adgroupsCmd.AddCommand(adgroupsAudienceCmd)
+	campaignsCmd.AddCommand(campaignsAudienceCmd)
+	rootCmd.AddCommand(audiencesCmd)
+}
@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/audit"
+	"github.com/the20100/gads-cli/internal/config"
+	"github.com/the20100/gads-cli/internal/output"
+)
+
+var auditSince string
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Show the local log of changes made with gads-cli",
+	Long: `Every mutate request that succeeds is recorded in an append-only log in the
+configuration directory (audit.jsonl): when, the command line with secrets
+redacted, the account, the operations sent and the resource names returned.
+Dry runs are not recorded, and --no-audit skips a command. The log is
+rotated at 10 MB, keeping the previous file as audit.jsonl.1.
+
+Writing the log never fails a command: problems are reported as warnings.`,
+}
+
+// ---- audit list ----
+
+var auditListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List recorded changes, oldest first",
+	Long: `List recorded changes, oldest first, optionally only those of one account
+(--account or GADS_ACCOUNT; the default account does not filter) and those
+made since a time: a duration (24h, 7d), a date (2026-01-31) or an RFC 3339
+time.
+
+Examples:
+  gads-cli audit list
+  gads-cli audit list --account=1234567890 --since=7d
+  gads-cli audit list --since=2026-01-31 --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var since time.Time
+		if auditSince != "" {
+			t, err := parseSince(auditSince, time.Now())
+			if err != nil {
+				return err
+			}
+			since = t
+		}
+		cid, err := auditAccountFilter()
+		if err != nil {
+			return err
+		}
+		log, err := auditLog()
+		if err != nil {
+			return err
+		}
+		all, err := log.Entries()
+		if err != nil {
+			return err
+		}
+		entries := []audit.Entry{}
+		for _, e := range all {
+			if (cid == "" || e.CustomerID == cid) && !e.Time.Before(since) {
+				entries = append(entries, e)
+			}
+		}
+
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(entries, output.IsPretty(cmd))
+		}
+		if len(entries) == 0 {
+			fmt.Fprintf(os.Stderr, "No changes recorded in %s.\n", log.Path())
+			return nil
+		}
+		headers := []string{"ID", "TIME", "ACCOUNT", "ENDPOINT", "OPS", "RESOURCES", "COMMAND"}
+		rows := make([][]string, len(entries))
+		for i, e := range entries {
+			rows[i] = []string{
+				e.ID,
+				e.Time.Local().Format("2006-01-02 15:04:05"),
+				e.CustomerID,
+				auditEndpoint(e.Endpoint),
+				strconv.Itoa(countOperations(e.Operations)),
+				summarizeNames(e.ResourceNames),
+				e.Command,
+			}
+		}
+		return output.PrintTable(headers, rows)
+	},
+}
+
+// auditLog returns the audit log of the configuration directory.
+func auditLog() (*audit.Log, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return nil, fmt.Errorf("locating the audit log: %w", err)
+	}
+	return audit.New(filepath.Join(dir, audit.FileName), audit.DefaultMaxSize), nil
+}
+
+// auditRun groups the audit entries of this invocation.
+var auditRun = audit.NewRun()
+
+var (
+	pendingMu   sync.Mutex
+	pendingUndo = map[string]*audit.Undo{}
+	// undoing is the ID of the entry the undo command is reversing.
+	undoing string
+)
+
+// recordUndo declares how to reverse the next request to service (e.g.
+// "campaigns"), for its audit entry. Commands call it before sending, once
+// they know the state they change. The next request uses it up, even when it
+// fails or only validates.
+func recordUndo(service string, u *audit.Undo) {
+	pendingMu.Lock()
+	defer pendingMu.Unlock()
+	pendingUndo[service] = u
+}
+
+// auditMutation records m in the audit log when it changed state. It never
+// fails the command: an entry that cannot be written is reported on stderr.
+func auditMutation(m api.Mutation) {
+	service := audit.Service(m.Endpoint)
+	pendingMu.Lock()
+	undo := pendingUndo[service]
+	delete(pendingUndo, service)
+	pendingMu.Unlock()
+	if m.Err != nil || m.ValidateOnly {
+		return
+	}
+
+	e := audit.NewEntry(m, auditRun, auditCommandLine(), time.Now())
+	e.Undo, e.Undoes = undo, undoing
+	if e.Undo == nil {
+		e.Undo = audit.NotUndoable(defaultUndoReason(e))
+	}
+
+	log, err := auditLog()
+	if err == nil {
+		err = log.Append(e)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not write the audit log: %v\n", err)
+	}
+}
+
+// defaultUndoReason explains why an entry whose command declared no undo
+// cannot be undone.
+func defaultUndoReason(e audit.Entry) string {
+	var ops []map[string]any
+	json.Unmarshal(e.Operations, &ops)
+	for _, op := range ops {
+		if _, ok := op["remove"]; ok {
+			return "it removed resources, which cannot be restored"
+		}
+	}
+	name := "this command"
+	if activeCmd != nil {
+		name = strings.TrimPrefix(activeCmd.CommandPath(), rootCmd.Name()+" ")
+	}
+	return fmt.Sprintf("%s does not record the state it changes (%s)", name, auditEndpoint(e.Endpoint))
+}
+
+// secretFlagRe matches flags whose values are not written to the audit log.
+var secretFlagRe = regexp.MustCompile(`^--?[\w-]*(token|secret|password)[\w-]*$`)
+
+// auditCommandLine returns the invocation like commandLine, with the values
+// of secret flags (e.g. --developer-token) replaced by REDACTED.
+func auditCommandLine() string {
+	args := append([]string(nil), os.Args[1:]...)
+	for i, arg := range args {
+		name, _, hasValue := strings.Cut(arg, "=")
+		if !secretFlagRe.MatchString(name) {
+			continue
+		}
+		if hasValue {
+			args[i] = name + "=REDACTED"
+		} else if i+1 < len(args) {
+			args[i+1] = "REDACTED"
+		}
+	}
+	return shellJoin(args)
+}
+
+// auditAccountFilter returns the customer ID given by --account or
+// GADS_ACCOUNT, or "" to list every account.
+func auditAccountFilter() (string, error) {
+	ref := accountFlag
+	if ref == "" {
+		ref = resolveEnv("GADS_ACCOUNT")
+	}
+	if ref == "" {
+		return "", nil
+	}
+	creds, err := config.Load()
+	if err != nil {
+		return "", fmt.Errorf("failed to load credentials: %w", err)
+	}
+	return resolveAccount(ref, creds.Aliases)
+}
+
+// parseSince parses --since: a duration before now (90m, 24h, 7d), a date
+// (start of that day, local time) or an RFC 3339 time.
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q (want e.g. 24h, 7d, 2026-01-31 or 2026-01-31T09:00:00Z)", s)
+}
+
+// auditEndpoint shortens an endpoint for the table:
+// "customers/123/campaigns:mutate" → "campaigns:mutate".
+func auditEndpoint(endpoint string) string {
+	if i := strings.LastIndex(endpoint, "/"); i >= 0 {
+		return endpoint[i+1:]
+	}
+	return endpoint
+}
+
+// countOperations returns the length of a list of operations, or 1 for a
+// request without one.
+func countOperations(ops json.RawMessage) int {
+	var list []json.RawMessage
+	if json.Unmarshal(ops, &list) != nil {
+		return 1
+	}
+	return len(list)
+}
+
+// summarizeNames shows the first resource name and how many others follow.
+func summarizeNames(names []string) string {
+	switch len(names) {
+	case 0:
+		return "-"
+	case 1:
+		return names[0]
+	}
+	return fmt.Sprintf("%s (+%d more)", names[0], len(names)-1)
+}
+
+func init() {
+	auditListCmd.Flags().StringVar(&auditSince, "since", "", "Only changes since this time: a duration (24h, 7d), a date or an RFC 3339 time")
+	auditCmd.AddCommand(auditListCmd)
+	rootCmd.AddCommand(auditCmd)
+}
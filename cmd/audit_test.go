@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAuditCommandLine(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "no secrets",
+			args: []string{"campaigns", "pause", "--account=111", "--id", "222"},
+			want: "gads-cli campaigns pause --account=111 --id 222",
+		},
+		{
+			name: "flag with value after =",
+			args: []string{"auth", "login", "--x-token=v", "--manager-account=111"},
+			want: "gads-cli auth login --x-token=REDACTED --manager-account=111",
+		},
+		{
+			name: "flag with separate value",
+			args: []string{"auth", "login", "--client-secret", "v", "--no-browser"},
+			want: "gads-cli auth login --client-secret REDACTED --no-browser",
+		},
+		{
+			name: "secret flag as the last argument",
+			args: []string{"auth", "login", "--developer-token"},
+			want: "gads-cli auth login --developer-token",
+		},
+		{
+			name: "single dash",
+			args: []string{"auth", "login", "-password=v"},
+			want: "gads-cli auth login -password=REDACTED",
+		},
+	}
+	saved := os.Args
+	t.Cleanup(func() { os.Args = saved })
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Args = append([]string{"gads-cli"}, tt.args...)
+			got := auditCommandLine()
+			if got != tt.want {
+				t.Errorf("auditCommandLine() = %q, want %q", got, tt.want)
+			}
+			if strings.Contains(got, " v ") || strings.HasSuffix(got, "=v") {
+				t.Errorf("secret value in %q", got)
+			}
+		})
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 30, 0, 0, time.UTC)
+	tests := []struct {
+		s       string
+		want    time.Time
+		wantErr bool
+	}{
+		{s: "7d", want: now.AddDate(0, 0, -7)},
+		{s: "0d", want: now},
+		{s: "24h", want: now.Add(-24 * time.Hour)},
+		{s: "90m", want: now.Add(-90 * time.Minute)},
+		{s: "2026-01-31", want: time.Date(2026, 1, 31, 0, 0, 0, 0, time.Local)},
+		{s: "2026-01-31T09:00:00Z", want: time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC)},
+		{s: "2026-01-31T09:00:00+02:00", want: time.Date(2026, 1, 31, 7, 0, 0, 0, time.UTC)},
+		{s: "-7d", wantErr: true},
+		{s: "-24h", wantErr: true},
+		{s: "7w", wantErr: true},
+		{s: "yesterday", wantErr: true},
+		{s: "2026-02-30", wantErr: true},
+		{s: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseSince(tt.s, now)
+		if tt.wantErr {
+			if err == nil || !strings.Contains(err.Error(), "invalid --since") {
+				t.Errorf("parseSince(%q) = %v, %v; want an invalid --since error", tt.s, got, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseSince(%q): %v", tt.s, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseSince(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
@@ -3,17 +3,27 @@ package cmd
 import (
 	"bufio"
 	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"html"
 	"net"
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/auth"
 	"github.com/the20100/gads-cli/internal/config"
+	"github.com/the20100/gads-cli/internal/gaql"
+	"github.com/the20100/gads-cli/internal/output"
 	"golang.org/x/oauth2"
+	"golang.org/x/term"
 )
 
 var authCmd = &cobra.Command{
@@ -28,6 +38,12 @@ var (
 	authDeveloperToken  string
 	authManagerAccount  string
 	authNoBrowser       bool
+	authRedirectPort    int
+	authKeyring         bool
+	authKeyringFallback bool
+	authEncrypt         bool
+	authServiceAccount  string
+	authImpersonate     string
 )
 
 var authLoginCmd = &cobra.Command{
@@ -38,7 +54,8 @@ var authLoginCmd = &cobra.Command{
 You need:
   1. A Google Cloud project with OAuth2 credentials (client_id + client_secret).
      Create one at https://console.cloud.google.com/apis/credentials
-     Set redirect URI to: http://localhost:8080
+     Use a "Desktop app" client: any loopback port is accepted, and the CLI
+     picks a free one. For clients locked to a port, pass --redirect-port.
   2. A Google Ads developer token from:
      https://ads.google.com/aw/apicenter
   3. Your Manager Account (MCC) customer ID.
@@ -46,12 +63,30 @@ You need:
 Run with a credentials file:
   gads-cli auth login --credentials-file=~/Downloads/client_secret.json
 
+Store credentials under a named profile (e.g. a second MCC):
+  gads-cli auth login --profile=work --credentials-file=~/Downloads/client_secret.json
+
 On a remote server (VPS) where no browser is available:
   gads-cli auth login --credentials-file=~/Downloads/client_secret.json --no-browser
 
-  This prints the auth URL for you to open locally. After authorizing, your
-  browser will redirect to localhost:8080 (which will fail to load — that's ok).
-  Copy the full URL from the address bar and paste it into the terminal.
+  This prints the auth URL for you to open on any machine. After authorizing,
+  your browser will redirect to localhost:8080 (which will fail to load — that's
+  ok). Paste the full URL from the address bar, or just the code= value, into
+  the terminal.
+
+With a service account using domain-wide delegation (no browser flow):
+  gads-cli auth login --service-account-key=key.json --impersonate=user@company.com \
+    --developer-token=TOKEN --manager-account=1234567890
+
+Store secrets (client secret, tokens, developer token) in the OS keychain
+instead of the credentials file:
+  gads-cli auth login --keyring
+
+  Without a keychain the login fails rather than saving secrets in plain text,
+  unless --keyring-fallback is set.
+
+Encrypt the credentials file with a passphrase (prompted, or GADS_PASSPHRASE):
+  gads-cli auth login --encrypt
 
 Or provide values interactively when prompted.`,
 	RunE: runAuthLogin,
@@ -64,6 +99,10 @@ func runAuthLogin(cmd *cobra.Command, args []string) error {
 		creds = &config.Credentials{}
 	}
 
+	if authServiceAccount != "" {
+		return runServiceAccountLogin(creds)
+	}
+
 	// --- Collect client_id and client_secret ---
 	if authCredentialsFile != "" {
 		clientID, clientSecret, err := config.ParseCredentialsFile(authCredentialsFile)
@@ -101,62 +140,159 @@ func runAuthLogin(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 	fmt.Println("Starting OAuth2 authorization flow...")
 
-	code, err := runOAuthFlow(creds, authNoBrowser)
+	// PKCE: the verifier never leaves this process.
+	pkce := auth.NewPKCE(nil)
+	code, redirectURL, err := runOAuthFlow(creds, authNoBrowser, authRedirectPort, pkce)
 	if err != nil {
 		return err
 	}
 
-	// Exchange code for tokens
-	oauthCfg := config.NewOAuthConfig(creds)
-	token, err := oauthCfg.Exchange(context.Background(), code, oauth2.AccessTypeOffline)
+	// Exchange code for tokens (the redirect URI must match the authorization request)
+	oauthCfg := config.NewOAuthConfig(creds, redirectURL)
+	token, err := oauthCfg.Exchange(oauthContext(), code, oauth2.AccessTypeOffline, pkce.ExchangeOption())
 	if err != nil {
 		return fmt.Errorf("exchanging auth code: %w", err)
 	}
 
+	setOAuthToken(creds, token)
+	if authKeyring {
+		creds.Storage = config.StorageKeyring
+	}
+	if authEncrypt {
+		if os.Getenv("GADS_PASSPHRASE") == "" {
+			pass, err := promptNewPassphrase()
+			if err != nil {
+				return err
+			}
+			config.SetPassphrase(pass)
+		}
+		creds.Encrypted = true
+	}
+
+	if err := saveCredentials(creds); err != nil {
+		return err
+	}
+
+	fmt.Printf("\nAuthentication successful!\n")
+	fmt.Printf("Profile: %s\n", config.ActiveProfile())
+	fmt.Printf("Credentials saved to: %s\n", config.Path())
+	fmt.Printf("Secret storage: %s\n", creds.StorageBackend())
+	if creds.Encrypted {
+		fmt.Println("Encrypted: yes")
+	}
+	fmt.Printf("Manager account: %s\n", creds.ManagerCustomerID)
+	return nil
+}
+
+// setOAuthToken stores the tokens of a user login in creds. They replace the
+// service account of the profile, which would otherwise take precedence.
+func setOAuthToken(creds *config.Credentials, token *oauth2.Token) {
 	creds.AccessToken = token.AccessToken
 	creds.RefreshToken = token.RefreshToken
 	creds.TokenType = token.TokenType
 	creds.TokenExpiry = token.Expiry
+	creds.ServiceAccountKeyFile = ""
+	creds.ImpersonateUser = ""
+}
 
-	if err := config.Save(creds); err != nil {
+// saveCredentials saves creds to the active profile. Without an OS keychain
+// a keyring profile fails to save, unless --keyring-fallback moves its
+// secrets to the credentials file.
+func saveCredentials(creds *config.Credentials) error {
+	err := config.Save(creds)
+	if errors.Is(err, config.ErrKeyringUnavailable) && authKeyringFallback {
+		fmt.Fprintf(os.Stderr, "Warning: %v — saving secrets to the credentials file in plain text instead.\n", err)
+		creds.Storage = config.StorageFile
+		err = config.Save(creds)
+	}
+	if errors.Is(err, config.ErrKeyringUnavailable) {
+		return fmt.Errorf("saving credentials: %w (pass --keyring-fallback to save secrets to the credentials file instead)", err)
+	}
+	if err != nil {
 		return fmt.Errorf("saving credentials: %w", err)
 	}
+	return nil
+}
 
-	fmt.Printf("\nAuthentication successful!\n")
+// runServiceAccountLogin stores a service account key path and impersonation
+// subject instead of running the OAuth2 browser flow.
+func runServiceAccountLogin(creds *config.Credentials) error {
+	keyPath, err := filepath.Abs(authServiceAccount)
+	if err != nil {
+		return fmt.Errorf("resolving key path: %w", err)
+	}
+	if authImpersonate == "" {
+		return fmt.Errorf("--impersonate is required with --service-account-key (the Google Ads user to act as)")
+	}
+	creds.ServiceAccountKeyFile = keyPath
+	creds.ImpersonateUser = authImpersonate
+	if _, err := config.NewServiceAccountConfig(creds); err != nil {
+		return err
+	}
+
+	if authDeveloperToken != "" {
+		creds.DeveloperToken = authDeveloperToken
+	} else if creds.DeveloperToken == "" {
+		creds.DeveloperToken = promptRequired("Developer Token: ")
+	}
+	if authManagerAccount != "" {
+		creds.ManagerCustomerID = authManagerAccount
+	} else if creds.ManagerCustomerID == "" {
+		creds.ManagerCustomerID = promptRequired("Manager Account (MCC) Customer ID: ")
+	}
+
+	// A service account replaces any previous user tokens.
+	creds.RefreshToken = ""
+	creds.AccessToken = ""
+	creds.TokenType = ""
+	creds.TokenExpiry = time.Time{}
+
+	if err := saveCredentials(creds); err != nil {
+		return err
+	}
+	fmt.Printf("Service account configured.\n")
+	fmt.Printf("Profile: %s\n", config.ActiveProfile())
 	fmt.Printf("Credentials saved to: %s\n", config.Path())
-	fmt.Printf("Manager account: %s\n", creds.ManagerCustomerID)
+	fmt.Printf("Impersonating: %s\n", creds.ImpersonateUser)
+	fmt.Println("\nRun: gads-cli auth check")
 	return nil
 }
 
-func runOAuthFlow(creds *config.Credentials, noBrowser bool) (string, error) {
-	oauthCfg := config.NewOAuthConfig(creds)
-	authURL := oauthCfg.AuthCodeURL("state", oauth2.AccessTypeOffline, oauth2.ApprovalForce)
+// runOAuthFlow obtains an authorization code and returns it together with the
+// redirect URI used, which must be passed again when exchanging the code.
+// The PKCE challenge of pkce is included in the authorization URL.
+//
+// In browser mode the callback server listens on a free loopback port unless
+// redirectPort is set (for OAuth clients locked to a specific port).
+func runOAuthFlow(creds *config.Credentials, noBrowser bool, redirectPort int, pkce auth.PKCE) (string, string, error) {
+	state, err := randomState()
+	if err != nil {
+		return "", "", err
+	}
 
 	if noBrowser {
-		return runOAuthFlowManual(authURL)
+		redirectURL := config.RedirectURL
+		if redirectPort > 0 {
+			redirectURL = fmt.Sprintf("http://localhost:%d", redirectPort)
+		}
+		authURL := config.NewOAuthConfig(creds, redirectURL).AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.ApprovalForce, pkce.AuthCodeOption())
+		code, err := runOAuthFlowManual(authURL, redirectURL, state)
+		return code, redirectURL, err
 	}
 
 	// Start a local HTTP server before opening the browser
-	mux := http.NewServeMux()
-	codeCh := make(chan string, 1)
-
-	ln, err := net.Listen("tcp", ":8080")
+	ln, redirectURL, err := listenCallback(redirectPort)
 	if err != nil {
-		return "", fmt.Errorf("failed to start local server on :8080 (is something else using it?): %w", err)
+		return "", "", err
 	}
 
+	authURL := config.NewOAuthConfig(creds, redirectURL).AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.ApprovalForce, pkce.AuthCodeOption())
+
+	mux := http.NewServeMux()
+	resultCh := make(chan oauthResult, 1)
+
 	srv := &http.Server{Handler: mux}
-	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		code := r.URL.Query().Get("code")
-		if code != "" {
-			fmt.Fprint(w, "<html><body><h2>Authorization successful!</h2><p>You can close this tab and return to the terminal.</p></body></html>")
-			codeCh <- code
-		} else {
-			errMsg := r.URL.Query().Get("error")
-			fmt.Fprintf(w, "<html><body><h2>Authorization failed</h2><p>%s</p></body></html>", errMsg)
-			codeCh <- ""
-		}
-	})
+	mux.HandleFunc("/", oauthCallbackHandler(state, resultCh))
 
 	go srv.Serve(ln) //nolint
 	defer srv.Close()
@@ -170,60 +306,181 @@ func runOAuthFlow(creds *config.Credentials, noBrowser bool) (string, error) {
 	defer cancel()
 
 	select {
-	case code := <-codeCh:
-		if code == "" {
-			return "", fmt.Errorf("authorization denied or failed")
+	case res := <-resultCh:
+		if res.err != nil {
+			return "", "", res.err
 		}
-		return code, nil
+		return res.code, redirectURL, nil
 	case <-ctx.Done():
-		return "", fmt.Errorf("authorization timed out after 5 minutes")
+		return "", "", fmt.Errorf("authorization timed out after 5 minutes")
 	}
 }
 
-func runOAuthFlowManual(authURL string) (string, error) {
-	fmt.Printf("\nOpen the following URL in your browser:\n\n%s\n\n", authURL)
-	fmt.Println("After authorizing, your browser will be redirected to localhost:8080.")
+// listenCallback opens the listener of the OAuth callback server and returns
+// it with the matching redirect URI. It only listens on the loopback
+// interface, so the code cannot be sent from another machine: on redirectPort
+// when set, on a free port otherwise.
+func listenCallback(redirectPort int) (net.Listener, string, error) {
+	if redirectPort > 0 {
+		ln, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", redirectPort))
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to start local server on 127.0.0.1:%d (is something else using it?): %w", redirectPort, err)
+		}
+		return ln, fmt.Sprintf("http://localhost:%d", redirectPort), nil
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to start local callback server: %w", err)
+	}
+	return ln, fmt.Sprintf("http://127.0.0.1:%d", ln.Addr().(*net.TCPAddr).Port), nil
+}
+
+// oauthResult is the outcome of a single OAuth callback request.
+type oauthResult struct {
+	code string
+	err  error
+}
+
+// randomState returns a cryptographically random OAuth state value.
+func randomState() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating OAuth state: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// oauthCallbackHandler handles the OAuth redirect. Requests whose state does
+// not match are rejected so that other local pages cannot inject a code.
+// Only the first result is delivered; later requests are answered but dropped.
+func oauthCallbackHandler(state string, resultCh chan<- oauthResult) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		var res oauthResult
+		switch {
+		case q.Get("state") != state:
+			res.err = fmt.Errorf("OAuth state mismatch — the callback did not come from this login attempt")
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, "<html><body><h2>Authorization failed</h2><p>Invalid state parameter.</p></body></html>")
+		case q.Get("error") != "":
+			res.err = fmt.Errorf("authorization denied or failed: %s", q.Get("error"))
+			fmt.Fprintf(w, "<html><body><h2>Authorization failed</h2><p>%s</p></body></html>", html.EscapeString(q.Get("error")))
+		case q.Get("code") == "":
+			res.err = fmt.Errorf("authorization denied or failed: no code in callback")
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, "<html><body><h2>Authorization failed</h2><p>No authorization code received.</p></body></html>")
+		default:
+			res.code = q.Get("code")
+			fmt.Fprint(w, "<html><body><h2>Authorization successful!</h2><p>You can close this tab and return to the terminal.</p></body></html>")
+		}
+		select {
+		case resultCh <- res:
+		default:
+		}
+	}
+}
+
+func runOAuthFlowManual(authURL, redirectURL, state string) (string, error) {
+	fmt.Printf("\nOpen the following URL in a browser on any machine:\n\n%s\n\n", authURL)
+	fmt.Printf("After authorizing, your browser will be redirected to %s.\n", redirectURL)
 	fmt.Println("That page will fail to load — that's expected on a remote server.")
-	fmt.Println("Copy the full URL from the browser's address bar and paste it below.")
-	fmt.Print("\nRedirect URL: ")
+	fmt.Println("Copy the full URL from the browser's address bar (or just the code= value) and paste it below.")
+	fmt.Print("\nRedirect URL or code: ")
 
 	reader := bufio.NewReader(os.Stdin)
-	rawURL, err := reader.ReadString('\n')
+	input, err := reader.ReadString('\n')
 	if err != nil {
 		return "", fmt.Errorf("reading redirect URL: %w", err)
 	}
-	rawURL = strings.TrimSpace(rawURL)
+	return parseManualAuthInput(input, state)
+}
+
+// parseManualAuthInput accepts either a bare authorization code or the full
+// redirected URL (http://localhost:8080/?code=...&state=...). When a URL is
+// given, its state must match the one sent in the authorization request.
+func parseManualAuthInput(input, state string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return "", fmt.Errorf("no input — paste the redirect URL or the authorization code")
+	}
+
+	if !strings.Contains(input, "://") && !strings.HasPrefix(input, "?") && !strings.Contains(input, "code=") {
+		return input, nil
+	}
 
-	parsed, err := url.Parse(rawURL)
+	parsed, err := url.Parse(input)
 	if err != nil {
 		return "", fmt.Errorf("parsing redirect URL: %w", err)
 	}
-
-	if errMsg := parsed.Query().Get("error"); errMsg != "" {
+	q := parsed.Query()
+	if errMsg := q.Get("error"); errMsg != "" {
 		return "", fmt.Errorf("authorization failed: %s", errMsg)
 	}
-
-	code := parsed.Query().Get("code")
+	if got := q.Get("state"); got != state {
+		return "", fmt.Errorf("state mismatch in redirect URL — start the login again and paste the URL from that attempt")
+	}
+	code := q.Get("code")
 	if code == "" {
 		return "", fmt.Errorf("no authorization code found in URL — make sure you copied the full redirect URL")
 	}
-
 	return code, nil
 }
 
 // ---- auth token ----
 
+var authTokenAccessOnly bool
+
 var authTokenCmd = &cobra.Command{
 	Use:   "token",
 	Short: "Show the current access token",
+	Long: `Show the current access token (masked by default).
+
+For scripting, --access-token-only prints the raw access token and --json
+prints {access_token, expiry, token_type}; both refresh the token first if it
+has expired.
+
+Examples:
+  gads-cli auth token
+  curl -H "Authorization: Bearer $(gads-cli auth token --access-token-only)" ...
+  gads-cli auth token --json`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		creds, err := config.Load()
 		if err != nil {
 			return fmt.Errorf("loading credentials: %w", err)
 		}
-		if creds.RefreshToken == "" {
+		if !creds.Authenticated() {
 			return fmt.Errorf("not authenticated — run: gads-cli auth login")
 		}
+
+		// Only an explicit --json/--pretty emits raw tokens; piping alone keeps the masked output.
+		jsonOut, _ := cmd.Flags().GetBool("json")
+		prettyOut, _ := cmd.Flags().GetBool("pretty")
+		if authTokenAccessOnly || jsonOut || prettyOut {
+			ts, err := newTokenSource(creds)
+			if err != nil {
+				return err
+			}
+			token, err := ts.Token()
+			if err != nil {
+				return fmt.Errorf("refreshing access token: %w", err)
+			}
+			if authTokenAccessOnly {
+				fmt.Println(token.AccessToken)
+				return nil
+			}
+			return output.PrintJSON(map[string]any{
+				"access_token": token.AccessToken,
+				"expiry":       token.Expiry,
+				"token_type":   token.Type(),
+			}, prettyOut)
+		}
+
+		if creds.IsServiceAccount() {
+			fmt.Printf("Service Account: %s\n", creds.ServiceAccountKeyFile)
+			fmt.Printf("Impersonating:   %s\n", creds.ImpersonateUser)
+			fmt.Println("Tokens are minted per run and not stored.")
+			return nil
+		}
 		fmt.Printf("Access Token:   %s\n", maskOrEmpty(creds.AccessToken))
 		fmt.Printf("Refresh Token:  %s\n", maskOrEmpty(creds.RefreshToken))
 		fmt.Printf("Token Type:     %s\n", creds.TokenType)
@@ -245,7 +502,7 @@ var authCheckCmd = &cobra.Command{
 	Use:   "check",
 	Short: "Validate the current credentials by making a test API call",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if err := initAPIClient(); err != nil {
+		if err := initAPIClient(cmd); err != nil {
 			return err
 		}
 		fmt.Println("Checking credentials...")
@@ -258,26 +515,289 @@ var authCheckCmd = &cobra.Command{
 	},
 }
 
+// ---- auth import ----
+
+var (
+	authImportYAML    string
+	authImportFromEnv bool
+)
+
+var authImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import credentials from a google-ads.yaml file or GOOGLE_ADS_* env vars",
+	Long: `Import credentials used by the official Google Ads client libraries.
+
+Reads client_id, client_secret, developer_token, refresh_token, and
+login_customer_id (plus json_key_file_path / impersonated_email for service
+accounts), saves them to the active profile, and validates them with a
+listAccessibleCustomers call.
+
+The rest of the profile is kept: secrets of a keyring profile go to the OS
+keychain and an encrypted file stays encrypted, as with auth login.
+
+Examples:
+  gads-cli auth import --google-ads-yaml=~/google-ads.yaml
+  gads-cli auth import --from-env
+  gads-cli auth import --google-ads-yaml=./google-ads.yaml --profile=work`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var (
+			imported *config.Credentials
+			source   string
+		)
+		switch {
+		case authImportYAML != "" && authImportFromEnv:
+			return fmt.Errorf("use either --google-ads-yaml or --from-env, not both")
+		case authImportYAML != "":
+			path := authImportYAML
+			if strings.HasPrefix(path, "~/") {
+				if home, err := os.UserHomeDir(); err == nil {
+					path = filepath.Join(home, path[2:])
+				}
+			}
+			var err error
+			imported, err = config.ParseGoogleAdsYAML(path)
+			if err != nil {
+				return fmt.Errorf("reading %s: %w", authImportYAML, err)
+			}
+			source = path
+		case authImportFromEnv:
+			imported = config.GoogleAdsFromEnv()
+			source = "GOOGLE_ADS_* environment variables"
+		default:
+			return fmt.Errorf("--google-ads-yaml or --from-env is required")
+		}
+
+		if !imported.Authenticated() {
+			return fmt.Errorf("no refresh_token in %s — run: gads-cli auth login", source)
+		}
+		if !imported.IsServiceAccount() && (imported.ClientID == "" || imported.ClientSecret == "") {
+			return fmt.Errorf("client_id and client_secret are required in %s", source)
+		}
+		if imported.DeveloperToken == "" {
+			return fmt.Errorf("developer_token is missing in %s", source)
+		}
+
+		// The profile keeps its storage, encryption, aliases and settings.
+		creds, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("loading credentials: %w", err)
+		}
+		importCredentials(creds, imported)
+		if err := saveCredentials(creds); err != nil {
+			return err
+		}
+		fmt.Printf("Imported credentials from %s\n", source)
+		fmt.Printf("Credentials saved to: %s\n", config.Path())
+
+		if err := initAPIClient(cmd); err != nil {
+			return err
+		}
+		accounts, err := apiClient.ListAccessibleCustomers()
+		if err != nil {
+			return fmt.Errorf("imported credentials failed validation: %w (run: gads-cli auth doctor)", err)
+		}
+		fmt.Printf("Credentials valid. Found %d accessible account(s).\n", len(accounts))
+		return nil
+	},
+}
+
+// importCredentials copies the credentials read by auth import onto creds,
+// the profile's. The imported identity replaces the previous one: its
+// tokens and the other login mode's fields are dropped.
+func importCredentials(creds, imported *config.Credentials) {
+	creds.ClientID = imported.ClientID
+	creds.ClientSecret = imported.ClientSecret
+	creds.DeveloperToken = imported.DeveloperToken
+	creds.RefreshToken = imported.RefreshToken
+	creds.ServiceAccountKeyFile = imported.ServiceAccountKeyFile
+	creds.ImpersonateUser = imported.ImpersonateUser
+	if imported.ManagerCustomerID != "" {
+		creds.ManagerCustomerID = imported.ManagerCustomerID
+	}
+	creds.AccessToken = ""
+	creds.TokenType = ""
+	creds.TokenExpiry = time.Time{}
+}
+
+// ---- auth doctor ----
+
+var authDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose authentication and account access problems",
+	Long: `Run a sequence of checks and print a pass/fail line for each:
+
+  1. Credentials file present and parseable
+  2. Refresh token (or service account) yields an access token
+  3. listAccessibleCustomers succeeds (developer token accepted)
+  4. The configured MCC is in the accessible list
+  5. A trivial GAQL query against the MCC succeeds
+
+The first failure is explained with a remediation hint and the remaining checks
+are skipped. Exits non-zero when any check fails.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		d := &doctorState{ctx: cmd.Context()}
+		for i, c := range doctorChecks {
+			hint, err := c.run(d)
+			if err == nil {
+				fmt.Printf("✓ %s\n", c.name)
+				continue
+			}
+			fmt.Printf("✗ %s: %v\n", c.name, err)
+			if hint != "" {
+				fmt.Printf("\n  Hint: %s\n\n", hint)
+			}
+			for _, rest := range doctorChecks[i+1:] {
+				fmt.Printf("- %s (skipped)\n", rest.name)
+			}
+			return fmt.Errorf("auth doctor: check failed: %s", c.name)
+		}
+		fmt.Println("\nAll checks passed.")
+		return nil
+	},
+}
+
+// doctorState carries results between auth doctor checks.
+type doctorState struct {
+	ctx        context.Context
+	creds      *config.Credentials
+	client     *api.Client
+	accessible []string
+}
+
+// doctorCheck is one auth doctor step. run returns a remediation hint along with any error.
+type doctorCheck struct {
+	name string
+	run  func(d *doctorState) (hint string, err error)
+}
+
+var doctorChecks = []doctorCheck{
+	{"credentials file present and parseable", checkDoctorCredentials},
+	{"access token obtained", checkDoctorToken},
+	{"listAccessibleCustomers succeeds", checkDoctorAccessible},
+	{"manager account is accessible", checkDoctorMCCListed},
+	{"GAQL query against manager account", checkDoctorQuery},
+}
+
+func checkDoctorCredentials(d *doctorState) (string, error) {
+	creds, err := config.Load()
+	if err != nil {
+		return fmt.Sprintf("fix or delete %s, then run: gads-cli auth login", config.Path()), err
+	}
+	if _, statErr := os.Stat(config.Path()); statErr != nil && creds.EnvSource("refresh_token") == "" {
+		return "run: gads-cli auth login", fmt.Errorf("%s not found", config.Path())
+	}
+	if !creds.Authenticated() {
+		return "run: gads-cli auth login", fmt.Errorf("no refresh token or service account configured")
+	}
+	if creds.DeveloperToken == "" {
+		return "run: gads-cli auth login --developer-token=TOKEN (from Tools → API Center in Google Ads)", fmt.Errorf("developer token not set")
+	}
+	d.creds = creds
+	return "", nil
+}
+
+func checkDoctorToken(d *doctorState) (string, error) {
+	ts, err := newTokenSource(d.creds)
+	if err != nil {
+		return "check the service account key path in the credentials file", err
+	}
+	if _, err := ts.Token(); err != nil {
+		if d.creds.IsServiceAccount() {
+			return "check that domain-wide delegation grants the adwords scope to this service account", err
+		}
+		return "the refresh token was revoked or expired — run: gads-cli auth login", err
+	}
+	d.client = api.New(authorizedClient(ts), d.creds.DeveloperToken, d.creds.ManagerCustomerID,
+		api.WithAPIVersion(resolveAPIVersion(d.creds))).WithContext(d.ctx)
+	return "", nil
+}
+
+func checkDoctorAccessible(d *doctorState) (string, error) {
+	accessible, err := d.client.ListAccessibleCustomers()
+	if err != nil {
+		var gErr *api.GoogleAdsError
+		if errors.As(err, &gErr) {
+			switch gErr.StatusCode {
+			case 401:
+				return "the access token was rejected — run: gads-cli auth login", err
+			case 403:
+				return "the developer token is invalid or not approved for this access level — check Tools → API Center", err
+			}
+		}
+		return "", err
+	}
+	d.accessible = accessible
+	return "", nil
+}
+
+func checkDoctorMCCListed(d *doctorState) (string, error) {
+	mccID := api.CleanCustomerID(d.creds.ManagerCustomerID)
+	if mccID == "" {
+		return "run: gads-cli auth login --manager-account=<MCC ID>", fmt.Errorf("no manager account configured")
+	}
+	for _, rn := range d.accessible {
+		if api.ResourceID(rn) == mccID {
+			return "", nil
+		}
+	}
+	ids := make([]string, len(d.accessible))
+	for i, rn := range d.accessible {
+		ids[i] = api.ResourceID(rn)
+	}
+	return fmt.Sprintf("the signed-in Google user has no direct access to %s; accessible: %s — log in as an MCC user or fix manager_customer_id", mccID, strings.Join(ids, ", ")),
+		fmt.Errorf("manager account %s not in accessible list", mccID)
+}
+
+func checkDoctorQuery(d *doctorState) (string, error) {
+	mccID := api.CleanCustomerID(d.creds.ManagerCustomerID)
+	if _, err := d.client.Search(mccID, gaql.Select("customer.id").From("customer").Limit(1).String()); err != nil {
+		return "the developer token may only have test access, or the user lacks read access on the MCC", err
+	}
+	return "", nil
+}
+
 // ---- auth status ----
 
 var authStatusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show current authentication status",
+	Long: `Show current authentication status.
+
+Values are read from the credentials file, then overridden by any of these
+environment variables (environment takes precedence):
+
+  GADS_CLIENT_ID           client_id
+  GADS_CLIENT_SECRET       client_secret
+  GADS_DEVELOPER_TOKEN     developer_token
+  GADS_REFRESH_TOKEN       refresh_token
+  GADS_LOGIN_CUSTOMER_ID   manager_customer_id
+
+Values that came from the environment are marked with [env: NAME].`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		creds, err := config.Load()
 		if err != nil {
 			return fmt.Errorf("loading credentials: %w", err)
 		}
+		fmt.Printf("Profile:     %s\n", config.ActiveProfile())
 		fmt.Printf("Config file: %s\n\n", config.Path())
-		if creds.RefreshToken == "" {
+		if !creds.Authenticated() {
 			fmt.Println("Status: not authenticated")
 			fmt.Println("\nRun: gads-cli auth login")
 			return nil
 		}
-		fmt.Printf("Status:           authenticated\n")
-		fmt.Printf("Client ID:        %s\n", maskOrEmpty(creds.ClientID))
-		fmt.Printf("Developer Token:  %s\n", maskOrEmpty(creds.DeveloperToken))
-		fmt.Printf("Manager Account:  %s\n", creds.ManagerCustomerID)
+		fmt.Printf("Status:           authenticated%s\n", envMarker(creds, "refresh_token"))
+		if creds.IsServiceAccount() {
+			fmt.Printf("Mode:             service account\n")
+			fmt.Printf("Key File:         %s\n", creds.ServiceAccountKeyFile)
+			fmt.Printf("Impersonating:    %s\n", creds.ImpersonateUser)
+		} else {
+			fmt.Printf("Client ID:        %s%s\n", maskOrEmpty(creds.ClientID), envMarker(creds, "client_id"))
+			fmt.Printf("Client Secret:    %s%s\n", maskOrEmpty(creds.ClientSecret), envMarker(creds, "client_secret"))
+		}
+		fmt.Printf("Developer Token:  %s%s\n", maskOrEmpty(creds.DeveloperToken), envMarker(creds, "developer_token"))
+		fmt.Printf("Manager Account:  %s%s\n", creds.ManagerCustomerID, envMarker(creds, "manager_customer_id"))
+		fmt.Printf("Secret Storage:   %s\n", creds.StorageBackend())
+		fmt.Printf("Encrypted:        %s\n", output.FormatBool(creds.Encrypted))
 		if !creds.TokenExpiry.IsZero() {
 			fmt.Printf("Token Expiry:     %s\n", creds.TokenExpiry.Format("2006-01-02 15:04:05 UTC"))
 		}
@@ -285,16 +805,116 @@ var authStatusCmd = &cobra.Command{
 	},
 }
 
+// envMarker returns a " [env: NAME]" suffix when key was set from the environment.
+func envMarker(creds *config.Credentials, key string) string {
+	if env := creds.EnvSource(key); env != "" {
+		return fmt.Sprintf("  [env: %s]", env)
+	}
+	return ""
+}
+
+// ---- auth profiles ----
+
+var authProfilesCmd = &cobra.Command{
+	Use:   "profiles",
+	Short: "List credential profiles",
+	Long: `List credential profiles stored in the config directory.
+
+The "default" profile is the legacy credentials.json file. The active profile
+(selected with --profile or GADS_PROFILE) is marked with *.
+
+Examples:
+  gads-cli auth profiles
+  gads-cli auth profiles --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		names, err := config.ListProfiles()
+		if err != nil {
+			return fmt.Errorf("listing profiles: %w", err)
+		}
+		if output.IsJSON(cmd) {
+			type profileInfo struct {
+				Name    string `json:"name"`
+				Path    string `json:"path"`
+				Default bool   `json:"default"`
+				Active  bool   `json:"active"`
+			}
+			list := make([]profileInfo, len(names))
+			for i, n := range names {
+				list[i] = profileInfo{
+					Name:    n,
+					Path:    config.ProfilePath(n),
+					Default: n == config.DefaultProfile,
+					Active:  n == config.ActiveProfile(),
+				}
+			}
+			return output.PrintJSON(list, output.IsPretty(cmd))
+		}
+		if len(names) == 0 {
+			fmt.Println("No profiles found. Run: gads-cli auth login [--profile=<name>]")
+			return nil
+		}
+		rows := make([][]string, len(names))
+		for i, n := range names {
+			marker := ""
+			if n == config.ActiveProfile() {
+				marker = "*"
+			}
+			name := n
+			if n == config.DefaultProfile {
+				name += " (default)"
+			}
+			rows[i] = []string{marker, name, config.ProfilePath(n)}
+		}
+		return output.PrintTable([]string{"", "PROFILE", "PATH"}, rows)
+	},
+}
+
 // ---- auth logout ----
 
+var authLogoutKeepRemote bool
+
 var authLogoutCmd = &cobra.Command{
 	Use:   "logout",
-	Short: "Remove saved credentials",
+	Short: "Revoke the refresh token and remove saved credentials",
+	Long: `Revoke the refresh token at Google, then remove the saved credentials.
+
+The local credentials are removed even if they cannot be read or revocation
+fails; in that case the command exits non-zero so the token can be revoked
+manually at https://myaccount.google.com/permissions.
+
+Examples:
+  gads-cli auth logout
+  gads-cli auth logout --keep-remote   # only delete local credentials`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		creds, loadErr := config.Load()
+
+		var revokeErr error
+		switch {
+		case authLogoutKeepRemote:
+			fmt.Println("Skipping token revocation (--keep-remote).")
+		case loadErr != nil:
+			// Unreadable credentials (e.g. a forgotten passphrase) are still
+			// removed, but the token in them cannot be revoked.
+			fmt.Fprintf(os.Stderr, "Warning: could not load credentials (%v) — skipping token revocation.\n", loadErr)
+			revokeErr = fmt.Errorf("loading credentials: %w", loadErr)
+		case creds.RefreshToken == "":
+			// Nothing to revoke (not logged in, or service account mode).
+		case creds.EnvSource("refresh_token") != "":
+			fmt.Printf("Skipping revocation of refresh token from %s.\n", creds.EnvSource("refresh_token"))
+		default:
+			if revokeErr = config.RevokeToken(oauthContext(), creds.RefreshToken); revokeErr == nil {
+				fmt.Println("Refresh token revoked.")
+			}
+		}
+
 		if err := config.Clear(); err != nil {
 			return fmt.Errorf("removing credentials: %w", err)
 		}
 		fmt.Println("Credentials removed.")
+
+		if revokeErr != nil {
+			return fmt.Errorf("token revocation failed (%v) — the refresh token may still be valid; revoke it at https://myaccount.google.com/permissions", revokeErr)
+		}
 		return nil
 	},
 }
@@ -304,11 +924,71 @@ func init() {
 	authLoginCmd.Flags().StringVar(&authDeveloperToken, "developer-token", "", "Google Ads developer token")
 	authLoginCmd.Flags().StringVar(&authManagerAccount, "manager-account", "", "Manager Account (MCC) customer ID")
 	authLoginCmd.Flags().BoolVar(&authNoBrowser, "no-browser", false, "Manual auth flow for remote/VPS: print the URL, prompt for the redirect URL")
+	authLoginCmd.Flags().IntVar(&authRedirectPort, "redirect-port", 0, "Fixed localhost port for the OAuth redirect (default: pick a free port; 8080 with --no-browser)")
+	authLoginCmd.Flags().BoolVar(&authKeyring, "keyring", false, "Store secrets in the OS keychain instead of the credentials file")
+	authLoginCmd.Flags().BoolVar(&authKeyringFallback, "keyring-fallback", false, "With --keyring, save secrets to the credentials file if no OS keychain is available")
+	authLoginCmd.Flags().BoolVar(&authEncrypt, "encrypt", false, "Encrypt the credentials file with a passphrase (env: GADS_PASSPHRASE)")
+	authLoginCmd.Flags().StringVar(&authServiceAccount, "service-account-key", "", "Path to a service account JSON key (domain-wide delegation)")
+	authLoginCmd.Flags().StringVar(&authImpersonate, "impersonate", "", "User email to impersonate with --service-account-key")
+
+	config.PassphraseFunc = promptPassphrase
+
+	authTokenCmd.Flags().BoolVar(&authTokenAccessOnly, "access-token-only", false, "Print only the raw access token (refreshed if expired)")
 
-	authCmd.AddCommand(authLoginCmd, authTokenCmd, authCheckCmd, authStatusCmd, authLogoutCmd)
+	authImportCmd.Flags().StringVar(&authImportYAML, "google-ads-yaml", "", "Path to a google-ads.yaml file")
+	authImportCmd.Flags().BoolVar(&authImportFromEnv, "from-env", false, "Read GOOGLE_ADS_* environment variables")
+	authImportCmd.Flags().BoolVar(&authKeyringFallback, "keyring-fallback", false, "On a keyring profile, save secrets to the credentials file if no OS keychain is available")
+
+	authLogoutCmd.Flags().BoolVar(&authLogoutKeepRemote, "keep-remote", false, "Only delete local credentials; do not revoke the token at Google")
+
+	authCmd.AddCommand(authLoginCmd, authImportCmd, authTokenCmd, authCheckCmd, authDoctorCmd, authStatusCmd, authProfilesCmd, authLogoutCmd)
 	rootCmd.AddCommand(authCmd)
 }
 
+// promptPassphrase returns GADS_PASSPHRASE or reads a passphrase from the
+// terminal without echo. It is installed as config.PassphraseFunc.
+func promptPassphrase() (string, error) {
+	if p := os.Getenv("GADS_PASSPHRASE"); p != "" {
+		return p, nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("credentials file is encrypted — set GADS_PASSPHRASE")
+	}
+	fmt.Fprint(os.Stderr, "Credentials passphrase: ")
+	b, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("reading passphrase: %w", err)
+	}
+	return string(b), nil
+}
+
+// promptNewPassphrase asks for a new passphrase twice and checks they match.
+func promptNewPassphrase() (string, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("--encrypt needs a terminal to prompt for a passphrase, or set GADS_PASSPHRASE")
+	}
+	fmt.Fprint(os.Stderr, "New passphrase: ")
+	first, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("reading passphrase: %w", err)
+	}
+	fmt.Fprint(os.Stderr, "Confirm passphrase: ")
+	second, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("reading passphrase: %w", err)
+	}
+	if len(first) == 0 {
+		return "", fmt.Errorf("passphrase must not be empty")
+	}
+	if string(first) != string(second) {
+		return "", fmt.Errorf("passphrases do not match")
+	}
+	return string(first), nil
+}
+
 // promptRequired reads a required value from stdin. Strips whitespace.
 func promptRequired(msg string) string {
 	reader := bufio.NewReader(os.Stdin)
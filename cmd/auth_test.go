@@ -0,0 +1,476 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/the20100/gads-cli/internal/config"
+	"github.com/zalando/go-keyring"
+	"golang.org/x/oauth2"
+)
+
+func TestListenCallbackLoopbackOnly(t *testing.T) {
+	// A port that was free a moment ago, for the --redirect-port case.
+	probe, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := probe.Addr().(*net.TCPAddr).Port
+	probe.Close()
+
+	tests := []struct {
+		name         string
+		redirectPort int
+		wantURL      string
+	}{
+		{name: "free port", wantURL: "http://127.0.0.1:"},
+		{name: "redirect port", redirectPort: port, wantURL: fmt.Sprintf("http://localhost:%d", port)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ln, redirectURL, err := listenCallback(tt.redirectPort)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer ln.Close()
+			addr := ln.Addr().(*net.TCPAddr)
+			if !addr.IP.IsLoopback() {
+				t.Errorf("listening on %s, want a loopback address", addr)
+			}
+			if !strings.HasPrefix(redirectURL, tt.wantURL) {
+				t.Errorf("redirect URL %q, want %q", redirectURL, tt.wantURL)
+			}
+			if !strings.HasSuffix(redirectURL, fmt.Sprintf(":%d", addr.Port)) {
+				t.Errorf("redirect URL %q does not match port %d", redirectURL, addr.Port)
+			}
+		})
+	}
+}
+
+func TestOAuthCallbackHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantCode   string
+		wantErr    string
+	}{
+		{name: "code", query: "?state=s3cret&code=4%2Fabc", wantStatus: http.StatusOK, wantCode: "4/abc"},
+		{name: "state mismatch", query: "?state=other&code=4%2Fabc", wantStatus: http.StatusBadRequest, wantErr: "state mismatch"},
+		{name: "no state", query: "?code=4%2Fabc", wantStatus: http.StatusBadRequest, wantErr: "state mismatch"},
+		{name: "error param", query: "?state=s3cret&error=access_denied", wantStatus: http.StatusOK, wantErr: "access_denied"},
+		{name: "missing code", query: "?state=s3cret", wantStatus: http.StatusBadRequest, wantErr: "no code"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resultCh := make(chan oauthResult, 1)
+			srv := httptest.NewServer(oauthCallbackHandler("s3cret", resultCh))
+			defer srv.Close()
+
+			resp, err := http.Get(srv.URL + "/" + tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			res := <-resultCh
+			if res.code != tt.wantCode {
+				t.Errorf("code %q, want %q", res.code, tt.wantCode)
+			}
+			switch {
+			case tt.wantErr == "" && res.err != nil:
+				t.Errorf("unexpected error: %v", res.err)
+			case tt.wantErr != "" && (res.err == nil || !strings.Contains(res.err.Error(), tt.wantErr)):
+				t.Errorf("error %v, want one containing %q", res.err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOAuthCallbackHandlerKeepsFirstResult(t *testing.T) {
+	resultCh := make(chan oauthResult, 1)
+	h := oauthCallbackHandler("s3cret", resultCh)
+	for _, query := range []string{"?state=s3cret&code=first", "?state=s3cret&code=second"} {
+		h(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/"+query, nil))
+	}
+	if res := <-resultCh; res.code != "first" {
+		t.Errorf("code %q, want the first callback's", res.code)
+	}
+	select {
+	case res := <-resultCh:
+		t.Errorf("second callback delivered: %+v", res)
+	default:
+	}
+}
+
+// doctorAPI is a fake of the Google endpoints auth doctor calls. Each field
+// makes one of them fail.
+type doctorAPI struct {
+	revoked, devTokenRejected, queryFails bool
+	accessible                            string
+}
+
+func (a doctorAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Host == "oauth2.googleapis.com":
+		if a.revoked {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, `{"error":"invalid_grant","error_description":"Token has been expired or revoked."}`)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"access_token":"ya29.test","token_type":"Bearer","expires_in":3600}`)
+	case r.Header.Get("Authorization") != "Bearer ya29.test":
+		w.WriteHeader(http.StatusUnauthorized)
+	case strings.HasSuffix(r.URL.Path, ":listAccessibleCustomers"):
+		if a.devTokenRejected {
+			w.WriteHeader(http.StatusForbidden)
+			io.WriteString(w, `{"error":{"code":403,"message":"The developer token is not approved.","status":"PERMISSION_DENIED"}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"resourceNames":[%s]}`, a.accessible)
+	case a.queryFails:
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, `{"error":{"code":403,"message":"The caller does not have permission","status":"PERMISSION_DENIED"}}`)
+	default:
+		io.WriteString(w, `{"results":[{"customer":{"id":"9990001111"}}]}`)
+	}
+}
+
+func TestAuthDoctor(t *testing.T) {
+	creds := config.Credentials{
+		ClientID:          "client.apps.googleusercontent.com",
+		ClientSecret:      "secret",
+		DeveloperToken:    "dev-token",
+		ManagerCustomerID: "999-000-1111",
+		RefreshToken:      "1//refresh",
+	}
+	mcc := `"customers/9990001111","customers/1234567890"`
+	noDevToken := creds
+	noDevToken.DeveloperToken = ""
+	noRefresh := creds
+	noRefresh.RefreshToken = ""
+
+	tests := []struct {
+		name     string
+		creds    *config.Credentials
+		api      doctorAPI
+		wantFail string // the check that fails, "" when all pass
+		wantHint string
+	}{
+		{name: "all pass", creds: &creds, api: doctorAPI{accessible: mcc}},
+		{name: "no credentials file", wantFail: "credentials file present and parseable", wantHint: "run: gads-cli auth login"},
+		{name: "not logged in", creds: &noRefresh, wantFail: "credentials file present and parseable", wantHint: "run: gads-cli auth login"},
+		{name: "no developer token", creds: &noDevToken, wantFail: "credentials file present and parseable", wantHint: "--developer-token"},
+		{name: "refresh token revoked", creds: &creds, api: doctorAPI{revoked: true}, wantFail: "access token obtained", wantHint: "revoked or expired"},
+		{name: "developer token rejected", creds: &creds, api: doctorAPI{devTokenRejected: true}, wantFail: "listAccessibleCustomers succeeds", wantHint: "developer token is invalid"},
+		{name: "manager not accessible", creds: &creds, api: doctorAPI{accessible: `"customers/1234567890"`}, wantFail: "manager account is accessible", wantHint: "accessible: 1234567890"},
+		{name: "query fails", creds: &creds, api: doctorAPI{accessible: mcc, queryFails: true}, wantFail: "GAQL query against manager account", wantHint: "test access"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useTestEnv(t)
+			savedTransport := baseTransport
+			t.Cleanup(func() { baseTransport = savedTransport })
+			baseTransport = handlerTransport{tt.api}
+			if tt.creds != nil {
+				c := *tt.creds
+				if err := config.Save(&c); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			authDoctorCmd.SetContext(context.Background())
+			stdout, _, err := runOutput(t, func() error { return authDoctorCmd.RunE(authDoctorCmd, nil) })
+
+			failed := false
+			for _, c := range doctorChecks {
+				switch {
+				case failed:
+					if !strings.Contains(stdout, "- "+c.name+" (skipped)") {
+						t.Errorf("%q not skipped:\n%s", c.name, stdout)
+					}
+				case c.name == tt.wantFail:
+					failed = true
+					if !strings.Contains(stdout, "✗ "+c.name+":") {
+						t.Errorf("%q did not fail:\n%s", c.name, stdout)
+					}
+				default:
+					if !strings.Contains(stdout, "✓ "+c.name+"\n") {
+						t.Errorf("%q did not pass:\n%s", c.name, stdout)
+					}
+				}
+			}
+			if tt.wantFail == "" {
+				if err != nil || !strings.Contains(stdout, "All checks passed.") {
+					t.Errorf("error %v, output:\n%s", err, stdout)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), "check failed: "+tt.wantFail) {
+				t.Errorf("error %v, want the failed check", err)
+			}
+			if !strings.Contains(stdout, "Hint: ") || !strings.Contains(stdout, tt.wantHint) {
+				t.Errorf("no hint containing %q:\n%s", tt.wantHint, stdout)
+			}
+		})
+	}
+}
+
+// useAuthImport sets the auth import flags for one test and answers its
+// validation request with a fake Google API. The OS keychain is replaced
+// with an in-memory one.
+func useAuthImport(t *testing.T, yaml string) {
+	t.Helper()
+	useTestEnv(t)
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	keyring.MockInit()
+	savedTransport := baseTransport
+	savedYAML, savedFromEnv, savedFallback := authImportYAML, authImportFromEnv, authKeyringFallback
+	t.Cleanup(func() {
+		baseTransport = savedTransport
+		authImportYAML, authImportFromEnv, authKeyringFallback = savedYAML, savedFromEnv, savedFallback
+		config.SetPassphrase("")
+	})
+	baseTransport = handlerTransport{doctorAPI{accessible: `"customers/9990001111"`}}
+	authImportYAML = filepath.Join(t.TempDir(), "google-ads.yaml")
+	authImportFromEnv, authKeyringFallback = false, false
+	if err := os.WriteFile(authImportYAML, []byte(yaml), 0600); err != nil {
+		t.Fatal(err)
+	}
+	authImportCmd.SetContext(context.Background())
+}
+
+const importYAML = `developer_token: new-dev-token
+client_id: new.apps.googleusercontent.com
+client_secret: new-client-secret
+refresh_token: 1//new-refresh
+login_customer_id: 9990001111
+`
+
+// oldProfile is a profile set up before auth import.
+func oldProfile() config.Credentials {
+	return config.Credentials{
+		ClientID:          "old.apps.googleusercontent.com",
+		ClientSecret:      "old-client-secret",
+		DeveloperToken:    "old-dev-token",
+		RefreshToken:      "1//old-refresh",
+		AccessToken:       "ya29.old",
+		ManagerCustomerID: "1112223333",
+		DefaultAccount:    "1234567890",
+		Aliases:           map[string]string{"acme": "1234567890"},
+		APIVersion:        "v22",
+		CacheTTL:          "5m",
+	}
+}
+
+func TestAuthImportKeepsProfile(t *testing.T) {
+	tests := []struct {
+		name      string
+		storage   string
+		encrypted bool
+	}{
+		{name: "file profile"},
+		{name: "keyring profile", storage: config.StorageKeyring},
+		{name: "encrypted profile", encrypted: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useAuthImport(t, importYAML)
+			old := oldProfile()
+			old.Storage, old.Encrypted = tt.storage, tt.encrypted
+			if tt.encrypted {
+				config.SetPassphrase("correct horse")
+			}
+			if err := config.Save(&old); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, _, err := runOutput(t, func() error { return authImportCmd.RunE(authImportCmd, nil) }); err != nil {
+				t.Fatal(err)
+			}
+
+			raw, err := os.ReadFile(config.Path())
+			if err != nil {
+				t.Fatal(err)
+			}
+			secrets := []string{"new-client-secret", "new-dev-token", "1//new-refresh"}
+			if tt.storage != "" || tt.encrypted {
+				for _, secret := range secrets {
+					if strings.Contains(string(raw), secret) {
+						t.Errorf("%s written to the credentials file in plain text:\n%s", secret, raw)
+					}
+				}
+			}
+			if got := strings.HasPrefix(string(raw), "gads-encrypted:"); got != tt.encrypted {
+				t.Errorf("file encrypted = %v, want %v", got, tt.encrypted)
+			}
+			if tt.storage == config.StorageKeyring {
+				stored, err := keyring.Get("gads-cli", config.ActiveProfile())
+				if err != nil || !strings.Contains(stored, "1//new-refresh") || strings.Contains(stored, "1//old-refresh") {
+					t.Errorf("keychain holds %q, %v; want the imported secrets", stored, err)
+				}
+			}
+
+			creds, err := config.Load()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if creds.RefreshToken != "1//new-refresh" || creds.ClientSecret != "new-client-secret" ||
+				creds.DeveloperToken != "new-dev-token" || creds.ManagerCustomerID != "9990001111" {
+				t.Errorf("imported credentials not saved: %+v", creds)
+			}
+			if creds.Storage != tt.storage || creds.Encrypted != tt.encrypted {
+				t.Errorf("storage %q, encrypted %v; want %q, %v", creds.Storage, creds.Encrypted, tt.storage, tt.encrypted)
+			}
+			if creds.DefaultAccount != "1234567890" || creds.Aliases["acme"] != "1234567890" ||
+				creds.APIVersion != "v22" || creds.CacheTTL != "5m" {
+				t.Errorf("profile settings lost: %+v", creds)
+			}
+			if creds.AccessToken == "ya29.old" {
+				t.Error("kept the previous identity's access token")
+			}
+		})
+	}
+}
+
+func TestAuthImportKeyringUnavailable(t *testing.T) {
+	for _, fallback := range []bool{false, true} {
+		t.Run(fmt.Sprintf("fallback=%v", fallback), func(t *testing.T) {
+			useAuthImport(t, importYAML)
+			old := oldProfile()
+			old.Storage = config.StorageKeyring
+			if err := config.Save(&old); err != nil {
+				t.Fatal(err)
+			}
+			before, _ := os.ReadFile(config.Path())
+			keyring.MockInitWithError(errors.New("no secret service"))
+			authKeyringFallback = fallback
+
+			_, stderr, err := runOutput(t, func() error { return authImportCmd.RunE(authImportCmd, nil) })
+			after, _ := os.ReadFile(config.Path())
+			if !fallback {
+				if !errors.Is(err, config.ErrKeyringUnavailable) || !strings.Contains(err.Error(), "--keyring-fallback") {
+					t.Errorf("error %v, want the keychain to be unavailable", err)
+				}
+				if string(after) != string(before) {
+					t.Errorf("credentials file changed:\n%s", after)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(stderr, "plain text") {
+				t.Errorf("no warning:\n%s", stderr)
+			}
+			if !strings.Contains(string(after), "1//new-refresh") || strings.Contains(string(after), `"storage": "keyring"`) {
+				t.Errorf("secrets not moved to the file:\n%s", after)
+			}
+		})
+	}
+}
+
+func TestAuthLoginSwitchesCredentialType(t *testing.T) {
+	t.Run("user to service account", func(t *testing.T) {
+		useTestEnv(t)
+		savedKey, savedSubject := authServiceAccount, authImpersonate
+		t.Cleanup(func() { authServiceAccount, authImpersonate = savedKey, savedSubject })
+		old := oldProfile()
+		old.TokenType = "Bearer"
+		old.TokenExpiry = time.Now().Add(time.Hour)
+		if err := config.Save(&old); err != nil {
+			t.Fatal(err)
+		}
+		authServiceAccount = filepath.Join(t.TempDir(), "key.json")
+		authImpersonate = "ads@example.com"
+		key := `{"type": "service_account", "client_email": "sa@example.iam.gserviceaccount.com", "private_key": "unused", "token_uri": "https://oauth2.googleapis.com/token"}`
+		if err := os.WriteFile(authServiceAccount, []byte(key), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, _, err := runOutput(t, func() error { return runAuthLogin(authLoginCmd, nil) }); err != nil {
+			t.Fatal(err)
+		}
+
+		creds, err := config.Load()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if creds.ServiceAccountKeyFile != authServiceAccount || creds.ImpersonateUser != "ads@example.com" {
+			t.Errorf("service account not saved: %+v", creds)
+		}
+		if creds.RefreshToken != "" || creds.AccessToken != "" || creds.TokenType != "" || !creds.TokenExpiry.IsZero() {
+			t.Errorf("user tokens kept: %+v", creds)
+		}
+		if creds.DeveloperToken != "old-dev-token" || creds.ManagerCustomerID != "1112223333" {
+			t.Errorf("profile settings lost: %+v", creds)
+		}
+	})
+
+	t.Run("service account to user", func(t *testing.T) {
+		useTestEnv(t)
+		creds := oldProfile()
+		creds.RefreshToken, creds.AccessToken = "", ""
+		creds.ServiceAccountKeyFile = "/keys/sa.json"
+		creds.ImpersonateUser = "ads@example.com"
+		expiry := time.Now().Add(time.Hour).Round(time.Second)
+
+		setOAuthToken(&creds, &oauth2.Token{AccessToken: "ya29.new", RefreshToken: "1//new-refresh", TokenType: "Bearer", Expiry: expiry})
+		if err := saveCredentials(&creds); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := config.Load()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.IsServiceAccount() || got.ServiceAccountKeyFile != "" || got.ImpersonateUser != "" {
+			t.Errorf("service account kept: %+v", got)
+		}
+		if got.RefreshToken != "1//new-refresh" || got.AccessToken != "ya29.new" || got.TokenType != "Bearer" || !got.TokenExpiry.Equal(expiry) {
+			t.Errorf("user tokens not saved: %+v", got)
+		}
+	})
+}
+
+func TestAuthLogoutUnreadableCredentials(t *testing.T) {
+	useTestEnv(t)
+	keyring.MockInit()
+	savedKeepRemote := authLogoutKeepRemote
+	t.Cleanup(func() { authLogoutKeepRemote = savedKeepRemote })
+	authLogoutKeepRemote = false
+	if err := os.MkdirAll(filepath.Dir(config.Path()), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(config.Path(), []byte(`{"refresh_token": "1//cut`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, stderr, err := runOutput(t, func() error { return authLogoutCmd.RunE(authLogoutCmd, nil) })
+	if err == nil || !strings.Contains(err.Error(), "myaccount.google.com/permissions") {
+		t.Errorf("error %v, want the token to be reported as not revoked", err)
+	}
+	if !strings.Contains(stderr, "skipping token revocation") {
+		t.Errorf("no warning that revocation was skipped:\n%s", stderr)
+	}
+	if !strings.Contains(stdout, "Credentials removed.") {
+		t.Errorf("credentials not reported removed:\n%s", stdout)
+	}
+	if _, err := os.Stat(config.Path()); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("credentials file still there: %v", err)
+	}
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/output"
+)
+
+var batchCmd = &cobra.Command{
+	Use:   "batch",
+	Short: "Apply multi-resource changes in a single atomic request",
+}
+
+var (
+	batchFile string
+)
+
+// ---- batch apply ----
+
+var batchApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Apply a JSON file of operations with googleAds:mutate",
+	Long: `Apply a JSON array of operations in one atomic googleAds:mutate request:
+either every operation succeeds or none is applied.
+
+Each element sets exactly one operation type (campaignBudgetOperation,
+campaignOperation, campaignCriterionOperation, adGroupOperation,
+adGroupCriterionOperation, adGroupAdOperation) with one of create, update
+(plus updateMask) or remove. Creates can use negative temporary IDs in
+resourceName so later operations can reference them:
+
+  [
+    {"campaignBudgetOperation": {"create": {
+      "resourceName": "customers/1234567890/campaignBudgets/-1",
+      "name": "Spring budget", "amountMicros": "5000000"}}},
+    {"campaignOperation": {"create": {
+      "resourceName": "customers/1234567890/campaigns/-2",
+      "name": "Spring sale", "status": "PAUSED",
+      "advertisingChannelType": "SEARCH",
+      "campaignBudget": "customers/1234567890/campaignBudgets/-1",
+      "manualCpc": {}}}}
+  ]
+
+Examples:
+  gads-cli batch apply --account=1234567890 --file=ops.json
+  gads-cli batch apply --account=1234567890 --file=ops.json --dry-run
+  cat ops.json | gads-cli batch apply --account=1234567890 --file=- --yes`,
+	Annotations: mutatingCommand,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, err := accountID()
+		if err != nil {
+			return err
+		}
+		if batchFile == "" {
+			return fmt.Errorf("--file is required")
+		}
+		ops, err := readBatchFile(batchFile)
+		if err != nil {
+			return err
+		}
+		if ok, err := confirmChange(fmt.Sprintf("Apply %d operation(s) to account %s?", len(ops), cid), batchSummary(ops)...); !ok {
+			return err
+		}
+
+		resp, err := apiClient.MutateGoogleAds(cid, ops)
+		if err != nil {
+			return err
+		}
+		if apiClient.ValidateOnly() {
+			fmt.Printf("DRY RUN — would have applied %d operation(s)\n", len(ops))
+			return nil
+		}
+
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(resp, output.IsPretty(cmd))
+		}
+		headers := []string{"#", "OPERATION", "ACTION", "TEMP ID", "RESOURCE"}
+		rows := make([][]string, len(ops))
+		for i, mop := range ops {
+			kind, op, _ := mop.Kind()
+			tempID := op.TempID()
+			if tempID == "" {
+				tempID = "-"
+			}
+			resource := "-"
+			if i < len(resp.MutateOperationResponses) {
+				resource = resp.MutateOperationResponses[i].ResourceName()
+			}
+			rows[i] = []string{strconv.Itoa(i), kind, op.Action(), tempID, resource}
+		}
+		if err := output.PrintTable(headers, rows); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "\n%d operation(s) applied.\n", len(resp.MutateOperationResponses))
+		return nil
+	},
+}
+
+// batchSummary counts ops by type and action for the confirmation, e.g.
+// "2 × campaignOperation create", in file order.
+func batchSummary(ops []api.MutateOperation) []string {
+	var keys []string
+	counts := make(map[string]int)
+	for _, mop := range ops {
+		kind, op, _ := mop.Kind()
+		key := kind + " " + op.Action()
+		if counts[key] == 0 {
+			keys = append(keys, key)
+		}
+		counts[key]++
+	}
+	lines := make([]string, len(keys))
+	for i, key := range keys {
+		lines[i] = fmt.Sprintf("%d × %s", counts[key], key)
+	}
+	return lines
+}
+
+// readBatchFile reads and validates the operations file ("-" for stdin).
+// Unknown operation types are rejected rather than silently dropped.
+func readBatchFile(path string) ([]api.MutateOperation, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var ops []api.MutateOperation
+	if err := dec.Decode(&ops); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("%s contains no operations", path)
+	}
+	for i, op := range ops {
+		if _, _, err := op.Kind(); err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+	return ops, nil
+}
+
+func init() {
+	batchApplyCmd.Flags().StringVar(&batchFile, "file", "", "JSON file with an array of operations, or - for stdin (required)")
+
+	batchCmd.AddCommand(batchApplyCmd)
+	rootCmd.AddCommand(batchCmd)
+}
@@ -0,0 +1,314 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/audit"
+	"github.com/the20100/gads-cli/internal/gaql"
+	"github.com/the20100/gads-cli/internal/output"
+)
+
+var biddingCmd = &cobra.Command{
+	Use:   "bidding",
+	Short: "Manage portfolio bidding strategies",
+	Long: `Manage portfolio bidding strategies: automated bidding strategies that
+several campaigns share, with one target for all of them. Attach a campaign
+to one with campaigns set-bidding --portfolio.`,
+}
+
+// biddingFlags holds the flags of one bidding subcommand.
+type biddingFlags struct {
+	name         string
+	strategyType string
+	target       string
+}
+
+// ---- bidding list ----
+
+func newBiddingListCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "list",
+		Short: "List portfolio bidding strategies and how many campaigns use each",
+		Long: `List the account's portfolio bidding strategies with their type, target
+and the number of campaigns attached to them. CPA and max CPC targets are in
+the account's currency; ROAS targets are percentages (400% is a ratio of 4).
+
+Examples:
+  gads-cli bidding list --account=1234567890
+  gads-cli bidding list --account=1234567890 --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			query := gaql.Select(
+				"bidding_strategy.id", "bidding_strategy.name", "bidding_strategy.status",
+				"bidding_strategy.type", "bidding_strategy.campaign_count",
+				"bidding_strategy.target_cpa.target_cpa_micros",
+				"bidding_strategy.target_roas.target_roas",
+				"bidding_strategy.maximize_conversions.target_cpa_micros",
+				"bidding_strategy.maximize_conversion_value.target_roas",
+				"bidding_strategy.target_spend.cpc_bid_ceiling_micros",
+				"bidding_strategy.target_impression_share.location",
+				"bidding_strategy.target_impression_share.location_fraction_micros").
+				From("bidding_strategy").
+				Where("bidding_strategy.status != 'REMOVED'").
+				OrderBy("bidding_strategy.id", false).
+				String()
+			rows, err := apiClient.Search(cid, query)
+			if err != nil {
+				return err
+			}
+			strategies := []api.BiddingStrategy{}
+			for _, raw := range rows {
+				var row api.BiddingStrategyRow
+				if err := json.Unmarshal(raw, &row); err != nil {
+					continue
+				}
+				strategies = append(strategies, row.BiddingStrategy)
+			}
+
+			if output.IsQuiet() {
+				ids := make([]string, len(strategies))
+				for i, s := range strategies {
+					ids[i] = s.ID
+				}
+				return output.PrintIDs(ids)
+			}
+			if output.IsJSON(cmd) {
+				return output.PrintJSON(strategies, output.IsPretty(cmd))
+			}
+			if len(strategies) == 0 {
+				fmt.Println("No portfolio bidding strategies found.")
+				return nil
+			}
+
+			headers := []string{"ID", "NAME", "TYPE", "TARGET", "CAMPAIGNS"}
+			tableRows := make([][]string, len(strategies))
+			for i, s := range strategies {
+				tableRows[i] = []string{
+					s.ID,
+					s.Name,
+					s.Type,
+					biddingTarget(cid, s),
+					strconv.FormatInt(int64(s.CampaignCount), 10),
+				}
+			}
+			return output.PrintTable(headers, tableRows)
+		},
+	}
+	return c
+}
+
+// biddingTarget describes the target of a portfolio strategy, e.g.
+// "CPA 12.50" or "ROAS 400%", or "-" without one.
+func biddingTarget(cid string, s api.BiddingStrategy) string {
+	switch {
+	case s.TargetCpa != nil && s.TargetCpa.TargetCpaMicros > 0:
+		return "CPA " + money(cid, int64(s.TargetCpa.TargetCpaMicros))
+	case s.MaximizeConversions != nil && s.MaximizeConversions.TargetCpaMicros > 0:
+		return "CPA " + money(cid, int64(s.MaximizeConversions.TargetCpaMicros))
+	case s.TargetRoas != nil && s.TargetRoas.TargetRoas > 0:
+		return fmt.Sprintf("ROAS %.0f%%", s.TargetRoas.TargetRoas*100)
+	case s.MaximizeConversionValue != nil && s.MaximizeConversionValue.TargetRoas > 0:
+		return fmt.Sprintf("ROAS %.0f%%", s.MaximizeConversionValue.TargetRoas*100)
+	case s.TargetSpend != nil && s.TargetSpend.CpcBidCeilingMicros > 0:
+		return "max CPC " + money(cid, int64(s.TargetSpend.CpcBidCeilingMicros))
+	case s.TargetImpressionShare != nil:
+		return fmt.Sprintf("%.0f%% impr. share (%s)", float64(s.TargetImpressionShare.LocationFractionMicros)/10_000,
+			strings.ToLower(formatEnum(s.TargetImpressionShare.Location)))
+	}
+	return "-"
+}
+
+// ---- bidding create ----
+
+func newBiddingCreateCmd() *cobra.Command {
+	var f biddingFlags
+	c := &cobra.Command{
+		Use:   "create",
+		Short: "Create a portfolio bidding strategy",
+		Long: `Create a portfolio bidding strategy that campaigns can then share. --target
+depends on --type:
+  TARGET_CPA                 target cost per conversion, in the account's currency (required)
+  TARGET_ROAS                target return on ad spend as a ratio: 4.0 is 400% (required)
+  MAXIMIZE_CONVERSIONS       optional target cost per conversion, in currency
+  MAXIMIZE_CONVERSION_VALUE  optional target ROAS, as a ratio
+  TARGET_SPEND               maximize clicks; optional max CPC, in currency
+
+Examples:
+  gads-cli bidding create --account=1234567890 --type=TARGET_ROAS --target=4.0 --name="Shopping ROAS 400%"
+  gads-cli bidding create --account=1234567890 --type=TARGET_CPA --target=12.50 --name="Leads CPA"
+  gads-cli bidding create --account=1234567890 --type=MAXIMIZE_CONVERSIONS --name="Max conversions"`,
+		Annotations: mutatingCommand,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			if f.name == "" {
+				return fmt.Errorf("--name is required")
+			}
+			op, err := api.BiddingStrategyOperation(f.name, f.strategyType, f.target)
+			if err != nil {
+				return err
+			}
+
+			strategyType := strings.ToUpper(f.strategyType)
+			target := "no target"
+			if f.target != "" {
+				if api.BiddingTargetIsRatio(strategyType) {
+					target = "target ROAS " + f.target
+				} else {
+					target = "target " + f.target
+				}
+			}
+			change := fmt.Sprintf("Account %s: create portfolio strategy %q (%s, %s)", cid, f.name, strategyType, target)
+			if ok, err := confirmChange("Create this bidding strategy?", change); !ok {
+				return err
+			}
+
+			ops := []map[string]any{op}
+			recordUndo("biddingStrategies", &audit.Undo{RemoveCreated: true})
+			resp, err := apiClient.MutateBiddingStrategies(cid, ops)
+			if err != nil {
+				return err
+			}
+			if apiClient.ValidateOnly() {
+				return reportDryRun(resp, len(ops))
+			}
+			if jsonResult() {
+				return printMutateResult(len(ops), resp)
+			}
+			fmt.Printf("Bidding strategy created: %s (%s, %s)\n", f.name, strategyType, target)
+			if len(resp.Results) > 0 {
+				fmt.Printf("Resource: %s\n", resp.Results[0].ResourceName)
+			}
+			return nil
+		},
+	}
+	c.Flags().StringVar(&f.name, "name", "", "Strategy name (required)")
+	c.Flags().StringVar(&f.strategyType, "type", "", "Strategy type: "+strings.Join(api.BiddingStrategyTypes(), ", ")+" (required)")
+	c.Flags().StringVar(&f.target, "target", "", "Target: CPA or max CPC in currency, ROAS as a ratio (4.0 = 400%)")
+	c.MarkFlagRequired("type")
+	c.RegisterFlagCompletionFunc("type", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return api.BiddingStrategyTypes(), cobra.ShellCompDirectiveNoFileComp
+	})
+	return c
+}
+
+// ---- campaigns set-bidding ----
+
+func newCampaignsSetBiddingCmd() *cobra.Command {
+	var f campaignFlags
+	c := &cobra.Command{
+		Use:   "set-bidding",
+		Short: "Attach a campaign to a portfolio bidding strategy",
+		Long: `Make a campaign bid with a portfolio bidding strategy (see bidding list),
+replacing its current strategy. Moving a campaign from one portfolio
+strategy to another can be undone; its previous standard strategy cannot be
+restored automatically.
+
+Examples:
+  gads-cli campaigns set-bidding --account=1234567890 --campaign=111222333 --portfolio=777888999`,
+		Annotations: mutatingCommand,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			if !api.IsNumericID(f.id) {
+				return fmt.Errorf("--campaign must be a numeric ID")
+			}
+			if !api.IsNumericID(f.portfolio) {
+				return fmt.Errorf("--portfolio must be a numeric ID")
+			}
+
+			rows, err := apiClient.Search(cid, gaql.Select(
+				"campaign.id", "campaign.name", "campaign.bidding_strategy_type", "campaign.bidding_strategy").
+				From("campaign").
+				Where("campaign.id = "+gaql.Quote(f.id)).
+				String())
+			if err != nil {
+				return err
+			}
+			if len(rows) == 0 {
+				return fmt.Errorf("campaign %s not found", f.id)
+			}
+			var row api.CampaignRow
+			if err := json.Unmarshal(rows[0], &row); err != nil {
+				return fmt.Errorf("parsing response: %w", err)
+			}
+
+			rows, err = apiClient.Search(cid, gaql.Select(
+				"bidding_strategy.id", "bidding_strategy.name", "bidding_strategy.type", "bidding_strategy.status").
+				From("bidding_strategy").
+				Where("bidding_strategy.id = "+gaql.Quote(f.portfolio)).
+				String())
+			if err != nil {
+				return err
+			}
+			if len(rows) == 0 {
+				return fmt.Errorf("portfolio bidding strategy %s not found — see: gads-cli bidding list", f.portfolio)
+			}
+			var strategy api.BiddingStrategyRow
+			if err := json.Unmarshal(rows[0], &strategy); err != nil {
+				return fmt.Errorf("parsing response: %w", err)
+			}
+			s := strategy.BiddingStrategy
+			if s.Status == "REMOVED" {
+				return fmt.Errorf("portfolio bidding strategy %s is removed", f.portfolio)
+			}
+
+			strategyResource := api.BiddingStrategyResourceName(cid, f.portfolio)
+			previous := row.Campaign.BiddingStrategy
+			if previous == strategyResource {
+				fmt.Printf("Campaign %s already uses portfolio strategy %s.\n", f.id, f.portfolio)
+				return nil
+			}
+			current := row.Campaign.BiddingStrategyType
+			if previous != "" {
+				current = "portfolio " + api.ResourceID(previous)
+			}
+			change := fmt.Sprintf("Campaign %s %q: bidding %s → portfolio %s %q (%s)", f.id, row.Campaign.Name, current, f.portfolio, s.Name, s.Type)
+			if ok, err := confirmChange("Apply this change?", change); !ok {
+				return err
+			}
+
+			resourceName := fmt.Sprintf("customers/%s/campaigns/%s", cid, f.id)
+			ops := []map[string]any{undoUpdate(resourceName, "biddingStrategy", strategyResource)}
+			if previous != "" {
+				recordUndo("campaigns", audit.Restore([]map[string]any{undoUpdate(resourceName, "biddingStrategy", previous)}))
+			} else {
+				recordUndo("campaigns", audit.NotUndoable(fmt.Sprintf("the campaign's previous %s strategy cannot be restored automatically; set it again in Google Ads", current)))
+			}
+			resp, err := apiClient.MutateCampaigns(cid, ops)
+			if err != nil {
+				return err
+			}
+			if apiClient.ValidateOnly() {
+				return reportDryRun(resp, len(ops))
+			}
+			if jsonResult() {
+				return printMutateResult(len(ops), resp)
+			}
+			fmt.Printf("Campaign %s now bids with portfolio strategy %s.\n", f.id, f.portfolio)
+			return nil
+		},
+	}
+	c.Flags().StringVar(&f.id, "campaign", "", "Campaign ID (required)")
+	c.Flags().StringVar(&f.portfolio, "portfolio", "", "Portfolio bidding strategy ID (required)")
+	c.MarkFlagRequired("campaign")
+	c.MarkFlagRequired("portfolio")
+	return c
+}
+
+func init() {
+	biddingCmd.AddCommand(newBiddingListCmd(), newBiddingCreateCmd())
+	rootCmd.AddCommand(biddingCmd)
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/gaql"
+	"github.com/the20100/gads-cli/internal/output"
+)
+
+// ---- insights bid-landscape ----
+
+func newInsightsBidLandscapeCmd() *cobra.Command {
+	var f keywordFlags
+	c := &cobra.Command{
+		Use:   "bid-landscape",
+		Short: "Estimated clicks, cost and conversions of a keyword or ad group at other CPC bids",
+		Long: `Show the bid simulator of a keyword (--keyword=<adGroupId>~<criterionId>) or
+of an ad group's default CPC bid (--adgroup): the clicks, impressions, cost
+and conversions its ads would have got over the simulated week at each of a
+range of CPC bids, lowest bid first.
+
+Google only simulates keywords and ad groups with enough recent traffic on
+manual CPC or enhanced CPC bidding; others have no simulation.
+
+Examples:
+  gads-cli insights bid-landscape --account=1234567890 --keyword=444555666~12345
+  gads-cli insights bid-landscape --account=1234567890 --adgroup=444555666
+  gads-cli insights bid-landscape --account=1234567890 --keyword=444555666~12345 --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			if (f.id == "") == (f.adGroupID == "") {
+				return fmt.Errorf("exactly one of --keyword or --adgroup is required")
+			}
+
+			var query, subject string
+			if f.id != "" {
+				if !keywordIDRe.MatchString(f.id) {
+					return fmt.Errorf("--keyword must be <adGroupId>~<criterionId>, e.g. 444555666~12345")
+				}
+				adGroupID, criterionID, _ := strings.Cut(f.id, "~")
+				subject = "keyword " + f.id
+				query = gaql.Select(
+					"ad_group_criterion_simulation.ad_group_id", "ad_group_criterion_simulation.criterion_id",
+					"ad_group_criterion_simulation.type", "ad_group_criterion_simulation.modification_method",
+					"ad_group_criterion_simulation.start_date", "ad_group_criterion_simulation.end_date",
+					"ad_group_criterion_simulation.cpc_bid_point_list.points").
+					From("ad_group_criterion_simulation").
+					Where("ad_group_criterion_simulation.ad_group_id = " + gaql.Quote(adGroupID)).
+					Where("ad_group_criterion_simulation.criterion_id = " + gaql.Quote(criterionID)).
+					Where("ad_group_criterion_simulation.type = 'CPC_BID'").
+					String()
+			} else {
+				if !api.IsNumericID(f.adGroupID) {
+					return fmt.Errorf("--adgroup must be a numeric ID")
+				}
+				subject = "ad group " + f.adGroupID
+				query = gaql.Select(
+					"ad_group_simulation.ad_group_id", "ad_group_simulation.type",
+					"ad_group_simulation.modification_method",
+					"ad_group_simulation.start_date", "ad_group_simulation.end_date",
+					"ad_group_simulation.cpc_bid_point_list.points").
+					From("ad_group_simulation").
+					Where("ad_group_simulation.ad_group_id = " + gaql.Quote(f.adGroupID)).
+					Where("ad_group_simulation.type = 'CPC_BID'").
+					String()
+			}
+			rows, err := apiClient.Search(cid, query)
+			if err != nil {
+				return err
+			}
+			simulations := []api.BidSimulation{}
+			for _, raw := range rows {
+				var row api.BidSimulationRow
+				if err := json.Unmarshal(raw, &row); err != nil {
+					continue
+				}
+				sim := row.AdGroupCriterionSimulation
+				if sim == nil {
+					sim = row.AdGroupSimulation
+				}
+				if sim == nil {
+					continue
+				}
+				points := sim.CpcBidPointList.Points
+				sort.SliceStable(points, func(i, j int) bool { return points[i].CpcBidMicros < points[j].CpcBidMicros })
+				simulations = append(simulations, *sim)
+			}
+
+			if output.IsJSON(cmd) {
+				return output.PrintJSON(simulations, output.IsPretty(cmd))
+			}
+			if len(simulations) == 0 {
+				fmt.Printf("No bid simulation available for %s: Google only simulates bids with enough recent traffic on manual or enhanced CPC bidding.\n", subject)
+				return nil
+			}
+
+			headers := []string{"CPC BID", "CLICKS", "IMPR", "COST", "CONV", "CONV VALUE"}
+			for i, sim := range simulations {
+				// CSV and markdown tables are for pasting elsewhere: no heading.
+				if !output.IsCSV() && !output.IsMarkdown() {
+					if i > 0 {
+						fmt.Println()
+					}
+					fmt.Printf("Bid simulation of %s, %s to %s (%s):\n\n", subject, sim.StartDate, sim.EndDate, strings.ToLower(formatEnum(sim.ModificationMethod)))
+				}
+				tableRows := make([][]string, len(sim.CpcBidPointList.Points))
+				for j, p := range sim.CpcBidPointList.Points {
+					tableRows[j] = []string{
+						money(cid, int64(p.CpcBidMicros)),
+						api.FormatMetricInt(int64(p.Clicks)),
+						api.FormatMetricInt(int64(p.Impressions)),
+						money(cid, int64(p.CostMicros)),
+						fmt.Sprintf("%.1f", p.BiddableConversions),
+						fmt.Sprintf("%.2f", p.BiddableConversionsValue),
+					}
+				}
+				if err := output.PrintTable(headers, tableRows); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	c.Flags().StringVar(&f.id, "keyword", "", "Keyword ID in format <adGroupId>~<criterionId>")
+	c.Flags().StringVar(&f.adGroupID, "adgroup", "", "Ad group ID, for its default CPC bid")
+	c.MarkFlagsMutuallyExclusive("keyword", "adgroup")
+	return c
+}
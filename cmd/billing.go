@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/gaql"
+	"github.com/the20100/gads-cli/internal/output"
+)
+
+var billingCmd = &cobra.Command{
+	Use:   "billing",
+	Short: "Show account budgets and billing setup (read-only)",
+}
+
+// billingStatus is the output of billing status --json.
+type billingStatus struct {
+	CurrencyCode   string              `json:"currencyCode,omitempty"`
+	AccountBudgets []api.AccountBudget `json:"accountBudgets"`
+}
+
+// ---- billing status ----
+
+var billingStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show spend to date against the account budget",
+	Long: `Show the account's budget orders: approved amount, spend to date, remaining
+amount, purchase order number and time period, in the account's currency.
+
+Only accounts on monthly invoicing have account budgets; for accounts paid
+by card the command says so instead. See billing setup for the payments
+account.
+
+Examples:
+  gads-cli billing status --account=1234567890
+  gads-cli billing status --account=1234567890 --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, err := accountID()
+		if err != nil {
+			return err
+		}
+
+		currency, err := accountCurrency(cid)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: account currency unavailable (%v); amounts are shown without it\n", err)
+		}
+
+		query := gaql.Select(
+			"account_budget.id", "account_budget.name", "account_budget.status", "account_budget.billing_setup",
+			"account_budget.purchase_order_number",
+			"account_budget.approved_spending_limit_micros", "account_budget.approved_spending_limit_type",
+			"account_budget.adjusted_spending_limit_micros", "account_budget.adjusted_spending_limit_type",
+			"account_budget.total_adjustments_micros", "account_budget.amount_served_micros",
+			"account_budget.approved_start_date_time", "account_budget.approved_end_date_time",
+			"account_budget.approved_end_time_type").
+			From("account_budget").
+			Where("account_budget.status = 'APPROVED'").
+			OrderBy("account_budget.approved_start_date_time", true).
+			String()
+		rows, err := apiClient.Search(cid, query)
+		if err != nil {
+			return fmt.Errorf("account budgets unavailable: %w", err)
+		}
+		status := billingStatus{CurrencyCode: currency, AccountBudgets: []api.AccountBudget{}}
+		for _, raw := range rows {
+			var row api.AccountBudgetRow
+			if err := json.Unmarshal(raw, &row); err != nil {
+				continue
+			}
+			status.AccountBudgets = append(status.AccountBudgets, row.AccountBudget)
+		}
+
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(status, output.IsPretty(cmd))
+		}
+		if len(status.AccountBudgets) == 0 {
+			fmt.Println("No approved account budget: the account is not on monthly invoicing (e.g. it is paid by card).")
+			fmt.Printf("See the payments account with: gads-cli billing setup --account=%s\n", cid)
+			return nil
+		}
+
+		for i, b := range status.AccountBudgets {
+			if i > 0 {
+				fmt.Println()
+			}
+			limit := formatBudgetLimit(b.AdjustedSpendingLimitMicros, b.AdjustedSpendingLimitType, currency)
+			remaining := "-"
+			if b.AdjustedSpendingLimitType != "INFINITE" {
+				remaining = formatMoney(int64(b.AdjustedSpendingLimitMicros-b.AmountServedMicros), currency)
+			}
+			end := b.ApprovedEndDateTime
+			if b.ApprovedEndTimeType == "FOREVER" {
+				end = "open-ended"
+			}
+			err := output.PrintKeyValue([][]string{
+				{"Budget", fmt.Sprintf("%s (%s)", orDash(b.Name), b.ID)},
+				{"Status", output.Colorize(b.Status)},
+				{"Approved", formatBudgetLimit(b.ApprovedSpendingLimitMicros, b.ApprovedSpendingLimitType, currency)},
+				{"Adjustments", formatMoney(int64(b.TotalAdjustmentsMicros), currency)},
+				{"Limit", limit},
+				{"Spent To Date", formatMoney(int64(b.AmountServedMicros), currency)},
+				{"Remaining", remaining},
+				{"Purchase Order", orDash(b.PurchaseOrderNumber)},
+				{"Period", fmt.Sprintf("%s → %s", orDash(b.ApprovedStartDateTime), orDash(end))},
+				{"Billing Setup", orDash(api.ResourceID(b.BillingSetup))},
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// ---- billing setup ----
+
+var billingSetupCmd = &cobra.Command{
+	Use:   "setup",
+	Short: "List billing setups with their payments account",
+	Long: `List the account's billing setups: the payments account and profile that pay
+for it and the period each setup covers.
+
+Examples:
+  gads-cli billing setup --account=1234567890
+  gads-cli billing setup --account=1234567890 --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, err := accountID()
+		if err != nil {
+			return err
+		}
+
+		query := gaql.Select(
+			"billing_setup.id", "billing_setup.status", "billing_setup.payments_account",
+			"billing_setup.payments_account_info.payments_account_id",
+			"billing_setup.payments_account_info.payments_account_name",
+			"billing_setup.payments_account_info.payments_profile_id",
+			"billing_setup.payments_account_info.payments_profile_name",
+			"billing_setup.start_date_time", "billing_setup.end_date_time", "billing_setup.end_time_type").
+			From("billing_setup").
+			OrderBy("billing_setup.start_date_time", true).
+			String()
+		rows, err := apiClient.Search(cid, query)
+		if err != nil {
+			return fmt.Errorf("billing setup unavailable: %w", err)
+		}
+		var setups []api.BillingSetup
+		for _, raw := range rows {
+			var row api.BillingSetupRow
+			if err := json.Unmarshal(raw, &row); err != nil {
+				continue
+			}
+			setups = append(setups, row.BillingSetup)
+		}
+
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(setups, output.IsPretty(cmd))
+		}
+		if len(setups) == 0 {
+			fmt.Println("No billing setup visible: billing may be managed by a manager account, or your login lacks billing access.")
+			return nil
+		}
+
+		headers := []string{"ID", "STATUS", "PAYMENTS ACCOUNT", "ACCOUNT NAME", "PROFILE", "START", "END"}
+		tableRows := make([][]string, len(setups))
+		for i, s := range setups {
+			info := s.PaymentsAccountInfo
+			end := s.EndDateTime
+			if s.EndTimeType == "FOREVER" {
+				end = "open-ended"
+			}
+			tableRows[i] = []string{
+				s.ID,
+				output.Colorize(s.Status),
+				orDash(info.PaymentsAccountID),
+				orDash(info.PaymentsAccountName),
+				orDash(info.PaymentsProfileName),
+				orDash(s.StartDateTime),
+				orDash(end),
+			}
+		}
+		return output.PrintTable(headers, tableRows)
+	},
+}
+
+// accountCurrency returns the account's currency code.
+func accountCurrency(cid string) (string, error) {
+	query := gaql.Select("customer.currency_code").From("customer").String()
+	rows, err := apiClient.Search(cid, query)
+	if err != nil {
+		return "", err
+	}
+	if len(rows) == 0 {
+		return "", fmt.Errorf("account %s not found", cid)
+	}
+	var row struct {
+		Customer api.CustomerClient `json:"customer"`
+	}
+	if err := json.Unmarshal(rows[0], &row); err != nil {
+		return "", fmt.Errorf("parsing customer: %w", err)
+	}
+	return row.Customer.CurrencyCode, nil
+}
+
+// formatMoney renders micros in the account's currency; with --raw-numbers
+// the plain amount is followed by the currency code, if known.
+func formatMoney(micros int64, currency string) string {
+	if api.DigitGrouping() || currency == "" {
+		return api.FormatMoney(float64(micros), currency)
+	}
+	return api.MicrosToCurrency(micros) + " " + currency
+}
+
+// formatBudgetLimit renders a spending limit, which is either an amount or
+// INFINITE.
+func formatBudgetLimit(micros api.Int64, limitType, currency string) string {
+	if limitType == "INFINITE" {
+		return "unlimited"
+	}
+	return formatMoney(int64(micros), currency)
+}
+
+func init() {
+	billingCmd.AddCommand(billingStatusCmd, billingSetupCmd)
+	rootCmd.AddCommand(billingCmd)
+}
@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/audit"
+	"github.com/the20100/gads-cli/internal/gaql"
+	"github.com/the20100/gads-cli/internal/output"
+)
+
+var budgetsCmd = &cobra.Command{
+	Use:   "budgets",
+	Short: "Manage campaign budgets, including shared budgets",
+}
+
+var (
+	budgetID       string
+	budgetCampaign string
+	budgetName     string
+	budgetAmount   int64
+	budgetShared   bool
+)
+
+// budgetWithCampaigns is a budget and the campaigns that use it.
+type budgetWithCampaigns struct {
+	api.CampaignBudget
+	Campaigns []api.Campaign `json:"campaigns"`
+}
+
+// ---- budgets list ----
+
+var budgetsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List budgets and how many campaigns use each",
+	Long: `List campaign budgets with their daily amount, whether they are shared, and
+the number of campaigns attached to them.
+
+Examples:
+  gads-cli budgets list --account=1234567890
+  gads-cli budgets list --account=1234567890 --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, err := accountID()
+		if err != nil {
+			return err
+		}
+
+		query := gaql.Select(
+			"campaign_budget.id", "campaign_budget.name", "campaign_budget.status",
+			"campaign_budget.amount_micros", "campaign_budget.explicitly_shared").
+			From("campaign_budget").
+			Where("campaign_budget.status != 'REMOVED'").
+			OrderBy("campaign_budget.id", false).
+			String()
+		rows, err := apiClient.Search(cid, query)
+		if err != nil {
+			return err
+		}
+		byBudget, err := campaignsByBudget(cid, "")
+		if err != nil {
+			return err
+		}
+
+		var budgets []budgetWithCampaigns
+		for _, raw := range rows {
+			var row api.CampaignBudgetRow
+			if err := json.Unmarshal(raw, &row); err != nil {
+				continue
+			}
+			b := row.CampaignBudget
+			budgets = append(budgets, budgetWithCampaigns{CampaignBudget: b, Campaigns: byBudget[b.ResourceName]})
+		}
+
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(budgets, output.IsPretty(cmd))
+		}
+		if len(budgets) == 0 {
+			fmt.Println("No budgets found.")
+			return nil
+		}
+
+		headers := []string{"ID", "NAME", "DAILY AMOUNT", "SHARED", "CAMPAIGNS"}
+		tableRows := make([][]string, len(budgets))
+		for i, b := range budgets {
+			tableRows[i] = []string{
+				b.ID,
+				b.Name,
+				money(cid, int64(b.AmountMicros)),
+				yesNo(b.ExplicitlyShared),
+				strconv.Itoa(len(b.Campaigns)),
+			}
+		}
+		return output.PrintTable(headers, tableRows)
+	},
+}
+
+// ---- budgets create ----
+
+var budgetsCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a campaign budget",
+	Long: `Create a daily campaign budget. Amount is in micros (1 unit = 1,000,000 micros).
+Use --shared for a budget that several campaigns can draw from.
+
+Examples:
+  gads-cli budgets create --account=1234567890 --name="Brand shared" --amount=20000000 --shared
+  gads-cli budgets create --account=1234567890 --name="Spring sale" --amount=5000000`,
+	Annotations: mutatingCommand,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, err := accountID()
+		if err != nil {
+			return err
+		}
+		if budgetName == "" {
+			return fmt.Errorf("--name is required")
+		}
+		if budgetAmount <= 0 {
+			return fmt.Errorf("--amount is required and must be positive (in micros)")
+		}
+
+		kind := "budget"
+		if budgetShared {
+			kind = "shared budget"
+		}
+		change := fmt.Sprintf("Account %s: create %s %q (%s daily)", cid, kind, budgetName, api.MicrosToCurrency(budgetAmount))
+		if ok, err := confirmChange("Create this budget?", change); !ok {
+			return err
+		}
+
+		ops := []map[string]any{{
+			"create": map[string]any{
+				"name":             budgetName,
+				"amountMicros":     strconv.FormatInt(budgetAmount, 10),
+				"deliveryMethod":   "STANDARD",
+				"explicitlyShared": budgetShared,
+			},
+		}}
+		resp, err := apiClient.MutateCampaignBudgets(cid, ops)
+		if err != nil {
+			return err
+		}
+		if apiClient.ValidateOnly() {
+			return reportDryRun(resp, len(ops))
+		}
+		if jsonResult() {
+			return printMutateResult(len(ops), resp)
+		}
+		fmt.Printf("Budget created: %s (%s daily)\n", budgetName, api.MicrosToCurrency(budgetAmount))
+		if len(resp.Results) > 0 {
+			fmt.Printf("Resource: %s\n", resp.Results[0].ResourceName)
+		}
+		return nil
+	},
+}
+
+// ---- budgets attach ----
+
+var budgetsAttachCmd = &cobra.Command{
+	Use:   "attach",
+	Short: "Move a campaign onto a budget",
+	Long: `Make a campaign draw from the given budget, typically a shared one.
+
+Examples:
+  gads-cli budgets attach --account=1234567890 --budget=999888777 --campaign=111222333`,
+	Annotations: mutatingCommand,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, err := accountID()
+		if err != nil {
+			return err
+		}
+		if budgetID == "" {
+			return fmt.Errorf("--budget is required")
+		}
+		if !api.IsNumericID(budgetID) {
+			return fmt.Errorf("--budget must be a numeric ID")
+		}
+		if budgetCampaign == "" {
+			return fmt.Errorf("--campaign is required")
+		}
+		if !api.IsNumericID(budgetCampaign) {
+			return fmt.Errorf("--campaign must be a numeric ID")
+		}
+
+		query := gaql.Select("campaign.id", "campaign.name", "campaign_budget.id").
+			From("campaign").
+			Where("campaign.id = " + gaql.Quote(budgetCampaign)).
+			String()
+		rows, err := apiClient.Search(cid, query)
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return fmt.Errorf("campaign %s not found", budgetCampaign)
+		}
+		var row api.CampaignRow
+		if err := json.Unmarshal(rows[0], &row); err != nil {
+			return fmt.Errorf("parsing response: %w", err)
+		}
+		change := fmt.Sprintf("Campaign %s %q: budget %s → %s", budgetCampaign, row.Campaign.Name, orNone(row.CampaignBudget.ID), budgetID)
+		if ok, err := confirmChange("Apply this change?", change); !ok {
+			return err
+		}
+
+		campaignResource := fmt.Sprintf("customers/%s/campaigns/%s", cid, budgetCampaign)
+		ops := []map[string]any{{
+			"updateMask": "campaignBudget",
+			"update": map[string]any{
+				"resourceName":   campaignResource,
+				"campaignBudget": fmt.Sprintf("customers/%s/campaignBudgets/%s", cid, budgetID),
+			},
+		}}
+		if row.CampaignBudget.ID != "" {
+			previous := fmt.Sprintf("customers/%s/campaignBudgets/%s", cid, row.CampaignBudget.ID)
+			recordUndo("campaigns", audit.Restore([]map[string]any{undoUpdate(campaignResource, "campaignBudget", previous)}))
+		}
+		resp, err := apiClient.MutateCampaigns(cid, ops)
+		if err != nil {
+			return err
+		}
+		if apiClient.ValidateOnly() {
+			return reportDryRun(resp, len(ops))
+		}
+		if jsonResult() {
+			return printMutateResult(len(ops), resp)
+		}
+		fmt.Printf("Campaign %s now uses budget %s.\n", budgetCampaign, budgetID)
+		return nil
+	},
+}
+
+// campaignsByBudget returns the account's campaigns that are not removed,
+// grouped by budget resource name, optionally only those matching cond.
+func campaignsByBudget(cid, cond string) (map[string][]api.Campaign, error) {
+	query := gaql.Select("campaign.id", "campaign.name", "campaign.status", "campaign.campaign_budget").
+		From("campaign").
+		Where("campaign.status != 'REMOVED'").
+		Where(cond).
+		String()
+	rows, err := apiClient.Search(cid, query)
+	if err != nil {
+		return nil, err
+	}
+	byBudget := map[string][]api.Campaign{}
+	for _, raw := range rows {
+		var row api.CampaignRow
+		if err := json.Unmarshal(raw, &row); err != nil {
+			continue
+		}
+		byBudget[row.Campaign.CampaignBudget] = append(byBudget[row.Campaign.CampaignBudget], row.Campaign)
+	}
+	return byBudget, nil
+}
+
+func init() {
+	budgetsCreateCmd.Flags().StringVar(&budgetName, "name", "", "Budget name (required)")
+	budgetsCreateCmd.Flags().Int64Var(&budgetAmount, "amount", 0, "Daily amount in micros (e.g. 5000000 = 5.00, required)")
+	budgetsCreateCmd.Flags().BoolVar(&budgetShared, "shared", false, "Create a shared budget that several campaigns can use")
+	budgetsAttachCmd.Flags().StringVar(&budgetID, "budget", "", "Budget ID (required)")
+	budgetsAttachCmd.Flags().StringVar(&budgetCampaign, "campaign", "", "Campaign ID (required)")
+
+	budgetsCmd.AddCommand(budgetsListCmd, budgetsCreateCmd, budgetsAttachCmd)
+	rootCmd.AddCommand(budgetsCmd)
+}
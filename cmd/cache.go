@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/cache"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the read query cache (--cache-ttl)",
+}
+
+// ---- cache clear ----
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Delete all cached query results",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := cache.Dir()
+		if err != nil {
+			return err
+		}
+		if err := cache.Clear(dir); err != nil {
+			return fmt.Errorf("clearing cache: %w", err)
+		}
+		fmt.Printf("Cache cleared: %s\n", dir)
+		return nil
+	},
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheClearCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
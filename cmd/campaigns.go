@@ -3,11 +3,14 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/audit"
+	"github.com/the20100/gads-cli/internal/gaql"
 	"github.com/the20100/gads-cli/internal/output"
 )
 
@@ -16,166 +19,281 @@ var campaignsCmd = &cobra.Command{
 	Short: "Manage Google Ads campaigns",
 }
 
-var (
-	campaignAccount  string
-	campaignID       string
-	campaignBudgetAm int64
-)
+// campaignFlags holds the flags of one campaigns subcommand; each subcommand
+// has its own, as with insightsFlags.
+type campaignFlags struct {
+	id             string
+	amount         string
+	amountMicros   int64
+	expectCurrent  string
+	label          string
+	unlabel        string
+	yes            bool
+	searchPartners string
+	display        string
+	withScore      bool
+	portfolio      string
+	labels         []string
+}
 
 // ---- campaigns list ----
 
-var campaignsListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List campaigns in an account",
-	Long: `List campaigns with status, budget, and type.
+func newCampaignsListCmd() *cobra.Command {
+	var f campaignFlags
+	c := &cobra.Command{
+		Use:   "list",
+		Short: "List campaigns in an account",
+		Long: `List campaigns with status, budget, and type. SERVING is the campaign's
+primary status: an ENABLED campaign may still not serve (e.g.
+NOT_ELIGIBLE (CAMPAIGN_ENDED)), and the reasons say why.
+
+--with-score adds each campaign's optimization score and its weight in the
+account's score; campaign types Google does not score show "-".
+
+--label (name or ID, repeatable) only lists the campaigns carrying one of
+the labels.
 
 Examples:
   gads-cli campaigns list --account=1234567890
-  gads-cli campaigns list --account=1234567890 --json`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		if campaignAccount == "" {
-			return fmt.Errorf("--account is required")
-		}
-		cid := api.CleanCustomerID(campaignAccount)
-
-		query := `SELECT campaign.id, campaign.name, campaign.status,
-			campaign.advertising_channel_type, campaign.bidding_strategy_type,
-			campaign_budget.id, campaign_budget.amount_micros
-		FROM campaign
-		WHERE campaign.status != 'REMOVED'
-		ORDER BY campaign.id`
-
-		rows, err := apiClient.Search(cid, query)
-		if err != nil {
-			return err
-		}
-
-		var campaigns []api.CampaignRow
-		for _, raw := range rows {
-			var row api.CampaignRow
-			if err := json.Unmarshal(raw, &row); err != nil {
-				continue
+  gads-cli campaigns list --account=1234567890 --json
+  gads-cli campaigns list --account=1234567890 --with-score
+  gads-cli campaigns list --account=1234567890 --label="Q4 promo" --label=Brand
+  gads-cli campaigns list --account=1234567890 --include-removed`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
 			}
-			campaigns = append(campaigns, row)
-		}
 
-		if output.IsJSON(cmd) {
-			return output.PrintJSON(campaigns, output.IsPretty(cmd))
-		}
-		if len(campaigns) == 0 {
-			fmt.Println("No campaigns found.")
-			return nil
-		}
-
-		headers := []string{"ID", "NAME", "STATUS", "TYPE", "DAILY BUDGET"}
-		tableRows := make([][]string, len(campaigns))
-		for i, r := range campaigns {
-			tableRows[i] = []string{
-				r.Campaign.ID,
-				output.Truncate(r.Campaign.Name, 36),
-				r.Campaign.Status,
-				formatChannelType(r.Campaign.AdvertisingChannelType),
-				api.MicrosToCurrency(r.CampaignBudget.AmountMicros),
-			}
-		}
-		output.PrintTable(headers, tableRows)
-		return nil
-	},
+			fields := []string{
+				"campaign.id", "campaign.name", "campaign.status",
+				"campaign.primary_status", "campaign.primary_status_reasons",
+				"campaign.advertising_channel_type", "campaign.bidding_strategy_type",
+				"campaign_budget.id", "campaign_budget.amount_micros",
+			}
+			if f.withScore {
+				fields = append(fields, "campaign.optimization_score", "campaign.optimization_score_weight")
+			}
+			labelFilter, err := campaignLabelFilter(apiClient, cid, f.labels)
+			if err != nil {
+				return err
+			}
+			query := gaql.Select(fields...).
+				From("campaign").
+				Where(notRemoved("campaign.status")).
+				Where(labelFilter).
+				OrderBy("campaign.id", false).
+				String()
+
+			if streamRows() {
+				return streamJSONL[api.CampaignRow](cid, query)
+			}
+			rows, err := apiClient.Search(cid, query)
+			if err != nil {
+				return err
+			}
+
+			var campaigns []api.CampaignRow
+			for _, raw := range rows {
+				var row api.CampaignRow
+				if err := json.Unmarshal(raw, &row); err != nil {
+					continue
+				}
+				campaigns = append(campaigns, row)
+			}
+
+			if output.IsQuiet() {
+				ids := make([]string, len(campaigns))
+				for i, r := range campaigns {
+					ids[i] = r.Campaign.ID
+				}
+				return output.PrintIDs(ids)
+			}
+			if output.IsJSON(cmd) {
+				return output.PrintJSON(campaigns, output.IsPretty(cmd))
+			}
+			if len(campaigns) == 0 {
+				fmt.Println("No campaigns found.")
+				return nil
+			}
+
+			headers := []string{"ID", "NAME", "STATUS", "SERVING", "TYPE", "DAILY BUDGET"}
+			if f.withScore {
+				headers = append(headers, "OPT SCORE", "WEIGHT")
+			}
+			tableRows := make([][]string, len(campaigns))
+			for i, r := range campaigns {
+				row := []string{
+					r.Campaign.ID,
+					r.Campaign.Name,
+					output.Colorize(r.Campaign.Status),
+					servingStatus(r.Campaign.PrimaryStatus, r.Campaign.PrimaryStatusReasons),
+					formatChannelType(r.Campaign.AdvertisingChannelType),
+					money(cid, int64(r.CampaignBudget.AmountMicros)),
+				}
+				if f.withScore {
+					row = append(row, formatOptimizationScore(r.Campaign.OptimizationScore),
+						formatScoreWeight(r.Campaign.OptimizationScoreWeight))
+				}
+				tableRows[i] = dimRemoved(r.Campaign.Status, row)
+			}
+			return output.PrintTable(headers, tableRows)
+		},
+	}
+	c.Flags().BoolVar(&f.withScore, "with-score", false, "Add the optimization score and its weight (OPT SCORE, WEIGHT columns)")
+	addCampaignLabelFlag(c, &f.labels)
+	addStreamFlag(c)
+	addIncludeRemovedFlag(c)
+	return c
 }
 
 // ---- campaigns get ----
 
-var campaignsGetCmd = &cobra.Command{
-	Use:   "get",
-	Short: "Get full details of a campaign",
-	Long: `Get detailed information about a specific campaign.
+func newCampaignsGetCmd() *cobra.Command {
+	var f campaignFlags
+	c := &cobra.Command{
+		Use:   "get",
+		Short: "Get full details of a campaign",
+		Long: `Get detailed information about a specific campaign, including whether
+it serves and, if not or only partly, why (e.g. NOT_ELIGIBLE: CAMPAIGN_ENDED),
+and its optimization score ("-" for campaign types Google does not score).
 
 Examples:
   gads-cli campaigns get --account=1234567890 --campaign=111222333`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		if campaignAccount == "" {
-			return fmt.Errorf("--account is required")
-		}
-		if campaignID == "" {
-			return fmt.Errorf("--campaign is required")
-		}
-		cid := api.CleanCustomerID(campaignAccount)
-
-		query := fmt.Sprintf(`SELECT campaign.id, campaign.name, campaign.status,
-			campaign.advertising_channel_type, campaign.bidding_strategy_type,
-			campaign_budget.id, campaign_budget.amount_micros
-		FROM campaign
-		WHERE campaign.id = '%s'`, campaignID)
-
-		rows, err := apiClient.Search(cid, query)
-		if err != nil {
-			return err
-		}
-		if len(rows) == 0 {
-			return fmt.Errorf("campaign %s not found", campaignID)
-		}
-
-		var row api.CampaignRow
-		if err := json.Unmarshal(rows[0], &row); err != nil {
-			return fmt.Errorf("parsing response: %w", err)
-		}
-
-		if output.IsJSON(cmd) {
-			return output.PrintJSON(row, output.IsPretty(cmd))
-		}
-
-		output.PrintKeyValue([][]string{
-			{"ID", row.Campaign.ID},
-			{"Name", row.Campaign.Name},
-			{"Status", row.Campaign.Status},
-			{"Type", formatChannelType(row.Campaign.AdvertisingChannelType)},
-			{"Bidding", row.Campaign.BiddingStrategyType},
-			{"Daily Budget", api.MicrosToCurrency(row.CampaignBudget.AmountMicros)},
-			{"Budget ID", row.CampaignBudget.ID},
-			{"Resource", row.Campaign.ResourceName},
-		})
-		return nil
-	},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			if !api.IsNumericID(f.id) {
+				return fmt.Errorf("--campaign must be a numeric ID")
+			}
+
+			query := gaql.Select(
+				"campaign.id", "campaign.name", "campaign.status",
+				"campaign.advertising_channel_type", "campaign.bidding_strategy_type",
+				"campaign.primary_status", "campaign.primary_status_reasons",
+				"campaign.network_settings.target_google_search",
+				"campaign.network_settings.target_search_network",
+				"campaign.network_settings.target_content_network",
+				"campaign.optimization_score", "campaign.optimization_score_weight",
+				"campaign_budget.id", "campaign_budget.amount_micros").
+				From("campaign").
+				Where("campaign.id = " + gaql.Quote(f.id)).
+				String()
+
+			rows, err := apiClient.Search(cid, query)
+			if err != nil {
+				return err
+			}
+			if len(rows) == 0 {
+				return fmt.Errorf("campaign %s not found", f.id)
+			}
+
+			var row api.CampaignRow
+			if err := json.Unmarshal(rows[0], &row); err != nil {
+				return fmt.Errorf("parsing response: %w", err)
+			}
+
+			if output.IsJSON(cmd) {
+				return output.PrintJSON(row, output.IsPretty(cmd))
+			}
+
+			kv := [][]string{
+				{"ID", row.Campaign.ID},
+				{"Name", row.Campaign.Name},
+				{"Status", output.Colorize(row.Campaign.Status)},
+				{"Serving", orDash(output.Colorize(row.Campaign.PrimaryStatus))},
+				{"Serving Reasons", servingReasons(row.Campaign.PrimaryStatus, row.Campaign.PrimaryStatusReasons)},
+				{"Type", formatChannelType(row.Campaign.AdvertisingChannelType)},
+				{"Bidding", row.Campaign.BiddingStrategyType},
+				{"Optimization Score", formatOptimizationScore(row.Campaign.OptimizationScore)},
+				{"Score Weight", formatScoreWeight(row.Campaign.OptimizationScoreWeight)},
+			}
+			kv = append(kv, networkSettingRows(row.Campaign.NetworkSettings)...)
+			return output.PrintKeyValue(append(kv, [][]string{
+				{"Daily Budget", money(cid, int64(row.CampaignBudget.AmountMicros))},
+				{"Budget ID", row.CampaignBudget.ID},
+				{"Resource", row.Campaign.ResourceName},
+			}...))
+		},
+	}
+	c.Flags().StringVar(&f.id, "campaign", "", "Campaign ID (required)")
+	c.MarkFlagRequired("campaign")
+	return c
 }
 
 // ---- campaigns pause ----
 
-var campaignsPauseCmd = &cobra.Command{
-	Use:   "pause",
-	Short: "Pause a campaign",
-	Long: `Set a campaign status to PAUSED.
+func newCampaignsPauseCmd() *cobra.Command {
+	var f campaignFlags
+	c := &cobra.Command{
+		Use:   "pause",
+		Short: "Pause a campaign",
+		Long: `Set a campaign status to PAUSED.
 
 Examples:
   gads-cli campaigns pause --account=1234567890 --campaign=111222333`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		return setCampaignStatus(campaignAccount, campaignID, "PAUSED")
-	},
+		Annotations: mutatingCommand,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setCampaignStatus(f.id, "PAUSED")
+		},
+	}
+	c.Flags().StringVar(&f.id, "campaign", "", "Campaign ID (required)")
+	c.MarkFlagRequired("campaign")
+	return c
 }
 
 // ---- campaigns enable ----
 
-var campaignsEnableCmd = &cobra.Command{
-	Use:   "enable",
-	Short: "Enable a campaign",
-	Long: `Set a campaign status to ENABLED.
+func newCampaignsEnableCmd() *cobra.Command {
+	var f campaignFlags
+	c := &cobra.Command{
+		Use:   "enable",
+		Short: "Enable a campaign",
+		Long: `Set a campaign status to ENABLED.
 
 Examples:
   gads-cli campaigns enable --account=1234567890 --campaign=111222333`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		return setCampaignStatus(campaignAccount, campaignID, "ENABLED")
-	},
+		Annotations: mutatingCommand,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setCampaignStatus(f.id, "ENABLED")
+		},
+	}
+	c.Flags().StringVar(&f.id, "campaign", "", "Campaign ID (required)")
+	c.MarkFlagRequired("campaign")
+	return c
 }
 
-func setCampaignStatus(account, campID, status string) error {
-	if account == "" {
-		return fmt.Errorf("--account is required")
+func setCampaignStatus(campID, status string) error {
+	cid, err := accountID()
+	if err != nil {
+		return err
 	}
-	if campID == "" {
-		return fmt.Errorf("--campaign is required")
+	if !api.IsNumericID(campID) {
+		return fmt.Errorf("--campaign must be a numeric ID")
 	}
-	cid := api.CleanCustomerID(account)
 	resourceName := fmt.Sprintf("customers/%s/campaigns/%s", cid, campID)
 
+	rows, err := apiClient.Search(cid, gaql.Select("campaign.id", "campaign.name", "campaign.status").
+		From("campaign").
+		Where("campaign.id = "+gaql.Quote(campID)).
+		String())
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return fmt.Errorf("campaign %s not found", campID)
+	}
+	var row api.CampaignRow
+	if err := json.Unmarshal(rows[0], &row); err != nil {
+		return fmt.Errorf("parsing response: %w", err)
+	}
+	change := fmt.Sprintf("Campaign %s %q: %s → %s", campID, row.Campaign.Name, row.Campaign.Status, status)
+	if ok, err := confirmChange("Apply this change?", change); !ok {
+		return err
+	}
+
 	ops := []map[string]any{
 		{
 			"updateMask": "status",
@@ -185,84 +303,427 @@ func setCampaignStatus(account, campID, status string) error {
 			},
 		},
 	}
-	if _, err := apiClient.MutateCampaigns(cid, ops); err != nil {
+	recordUndo("campaigns", audit.Restore([]map[string]any{undoUpdate(resourceName, "status", row.Campaign.Status)}))
+	resp, err := apiClient.MutateCampaigns(cid, ops)
+	if err != nil {
 		return err
 	}
+	if apiClient.ValidateOnly() {
+		return reportDryRun(resp, len(ops))
+	}
+	if jsonResult() {
+		return printMutateResult(len(ops), resp)
+	}
 	fmt.Printf("Campaign %s status set to %s.\n", campID, status)
 	return nil
 }
 
 // ---- campaigns budget ----
 
-var campaignsBudgetCmd = &cobra.Command{
-	Use:   "budget",
-	Short: "Update the daily budget of a campaign",
-	Long: `Update the daily budget for a campaign. Amount is in micros (1 unit = 1,000,000 micros).
+func newCampaignsBudgetCmd() *cobra.Command {
+	var f campaignFlags
+	c := &cobra.Command{
+		Use:   "budget",
+		Short: "Update the daily budget of a campaign",
+		Long: `Update the daily budget for a campaign. --amount is in the account's
+currency, with a point for decimals (5, 5.50, 5,000.25); --amount-micros
+takes micros instead (1 unit = 1,000,000 micros).
+
+The current and new amounts are shown for confirmation. If the budget is
+shared with other campaigns they are listed too, since the new amount applies
+to all of them. An --amount of 1,000 times the current budget or more is
+refused as a likely amount in micros; a budget of 0 cannot be checked.
+
+--expect-current guards against overwriting someone else's change: the
+budget is read again right before it is updated, and nothing is changed
+unless it is still this amount.
 
 Examples:
-  gads-cli campaigns budget --account=1234567890 --campaign=111222333 --amount=5000000`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		if campaignAccount == "" {
-			return fmt.Errorf("--account is required")
-		}
-		if campaignID == "" {
-			return fmt.Errorf("--campaign is required")
-		}
-		if campaignBudgetAm <= 0 {
-			return fmt.Errorf("--amount is required and must be positive (in micros)")
-		}
-		cid := api.CleanCustomerID(campaignAccount)
-
-		// First fetch the budget resource name from the campaign
-		query := fmt.Sprintf(`SELECT campaign.id, campaign_budget.id
-		FROM campaign
-		WHERE campaign.id = '%s'`, campaignID)
-
-		rows, err := apiClient.Search(cid, query)
-		if err != nil {
-			return err
-		}
-		if len(rows) == 0 {
-			return fmt.Errorf("campaign %s not found", campaignID)
-		}
-		var row api.CampaignRow
-		if err := json.Unmarshal(rows[0], &row); err != nil {
-			return fmt.Errorf("parsing response: %w", err)
-		}
-		if row.CampaignBudget.ID == "" {
-			return fmt.Errorf("could not find budget for campaign %s", campaignID)
-		}
-
-		budgetResourceName := fmt.Sprintf("customers/%s/campaignBudgets/%s", cid, row.CampaignBudget.ID)
-		ops := []map[string]any{
-			{
-				"updateMask": "amountMicros",
-				"update": map[string]any{
-					"resourceName": budgetResourceName,
-					"amountMicros": strconv.FormatInt(campaignBudgetAm, 10),
+  gads-cli campaigns budget --account=1234567890 --campaign=111222333 --amount=5.50
+  gads-cli campaigns budget --account=1234567890 --campaign=111222333 --amount-micros=5500000
+  gads-cli campaigns budget --account=1234567890 --campaign=111222333 --amount=7 --expect-current=5.50`,
+		Annotations: mutatingCommand,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			if !api.IsNumericID(f.id) {
+				return fmt.Errorf("--campaign must be a numeric ID")
+			}
+			amount := f.amountMicros
+			if f.amount != "" {
+				if amount, err = api.ParseCurrencyToMicros(f.amount); err != nil {
+					return fmt.Errorf("--amount: %w", err)
+				}
+			}
+			if amount <= 0 {
+				return fmt.Errorf("--amount or --amount-micros is required and must be positive")
+			}
+			expected := int64(-1)
+			if f.expectCurrent != "" {
+				if expected, err = api.ParseCurrencyToMicros(f.expectCurrent); err != nil {
+					return fmt.Errorf("--expect-current: %w", err)
+				}
+			}
+
+			// First fetch the budget resource name from the campaign
+			query := gaql.Select("campaign.id", "campaign.name", "campaign_budget.id",
+				"campaign_budget.amount_micros", "campaign_budget.explicitly_shared").
+				From("campaign").
+				Where("campaign.id = " + gaql.Quote(f.id)).
+				String()
+
+			rows, err := apiClient.Search(cid, query)
+			if err != nil {
+				return err
+			}
+			if len(rows) == 0 {
+				return fmt.Errorf("campaign %s not found", f.id)
+			}
+			var row api.CampaignRow
+			if err := json.Unmarshal(rows[0], &row); err != nil {
+				return fmt.Errorf("parsing response: %w", err)
+			}
+			if row.CampaignBudget.ID == "" {
+				return fmt.Errorf("could not find budget for campaign %s", f.id)
+			}
+			if current := int64(row.CampaignBudget.AmountMicros); f.amount != "" && current > 0 && amount/1000 >= current {
+				return fmt.Errorf("--amount is in the account's currency: %s would raise the daily budget from %s to %s; use --amount-micros for micros",
+					f.amount, api.MicrosToCurrency(current), api.MicrosToCurrency(amount))
+			}
+
+			budgetResourceName := fmt.Sprintf("customers/%s/campaignBudgets/%s", cid, row.CampaignBudget.ID)
+			what := fmt.Sprintf("the daily budget of campaign %s", f.id)
+			if expected >= 0 {
+				// Fail before asking when it already differs.
+				if err := checkExpectedAmount(what, expected, func() (int64, error) {
+					return int64(row.CampaignBudget.AmountMicros), nil
+				}); err != nil {
+					return err
+				}
+			}
+			changes := []string{fmt.Sprintf("Campaign %s %q: daily budget %s → %s", f.id, row.Campaign.Name,
+				api.MicrosToCurrency(int64(row.CampaignBudget.AmountMicros)), api.MicrosToCurrency(amount))}
+			if row.CampaignBudget.ExplicitlyShared {
+				byBudget, err := campaignsByBudget(cid, "campaign.campaign_budget = "+gaql.Quote(budgetResourceName))
+				if err != nil {
+					return err
+				}
+				var others []api.Campaign
+				for _, c := range byBudget[budgetResourceName] {
+					if c.ID != f.id {
+						others = append(others, c)
+					}
+				}
+				if len(others) > 0 {
+					fmt.Fprintf(os.Stderr, "Warning: budget %s is shared with %d other campaign(s), which change too:\n", row.CampaignBudget.ID, len(others))
+					for _, c := range others {
+						changes = append(changes, fmt.Sprintf("Campaign %s %q (shared budget)", c.ID, c.Name))
+					}
+				}
+			}
+			if ok, err := confirmChange("Update the budget?", changes...); !ok {
+				return err
+			}
+			if expected >= 0 {
+				if err := checkExpectedAmount(what, expected, func() (int64, error) {
+					return currentBudgetMicros(cid, row.CampaignBudget.ID)
+				}); err != nil {
+					return err
+				}
+			}
+			ops := []map[string]any{
+				{
+					"updateMask": "amountMicros",
+					"update": map[string]any{
+						"resourceName": budgetResourceName,
+						"amountMicros": strconv.FormatInt(amount, 10),
+					},
 				},
-			},
-		}
-		if _, err := apiClient.MutateCampaignBudgets(cid, ops); err != nil {
-			return err
-		}
-		fmt.Printf("Campaign %s budget updated to %s (budget ID: %s).\n",
-			campaignID, api.MicrosToCurrency(strconv.FormatInt(campaignBudgetAm, 10)), row.CampaignBudget.ID)
-		return nil
-	},
+			}
+			recordUndo("campaignBudgets", audit.Restore([]map[string]any{
+				undoUpdate(budgetResourceName, "amountMicros", strconv.FormatInt(int64(row.CampaignBudget.AmountMicros), 10)),
+			}))
+			resp, err := apiClient.MutateCampaignBudgets(cid, ops)
+			if err != nil {
+				return err
+			}
+			if apiClient.ValidateOnly() {
+				return reportDryRun(resp, len(ops))
+			}
+			if jsonResult() {
+				return printMutateResult(len(ops), resp)
+			}
+			fmt.Printf("Campaign %s budget updated to %s (budget ID: %s).\n",
+				f.id, api.MicrosToCurrency(amount), row.CampaignBudget.ID)
+			return nil
+		},
+	}
+	c.Flags().StringVar(&f.id, "campaign", "", "Campaign ID (required)")
+	c.MarkFlagRequired("campaign")
+	c.Flags().StringVar(&f.amount, "amount", "", "New daily budget in the account's currency (e.g. 5.50)")
+	c.Flags().Int64Var(&f.amountMicros, "amount-micros", 0, "New daily budget in micros (e.g. 5500000 = 5.50)")
+	c.MarkFlagsMutuallyExclusive("amount", "amount-micros")
+	c.Flags().StringVar(&f.expectCurrent, "expect-current", "", "Only update if the budget is still this amount, in the account's currency, right before the update")
+	return c
 }
 
-func init() {
-	// Shared flags
-	for _, c := range []*cobra.Command{campaignsListCmd, campaignsGetCmd, campaignsPauseCmd, campaignsEnableCmd, campaignsBudgetCmd} {
-		c.Flags().StringVar(&campaignAccount, "account", "", "Customer account ID (required)")
+// currentBudgetMicros reads the current amount of a campaign budget, in micros.
+func currentBudgetMicros(cid, budgetID string) (int64, error) {
+	rows, err := apiClient.Search(cid, gaql.Select("campaign_budget.id", "campaign_budget.amount_micros").
+		From("campaign_budget").
+		Where("campaign_budget.id = "+gaql.Quote(budgetID)).
+		String())
+	if err != nil {
+		return 0, err
 	}
-	for _, c := range []*cobra.Command{campaignsGetCmd, campaignsPauseCmd, campaignsEnableCmd, campaignsBudgetCmd} {
-		c.Flags().StringVar(&campaignID, "campaign", "", "Campaign ID (required)")
+	if len(rows) == 0 {
+		return 0, fmt.Errorf("budget %s not found", budgetID)
 	}
-	campaignsBudgetCmd.Flags().Int64Var(&campaignBudgetAm, "amount", 0, "New daily budget in micros (e.g. 5000000 = 5.00)")
+	var row api.CampaignBudgetRow
+	if err := json.Unmarshal(rows[0], &row); err != nil {
+		return 0, fmt.Errorf("parsing response: %w", err)
+	}
+	return int64(row.CampaignBudget.AmountMicros), nil
+}
+
+// ---- campaigns network-settings ----
+
+// networkSettingFlags are the on/off flags of campaigns network-settings,
+// with the update mask path and name of the network each one sets.
+var networkSettingFlags = []struct{ flag, path, name string }{
+	{"search-partners", "networkSettings.targetSearchNetwork", "search partners"},
+	{"display", "networkSettings.targetContentNetwork", "Display Network"},
+}
+
+func newCampaignsNetworkSettingsCmd() *cobra.Command {
+	var f campaignFlags
+	c := &cobra.Command{
+		Use:   "network-settings",
+		Short: "Show or change the networks a campaign serves on",
+		Long: `Show whether a campaign serves on Google Search, search partners and the
+Display Network. With --search-partners or --display (on or off), change
+those settings instead, after confirmation.
+
+Examples:
+  gads-cli campaigns network-settings --account=1234567890 --campaign=111222333
+  gads-cli campaigns network-settings --account=1234567890 --campaign=111222333 --search-partners=off
+  gads-cli campaigns network-settings --account=1234567890 --campaign=111222333 --search-partners=on --display=off`,
+		Annotations: mutatingCommand,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			if !api.IsNumericID(f.id) {
+				return fmt.Errorf("--campaign must be a numeric ID")
+			}
+			values := map[string]string{"search-partners": f.searchPartners, "display": f.display}
+			set := map[string]bool{}
+			for _, s := range networkSettingFlags {
+				if !cmd.Flags().Changed(s.flag) {
+					continue
+				}
+				on, err := parseOnOff(s.flag, values[s.flag])
+				if err != nil {
+					return err
+				}
+				set[s.path] = on
+			}
+
+			rows, err := apiClient.Search(cid, gaql.Select(
+				"campaign.id", "campaign.name",
+				"campaign.network_settings.target_google_search",
+				"campaign.network_settings.target_search_network",
+				"campaign.network_settings.target_content_network").
+				From("campaign").
+				Where("campaign.id = "+gaql.Quote(f.id)).
+				String())
+			if err != nil {
+				return err
+			}
+			if len(rows) == 0 {
+				return fmt.Errorf("campaign %s not found", f.id)
+			}
+			var row api.CampaignRow
+			if err := json.Unmarshal(rows[0], &row); err != nil {
+				return fmt.Errorf("parsing response: %w", err)
+			}
+			if row.Campaign.NetworkSettings == nil {
+				row.Campaign.NetworkSettings = &api.NetworkSettings{}
+			}
+			ns := row.Campaign.NetworkSettings
+
+			if len(set) == 0 {
+				if output.IsJSON(cmd) {
+					return output.PrintJSON(row.Campaign, output.IsPretty(cmd))
+				}
+				return output.PrintKeyValue(append([][]string{
+					{"ID", row.Campaign.ID},
+					{"Name", row.Campaign.Name},
+				}, networkSettingRows(ns)...))
+			}
+
+			current := map[string]bool{
+				"networkSettings.targetSearchNetwork":  ns.TargetSearchNetwork,
+				"networkSettings.targetContentNetwork": ns.TargetContentNetwork,
+			}
+			fields, previous := map[string]any{}, map[string]any{}
+			var changes []string
+			for _, s := range networkSettingFlags {
+				on, ok := set[s.path]
+				if !ok || on == current[s.path] {
+					continue
+				}
+				fields[s.path], previous[s.path] = on, current[s.path]
+				changes = append(changes, fmt.Sprintf("Campaign %s %q: %s %s → %s", f.id, row.Campaign.Name, s.name, onOff(current[s.path]), onOff(on)))
+			}
+			if len(fields) == 0 {
+				fmt.Printf("Campaign %s already has these network settings.\n", f.id)
+				return nil
+			}
+			if ok, err := confirmChange("Apply this change?", changes...); !ok {
+				return err
+			}
+
+			resourceName := fmt.Sprintf("customers/%s/campaigns/%s", cid, f.id)
+			ops := []map[string]any{updateOperation(resourceName, fields)}
+			recordUndo("campaigns", audit.Restore([]map[string]any{updateOperation(resourceName, previous)}))
+			resp, err := apiClient.MutateCampaigns(cid, ops)
+			if err != nil {
+				return err
+			}
+			if apiClient.ValidateOnly() {
+				return reportDryRun(resp, len(ops))
+			}
+			if jsonResult() {
+				return printMutateResult(len(ops), resp)
+			}
+			fmt.Printf("Network settings of campaign %s updated.\n", f.id)
+			return nil
+		},
+	}
+	c.Flags().StringVar(&f.id, "campaign", "", "Campaign ID (required)")
+	c.MarkFlagRequired("campaign")
+	c.Flags().StringVar(&f.searchPartners, "search-partners", "", "Serve on Google search partners: on or off")
+	c.Flags().StringVar(&f.display, "display", "", "Serve on the Display Network: on or off")
+	return c
+}
+
+// networkSettingRows describes the networks of a campaign, e.g.
+// "Search Partners: off", for campaigns get and network-settings.
+func networkSettingRows(ns *api.NetworkSettings) [][]string {
+	if ns == nil {
+		ns = &api.NetworkSettings{}
+	}
+	return [][]string{
+		{"Google Search", onOff(ns.TargetGoogleSearch)},
+		{"Search Partners", onOff(ns.TargetSearchNetwork)},
+		{"Display Network", onOff(ns.TargetContentNetwork)},
+	}
+}
+
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
+
+// parseOnOff reads the on/off value of --flag.
+func parseOnOff(flag, v string) (bool, error) {
+	switch strings.ToLower(v) {
+	case "on":
+		return true, nil
+	case "off":
+		return false, nil
+	}
+	return false, fmt.Errorf("--%s must be on or off, got %q", flag, v)
+}
+
+// ---- campaigns label ----
+
+func newCampaignsLabelCmd() *cobra.Command {
+	var f campaignFlags
+	c := &cobra.Command{
+		Use:   "label",
+		Short: "Attach a label to a campaign, or detach one",
+		Long: `Attach (--label) or detach (--remove-label) a label, given by name or ID.
+
+Examples:
+  gads-cli campaigns label --account=1234567890 --campaign=111222333 --label="Q4 promo"
+  gads-cli campaigns label --account=1234567890 --campaign=111222333 --remove-label=555666777`,
+		Annotations: mutatingCommand,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			if !api.IsNumericID(f.id) {
+				return fmt.Errorf("--campaign must be a numeric ID")
+			}
+			if (f.label == "") == (f.unlabel == "") {
+				return fmt.Errorf("exactly one of --label or --remove-label is required")
+			}
+
+			ref, verb := f.label, "attached to"
+			if f.unlabel != "" {
+				ref, verb = f.unlabel, "detached from"
+			}
+			label, err := resolveLabel(apiClient, cid, ref)
+			if err != nil {
+				return err
+			}
+
+			action := "attach"
+			if f.unlabel != "" {
+				action = "detach"
+			}
+			change := fmt.Sprintf("Campaign %s: %s label %q", f.id, action, label.Name)
+			if ok, err := confirmChange("Apply this change?", change); !ok {
+				return err
+			}
 
-	campaignsCmd.AddCommand(campaignsListCmd, campaignsGetCmd, campaignsPauseCmd, campaignsEnableCmd, campaignsBudgetCmd)
+			var ops []map[string]any
+			if f.label != "" {
+				ops = []map[string]any{{
+					"create": map[string]any{
+						"campaign": fmt.Sprintf("customers/%s/campaigns/%s", cid, f.id),
+						"label":    label.ResourceName,
+					},
+				}}
+				recordUndo("campaignLabels", &audit.Undo{RemoveCreated: true})
+			} else {
+				ops = []map[string]any{{"remove": api.CampaignLabelResourceName(cid, f.id, label.ID)}}
+			}
+			resp, err := apiClient.MutateCampaignLabels(cid, ops)
+			if err != nil {
+				return err
+			}
+			if apiClient.ValidateOnly() {
+				return reportDryRun(resp, len(ops))
+			}
+			if jsonResult() {
+				return printMutateResult(len(ops), resp)
+			}
+			fmt.Printf("Label %s %s campaign %s.\n", label.Name, verb, f.id)
+			return nil
+		},
+	}
+	c.Flags().StringVar(&f.id, "campaign", "", "Campaign ID (required)")
+	c.MarkFlagRequired("campaign")
+	c.Flags().StringVar(&f.label, "label", "", "Label name or ID to attach")
+	c.Flags().StringVar(&f.unlabel, "remove-label", "", "Label name or ID to detach")
+	return c
+}
+
+func init() {
+	campaignsCmd.AddCommand(
+		newCampaignsListCmd(), newCampaignsGetCmd(), newCampaignsPauseCmd(),
+		newCampaignsEnableCmd(), newCampaignsBudgetCmd(), newCampaignsLabelCmd(),
+		newCampaignsNetworkSettingsCmd(), newCampaignsSetBiddingCmd(),
+	)
 	rootCmd.AddCommand(campaignsCmd)
 }
 
@@ -270,3 +731,46 @@ func formatChannelType(t string) string {
 	return strings.ToLower(strings.ReplaceAll(t, "_", " "))
 }
 
+// formatOptimizationScore writes an optimization score as a percentage,
+// "87.5%", or "-" for campaign types and accounts without one.
+func formatOptimizationScore(score *float64) string {
+	if score == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", *score*100)
+}
+
+// formatScoreWeight writes the weight of a campaign's optimization score in
+// the account's, "1.25", or "-" without one.
+func formatScoreWeight(weight *float64) string {
+	if weight == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f", *weight)
+}
+
+// servingStatus is the SERVING cell of list commands: the primary status,
+// with its reasons unless eligible, e.g. "NOT_ELIGIBLE (CAMPAIGN_ENDED)".
+func servingStatus(status string, reasons []string) string {
+	if status == "" {
+		return "-"
+	}
+	if status == "ELIGIBLE" || len(reasons) == 0 {
+		return output.Colorize(status)
+	}
+	return output.Colorize(status) + " (" + strings.Join(reasons, ", ") + ")"
+}
+
+// servingReasons expands the primary status reasons for get commands, e.g.
+// "NOT_ELIGIBLE: CAMPAIGN_ENDED, NOT_ELIGIBLE: BUDGET_REMOVED".
+func servingReasons(status string, reasons []string) string {
+	if len(reasons) == 0 {
+		return "-"
+	}
+	expanded := make([]string, len(reasons))
+	for i, r := range reasons {
+		expanded[i] = status + ": " + r
+	}
+	return strings.Join(expanded, ", ")
+}
+
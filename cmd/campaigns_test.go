@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// budgetAPI serves a campaign whose budget is 5.50 when first read and
+// reread, unless changedTo is set: then someone else changes it right after
+// the first read. It counts the mutates it receives.
+type budgetAPI struct {
+	changedTo string
+	reads     int
+	mutates   int
+}
+
+func (b *budgetAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/campaignBudgets:mutate"):
+		b.mutates++
+		io.WriteString(w, `{"results":[{"resourceName":"customers/1234567890/campaignBudgets/900"}]}`)
+	case strings.Contains(string(body), "FROM campaign_budget"):
+		b.reads++
+		amount := "5500000"
+		if b.changedTo != "" {
+			amount = b.changedTo
+		}
+		fmt.Fprintf(w, `{"results":[{"campaignBudget":{"id":"900","amountMicros":%q}}]}`, amount)
+	default:
+		b.reads++
+		io.WriteString(w, `{"results":[{"campaign":{"id":"111","name":"Brand"},"campaignBudget":{"id":"900","amountMicros":"5500000"}}]}`)
+	}
+}
+
+func TestCampaignsBudgetExpectCurrent(t *testing.T) {
+	tests := []struct {
+		name        string
+		expect      string
+		changedTo   string
+		wantMutates int
+		wantErr     string
+	}{
+		{name: "unchanged", expect: "5.50", wantMutates: 1},
+		{name: "without the guard", changedTo: "6000000", wantMutates: 1},
+		{name: "already different", expect: "5", wantErr: "is 5.50, not 5.00"},
+		{name: "changed concurrently", expect: "5.50", changedTo: "6000000", wantErr: "is 6.00, not 5.50"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &budgetAPI{changedTo: tt.changedTo}
+			useTestAPI(t, api.ServeHTTP)
+			accountFlag = "1234567890"
+
+			args := []string{"--campaign=111", "--amount=7"}
+			if tt.expect != "" {
+				args = append(args, "--expect-current="+tt.expect)
+			}
+			_, _, err := execute(t, newCampaignsBudgetCmd(), args...)
+			if tt.wantErr == "" && err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("error %v, want one containing %q", err, tt.wantErr)
+			}
+			if api.mutates != tt.wantMutates {
+				t.Errorf("sent %d mutates, want %d", api.mutates, tt.wantMutates)
+			}
+		})
+	}
+}
@@ -0,0 +1,309 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/gaql"
+	"github.com/the20100/gads-cli/internal/output"
+)
+
+// The change history only covers the last 30 days, and change_event
+// queries must have a LIMIT of at most 10,000 rows.
+const (
+	changeHistoryDays  = 30
+	maxChangeEventRows = 10_000
+)
+
+// changeImpactDay is a day of insights change-impact: the campaign's
+// metrics and the changes made that day.
+type changeImpactDay struct {
+	Date    string            `json:"date"`
+	Metrics api.Metrics       `json:"metrics"`
+	Changes []api.ChangeEvent `json:"changes"`
+}
+
+// ---- insights change-impact ----
+
+func newInsightsChangeImpactCmd() *cobra.Command {
+	var (
+		campaignID string
+		days       int
+		limit      int
+	)
+	c := &cobra.Command{
+		Use:   "change-impact",
+		Short: "Daily campaign metrics alongside the changes made each day",
+		Long: `Show a campaign's daily cost, clicks, impressions and conversions over the
+last --days days (today included), with the changes made each day from the
+account's change history: budget edits, status changes, bidding strategy
+changes, ad group, keyword and ad changes, with the time, the user and the
+old and new values. Δ COST is the change in cost from the previous day, so
+a spike and what was changed right before it line up.
+
+Changes to the campaign's budget are included even when the budget is
+shared. Times are in the account's time zone. The change history only goes
+back 30 days, so --days is at most 30; at most --limit changes are read
+(up to 10,000), and a notice says when that cut the history short.
+
+Examples:
+  gads-cli insights change-impact --account=1234567890 --campaign=111222333
+  gads-cli insights change-impact --account=1234567890 --campaign=111222333 --days=30
+  gads-cli insights change-impact --account=1234567890 --campaign=111222333 --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			if !api.IsNumericID(campaignID) {
+				return fmt.Errorf("--campaign must be a numeric ID")
+			}
+			if days < 1 || days > changeHistoryDays {
+				return fmt.Errorf("--days must be between 1 and %d: the change history only covers the last %d days", changeHistoryDays, changeHistoryDays)
+			}
+			if limit < 1 || limit > maxChangeEventRows {
+				return fmt.Errorf("--limit must be between 1 and %d", maxChangeEventRows)
+			}
+
+			campaign, err := changeImpactCampaign(cid, campaignID)
+			if err != nil {
+				return err
+			}
+			now := time.Now()
+			start := now.AddDate(0, 0, 1-days).Format("2006-01-02")
+			end := now.Format("2006-01-02")
+
+			query := gaql.Select(
+				"segments.date", "metrics.impressions", "metrics.clicks",
+				"metrics.cost_micros", "metrics.conversions").
+				From("campaign").
+				Where("campaign.id = " + gaql.Quote(campaignID)).
+				Where(dateBetween(start, end)).
+				String()
+			rows, err := apiClient.Search(cid, query)
+			if err != nil {
+				return err
+			}
+			metrics := map[string]api.Metrics{}
+			for _, raw := range rows {
+				var row api.CampaignDayRow
+				if err := json.Unmarshal(raw, &row); err != nil {
+					continue
+				}
+				metrics[row.Segments.Date] = row.Metrics
+			}
+
+			// GAQL has no OR, and budget changes are not tied to a
+			// campaign: read the campaign's and its budget's separately.
+			changes, err := fetchChangeEvents(cid, "change_event.campaign = "+gaql.Quote(campaign.ResourceName), start, end, limit)
+			if err != nil {
+				return err
+			}
+			if campaign.CampaignBudget != "" {
+				budgetChanges, err := fetchChangeEvents(cid, "change_event.change_resource_name = "+gaql.Quote(campaign.CampaignBudget), start, end, limit)
+				if err != nil {
+					return err
+				}
+				changes = append(changes, budgetChanges...)
+			}
+			byDate := map[string][]api.ChangeEvent{}
+			for _, e := range changes {
+				date, _, _ := strings.Cut(e.ChangeDateTime, " ")
+				byDate[date] = append(byDate[date], e)
+			}
+
+			var timeline []changeImpactDay
+			for d := now.AddDate(0, 0, 1-days); d.Format("2006-01-02") <= end; d = d.AddDate(0, 0, 1) {
+				date := d.Format("2006-01-02")
+				day := changeImpactDay{Date: date, Metrics: metrics[date], Changes: byDate[date]}
+				sort.SliceStable(day.Changes, func(i, j int) bool {
+					return day.Changes[i].ChangeDateTime < day.Changes[j].ChangeDateTime
+				})
+				if day.Changes == nil {
+					day.Changes = []api.ChangeEvent{}
+				}
+				timeline = append(timeline, day)
+			}
+
+			if output.IsJSON(cmd) {
+				return output.PrintJSON(timeline, output.IsPretty(cmd))
+			}
+			fmt.Printf("Campaign %s %q, %s to %s: %d change(s)\n\n", campaignID, campaign.Name, start, end, len(changes))
+			headers := []string{"DATE", "COST", "CLICKS", "IMPR", "CONV", "Δ COST", "TIME", "USER", "CHANGE"}
+			var tableRows [][]string
+			var prevCost int64
+			for i, day := range timeline {
+				cost := int64(day.Metrics.CostMicros)
+				row := []string{
+					day.Date,
+					money(cid, cost),
+					api.FormatMetricInt(int64(day.Metrics.Clicks)),
+					api.FormatMetricInt(int64(day.Metrics.Impressions)),
+					fmt.Sprintf("%.1f", day.Metrics.Conversions),
+					costDelta(prevCost, cost, i > 0),
+					"", "", "",
+				}
+				prevCost = cost
+				if len(day.Changes) == 0 {
+					tableRows = append(tableRows, row)
+					continue
+				}
+				for j, e := range day.Changes {
+					if j > 0 {
+						row = make([]string, len(headers))
+					}
+					_, clock, _ := strings.Cut(e.ChangeDateTime, " ")
+					if len(clock) > 5 {
+						clock = clock[:5]
+					}
+					row[6], row[7], row[8] = clock, changeAuthor(e), describeChange(cid, e)
+					tableRows = append(tableRows, row)
+				}
+			}
+			return output.PrintTable(headers, tableRows)
+		},
+	}
+	c.Flags().StringVar(&campaignID, "campaign", "", "Campaign ID (required)")
+	c.MarkFlagRequired("campaign")
+	c.Flags().IntVar(&days, "days", 14, "Number of days to show, today included (at most 30)")
+	c.Flags().IntVar(&limit, "limit", 1000, "Maximum changes to read from the change history (at most 10000)")
+	return c
+}
+
+// changeImpactCampaign returns the name, resource name and budget of a
+// campaign.
+func changeImpactCampaign(cid, campaignID string) (*api.Campaign, error) {
+	query := gaql.Select("campaign.id", "campaign.name", "campaign.campaign_budget").
+		From("campaign").
+		Where("campaign.id = " + gaql.Quote(campaignID)).
+		String()
+	rows, err := apiClient.Search(cid, query)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("campaign %s not found", campaignID)
+	}
+	var row api.CampaignRow
+	if err := json.Unmarshal(rows[0], &row); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+	return &row.Campaign, nil
+}
+
+// fetchChangeEvents returns the change history entries matching cond
+// between start and end (YYYY-MM-DD, inclusive), newest first. A notice is
+// printed on stderr when limit cut the history short.
+func fetchChangeEvents(cid, cond, start, end string, limit int) ([]api.ChangeEvent, error) {
+	query := gaql.Select(
+		"change_event.change_date_time", "change_event.change_resource_type",
+		"change_event.change_resource_name", "change_event.resource_change_operation",
+		"change_event.changed_fields", "change_event.user_email", "change_event.client_type",
+		"change_event.old_resource", "change_event.new_resource").
+		From("change_event").
+		Where("change_event.change_date_time >= "+gaql.Quote(start)).
+		Where("change_event.change_date_time <= "+gaql.Quote(end+" 23:59:59")).
+		Where(cond).
+		OrderBy("change_event.change_date_time", true).
+		Limit(limit).
+		String()
+	rows, err := apiClient.Search(cid, query)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) >= limit {
+		fmt.Fprintf(os.Stderr, "Note: only the latest %d changes were read; raise --limit (at most %d) for older ones.\n", limit, maxChangeEventRows)
+	}
+	var events []api.ChangeEvent
+	for _, raw := range rows {
+		var row api.ChangeEventRow
+		if err := json.Unmarshal(raw, &row); err != nil {
+			continue
+		}
+		events = append(events, row.ChangeEvent)
+	}
+	return events, nil
+}
+
+// costDelta writes the change in cost from the previous day, "+85%".
+func costDelta(prev, cost int64, hasPrev bool) string {
+	if !hasPrev || prev == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%+.0f%%", float64(cost-prev)/float64(prev)*100)
+}
+
+// changeAuthor is who made a change: the user's email, or the kind of
+// client for changes without one ("Google ads automated rule").
+func changeAuthor(e api.ChangeEvent) string {
+	if e.UserEmail != "" {
+		return e.UserEmail
+	}
+	return formatEnum(e.ClientType)
+}
+
+// describeChange summarizes a change for people, with the old and new
+// values of the changed fields:
+// `Campaign budget 999 updated: amountMicros 10.00 → 20.00`.
+func describeChange(cid string, e api.ChangeEvent) string {
+	op := strings.ToLower(e.ResourceChangeOperation)
+	if op == "" {
+		op = "changed"
+	} else {
+		op += "d"
+	}
+	s := formatEnum(e.ChangeResourceType)
+	if i := strings.LastIndexByte(e.ChangeResourceName, '/'); i >= 0 {
+		s += " " + e.ChangeResourceName[i+1:]
+	}
+	s += " " + op
+	if e.ResourceChangeOperation != "UPDATE" || e.ChangedFields == "" {
+		return s
+	}
+	old, updated := changedResource(e.OldResource), changedResource(e.NewResource)
+	var fields []string
+	for _, path := range strings.Split(e.ChangedFields, ",") {
+		fields = append(fields, fmt.Sprintf("%s %s → %s", path,
+			formatChangeValue(cid, path, fieldAt(old, path)),
+			formatChangeValue(cid, path, fieldAt(updated, path))))
+	}
+	return s + ": " + strings.Join(fields, "; ")
+}
+
+// changedResource returns the resource of a change event's old or new
+// resource, which is its only field ({"campaign": {...}}).
+func changedResource(r map[string]any) map[string]any {
+	for _, v := range r {
+		if m, ok := v.(map[string]any); ok {
+			return m
+		}
+	}
+	return nil
+}
+
+// formatChangeValue writes a changed field's value: amounts for micros,
+// "set" for messages such as a bidding strategy, "-" when unset.
+func formatChangeValue(cid, path string, v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "-"
+	case map[string]any, []any:
+		return "set"
+	case string:
+		if strings.HasSuffix(path, "Micros") {
+			if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+				return money(cid, n)
+			}
+		}
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
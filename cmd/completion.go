@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/gaql"
+)
+
+var completionCmd = &cobra.Command{
+	Use:   "completion bash|zsh|fish|powershell",
+	Short: "Generate a shell completion script",
+	Long: `Generate a completion script for your shell. Besides commands and flags,
+it completes --account (and --login-customer-id) with your accessible
+accounts and aliases, and --campaign and --adgroup with those of the chosen
+account, fetched live and cached for a minute.
+
+Examples:
+  # bash (needs the bash-completion package)
+  gads-cli completion bash > /etc/bash_completion.d/gads-cli
+
+  # zsh
+  gads-cli completion zsh > "${fpath[1]}/_gads-cli"
+
+  # fish
+  gads-cli completion fish > ~/.config/fish/completions/gads-cli.fish
+
+  # PowerShell
+  gads-cli completion powershell | Out-String | Invoke-Expression`,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch args[0] {
+		case "bash":
+			return rootCmd.GenBashCompletionV2(os.Stdout, true)
+		case "zsh":
+			return rootCmd.GenZshCompletion(os.Stdout)
+		case "fish":
+			return rootCmd.GenFishCompletion(os.Stdout, true)
+		default:
+			return rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
+		}
+	},
+}
+
+const (
+	// completionTimeout bounds the API calls made for one completion, so a
+	// slow network never stalls the shell.
+	completionTimeout = 3 * time.Second
+	// completionCacheTTL keeps candidates for repeated tab presses, unless
+	// --cache-ttl says otherwise.
+	completionCacheTTL = time.Minute
+)
+
+// completionClient sets up apiClient for a completion function. It reports
+// false, after which the function offers no candidates, when the CLI is not
+// authenticated or not configured: completion never prints errors into the
+// shell.
+func completionClient() (context.CancelFunc, bool) {
+	if err := selectProfile(); err != nil {
+		return nil, false
+	}
+	if !rootCmd.PersistentFlags().Changed("cache-ttl") {
+		cacheTTL = completionCacheTTL
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	// A blank command: candidates are read-only lookups that may be cached,
+	// even when completing the flags of a mutating command.
+	probe := &cobra.Command{}
+	probe.SetContext(ctx)
+	if err := initAPIClient(probe); err != nil {
+		cancel()
+		return nil, false
+	}
+	return cancel, true
+}
+
+// completeAccounts offers the accessible accounts, "ID<tab>name", and the
+// profile's aliases.
+func completeAccounts(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cancel, ok := completionClient()
+	if !ok {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	defer cancel()
+
+	var candidates []string
+	for _, name := range sortedKeys(accountAliases) {
+		candidates = append(candidates, name+"\talias of "+accountAliases[name])
+	}
+	if mccID := apiClient.LoginCustomerID(); mccID != "" {
+		query := gaql.Select("customer_client.id", "customer_client.descriptive_name").
+			From("customer_client").
+			Where("customer_client.hidden = false").
+			OrderBy("customer_client.id", false).
+			String()
+		if rows, err := apiClient.Search(mccID, query); err == nil {
+			for _, raw := range rows {
+				var row api.CustomerClientRow
+				if json.Unmarshal(raw, &row) == nil {
+					candidates = append(candidates, row.CustomerClient.ID+"\t"+row.CustomerClient.DescriptiveName)
+				}
+			}
+			return candidates, cobra.ShellCompDirectiveNoFileComp
+		}
+	}
+	// Without a manager account only IDs are known.
+	if names, err := apiClient.ListAccessibleCustomers(); err == nil {
+		for _, name := range names {
+			candidates = append(candidates, api.ResourceID(name))
+		}
+	}
+	return candidates, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeCampaigns offers the campaigns of the account given so far (or
+// the default account), "ID<tab>name".
+func completeCampaigns(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cancel, ok := completionClient()
+	if !ok {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	defer cancel()
+	cid, err := accountID()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	query := gaql.Select("campaign.id", "campaign.name").
+		From("campaign").
+		Where("campaign.status != 'REMOVED'").
+		OrderBy("campaign.name", false).
+		String()
+	rows, err := apiClient.Search(cid, query)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var candidates []string
+	for _, raw := range rows {
+		var row api.CampaignRow
+		if json.Unmarshal(raw, &row) == nil {
+			candidates = append(candidates, row.Campaign.ID+"\t"+row.Campaign.Name)
+		}
+	}
+	return candidates, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeAdGroups offers the ad groups of the account, limited to the
+// command's --campaign when it is given, "ID<tab>campaign › ad group".
+func completeAdGroups(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cancel, ok := completionClient()
+	if !ok {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	defer cancel()
+	cid, err := accountID()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	campaign := ""
+	if f := cmd.Flags().Lookup("campaign"); f != nil && api.IsNumericID(f.Value.String()) {
+		campaign = f.Value.String()
+	}
+	query := gaql.Select("ad_group.id", "ad_group.name", "campaign.name").
+		From("ad_group").
+		Where("ad_group.status != 'REMOVED'").
+		WhereIf(campaign != "", "campaign.id = "+gaql.Quote(campaign)).
+		OrderBy("campaign.name", false).
+		String()
+	rows, err := apiClient.Search(cid, query)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var candidates []string
+	for _, raw := range rows {
+		var row api.AdGroupRow
+		if json.Unmarshal(raw, &row) == nil {
+			candidates = append(candidates, fmt.Sprintf("%s\t%s › %s", row.AdGroup.ID, row.Campaign.Name, row.AdGroup.Name))
+		}
+	}
+	return candidates, cobra.ShellCompDirectiveNoFileComp
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// registerCompletions attaches the dynamic completions: --account and
+// --login-customer-id on the root, --campaign and --adgroup on every command
+// that has them. It runs from Execute, once all commands are registered.
+func registerCompletions() {
+	rootCmd.RegisterFlagCompletionFunc("account", completeAccounts)
+	rootCmd.RegisterFlagCompletionFunc("login-customer-id", completeAccounts)
+	registerIDCompletions(rootCmd)
+}
+
+func registerIDCompletions(c *cobra.Command) {
+	completions := map[string]func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective){
+		"campaign": completeCampaigns,
+		"adgroup":  completeAdGroups,
+	}
+	for name, fn := range completions {
+		if c.LocalNonPersistentFlags().Lookup(name) != nil {
+			c.RegisterFlagCompletionFunc(name, fn)
+		}
+	}
+	for _, sub := range c.Commands() {
+		registerIDCompletions(sub)
+	}
+}
+
+func init() {
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	rootCmd.AddCommand(completionCmd)
+}
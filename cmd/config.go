@@ -0,0 +1,574 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/config"
+	"github.com/the20100/gads-cli/internal/output"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage settings: profile settings and defaults for flags",
+}
+
+// configSetting is a key of config set/get/unset, stored in the active
+// profile's credentials file.
+type configSetting struct {
+	help  string
+	field func(*config.Credentials) *string
+	// parse validates and normalizes a value given to config set.
+	parse func(string) (string, error)
+}
+
+var configSettings = map[string]configSetting{
+	"default-account": {
+		help:  "Customer account used when neither --account nor GADS_ACCOUNT is given (per profile)",
+		field: func(c *config.Credentials) *string { return &c.DefaultAccount },
+		parse: func(v string) (string, error) {
+			id := api.CleanCustomerID(v)
+			if !api.IsNumericID(id) {
+				return "", fmt.Errorf("default-account must be a customer ID, e.g. 123-456-7890")
+			}
+			return id, nil
+		},
+	},
+}
+
+// preference is a key of config set/get/unset stored in config.json,
+// shared by every profile: a default for flags, applied unless one of its
+// flags is given or its environment variable is set.
+type preference struct {
+	help  string
+	def   string // effective value when unset
+	parse func(string) (string, error)
+	// flags override the preference.
+	flags []string
+	env   string
+	// flag returns the flag setting value and its value; "" leaves the
+	// flags alone.
+	flag func(value string) (name, flagValue string)
+}
+
+var preferences = map[string]preference{
+	"output-format": {
+		help:  "Default output format: table (JSON when piped), json or csv",
+		def:   "table",
+		parse: oneOf("table", "json", "csv"),
+		flags: []string{"json", "pretty", "csv", "markdown", "jsonl", "format", "quiet"},
+		flag: func(v string) (string, string) {
+			if v == "table" {
+				return "", ""
+			}
+			return v, "true"
+		},
+	},
+	"days": {
+		help:  "Default --days of insights commands and accounts list --with-spend",
+		def:   "30",
+		parse: positiveInt,
+		flags: []string{"days", "start", "end", "period"},
+		flag:  sameFlag("days"),
+	},
+	"concurrency": {
+		help:  "Default --concurrency of multi-account commands",
+		def:   strconv.Itoa(api.DefaultConcurrency),
+		parse: positiveInt,
+		flags: []string{"concurrency"},
+		flag:  sameFlag("concurrency"),
+	},
+	"color": {
+		help:  "Colored statuses in tables: on or off",
+		def:   "on",
+		parse: oneOf("on", "off"),
+		flags: []string{"no-color"},
+		env:   "NO_COLOR",
+		flag: func(v string) (string, string) {
+			return "no-color", strconv.FormatBool(v == "off")
+		},
+	},
+	"assume-yes": {
+		help: "Apply changes without asking for confirmation: true or false",
+		def:  "false",
+		parse: func(v string) (string, error) {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return "", fmt.Errorf("must be true or false")
+			}
+			return strconv.FormatBool(b), nil
+		},
+		flags: []string{"yes"},
+		env:   "GADS_ASSUME_YES",
+		flag:  sameFlag("yes"),
+	},
+}
+
+func oneOf(values ...string) func(string) (string, error) {
+	return func(v string) (string, error) {
+		v = strings.ToLower(v)
+		for _, ok := range values {
+			if v == ok {
+				return v, nil
+			}
+		}
+		return "", fmt.Errorf("must be one of: %s", strings.Join(values, ", "))
+	}
+}
+
+func positiveInt(v string) (string, error) {
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return "", fmt.Errorf("must be a positive whole number")
+	}
+	return strconv.Itoa(n), nil
+}
+
+// sameFlag returns a preference's flag func setting name to the value.
+func sameFlag(name string) func(string) (string, string) {
+	return func(v string) (string, string) { return name, v }
+}
+
+// applyPreferences sets the flags of cmd from config.json where neither
+// the flags nor the environment say otherwise. The flags are not marked as
+// changed, so flag groups and precedence checks still see only what was
+// typed. A file or value that cannot be read is skipped with a warning, so
+// it never blocks a command (including the config command fixing it).
+func applyPreferences(cmd *cobra.Command) {
+	prefs, err := config.LoadPreferences()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring preferences: %v\n", err)
+		return
+	}
+	for _, key := range preferenceKeys() {
+		v, ok := prefs[key]
+		if !ok {
+			continue
+		}
+		p := preferences[key]
+		v, err := p.parse(v)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring %s in config.json: %v\n", key, err)
+			continue
+		}
+		if _, source := preferenceValue(cmd, key, nil); source == "flag" || source == "env" {
+			continue
+		}
+		name, value := p.flag(v)
+		if f := cmd.Flags().Lookup(name); f != nil {
+			f.Value.Set(value)
+		}
+	}
+}
+
+// preferenceValue returns the effective value of preference key for cmd and
+// its source: flag, env, config or default. A flag or environment variable
+// is shown as given, e.g. "--csv=true" or "NO_COLOR=1".
+func preferenceValue(cmd *cobra.Command, key string, prefs config.Preferences) (string, string) {
+	p := preferences[key]
+	for _, name := range p.flags {
+		if f := cmd.Flags().Lookup(name); f != nil && f.Changed {
+			return "--" + name + "=" + f.Value.String(), "flag"
+		}
+	}
+	if p.env != "" {
+		if v := os.Getenv(p.env); v != "" {
+			return p.env + "=" + v, "env"
+		}
+	}
+	if v, ok := prefs[key]; ok {
+		if v, err := p.parse(v); err == nil {
+			return v, "config"
+		}
+	}
+	return p.def, "default"
+}
+
+// effectiveSettings lists every setting with its effective value and
+// source for cmd, sorted by key: the profile's, then preferences.
+func effectiveSettings(cmd *cobra.Command) [][3]string {
+	var rows [][3]string
+	creds, _ := config.Load()
+	for _, key := range sortedKeys(configSettings) {
+		value, source := "", "default"
+		if creds != nil {
+			if value = *configSettings[key].field(creds); value != "" {
+				source = "profile"
+			}
+		}
+		rows = append(rows, [3]string{key, value, source})
+	}
+	prefs, _ := config.LoadPreferences()
+	for _, key := range preferenceKeys() {
+		value, source := preferenceValue(cmd, key, prefs)
+		rows = append(rows, [3]string{key, value, source})
+	}
+	return rows
+}
+
+// checkSetting returns an error listing the known keys unless key is one.
+func checkSetting(key string) error {
+	if _, ok := configSettings[key]; ok {
+		return nil
+	}
+	if _, ok := preferences[key]; ok {
+		return nil
+	}
+	return fmt.Errorf("unknown setting %q (available: %s)", key, strings.Join(settingKeys(), ", "))
+}
+
+// settingKeys returns every setting key, sorted.
+func settingKeys() []string {
+	keys := append(sortedKeys(configSettings), preferenceKeys()...)
+	sort.Strings(keys)
+	return keys
+}
+
+func preferenceKeys() []string {
+	return sortedKeys(preferences)
+}
+
+// ---- config set ----
+
+var configSetCmd = &cobra.Command{
+	Use:   "set KEY VALUE",
+	Short: "Store a setting",
+	Long: `Store a setting. default-account is stored in the active profile's
+credentials file; the others are defaults for flags, stored in config.json
+in the same directory and shared by every profile. A flag, or the
+environment variable noted, always overrides them.
+
+Settings:
+  default-account   Customer account used when neither --account nor
+                    GADS_ACCOUNT is given (per profile)
+  output-format     Default output format: table (JSON when piped), json
+                    or csv
+  days              Default --days of insights commands and accounts list
+                    --with-spend
+  concurrency       Default --concurrency of multi-account commands
+  color             Colored statuses in tables: on or off (env: NO_COLOR)
+  assume-yes        Apply changes without asking for confirmation: true or
+                    false (env: GADS_ASSUME_YES)
+
+Examples:
+  gads-cli config set default-account 123-456-7890
+  gads-cli config set default-account 1234567890 --profile=agency
+  gads-cli config set output-format json
+  gads-cli config set days 7`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkSetting(args[0]); err != nil {
+			return err
+		}
+		if p, ok := preferences[args[0]]; ok {
+			value, err := p.parse(args[1])
+			if err != nil {
+				return fmt.Errorf("%s %w", args[0], err)
+			}
+			prefs, err := config.LoadPreferences()
+			if err != nil {
+				return err
+			}
+			prefs[args[0]] = value
+			if err := config.SavePreferences(prefs); err != nil {
+				return fmt.Errorf("saving preferences: %w", err)
+			}
+			fmt.Printf("%s set to %s (all profiles)\n", args[0], value)
+			return nil
+		}
+		setting := configSettings[args[0]]
+		value, err := setting.parse(args[1])
+		if err != nil {
+			return err
+		}
+		creds, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("loading credentials: %w", err)
+		}
+		*setting.field(creds) = value
+		if err := config.Save(creds); err != nil {
+			return fmt.Errorf("saving credentials: %w", err)
+		}
+		fmt.Printf("%s set to %s (profile %s)\n", args[0], value, config.ActiveProfile())
+		return nil
+	},
+}
+
+// ---- config get ----
+
+var configGetCmd = &cobra.Command{
+	Use:   "get [KEY]",
+	Short: "Show stored settings",
+	Long: `Show one stored setting, or all of them: the active profile's and the
+preferences of config.json. Unset settings are empty; see config list for
+the values in effect.
+
+Examples:
+  gads-cli config get
+  gads-cli config get default-account`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		keys := settingKeys()
+		if len(args) == 1 {
+			if err := checkSetting(args[0]); err != nil {
+				return err
+			}
+			keys = args
+		}
+		creds, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("loading credentials: %w", err)
+		}
+		prefs, err := config.LoadPreferences()
+		if err != nil {
+			return err
+		}
+		stored := func(key string) string {
+			if s, ok := configSettings[key]; ok {
+				return *s.field(creds)
+			}
+			return prefs[key]
+		}
+		if output.IsJSON(cmd) {
+			values := make(map[string]string, len(keys))
+			for _, k := range keys {
+				values[k] = stored(k)
+			}
+			return output.PrintJSON(values, output.IsPretty(cmd))
+		}
+		if len(args) == 1 {
+			fmt.Println(stored(keys[0]))
+			return nil
+		}
+		rows := make([][]string, len(keys))
+		for i, k := range keys {
+			rows[i] = []string{k, orNone(stored(k))}
+		}
+		return output.PrintKeyValue(rows)
+	},
+}
+
+// ---- config list ----
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List every setting with the value in effect and its source",
+	Long: `List every setting with the value in effect and where it comes from:
+flag, env (an environment variable), config (config.json), profile (the
+credentials file) or default.
+
+Examples:
+  gads-cli config list
+  gads-cli config list --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		settings := effectiveSettings(cmd)
+		if output.IsJSON(cmd) {
+			out := make([]map[string]string, len(settings))
+			for i, s := range settings {
+				out[i] = map[string]string{"key": s[0], "value": s[1], "source": s[2]}
+			}
+			return output.PrintJSON(out, output.IsPretty(cmd))
+		}
+		rows := make([][]string, len(settings))
+		for i, s := range settings {
+			help := preferences[s[0]].help
+			if c, ok := configSettings[s[0]]; ok {
+				help = c.help
+			}
+			rows[i] = []string{s[0], orNone(s[1]), s[2], help}
+		}
+		return output.PrintTable([]string{"KEY", "VALUE", "SOURCE", "DESCRIPTION"}, rows)
+	},
+}
+
+// ---- config unset ----
+
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset KEY",
+	Short: "Remove a stored setting",
+	Long: `Remove a setting from the active profile's credentials file or, for
+flag defaults, from config.json.
+
+Examples:
+  gads-cli config unset default-account
+  gads-cli config unset output-format`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkSetting(args[0]); err != nil {
+			return err
+		}
+		if _, ok := preferences[args[0]]; ok {
+			prefs, err := config.LoadPreferences()
+			if err != nil {
+				return err
+			}
+			delete(prefs, args[0])
+			if err := config.SavePreferences(prefs); err != nil {
+				return fmt.Errorf("saving preferences: %w", err)
+			}
+			fmt.Printf("%s unset (all profiles)\n", args[0])
+			return nil
+		}
+		setting := configSettings[args[0]]
+		creds, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("loading credentials: %w", err)
+		}
+		*setting.field(creds) = ""
+		if err := config.Save(creds); err != nil {
+			return fmt.Errorf("saving credentials: %w", err)
+		}
+		fmt.Printf("%s unset (profile %s)\n", args[0], config.ActiveProfile())
+		return nil
+	},
+}
+
+// ---- config alias ----
+
+var configAliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "Manage account aliases, usable wherever an account ID is",
+	Long: `Manage account aliases. An alias can be given to --account,
+--login-customer-id, GADS_ACCOUNT and GADS_LOGIN_CUSTOMER_ID instead of
+the customer ID. Aliases are stored per profile.
+
+Examples:
+  gads-cli config alias add acme 123-456-7890
+  gads-cli campaigns list --account=acme
+  gads-cli config alias list
+  gads-cli config alias remove acme`,
+}
+
+// aliasName is the form of an alias: one word, safe to type unquoted.
+var aliasName = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+var configAliasAddCmd = &cobra.Command{
+	Use:   "add NAME ID",
+	Short: "Add or replace an account alias",
+	Long: `Add an alias for a customer ID, replacing any alias of the same name.
+
+An alias that looks like a customer ID is ignored in favor of the ID itself.
+
+Examples:
+  gads-cli config alias add acme 123-456-7890`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, id := args[0], api.CleanCustomerID(args[1])
+		if !aliasName.MatchString(name) {
+			return fmt.Errorf("invalid alias %q: use letters, digits, -, _ or .", name)
+		}
+		if !api.IsNumericID(id) {
+			return fmt.Errorf("%q is not a customer ID, e.g. 123-456-7890", args[1])
+		}
+		creds, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("loading credentials: %w", err)
+		}
+		if creds.Aliases == nil {
+			creds.Aliases = make(map[string]string)
+		}
+		creds.Aliases[name] = id
+		if err := config.Save(creds); err != nil {
+			return fmt.Errorf("saving credentials: %w", err)
+		}
+		if api.IsNumericID(api.CleanCustomerID(name)) {
+			fmt.Fprintf(os.Stderr, "Warning: alias %q looks like a customer ID and will be read as one\n", name)
+		}
+		fmt.Printf("Alias added: %s → %s\n", name, id)
+		return nil
+	},
+}
+
+var configAliasListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List account aliases",
+	Long: `List the account aliases of the active profile.
+
+Examples:
+  gads-cli config alias list
+  gads-cli config alias list --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		creds, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("loading credentials: %w", err)
+		}
+		if output.IsJSON(cmd) {
+			aliases := creds.Aliases
+			if aliases == nil {
+				aliases = map[string]string{}
+			}
+			return output.PrintJSON(aliases, output.IsPretty(cmd))
+		}
+		if len(creds.Aliases) == 0 {
+			fmt.Println("No aliases. Add one with: gads-cli config alias add <name> <id>")
+			return nil
+		}
+		names := make([]string, 0, len(creds.Aliases))
+		for name := range creds.Aliases {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		rows := make([][]string, len(names))
+		for i, name := range names {
+			rows[i] = []string{name, creds.Aliases[name]}
+		}
+		return output.PrintTable([]string{"ALIAS", "ACCOUNT ID"}, rows)
+	},
+}
+
+var configAliasRemoveCmd = &cobra.Command{
+	Use:   "remove NAME",
+	Short: "Remove an account alias",
+	Long: `Remove an account alias from the active profile.
+
+Examples:
+  gads-cli config alias remove acme`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		creds, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("loading credentials: %w", err)
+		}
+		if _, ok := creds.Aliases[args[0]]; !ok {
+			return fmt.Errorf("no alias %q — see: gads-cli config alias list", args[0])
+		}
+		delete(creds.Aliases, args[0])
+		if err := config.Save(creds); err != nil {
+			return fmt.Errorf("saving credentials: %w", err)
+		}
+		fmt.Printf("Alias removed: %s\n", args[0])
+		return nil
+	},
+}
+
+// aliasesByID inverts aliases for display, joining several names of the
+// same account with commas.
+func aliasesByID(aliases map[string]string) map[string]string {
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	byID := make(map[string]string, len(aliases))
+	for _, name := range names {
+		id := aliases[name]
+		if byID[id] != "" {
+			byID[id] += ","
+		}
+		byID[id] += name
+	}
+	return byID
+}
+
+func init() {
+	configAliasCmd.AddCommand(configAliasAddCmd, configAliasListCmd, configAliasRemoveCmd)
+	configCmd.AddCommand(configSetCmd, configGetCmd, configListCmd, configUnsetCmd, configAliasCmd)
+	rootCmd.AddCommand(configCmd)
+}
@@ -0,0 +1,540 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/gaql"
+	"github.com/the20100/gads-cli/internal/output"
+)
+
+var conversionsCmd = &cobra.Command{
+	Use:   "conversions",
+	Short: "Manage conversion actions",
+}
+
+var (
+	conversionID       string
+	conversionName     string
+	conversionType     string
+	conversionCategory string
+	conversionCount    string
+	conversionValue    float64
+	conversionCurrency string
+	conversionFile     string
+)
+
+// conversionActionFields are selected by conversions list and get.
+var conversionActionFields = []string{
+	"conversion_action.id", "conversion_action.name", "conversion_action.status",
+	"conversion_action.type", "conversion_action.category", "conversion_action.counting_type",
+	"conversion_action.attribution_model_settings.attribution_model",
+	"conversion_action.include_in_conversions_metric",
+	"conversion_action.value_settings.default_value",
+	"conversion_action.value_settings.default_currency_code",
+	"conversion_action.value_settings.always_use_default_value",
+}
+
+// ---- conversions list ----
+
+var conversionsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List conversion actions in an account",
+	Long: `List conversion actions with their type, category, counting and attribution
+settings. Actions that are not ENABLED are flagged with "!".
+
+Examples:
+  gads-cli conversions list --account=1234567890
+  gads-cli conversions list --account=1234567890 --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, err := accountID()
+		if err != nil {
+			return err
+		}
+
+		query := gaql.Select(conversionActionFields...).
+			From("conversion_action").
+			Where("conversion_action.status != 'REMOVED'").
+			OrderBy("conversion_action.name", false).
+			String()
+
+		rows, err := apiClient.Search(cid, query)
+		if err != nil {
+			return err
+		}
+
+		var actions []api.ConversionActionRow
+		for _, raw := range rows {
+			var row api.ConversionActionRow
+			if err := json.Unmarshal(raw, &row); err != nil {
+				continue
+			}
+			actions = append(actions, row)
+		}
+
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(actions, output.IsPretty(cmd))
+		}
+		if len(actions) == 0 {
+			fmt.Println("No conversion actions found.")
+			return nil
+		}
+
+		headers := []string{"ID", "NAME", "STATUS", "TYPE", "CATEGORY", "COUNTING", "ATTRIBUTION", "PRIMARY"}
+		tableRows := make([][]string, len(actions))
+		disabled := 0
+		for i, r := range actions {
+			a := r.ConversionAction
+			status := output.Colorize(a.Status)
+			if a.Status != "ENABLED" {
+				status += " !"
+				disabled++
+			}
+			tableRows[i] = []string{
+				a.ID,
+				a.Name,
+				status,
+				a.Type,
+				a.Category,
+				a.CountingType,
+				a.AttributionModelSettings.AttributionModel,
+				yesNo(a.IncludeInConversionsMetric),
+			}
+		}
+		if err := output.PrintTable(headers, tableRows); err != nil {
+			return err
+		}
+		if disabled > 0 {
+			fmt.Fprintf(os.Stderr, "\n%d conversion action(s) not ENABLED — they record no conversions.\n", disabled)
+		}
+		return nil
+	},
+}
+
+// ---- conversions get ----
+
+var conversionsGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Get full details of a conversion action",
+	Long: `Get detailed information about a specific conversion action.
+
+Examples:
+  gads-cli conversions get --account=1234567890 --id=987654321`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, err := accountID()
+		if err != nil {
+			return err
+		}
+		if conversionID == "" {
+			return fmt.Errorf("--id is required")
+		}
+		if !api.IsNumericID(conversionID) {
+			return fmt.Errorf("--id must be a numeric ID")
+		}
+
+		query := gaql.Select(conversionActionFields...).
+			From("conversion_action").
+			Where("conversion_action.id = " + gaql.Quote(conversionID)).
+			String()
+
+		rows, err := apiClient.Search(cid, query)
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return fmt.Errorf("conversion action %s not found", conversionID)
+		}
+
+		var row api.ConversionActionRow
+		if err := json.Unmarshal(rows[0], &row); err != nil {
+			return fmt.Errorf("parsing response: %w", err)
+		}
+
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(row, output.IsPretty(cmd))
+		}
+
+		a := row.ConversionAction
+		return output.PrintKeyValue([][]string{
+			{"ID", a.ID},
+			{"Name", a.Name},
+			{"Status", output.Colorize(a.Status)},
+			{"Type", a.Type},
+			{"Category", a.Category},
+			{"Counting", a.CountingType},
+			{"Attribution", a.AttributionModelSettings.AttributionModel},
+			{"In Conversions", yesNo(a.IncludeInConversionsMetric)},
+			{"Default Value", fmt.Sprintf("%.2f %s", a.ValueSettings.DefaultValue, a.ValueSettings.DefaultCurrencyCode)},
+			{"Always Default", yesNo(a.ValueSettings.AlwaysUseDefaultValue)},
+			{"Resource", a.ResourceName},
+		})
+	},
+}
+
+// ---- conversions create ----
+
+var conversionsCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a conversion action",
+	Long: `Create an ENABLED conversion action.
+
+--type defaults to WEBPAGE; use UPLOAD_CLICKS for actions fed by
+conversions upload-clicks. --category takes a ConversionActionCategory such as
+PURCHASE, SIGNUP, SUBMIT_LEAD_FORM or QUALIFIED_LEAD.
+
+Examples:
+  gads-cli conversions create --account=1234567890 --name="Purchase" --category=PURCHASE --value=50 --count=MANY_PER_CLICK
+  gads-cli conversions create --account=1234567890 --name="CRM qualified lead" --type=UPLOAD_CLICKS --category=QUALIFIED_LEAD --count=ONE_PER_CLICK`,
+	Annotations: mutatingCommand,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, err := accountID()
+		if err != nil {
+			return err
+		}
+		if conversionName == "" {
+			return fmt.Errorf("--name is required")
+		}
+		count := strings.ToUpper(conversionCount)
+		if count != "ONE_PER_CLICK" && count != "MANY_PER_CLICK" {
+			return fmt.Errorf("--count must be ONE_PER_CLICK or MANY_PER_CLICK")
+		}
+		if conversionValue < 0 {
+			return fmt.Errorf("--value must not be negative")
+		}
+
+		action := map[string]any{
+			"name":         conversionName,
+			"status":       "ENABLED",
+			"type":         strings.ToUpper(conversionType),
+			"category":     strings.ToUpper(conversionCategory),
+			"countingType": count,
+		}
+		if conversionValue > 0 || conversionCurrency != "" {
+			valueSettings := map[string]any{"defaultValue": conversionValue}
+			if conversionCurrency != "" {
+				valueSettings["defaultCurrencyCode"] = strings.ToUpper(conversionCurrency)
+			}
+			action["valueSettings"] = valueSettings
+		}
+		change := fmt.Sprintf("Account %s: create conversion action %q (%s, %s)",
+			cid, conversionName, action["type"], action["category"])
+		if ok, err := confirmChange("Create this conversion action?", change); !ok {
+			return err
+		}
+		ops := []map[string]any{{"create": action}}
+
+		resp, err := apiClient.MutateConversionActions(cid, ops)
+		if err != nil {
+			return err
+		}
+		if apiClient.ValidateOnly() {
+			return reportDryRun(resp, len(ops))
+		}
+		if jsonResult() {
+			return printMutateResult(len(ops), resp)
+		}
+		fmt.Printf("Conversion action created: %s\n", conversionName)
+		if len(resp.Results) > 0 {
+			fmt.Printf("Resource: %s\n", resp.Results[0].ResourceName)
+		}
+		return nil
+	},
+}
+
+// ---- conversions upload-clicks ----
+
+var conversionsUploadClicksCmd = &cobra.Command{
+	Use:   "upload-clicks",
+	Short: "Upload offline click conversions from a CSV file",
+	Long: `Upload offline conversions (e.g. CRM-qualified leads) attributed to ad clicks.
+
+The CSV file needs a header row with these columns (any order):
+  gclid              Google click ID of the ad click
+  conversion_action  conversion action name, ID or resource name
+  conversion_time    "yyyy-mm-dd hh:mm:ss+hh:mm" or RFC3339 (e.g. 2024-05-01T14:30:00Z)
+  value              conversion value (optional)
+  currency           currency code, e.g. EUR (optional)
+
+Rows are sent in batches of up to 2000 with partial failure, so valid rows are
+uploaded even when others fail; failures are reported with their CSV line.
+Use --dry-run to validate the file with the API without uploading.
+
+Examples:
+  gads-cli conversions upload-clicks --account=1234567890 --file=conversions.csv --dry-run
+  gads-cli conversions upload-clicks --account=1234567890 --file=conversions.csv`,
+	Annotations: mutatingCommand,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, err := accountID()
+		if err != nil {
+			return err
+		}
+		if conversionFile == "" {
+			return fmt.Errorf("--file is required")
+		}
+
+		rows, err := readClickConversions(conversionFile)
+		if err != nil {
+			return err
+		}
+		if err := resolveConversionActions(cid, rows); err != nil {
+			return err
+		}
+		change := fmt.Sprintf("Account %s: upload %d click conversion(s) from %s", cid, len(rows), conversionFile)
+		if ok, err := confirmChange("Upload these conversions?", change); !ok {
+			return err
+		}
+
+		var failures []clickConversionFailure
+		uploaded := 0
+		for start := 0; start < len(rows); start += api.MaxClickConversionsPerRequest {
+			end := min(start+api.MaxClickConversionsPerRequest, len(rows))
+			batch := make([]api.ClickConversion, end-start)
+			for i, r := range rows[start:end] {
+				batch[i] = r.ClickConversion
+			}
+			resp, err := apiClient.UploadClickConversions(cid, batch)
+			if err != nil {
+				return fmt.Errorf("uploading lines %d-%d: %w", rows[start].Line, rows[end-1].Line, err)
+			}
+			opErrs := resp.ConversionErrors()
+			uploaded += len(batch) - countIndexed(opErrs)
+			for _, e := range opErrs {
+				f := clickConversionFailure{Code: e.Code, Message: e.Message}
+				if e.Index >= 0 && e.Index < len(batch) {
+					f.Line = rows[start+e.Index].Line
+				}
+				failures = append(failures, f)
+			}
+		}
+
+		if output.IsJSON(cmd) {
+			if err := output.PrintJSON(map[string]any{
+				"dry_run":  apiClient.ValidateOnly(),
+				"uploaded": uploaded,
+				"failed":   failures,
+			}, output.IsPretty(cmd)); err != nil {
+				return err
+			}
+		} else {
+			for _, f := range failures {
+				fmt.Fprintf(os.Stderr, "%s\n", f)
+			}
+			if apiClient.ValidateOnly() {
+				fmt.Printf("DRY RUN — would have uploaded %d conversion(s)\n", uploaded)
+			} else {
+				fmt.Printf("%d conversion(s) uploaded.\n", uploaded)
+			}
+		}
+		if len(failures) > 0 {
+			return fmt.Errorf("%d conversion(s) failed", len(failures))
+		}
+		return nil
+	},
+}
+
+// clickConversionRow is a parsed CSV row. ActionRef is the conversion action
+// as given in the file, resolved into ConversionAction before upload.
+type clickConversionRow struct {
+	api.ClickConversion
+	Line      int
+	ActionRef string
+}
+
+// clickConversionFailure is a rejected conversion; Line is 0 when the API
+// did not say which one failed.
+type clickConversionFailure struct {
+	Line    int    `json:"line,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+func (f clickConversionFailure) String() string {
+	reason := f.Message
+	if f.Code != "" {
+		reason = fmt.Sprintf("%s: %s", f.Code, f.Message)
+	}
+	if f.Line > 0 {
+		return fmt.Sprintf("line %d: %s", f.Line, reason)
+	}
+	return reason
+}
+
+// countIndexed returns how many errors point at a specific conversion.
+func countIndexed(opErrs []api.OperationError) int {
+	n := 0
+	for _, e := range opErrs {
+		if e.Index >= 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// readClickConversions parses the upload CSV ("-" for stdin). Invalid rows
+// abort the upload before anything is sent.
+func readClickConversions(path string) ([]clickConversionRow, error) {
+	var in io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+		defer f.Close()
+		in = f
+	}
+	r := csv.NewReader(in)
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading %s header: %w", path, err)
+	}
+	col := map[string]int{}
+	for i, h := range header {
+		col[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	for _, name := range []string{"gclid", "conversion_action", "conversion_time"} {
+		if _, ok := col[name]; !ok {
+			return nil, fmt.Errorf("%s: missing %q column", path, name)
+		}
+	}
+	field := func(rec []string, name string) string {
+		if i, ok := col[name]; ok && i < len(rec) {
+			return strings.TrimSpace(rec[i])
+		}
+		return ""
+	}
+
+	var rows []clickConversionRow
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+		line, _ := r.FieldPos(0)
+		row := clickConversionRow{Line: line, ActionRef: field(rec, "conversion_action")}
+		row.Gclid = field(rec, "gclid")
+		if row.Gclid == "" {
+			return nil, fmt.Errorf("line %d: gclid is empty", line)
+		}
+		if row.ActionRef == "" {
+			return nil, fmt.Errorf("line %d: conversion_action is empty", line)
+		}
+		if row.ConversionDateTime, err = conversionDateTime(field(rec, "conversion_time")); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if v := field(rec, "value"); v != "" {
+			if row.ConversionValue, err = strconv.ParseFloat(v, 64); err != nil {
+				return nil, fmt.Errorf("line %d: invalid value %q", line, v)
+			}
+		}
+		row.CurrencyCode = strings.ToUpper(field(rec, "currency"))
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%s contains no conversions", path)
+	}
+	return rows, nil
+}
+
+// conversionTimeLayout is the format the API requires for conversionDateTime.
+const conversionTimeLayout = "2006-01-02 15:04:05-07:00"
+
+// conversionDateTime normalizes s to conversionTimeLayout. RFC3339 input is
+// converted; a time without a UTC offset is rejected since the API needs one.
+func conversionDateTime(s string) (string, error) {
+	if s == "" {
+		return "", fmt.Errorf("conversion_time is empty")
+	}
+	for _, layout := range []string{"2006-01-02 15:04:05Z07:00", time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Format(conversionTimeLayout), nil
+		}
+	}
+	return "", fmt.Errorf("invalid conversion_time %q (want yyyy-mm-dd hh:mm:ss+hh:mm or RFC3339)", s)
+}
+
+// resolveConversionActions fills in each row's conversion action resource
+// name. IDs and resource names are used as is; names are looked up in one query.
+func resolveConversionActions(cid string, rows []clickConversionRow) error {
+	byName := map[string]string{}
+	for _, r := range rows {
+		if !strings.HasPrefix(r.ActionRef, "customers/") && !api.IsNumericID(r.ActionRef) {
+			byName[r.ActionRef] = ""
+		}
+	}
+	if len(byName) > 0 {
+		quoted := make([]string, 0, len(byName))
+		for name := range byName {
+			quoted = append(quoted, gaql.Quote(name))
+		}
+		sort.Strings(quoted)
+		query := gaql.Select("conversion_action.resource_name", "conversion_action.name").
+			From("conversion_action").
+			Where("conversion_action.status != 'REMOVED'").
+			Where("conversion_action.name IN (" + strings.Join(quoted, ", ") + ")").
+			String()
+		results, err := apiClient.Search(cid, query)
+		if err != nil {
+			return fmt.Errorf("resolving conversion actions: %w", err)
+		}
+		for _, raw := range results {
+			var row api.ConversionActionRow
+			if err := json.Unmarshal(raw, &row); err != nil {
+				continue
+			}
+			byName[row.ConversionAction.Name] = row.ConversionAction.ResourceName
+		}
+	}
+
+	for i := range rows {
+		ref := rows[i].ActionRef
+		switch {
+		case strings.HasPrefix(ref, "customers/"):
+			rows[i].ConversionAction = ref
+		case api.IsNumericID(ref):
+			rows[i].ConversionAction = fmt.Sprintf("customers/%s/conversionActions/%s", cid, ref)
+		default:
+			if byName[ref] == "" {
+				return fmt.Errorf("line %d: conversion action %q not found", rows[i].Line, ref)
+			}
+			rows[i].ConversionAction = byName[ref]
+		}
+	}
+	return nil
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+func init() {
+	conversionsGetCmd.Flags().StringVar(&conversionID, "id", "", "Conversion action ID (required)")
+
+	conversionsCreateCmd.Flags().StringVar(&conversionName, "name", "", "Conversion action name (required)")
+	conversionsCreateCmd.Flags().StringVar(&conversionType, "type", "WEBPAGE", "Conversion action type, e.g. WEBPAGE or UPLOAD_CLICKS")
+	conversionsCreateCmd.Flags().StringVar(&conversionCategory, "category", "DEFAULT", "Category, e.g. PURCHASE, SIGNUP, SUBMIT_LEAD_FORM")
+	conversionsCreateCmd.Flags().StringVar(&conversionCount, "count", "ONE_PER_CLICK", "Counting type: ONE_PER_CLICK or MANY_PER_CLICK")
+	conversionsCreateCmd.Flags().Float64Var(&conversionValue, "value", 0, "Default conversion value")
+	conversionsCreateCmd.Flags().StringVar(&conversionCurrency, "currency", "", "Currency code for --value, e.g. EUR (default: the account currency)")
+
+	conversionsUploadClicksCmd.Flags().StringVar(&conversionFile, "file", "", "CSV file of conversions, or - for stdin (required)")
+
+	conversionsCmd.AddCommand(conversionsListCmd, conversionsGetCmd, conversionsCreateCmd, conversionsUploadClicksCmd)
+	rootCmd.AddCommand(conversionsCmd)
+}
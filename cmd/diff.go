@@ -0,0 +1,393 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/output"
+)
+
+var (
+	diffAgainst string
+	diffCurrent string
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare a snapshot with the live account or another snapshot",
+	Long: `Compare the account structure in a snapshot written by export (--against)
+with the live account, or with a second snapshot (--current), and report
+the campaigns, ad groups, keywords and ads added, removed or changed.
+
+Entities are matched by ID. Only structure and settings are compared —
+statuses, names, budgets, bids, bidding and network settings, keyword text
+and match type, ad texts and URLs — never metrics, so an unchanged account
+shows no differences.
+
+Examples:
+  gads-cli export --account=1234567890 -o before.json
+  gads-cli apply --account=1234567890 --file=changes.csv
+  gads-cli diff --account=1234567890 --against=before.json
+  gads-cli diff --against=before.json --current=after.json --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		old, err := readSnapshot(diffAgainst)
+		if err != nil {
+			return err
+		}
+		var cur *api.Snapshot
+		if diffCurrent != "" {
+			if cur, err = readSnapshot(diffCurrent); err != nil {
+				return err
+			}
+		} else {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			if old.CustomerID != "" && old.CustomerID != cid {
+				return fmt.Errorf("%s is a snapshot of account %s, not %s — pass --account=%s", diffAgainst, old.CustomerID, cid, old.CustomerID)
+			}
+			if cur, err = collectSnapshot(cid); err != nil {
+				return err
+			}
+		}
+
+		changes := diffSnapshots(old, cur)
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(map[string]any{
+				"against": snapshotInfo(old, diffAgainst),
+				"current": snapshotInfo(cur, diffCurrent),
+				"summary": summarizeDiff(changes),
+				"changes": changes,
+			}, output.IsPretty(cmd))
+		}
+		if len(changes) == 0 {
+			fmt.Println("No differences.")
+			return nil
+		}
+		printDiffTree(changes, diffParentNames(old, cur))
+		s := summarizeDiff(changes)
+		fmt.Printf("\n%d added, %d removed, %d changed\n", s["added"], s["removed"], s["changed"])
+		return nil
+	},
+}
+
+// readSnapshot reads a snapshot written by export.
+func readSnapshot(path string) (*api.Snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	defer f.Close()
+	var s api.Snapshot
+	if err := json.NewDecoder(f).Decode(&s); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if s.SchemaVersion == 0 {
+		return nil, fmt.Errorf("%s is not a snapshot (no schemaVersion) — create one with: gads-cli export", path)
+	}
+	if s.SchemaVersion > api.SnapshotVersion {
+		return nil, fmt.Errorf("%s has schema version %d; this gads-cli reads up to %d — run: gads-cli update", path, s.SchemaVersion, api.SnapshotVersion)
+	}
+	return &s, nil
+}
+
+// collectSnapshot reads the live structure of cid into memory, with the
+// queries of export.
+func collectSnapshot(cid string) (*api.Snapshot, error) {
+	s := &api.Snapshot{SchemaVersion: api.SnapshotVersion, ExportedAt: time.Now().UTC(), CustomerID: cid}
+	for _, sec := range snapshotSections() {
+		err := streamSection(cid, sec, func(v any) error {
+			switch e := v.(type) {
+			case api.SnapshotCampaign:
+				s.Campaigns = append(s.Campaigns, e)
+			case api.SnapshotAdGroup:
+				s.AdGroups = append(s.AdGroups, e)
+			case api.SnapshotKeyword:
+				s.Keywords = append(s.Keywords, e)
+			case api.SnapshotAd:
+				s.Ads = append(s.Ads, e)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// snapshotInfo describes a side of the diff for --json; path is empty for
+// the live account.
+func snapshotInfo(s *api.Snapshot, path string) map[string]any {
+	info := map[string]any{"customerId": s.CustomerID, "exportedAt": s.ExportedAt}
+	if path == "" {
+		info["source"] = "live"
+	} else {
+		info["source"] = path
+	}
+	return info
+}
+
+// diffChange is an entity added, removed or changed between two snapshots.
+type diffChange struct {
+	Entity     string        `json:"entity"` // campaign, adGroup, keyword or ad
+	ID         string        `json:"id"`
+	CampaignID string        `json:"campaignId"`
+	AdGroupID  string        `json:"adGroupId,omitempty"`
+	Label      string        `json:"label"`  // the name, or e.g. red shoes [EXACT]
+	Change     string        `json:"change"` // added, removed or changed
+	Fields     []fieldChange `json:"fields,omitempty"`
+}
+
+// fieldChange is a setting that differs; values are as in the snapshot.
+type fieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// diffEntity is a snapshot entry reduced to what the diff compares.
+type diffEntity struct {
+	kind       string
+	id         string
+	campaignID string
+	adGroupID  string
+	label      string
+	fields     [][2]string // name, value, in display order
+}
+
+// key identifies an entity across snapshots. Criterion and ad IDs are only
+// unique within their ad group (or campaign, for campaign negatives).
+func (e diffEntity) key() string {
+	return e.kind + "/" + e.campaignID + "/" + e.adGroupID + "/" + e.id
+}
+
+// diffKindOrder orders the entities of one parent in the tree.
+var diffKindOrder = map[string]int{"campaign": 0, "adGroup": 1, "keyword": 2, "ad": 3}
+
+func snapshotEntities(s *api.Snapshot) []diffEntity {
+	var entities []diffEntity
+	for _, c := range s.Campaigns {
+		entities = append(entities, diffEntity{
+			kind: "campaign", id: c.ID, campaignID: c.ID, label: c.Name,
+			fields: [][2]string{
+				{"name", c.Name},
+				{"status", c.Status},
+				{"advertisingChannelType", c.AdvertisingChannelType},
+				{"biddingStrategyType", c.BiddingStrategyType},
+				{"targetCpaMicros", strconv.FormatInt(c.TargetCpaMicros, 10)},
+				{"targetRoas", strconv.FormatFloat(c.TargetRoas, 'g', -1, 64)},
+				{"networks.googleSearch", strconv.FormatBool(c.Networks.GoogleSearch)},
+				{"networks.searchNetwork", strconv.FormatBool(c.Networks.SearchNetwork)},
+				{"networks.contentNetwork", strconv.FormatBool(c.Networks.ContentNetwork)},
+				{"budget.id", c.Budget.ID},
+				{"budget.name", c.Budget.Name},
+				{"budget.amountMicros", strconv.FormatInt(c.Budget.AmountMicros, 10)},
+				{"budget.deliveryMethod", c.Budget.DeliveryMethod},
+				{"budget.shared", strconv.FormatBool(c.Budget.Shared)},
+			},
+		})
+	}
+	for _, g := range s.AdGroups {
+		entities = append(entities, diffEntity{
+			kind: "adGroup", id: g.ID, campaignID: g.CampaignID, adGroupID: g.ID, label: g.Name,
+			fields: [][2]string{
+				{"name", g.Name},
+				{"status", g.Status},
+				{"type", g.Type},
+				{"cpcBidMicros", strconv.FormatInt(g.CpcBidMicros, 10)},
+			},
+		})
+	}
+	for _, k := range s.Keywords {
+		label := fmt.Sprintf("%s [%s]", k.Text, k.MatchType)
+		if k.Negative {
+			label += " (negative)"
+		}
+		entities = append(entities, diffEntity{
+			kind: "keyword", id: k.CriterionID, campaignID: k.CampaignID, adGroupID: k.AdGroupID, label: label,
+			fields: [][2]string{
+				{"text", k.Text},
+				{"matchType", k.MatchType},
+				{"negative", strconv.FormatBool(k.Negative)},
+				{"status", k.Status},
+				{"cpcBidMicros", strconv.FormatInt(k.CpcBidMicros, 10)},
+			},
+		})
+	}
+	for _, a := range s.Ads {
+		entities = append(entities, diffEntity{
+			kind: "ad", id: a.ID, campaignID: a.CampaignID, adGroupID: a.AdGroupID, label: "responsive search ad",
+			fields: [][2]string{
+				{"status", a.Status},
+				{"finalUrls", strings.Join(a.FinalURLs, " ")},
+				{"headlines", joinAdTexts(a.Headlines)},
+				{"descriptions", joinAdTexts(a.Descriptions)},
+				{"path1", a.Path1},
+				{"path2", a.Path2},
+			},
+		})
+	}
+	return entities
+}
+
+// joinAdTexts renders RSA texts for comparison, pins included:
+// "Free shipping | Shop now (HEADLINE_1)".
+func joinAdTexts(texts []api.SnapshotAdText) string {
+	parts := make([]string, len(texts))
+	for i, t := range texts {
+		parts[i] = t.Text
+		if t.PinnedField != "" {
+			parts[i] += " (" + t.PinnedField + ")"
+		}
+	}
+	return strings.Join(parts, " | ")
+}
+
+// diffSnapshots returns the differences from old to cur, ordered for the
+// tree: by campaign, then ad group, then entity kind and ID.
+func diffSnapshots(old, cur *api.Snapshot) []diffChange {
+	before := make(map[string]diffEntity)
+	for _, e := range snapshotEntities(old) {
+		before[e.key()] = e
+	}
+	changes := []diffChange{}
+	seen := make(map[string]bool)
+	for _, e := range snapshotEntities(cur) {
+		seen[e.key()] = true
+		o, ok := before[e.key()]
+		if !ok {
+			changes = append(changes, newDiffChange(e, "added", nil))
+			continue
+		}
+		var fields []fieldChange
+		for i, f := range e.fields {
+			if i < len(o.fields) && o.fields[i][1] != f[1] {
+				fields = append(fields, fieldChange{Field: f[0], Old: o.fields[i][1], New: f[1]})
+			}
+		}
+		if len(fields) > 0 {
+			changes = append(changes, newDiffChange(e, "changed", fields))
+		}
+	}
+	for _, e := range snapshotEntities(old) {
+		if !seen[e.key()] {
+			changes = append(changes, newDiffChange(e, "removed", nil))
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		a, b := changes[i], changes[j]
+		if a.CampaignID != b.CampaignID {
+			return idLess(a.CampaignID, b.CampaignID)
+		}
+		if a.AdGroupID != b.AdGroupID {
+			return idLess(a.AdGroupID, b.AdGroupID)
+		}
+		if diffKindOrder[a.Entity] != diffKindOrder[b.Entity] {
+			return diffKindOrder[a.Entity] < diffKindOrder[b.Entity]
+		}
+		return idLess(a.ID, b.ID)
+	})
+	return changes
+}
+
+func newDiffChange(e diffEntity, change string, fields []fieldChange) diffChange {
+	return diffChange{
+		Entity:     e.kind,
+		ID:         e.id,
+		CampaignID: e.campaignID,
+		AdGroupID:  e.adGroupID,
+		Label:      e.label,
+		Change:     change,
+		Fields:     fields,
+	}
+}
+
+// idLess orders numeric IDs by value, and "" (no ad group) first.
+func idLess(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
+func summarizeDiff(changes []diffChange) map[string]int {
+	s := map[string]int{"added": 0, "removed": 0, "changed": 0}
+	for _, c := range changes {
+		s[c.Change]++
+	}
+	return s
+}
+
+// diffMarkers prefix tree lines by change.
+var diffMarkers = map[string]string{"added": "+", "removed": "-", "changed": "~"}
+
+// diffNouns names entity kinds in the tree.
+var diffNouns = map[string]string{"campaign": "Campaign", "adGroup": "Ad group", "keyword": "Keyword", "ad": "Ad"}
+
+// diffParentNames maps campaign and ad group IDs to their names, in either
+// snapshot, for the tree's context lines.
+func diffParentNames(snapshots ...*api.Snapshot) map[string]string {
+	names := make(map[string]string)
+	for _, s := range snapshots {
+		for _, c := range s.Campaigns {
+			names["campaign/"+c.ID] = c.Name
+		}
+		for _, g := range s.AdGroups {
+			names["adGroup/"+g.ID] = g.Name
+		}
+	}
+	return names
+}
+
+// printDiffTree prints changes grouped under their campaign and ad group.
+// A parent that did not change itself is printed without a marker, for
+// context.
+func printDiffTree(changes []diffChange, names map[string]string) {
+	lastCampaign, lastAdGroup := "", ""
+	for _, c := range changes {
+		if c.CampaignID != lastCampaign {
+			lastCampaign, lastAdGroup = c.CampaignID, ""
+			if c.Entity != "campaign" {
+				fmt.Printf("  Campaign %s %q\n", c.CampaignID, names["campaign/"+c.CampaignID])
+			}
+		}
+		if c.AdGroupID != "" && c.AdGroupID != lastAdGroup {
+			lastAdGroup = c.AdGroupID
+			if c.Entity != "adGroup" {
+				fmt.Printf("    Ad group %s %q\n", c.AdGroupID, names["adGroup/"+c.AdGroupID])
+			}
+		}
+		indent := ""
+		switch {
+		case c.Entity == "campaign":
+		case c.Entity == "adGroup" || c.AdGroupID == "":
+			indent = "  "
+		default:
+			indent = "    "
+		}
+		label := c.Label
+		if c.Entity == "campaign" || c.Entity == "adGroup" {
+			label = strconv.Quote(label)
+		}
+		fmt.Printf("%s%s %s %s %s\n", indent, diffMarkers[c.Change], diffNouns[c.Entity], c.ID, label)
+		for _, f := range c.Fields {
+			fmt.Printf("%s    %s: %s → %s\n", indent, f.Field, orDash(f.Old), orDash(f.New))
+		}
+	}
+}
+
+func init() {
+	diffCmd.Flags().StringVar(&diffAgainst, "against", "", "Snapshot to compare from, written by export (required)")
+	diffCmd.MarkFlagRequired("against")
+	diffCmd.Flags().StringVar(&diffCurrent, "current", "", "Snapshot to compare to (default: the live account)")
+	rootCmd.AddCommand(diffCmd)
+}
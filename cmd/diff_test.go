@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/the20100/gads-cli/internal/api"
+)
+
+// diffBase is a small account: one campaign with two ad groups that both have
+// a keyword with criterion ID 7, and a campaign negative.
+func diffBase() *api.Snapshot {
+	return &api.Snapshot{
+		SchemaVersion: api.SnapshotVersion,
+		Campaigns: []api.SnapshotCampaign{
+			{ID: "1", Name: "Brand", Status: "ENABLED", Budget: api.SnapshotBudget{ID: "9", AmountMicros: 10_000_000}},
+		},
+		AdGroups: []api.SnapshotAdGroup{
+			{ID: "10", CampaignID: "1", Name: "Shoes", Status: "ENABLED", CpcBidMicros: 500_000},
+			{ID: "20", CampaignID: "1", Name: "Boots", Status: "ENABLED"},
+		},
+		Keywords: []api.SnapshotKeyword{
+			{CriterionID: "7", CampaignID: "1", AdGroupID: "10", Text: "red shoes", MatchType: "EXACT", Status: "ENABLED"},
+			{CriterionID: "7", CampaignID: "1", AdGroupID: "20", Text: "red shoes", MatchType: "EXACT", Status: "ENABLED"},
+			{CriterionID: "7", CampaignID: "1", Text: "free", MatchType: "BROAD", Negative: true},
+		},
+		Ads: []api.SnapshotAd{
+			{ID: "100", CampaignID: "1", AdGroupID: "10", Status: "ENABLED", Headlines: []api.SnapshotAdText{{Text: "Shoes"}}},
+		},
+	}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(s *api.Snapshot)
+		want   []diffChange
+	}{
+		{name: "no change", change: func(s *api.Snapshot) {}, want: []diffChange{}},
+		{
+			name: "added",
+			change: func(s *api.Snapshot) {
+				s.Keywords = append(s.Keywords, api.SnapshotKeyword{CriterionID: "8", CampaignID: "1", AdGroupID: "20", Text: "boots", MatchType: "PHRASE"})
+			},
+			want: []diffChange{{Entity: "keyword", ID: "8", CampaignID: "1", AdGroupID: "20", Label: "boots [PHRASE]", Change: "added"}},
+		},
+		{
+			name:   "removed",
+			change: func(s *api.Snapshot) { s.Ads = nil },
+			want:   []diffChange{{Entity: "ad", ID: "100", CampaignID: "1", AdGroupID: "10", Label: "responsive search ad", Change: "removed"}},
+		},
+		{
+			name: "changed",
+			change: func(s *api.Snapshot) {
+				s.Campaigns[0].Status = "PAUSED"
+				s.Campaigns[0].Budget.AmountMicros = 20_000_000
+				s.Ads[0].Headlines = append(s.Ads[0].Headlines, api.SnapshotAdText{Text: "Free shipping", PinnedField: "HEADLINE_1"})
+			},
+			want: []diffChange{
+				{Entity: "campaign", ID: "1", CampaignID: "1", Label: "Brand", Change: "changed", Fields: []fieldChange{
+					{Field: "status", Old: "ENABLED", New: "PAUSED"},
+					{Field: "budget.amountMicros", Old: "10000000", New: "20000000"},
+				}},
+				{Entity: "ad", ID: "100", CampaignID: "1", AdGroupID: "10", Label: "responsive search ad", Change: "changed", Fields: []fieldChange{
+					{Field: "headlines", Old: "Shoes", New: "Shoes | Free shipping (HEADLINE_1)"},
+				}},
+			},
+		},
+		{
+			name:   "repeated keyword ID, one ad group",
+			change: func(s *api.Snapshot) { s.Keywords[1].Status = "PAUSED" },
+			want: []diffChange{{Entity: "keyword", ID: "7", CampaignID: "1", AdGroupID: "20", Label: "red shoes [EXACT]", Change: "changed", Fields: []fieldChange{
+				{Field: "status", Old: "ENABLED", New: "PAUSED"},
+			}}},
+		},
+		{
+			name:   "repeated keyword ID, campaign negative",
+			change: func(s *api.Snapshot) { s.Keywords = s.Keywords[:2] },
+			want:   []diffChange{{Entity: "keyword", ID: "7", CampaignID: "1", Label: "free [BROAD] (negative)", Change: "removed"}},
+		},
+		{
+			name: "moved between ad groups",
+			change: func(s *api.Snapshot) {
+				s.Ads[0].AdGroupID = "20"
+			},
+			want: []diffChange{
+				{Entity: "ad", ID: "100", CampaignID: "1", AdGroupID: "10", Label: "responsive search ad", Change: "removed"},
+				{Entity: "ad", ID: "100", CampaignID: "1", AdGroupID: "20", Label: "responsive search ad", Change: "added"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cur := diffBase()
+			tt.change(cur)
+			got := diffSnapshots(diffBase(), cur)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffSnapshots =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnapshotEntitiesKeys(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, e := range snapshotEntities(diffBase()) {
+		if seen[e.key()] {
+			t.Errorf("key %s used twice", e.key())
+		}
+		seen[e.key()] = true
+	}
+	if len(seen) != 7 {
+		t.Errorf("%d entities, want 7", len(seen))
+	}
+}
+
+func TestDiffIgnoresMetrics(t *testing.T) {
+	dir := t.TempDir()
+	snapshot := func(name, exportedAt, clicks string) *api.Snapshot {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		data := `{"schemaVersion": 1, "exportedAt": "` + exportedAt + `", "customerId": "111",
+			"campaigns": [{"id": "1", "name": "Brand", "status": "ENABLED", "metrics": {"clicks": "` + clicks + `", "costMicros": "` + clicks + `000"}}],
+			"adGroups": [{"id": "10", "campaignId": "1", "name": "Shoes", "metrics": {"impressions": "` + clicks + `0"}}]}`
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		s, err := readSnapshot(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	old := snapshot("old.json", "2026-01-01T00:00:00Z", "10")
+	cur := snapshot("new.json", "2026-02-01T00:00:00Z", "250")
+	if changes := diffSnapshots(old, cur); len(changes) != 0 {
+		t.Errorf("metric-only changes reported: %+v", changes)
+	}
+}
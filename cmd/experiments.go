@@ -0,0 +1,535 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/gaql"
+	"github.com/the20100/gads-cli/internal/output"
+)
+
+var experimentsCmd = &cobra.Command{
+	Use:   "experiments",
+	Short: "Run campaign experiments (A/B tests against a base campaign)",
+}
+
+var (
+	expID           string
+	expBaseCampaign string
+	expName         string
+	expSuffix       string
+	expType         string
+	expSplit        int
+	expStartDate    string
+	expEndDate      string
+	expNoSchedule   bool
+)
+
+// experimentView is an experiment with its arms, as printed by list --json.
+type experimentView struct {
+	api.Experiment
+	Arms []api.ExperimentArm `json:"arms"`
+}
+
+// ---- experiments list ----
+
+var experimentsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List experiments with their status and traffic split",
+	Long: `List experiments with their status, dates, traffic split (control/treatment)
+and the treatment campaign. Use experiments arms to see which campaign serves
+each arm.
+
+Examples:
+  gads-cli experiments list --account=1234567890
+  gads-cli experiments list --account=1234567890 --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, err := accountID()
+		if err != nil {
+			return err
+		}
+
+		query := gaql.Select(
+			"experiment.resource_name", "experiment.experiment_id", "experiment.name",
+			"experiment.description", "experiment.suffix", "experiment.type", "experiment.status",
+			"experiment.start_date", "experiment.end_date").
+			From("experiment").
+			Where("experiment.status != 'REMOVED'").
+			OrderBy("experiment.experiment_id", true).
+			String()
+		rows, err := apiClient.Search(cid, query)
+		if err != nil {
+			return err
+		}
+		arms, err := fetchExperimentArms(cid, "")
+		if err != nil {
+			return err
+		}
+		byExperiment := make(map[string][]api.ExperimentArm)
+		for _, a := range arms {
+			byExperiment[a.Experiment] = append(byExperiment[a.Experiment], a)
+		}
+
+		var experiments []experimentView
+		for _, raw := range rows {
+			var row api.ExperimentRow
+			if err := json.Unmarshal(raw, &row); err != nil {
+				continue
+			}
+			experiments = append(experiments, experimentView{
+				Experiment: row.Experiment,
+				Arms:       byExperiment[row.Experiment.ResourceName],
+			})
+		}
+
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(experiments, output.IsPretty(cmd))
+		}
+		if len(experiments) == 0 {
+			fmt.Println("No experiments found.")
+			return nil
+		}
+
+		headers := []string{"ID", "NAME", "STATUS", "START", "END", "SPLIT", "TREATMENT CAMPAIGN"}
+		tableRows := make([][]string, len(experiments))
+		for i, e := range experiments {
+			split, treatment := "-", "-"
+			var control, treated int64
+			for _, a := range e.Arms {
+				if a.Control {
+					control = int64(a.TrafficSplit)
+					continue
+				}
+				treated = int64(a.TrafficSplit)
+				if ids := armCampaignIDs(a); len(ids) > 0 {
+					treatment = strings.Join(ids, ", ")
+				}
+			}
+			if len(e.Arms) > 0 {
+				split = fmt.Sprintf("%d/%d", control, treated)
+			}
+			tableRows[i] = []string{
+				e.ExperimentID,
+				e.Name,
+				output.Colorize(e.Status),
+				orDash(e.StartDate),
+				orDash(e.EndDate),
+				split,
+				treatment,
+			}
+		}
+		return output.PrintTable(headers, tableRows)
+	},
+}
+
+// ---- experiments arms ----
+
+var experimentsArmsCmd = &cobra.Command{
+	Use:   "arms",
+	Short: "Show which campaign serves each arm of an experiment",
+	Long: `Show the control and treatment arms of an experiment with the campaigns that
+serve them. Before the experiment is scheduled the treatment campaign is a
+draft, marked "(draft)". Once it serves, its metrics are reported under its
+own campaign ID, e.g. by insights campaigns or insights adgroups --campaign.
+
+Examples:
+  gads-cli experiments arms --account=1234567890 --experiment=987654321
+  gads-cli experiments arms --account=1234567890 --experiment=987654321 --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, experiment, err := experimentTarget()
+		if err != nil {
+			return err
+		}
+		arms, err := fetchExperimentArms(cid, "experiment_arm.experiment = "+gaql.Quote(experiment))
+		if err != nil {
+			return err
+		}
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(arms, output.IsPretty(cmd))
+		}
+		if len(arms) == 0 {
+			fmt.Println("No arms found.")
+			return nil
+		}
+
+		var ids []string
+		for _, a := range arms {
+			ids = append(ids, armCampaignIDs(a)...)
+		}
+		names, err := campaignNames(cid, ids)
+		if err != nil {
+			return err
+		}
+
+		headers := []string{"ARM", "ROLE", "SPLIT", "CAMPAIGN ID", "CAMPAIGN"}
+		var tableRows [][]string
+		for _, a := range arms {
+			role := "treatment"
+			if a.Control {
+				role = "control"
+			}
+			draft := len(a.Campaigns) == 0
+			armIDs := armCampaignIDs(a)
+			if len(armIDs) == 0 {
+				armIDs = []string{"-"}
+			}
+			for _, id := range armIDs {
+				name := orDash(names[id])
+				if draft && id != "-" {
+					name += " (draft)"
+				}
+				tableRows = append(tableRows, []string{
+					a.Name,
+					role,
+					fmt.Sprintf("%d%%", int64(a.TrafficSplit)),
+					id,
+					name,
+				})
+			}
+		}
+		return output.PrintTable(headers, tableRows)
+	},
+}
+
+// ---- experiments create ----
+
+var experimentsCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create and schedule an experiment on a campaign",
+	Long: `Create an experiment that splits traffic between --base-campaign (control) and
+a copy of it (treatment), then schedule it. --traffic-split is the share of
+traffic, in percent, sent to the treatment.
+
+With --no-schedule the experiment stays in SETUP so the draft treatment
+campaign (see experiments arms) can be changed first, e.g. its bidding
+strategy; start it afterwards with experiments schedule.
+
+Examples:
+  gads-cli experiments create --account=1234567890 --base-campaign=111222333 --name="tCPA test"
+  gads-cli experiments create --account=1234567890 --base-campaign=111222333 --name="tCPA test" \
+    --traffic-split=30 --start-date=2024-06-01 --end-date=2024-06-30 --no-schedule`,
+	Annotations: mutatingCommand,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, err := accountID()
+		if err != nil {
+			return err
+		}
+		if expBaseCampaign == "" {
+			return fmt.Errorf("--base-campaign is required")
+		}
+		if !api.IsNumericID(expBaseCampaign) {
+			return fmt.Errorf("--base-campaign must be a numeric ID")
+		}
+		if expName == "" {
+			return fmt.Errorf("--name is required")
+		}
+		if expSplit < 1 || expSplit > 99 {
+			return fmt.Errorf("--traffic-split must be between 1 and 99")
+		}
+		if _, err := time.Parse("2006-01-02", expStartDate); expStartDate != "" && err != nil {
+			return fmt.Errorf("--start-date must be YYYY-MM-DD")
+		}
+		if _, err := time.Parse("2006-01-02", expEndDate); expEndDate != "" && err != nil {
+			return fmt.Errorf("--end-date must be YYYY-MM-DD")
+		}
+
+		experiment := map[string]any{
+			"name":   expName,
+			"suffix": expSuffix,
+			"type":   strings.ToUpper(expType),
+			"status": "SETUP",
+		}
+		if expStartDate != "" {
+			experiment["startDate"] = expStartDate
+		}
+		if expEndDate != "" {
+			experiment["endDate"] = expEndDate
+		}
+		changes := []string{fmt.Sprintf("Campaign %s: create experiment %q sending %d%% of traffic to a copy named with %q",
+			expBaseCampaign, expName, expSplit, expSuffix)}
+		if !expNoSchedule {
+			changes = append(changes, "The experiment is scheduled and serves from its start date")
+		}
+		if ok, err := confirmChange("Create this experiment?", changes...); !ok {
+			return err
+		}
+		ops := []map[string]any{{"create": experiment}}
+		resp, err := apiClient.MutateExperiments(cid, ops)
+		if err != nil {
+			return err
+		}
+		if apiClient.ValidateOnly() {
+			fmt.Fprintln(os.Stderr, "Note: only the experiment was validated; its arms need the created experiment")
+			return reportDryRun(resp, len(ops))
+		}
+		if len(resp.Results) == 0 {
+			return fmt.Errorf("experiment create returned no result")
+		}
+		experimentName := resp.Results[0].ResourceName
+
+		armOps := []map[string]any{
+			{"create": map[string]any{
+				"experiment":   experimentName,
+				"name":         "control",
+				"control":      true,
+				"trafficSplit": 100 - expSplit,
+				"campaigns":    []string{fmt.Sprintf("customers/%s/campaigns/%s", cid, expBaseCampaign)},
+			}},
+			{"create": map[string]any{
+				"experiment":   experimentName,
+				"name":         "treatment",
+				"control":      false,
+				"trafficSplit": expSplit,
+			}},
+		}
+		armResp, err := apiClient.MutateExperimentArms(cid, armOps)
+		if err != nil {
+			return fmt.Errorf("experiment %s was created but its arms could not be: %w", experimentName, err)
+		}
+		if jsonResult() {
+			res := newMutateResult(resp, armResp)
+			if expNoSchedule {
+				return writeMutateResult(res)
+			}
+			return scheduleExperiment(cid, experimentName, res)
+		}
+		fmt.Printf("Experiment created: %s\n", expName)
+		fmt.Printf("Resource: %s\n", experimentName)
+
+		experimentID := api.ResourceID(experimentName)
+		if expNoSchedule {
+			fmt.Printf("Not scheduled. Edit the draft treatment campaign (see: gads-cli experiments arms --account=%s --experiment=%s),\n", cid, experimentID)
+			fmt.Printf("then run: gads-cli experiments schedule --account=%s --experiment=%s\n", cid, experimentID)
+			return nil
+		}
+		return scheduleExperiment(cid, experimentName, newMutateResult())
+	},
+}
+
+// ---- experiments schedule ----
+
+var experimentsScheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Schedule an experiment created with --no-schedule",
+	Long: `Schedule an experiment in SETUP status. The treatment campaign is created
+asynchronously; the experiment serves from its start date once that is done.
+
+Examples:
+  gads-cli experiments schedule --account=1234567890 --experiment=987654321`,
+	Annotations: mutatingCommand,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, experiment, err := experimentTarget()
+		if err != nil {
+			return err
+		}
+		if ok, err := confirmChange(fmt.Sprintf("Schedule experiment %s?", expID)); !ok {
+			return err
+		}
+		res := newMutateResult()
+		res.add(experiment)
+		return scheduleExperiment(cid, experiment, res)
+	},
+}
+
+// ---- experiments end ----
+
+var experimentsEndCmd = &cobra.Command{
+	Use:   "end",
+	Short: "End an experiment without applying its changes",
+	Long: `End a running experiment. The treatment campaign stops serving and the base
+campaign gets all traffic again; nothing is copied to it.
+
+Examples:
+  gads-cli experiments end --account=1234567890 --experiment=987654321
+  gads-cli experiments end --account=1234567890 --experiment=987654321 --yes`,
+	Annotations: mutatingCommand,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, experiment, err := experimentTarget()
+		if err != nil {
+			return err
+		}
+		change := fmt.Sprintf("Experiment %s: end; the treatment stops serving and nothing is copied to the base campaign", expID)
+		if ok, err := confirmChange(fmt.Sprintf("End experiment %s?", expID), change); !ok {
+			return err
+		}
+		if err := apiClient.EndExperiment(cid, experiment); err != nil {
+			return err
+		}
+		if apiClient.ValidateOnly() {
+			return reportDryRun(&api.MutateResponse{}, 1)
+		}
+		if jsonResult() {
+			res := newMutateResult()
+			res.add(experiment)
+			return writeMutateResult(res)
+		}
+		fmt.Printf("Experiment %s ended.\n", expID)
+		return nil
+	},
+}
+
+// ---- experiments promote ----
+
+var experimentsPromoteCmd = &cobra.Command{
+	Use:   "promote",
+	Short: "Apply the treatment's changes to the base campaign and end the experiment",
+	Long: `Promote an experiment: the changes made in the treatment campaign are copied to
+the base campaign, which then gets all traffic. Promotion runs
+asynchronously; follow it with experiments list (PROMOTING, then PROMOTED or
+PROMOTION_FAILED).
+
+Examples:
+  gads-cli experiments promote --account=1234567890 --experiment=987654321
+  gads-cli experiments promote --account=1234567890 --experiment=987654321 --yes`,
+	Annotations: mutatingCommand,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, experiment, err := experimentTarget()
+		if err != nil {
+			return err
+		}
+		change := fmt.Sprintf("Experiment %s: promote; the treatment's changes are copied to the base campaign", expID)
+		if ok, err := confirmChange(fmt.Sprintf("Promote experiment %s?", expID), change); !ok {
+			return err
+		}
+		if _, err := apiClient.PromoteExperiment(cid, experiment); err != nil {
+			return err
+		}
+		if apiClient.ValidateOnly() {
+			return reportDryRun(&api.MutateResponse{}, 1)
+		}
+		if jsonResult() {
+			res := newMutateResult()
+			res.add(experiment)
+			return writeMutateResult(res)
+		}
+		fmt.Printf("Promotion of experiment %s started; check progress with: gads-cli experiments list --account=%s\n", expID, cid)
+		return nil
+	},
+}
+
+// experimentTarget validates --account and --experiment and returns the
+// customer ID and the experiment resource name.
+func experimentTarget() (string, string, error) {
+	cid, err := accountID()
+	if err != nil {
+		return "", "", err
+	}
+	if expID == "" {
+		return "", "", fmt.Errorf("--experiment is required")
+	}
+	if !api.IsNumericID(expID) {
+		return "", "", fmt.Errorf("--experiment must be a numeric ID")
+	}
+	return cid, api.ExperimentResourceName(cid, expID), nil
+}
+
+// scheduleExperiment schedules experiment and reports the outcome; with
+// --json, as res.
+func scheduleExperiment(cid, experiment string, res mutateResult) error {
+	if _, err := apiClient.ScheduleExperiment(cid, experiment); err != nil {
+		return err
+	}
+	if apiClient.ValidateOnly() {
+		return reportDryRun(&api.MutateResponse{}, 1)
+	}
+	if jsonResult() {
+		return writeMutateResult(res)
+	}
+	fmt.Printf("Experiment %s scheduled; the treatment campaign is being created.\n", api.ResourceID(experiment))
+	fmt.Printf("Check progress with: gads-cli experiments arms --account=%s --experiment=%s\n", cid, api.ResourceID(experiment))
+	return nil
+}
+
+// fetchExperimentArms returns experiment arms, optionally only those matching
+// cond, control arms first.
+func fetchExperimentArms(cid, cond string) ([]api.ExperimentArm, error) {
+	query := gaql.Select(
+		"experiment_arm.resource_name", "experiment_arm.experiment", "experiment_arm.name",
+		"experiment_arm.control", "experiment_arm.traffic_split",
+		"experiment_arm.campaigns", "experiment_arm.in_design_campaigns").
+		From("experiment_arm").
+		Where(cond).
+		OrderBy("experiment_arm.control", true).
+		String()
+	rows, err := apiClient.Search(cid, query)
+	if err != nil {
+		return nil, err
+	}
+	var arms []api.ExperimentArm
+	for _, raw := range rows {
+		var row api.ExperimentArmRow
+		if err := json.Unmarshal(raw, &row); err != nil {
+			continue
+		}
+		arms = append(arms, row.ExperimentArm)
+	}
+	return arms, nil
+}
+
+// armCampaignIDs returns the IDs of the campaigns serving an arm, or of its
+// draft campaigns before the experiment is scheduled.
+func armCampaignIDs(a api.ExperimentArm) []string {
+	names := a.Campaigns
+	if len(names) == 0 {
+		names = a.InDesignCampaigns
+	}
+	ids := make([]string, len(names))
+	for i, n := range names {
+		ids[i] = api.ResourceID(n)
+	}
+	return ids
+}
+
+// campaignNames maps campaign IDs to names. Campaigns that can't be read (e.g.
+// drafts the API doesn't return yet) are left out.
+func campaignNames(cid string, ids []string) (map[string]string, error) {
+	names := make(map[string]string)
+	if len(ids) == 0 {
+		return names, nil
+	}
+	quoted := make([]string, len(ids))
+	for i, id := range ids {
+		quoted[i] = gaql.Quote(id)
+	}
+	query := gaql.Select("campaign.id", "campaign.name").
+		From("campaign").
+		Where("campaign.id IN (" + strings.Join(quoted, ", ") + ")").
+		String()
+	rows, err := apiClient.Search(cid, query)
+	if err != nil {
+		return nil, err
+	}
+	for _, raw := range rows {
+		var row api.CampaignRow
+		if err := json.Unmarshal(raw, &row); err != nil {
+			continue
+		}
+		names[row.Campaign.ID] = row.Campaign.Name
+	}
+	return names, nil
+}
+
+func init() {
+	for _, c := range []*cobra.Command{experimentsArmsCmd, experimentsScheduleCmd, experimentsEndCmd, experimentsPromoteCmd} {
+		c.Flags().StringVar(&expID, "experiment", "", "Experiment ID (required)")
+	}
+	experimentsCreateCmd.Flags().StringVar(&expBaseCampaign, "base-campaign", "", "Campaign to test against (required)")
+	experimentsCreateCmd.Flags().StringVar(&expName, "name", "", "Experiment name (required)")
+	experimentsCreateCmd.Flags().IntVar(&expSplit, "traffic-split", 50, "Percent of traffic sent to the treatment (1–99)")
+	experimentsCreateCmd.Flags().StringVar(&expSuffix, "suffix", "[experiment]", "Suffix appended to the treatment campaign's name")
+	experimentsCreateCmd.Flags().StringVar(&expType, "type", "SEARCH_CUSTOM", "Experiment type: SEARCH_CUSTOM or DISPLAY_CUSTOM")
+	experimentsCreateCmd.Flags().StringVar(&expStartDate, "start-date", "", "Start date YYYY-MM-DD (default: as soon as scheduled)")
+	experimentsCreateCmd.Flags().StringVar(&expEndDate, "end-date", "", "End date YYYY-MM-DD (default: the base campaign's end date)")
+	experimentsCreateCmd.Flags().BoolVar(&expNoSchedule, "no-schedule", false, "Leave the experiment in SETUP to edit the treatment first")
+
+	experimentsCmd.AddCommand(
+		experimentsListCmd, experimentsArmsCmd, experimentsCreateCmd,
+		experimentsScheduleCmd, experimentsEndCmd, experimentsPromoteCmd,
+	)
+	rootCmd.AddCommand(experimentsCmd)
+}
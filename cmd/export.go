@@ -0,0 +1,346 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/gaql"
+	"github.com/the20100/gads-cli/internal/output"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the account's structure as a JSON snapshot",
+	Long: `Export a snapshot of the account's structure: campaigns with their budgets
+and settings, ad groups, keywords including negatives, and responsive search
+ads. Removed entities and metrics are left out.
+
+The snapshot is one JSON document with a schemaVersion and exportedAt, and a
+list per entity type whose entries point at their parents by ID. Rows are
+read with searchStream and written as they arrive, so accounts with tens of
+thousands of keywords export in constant memory; progress is reported on
+stderr. Large accounts may need a longer --timeout.
+
+Examples:
+  gads-cli export --account=1234567890 --output=snapshot.json
+  gads-cli export --account=1234567890 --timeout=10m -o snapshot.json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, err := accountID()
+		if err != nil {
+			return err
+		}
+		return writeSnapshot(cid, time.Now().UTC())
+	},
+}
+
+// snapshotSection is a query filling one list of a snapshot. Keywords come
+// from two sections: ad group criteria and campaign negatives.
+type snapshotSection struct {
+	key    string // JSON key of the list, e.g. "keywords"
+	label  string // for progress, e.g. "campaign negative keywords"
+	query  string
+	decode func(raw json.RawMessage) (any, error)
+}
+
+// snapshotCampaignRow decodes the campaign settings a snapshot keeps.
+type snapshotCampaignRow struct {
+	Campaign struct {
+		ID                     string `json:"id"`
+		Name                   string `json:"name"`
+		Status                 string `json:"status"`
+		AdvertisingChannelType string `json:"advertisingChannelType"`
+		BiddingStrategyType    string `json:"biddingStrategyType"`
+		TargetCpa              struct {
+			TargetCpaMicros api.Int64 `json:"targetCpaMicros"`
+		} `json:"targetCpa"`
+		TargetRoas struct {
+			TargetRoas float64 `json:"targetRoas"`
+		} `json:"targetRoas"`
+		NetworkSettings struct {
+			TargetGoogleSearch   bool `json:"targetGoogleSearch"`
+			TargetSearchNetwork  bool `json:"targetSearchNetwork"`
+			TargetContentNetwork bool `json:"targetContentNetwork"`
+		} `json:"networkSettings"`
+	} `json:"campaign"`
+	CampaignBudget struct {
+		ID               string    `json:"id"`
+		Name             string    `json:"name"`
+		AmountMicros     api.Int64 `json:"amountMicros"`
+		DeliveryMethod   string    `json:"deliveryMethod"`
+		ExplicitlyShared bool      `json:"explicitlyShared"`
+	} `json:"campaignBudget"`
+}
+
+// snapshotNegativeRow decodes a campaign-level negative keyword.
+type snapshotNegativeRow struct {
+	CampaignCriterion struct {
+		CriterionID string `json:"criterionId"`
+		Keyword     struct {
+			Text      string `json:"text"`
+			MatchType string `json:"matchType"`
+		} `json:"keyword"`
+	} `json:"campaignCriterion"`
+	Campaign api.Campaign `json:"campaign"`
+}
+
+func snapshotSections() []snapshotSection {
+	return []snapshotSection{
+		{
+			key:   "campaigns",
+			label: "campaigns",
+			query: gaql.Select("campaign.id", "campaign.name", "campaign.status",
+				"campaign.advertising_channel_type", "campaign.bidding_strategy_type",
+				"campaign.target_cpa.target_cpa_micros", "campaign.target_roas.target_roas",
+				"campaign.network_settings.target_google_search",
+				"campaign.network_settings.target_search_network",
+				"campaign.network_settings.target_content_network",
+				"campaign_budget.id", "campaign_budget.name", "campaign_budget.amount_micros",
+				"campaign_budget.delivery_method", "campaign_budget.explicitly_shared").
+				From("campaign").
+				Where("campaign.status != 'REMOVED'").
+				OrderBy("campaign.id", false).
+				String(),
+			decode: func(raw json.RawMessage) (any, error) {
+				var r snapshotCampaignRow
+				if err := json.Unmarshal(raw, &r); err != nil {
+					return nil, err
+				}
+				return api.SnapshotCampaign{
+					ID:                     r.Campaign.ID,
+					Name:                   r.Campaign.Name,
+					Status:                 r.Campaign.Status,
+					AdvertisingChannelType: r.Campaign.AdvertisingChannelType,
+					BiddingStrategyType:    r.Campaign.BiddingStrategyType,
+					TargetCpaMicros:        int64(r.Campaign.TargetCpa.TargetCpaMicros),
+					TargetRoas:             r.Campaign.TargetRoas.TargetRoas,
+					Networks: api.SnapshotNetworks{
+						GoogleSearch:   r.Campaign.NetworkSettings.TargetGoogleSearch,
+						SearchNetwork:  r.Campaign.NetworkSettings.TargetSearchNetwork,
+						ContentNetwork: r.Campaign.NetworkSettings.TargetContentNetwork,
+					},
+					Budget: api.SnapshotBudget{
+						ID:             r.CampaignBudget.ID,
+						Name:           r.CampaignBudget.Name,
+						AmountMicros:   int64(r.CampaignBudget.AmountMicros),
+						DeliveryMethod: r.CampaignBudget.DeliveryMethod,
+						Shared:         r.CampaignBudget.ExplicitlyShared,
+					},
+				}, nil
+			},
+		},
+		{
+			key:   "adGroups",
+			label: "ad groups",
+			query: gaql.Select("ad_group.id", "ad_group.name", "ad_group.status", "ad_group.type",
+				"ad_group.cpc_bid_micros", "campaign.id").
+				From("ad_group").
+				Where("ad_group.status != 'REMOVED'").
+				Where("campaign.status != 'REMOVED'").
+				OrderBy("ad_group.id", false).
+				String(),
+			decode: func(raw json.RawMessage) (any, error) {
+				var r api.AdGroupRow
+				if err := json.Unmarshal(raw, &r); err != nil {
+					return nil, err
+				}
+				return api.SnapshotAdGroup{
+					ID:           r.AdGroup.ID,
+					CampaignID:   r.Campaign.ID,
+					Name:         r.AdGroup.Name,
+					Status:       r.AdGroup.Status,
+					Type:         r.AdGroup.Type,
+					CpcBidMicros: int64(r.AdGroup.CpcBidMicros),
+				}, nil
+			},
+		},
+		{
+			key:   "keywords",
+			label: "keywords",
+			query: gaql.Select("ad_group_criterion.criterion_id", "ad_group_criterion.keyword.text",
+				"ad_group_criterion.keyword.match_type", "ad_group_criterion.negative",
+				"ad_group_criterion.status", "ad_group_criterion.cpc_bid_micros",
+				"ad_group.id", "campaign.id").
+				From("ad_group_criterion").
+				Where("ad_group_criterion.type = 'KEYWORD'").
+				Where("ad_group_criterion.status != 'REMOVED'").
+				Where("ad_group.status != 'REMOVED'").
+				Where("campaign.status != 'REMOVED'").
+				OrderBy("ad_group.id", false).
+				String(),
+			decode: func(raw json.RawMessage) (any, error) {
+				var r api.KeywordRow
+				if err := json.Unmarshal(raw, &r); err != nil {
+					return nil, err
+				}
+				return api.SnapshotKeyword{
+					CriterionID:  r.AdGroupCriterion.CriterionID,
+					CampaignID:   r.Campaign.ID,
+					AdGroupID:    r.AdGroup.ID,
+					Text:         r.AdGroupCriterion.Keyword.Text,
+					MatchType:    r.AdGroupCriterion.Keyword.MatchType,
+					Negative:     r.AdGroupCriterion.Negative,
+					Status:       r.AdGroupCriterion.Status,
+					CpcBidMicros: int64(r.AdGroupCriterion.CpcBidMicros),
+				}, nil
+			},
+		},
+		{
+			key:   "keywords",
+			label: "campaign negative keywords",
+			query: gaql.Select("campaign_criterion.criterion_id", "campaign_criterion.keyword.text",
+				"campaign_criterion.keyword.match_type", "campaign.id").
+				From("campaign_criterion").
+				Where("campaign_criterion.type = 'KEYWORD'").
+				Where("campaign_criterion.negative = TRUE").
+				Where("campaign.status != 'REMOVED'").
+				OrderBy("campaign.id", false).
+				String(),
+			decode: func(raw json.RawMessage) (any, error) {
+				var r snapshotNegativeRow
+				if err := json.Unmarshal(raw, &r); err != nil {
+					return nil, err
+				}
+				return api.SnapshotKeyword{
+					CriterionID: r.CampaignCriterion.CriterionID,
+					CampaignID:  r.Campaign.ID,
+					Text:        r.CampaignCriterion.Keyword.Text,
+					MatchType:   r.CampaignCriterion.Keyword.MatchType,
+					Negative:    true,
+				}, nil
+			},
+		},
+		{
+			key:   "ads",
+			label: "responsive search ads",
+			query: gaql.Select("ad_group_ad.ad.id", "ad_group_ad.status", "ad_group_ad.ad.final_urls",
+				"ad_group_ad.ad.responsive_search_ad.headlines",
+				"ad_group_ad.ad.responsive_search_ad.descriptions",
+				"ad_group_ad.ad.responsive_search_ad.path1",
+				"ad_group_ad.ad.responsive_search_ad.path2",
+				"ad_group.id", "campaign.id").
+				From("ad_group_ad").
+				Where("ad_group_ad.ad.type = 'RESPONSIVE_SEARCH_AD'").
+				Where("ad_group_ad.status != 'REMOVED'").
+				Where("ad_group.status != 'REMOVED'").
+				Where("campaign.status != 'REMOVED'").
+				OrderBy("ad_group.id", false).
+				String(),
+			decode: func(raw json.RawMessage) (any, error) {
+				var r api.AdRow
+				if err := json.Unmarshal(raw, &r); err != nil {
+					return nil, err
+				}
+				rsa := r.AdGroupAd.Ad.ResponsiveSearchAd
+				return api.SnapshotAd{
+					ID:           r.AdGroupAd.Ad.ID,
+					CampaignID:   r.Campaign.ID,
+					AdGroupID:    r.AdGroup.ID,
+					Status:       r.AdGroupAd.Status,
+					FinalURLs:    r.AdGroupAd.Ad.FinalUrls,
+					Headlines:    snapshotAdTexts(rsa.Headlines),
+					Descriptions: snapshotAdTexts(rsa.Descriptions),
+					Path1:        rsa.Path1,
+					Path2:        rsa.Path2,
+				}, nil
+			},
+		},
+	}
+}
+
+// snapshotAdTexts drops the performance labels of RSA assets, which are
+// metrics rather than structure.
+func snapshotAdTexts(assets []api.AdTextAsset) []api.SnapshotAdText {
+	texts := make([]api.SnapshotAdText, len(assets))
+	for i, a := range assets {
+		texts[i] = api.SnapshotAdText{Text: a.Text, PinnedField: a.PinnedField}
+	}
+	return texts
+}
+
+// snapshotProgressEvery is how often, in rows, a long section reports
+// progress.
+const snapshotProgressEvery = 10000
+
+// streamSection runs sec's query with searchStream and calls fn with each
+// decoded entry, reporting progress on stderr unless --quiet.
+func streamSection(cid string, sec snapshotSection, fn func(v any) error) error {
+	n := 0
+	err := apiClient.SearchStream(cid, sec.query, func(raw json.RawMessage) error {
+		v, err := sec.decode(raw)
+		if err != nil {
+			return fmt.Errorf("parsing %s: %w", sec.label, err)
+		}
+		n++
+		if n%snapshotProgressEvery == 0 && !output.IsQuiet() {
+			fmt.Fprintf(os.Stderr, "%s: %d…\n", sec.label, n)
+		}
+		return fn(v)
+	})
+	if err != nil {
+		return fmt.Errorf("exporting %s: %w", sec.label, err)
+	}
+	if !output.IsQuiet() {
+		fmt.Fprintf(os.Stderr, "%s: %d\n", sec.label, n)
+	}
+	return nil
+}
+
+// writeSnapshot streams the snapshot of cid to the output, one entity per
+// line, without holding the lists in memory.
+func writeSnapshot(cid string, exportedAt time.Time) error {
+	w := bufio.NewWriter(output.Writer())
+	header, err := json.Marshal(api.Snapshot{
+		SchemaVersion: api.SnapshotVersion,
+		ExportedAt:    exportedAt,
+		CustomerID:    cid,
+	})
+	if err != nil {
+		return err
+	}
+	// Keep the scalar fields and write the (empty) lists as they stream.
+	header = header[:bytes.Index(header, []byte(`,"campaigns"`))]
+	w.Write(header)
+
+	sections := snapshotSections()
+	for i := 0; i < len(sections); {
+		key := sections[i].key
+		fmt.Fprintf(w, ",\n  %q: [", key)
+		n := 0
+		// Consecutive sections with the same key fill one list.
+		for ; i < len(sections) && sections[i].key == key; i++ {
+			err := streamSection(cid, sections[i], func(v any) error {
+				line, err := json.Marshal(v)
+				if err != nil {
+					return err
+				}
+				if n > 0 {
+					w.WriteString(",")
+				}
+				w.WriteString("\n    ")
+				w.Write(line)
+				n++
+				output.AddRows(1)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if n > 0 {
+			w.WriteString("\n  ")
+		}
+		w.WriteString("]")
+	}
+	w.WriteString("\n}\n")
+	return w.Flush()
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+}
@@ -0,0 +1,500 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/gaql"
+	"github.com/the20100/gads-cli/internal/output"
+)
+
+var extensionsCmd = &cobra.Command{
+	Use:   "extensions",
+	Short: "Manage sitelinks and callouts",
+	Long: `Manage asset-based extensions. Each extension is an asset linked either to one
+campaign (--campaign) or to the whole account.`,
+}
+
+var extSitelinksCmd = &cobra.Command{
+	Use:   "sitelinks",
+	Short: "Manage sitelink extensions",
+}
+
+var extCalloutsCmd = &cobra.Command{
+	Use:   "callouts",
+	Short: "Manage callout extensions",
+}
+
+var (
+	extCampaign     string
+	extText         string
+	extURL          string
+	extDescription1 string
+	extDescription2 string
+	extAssetID      string
+	extDeleteAsset  bool
+)
+
+// Length limits the API enforces on extension text, in characters.
+const (
+	maxSitelinkTextLen        = 25
+	maxSitelinkDescriptionLen = 35
+	maxCalloutTextLen         = 25
+)
+
+// extensionLink is an asset linked to a campaign or, when Campaign is empty,
+// to the account.
+type extensionLink struct {
+	ResourceName string    `json:"resourceName"`
+	Campaign     string    `json:"campaign,omitempty"`
+	CampaignName string    `json:"campaignName,omitempty"`
+	Status       string    `json:"status"`
+	Asset        api.Asset `json:"asset"`
+}
+
+// ---- extensions sitelinks list ----
+
+var extSitelinksListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List sitelinks linked to the account and its campaigns",
+	Long: `List sitelinks with the campaign they are linked to ("account" for
+account-level sitelinks), their link text, descriptions and URL.
+
+Examples:
+  gads-cli extensions sitelinks list --account=1234567890
+  gads-cli extensions sitelinks list --account=1234567890 --campaign=111222333
+  gads-cli extensions sitelinks list --account=1234567890 --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		links, err := fetchExtensionLinks("SITELINK")
+		if err != nil {
+			return err
+		}
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(links, output.IsPretty(cmd))
+		}
+		if len(links) == 0 {
+			fmt.Println("No sitelinks found.")
+			return nil
+		}
+
+		headers := []string{"ASSET ID", "CAMPAIGN", "TEXT", "DESCRIPTIONS", "URL"}
+		tableRows := make([][]string, len(links))
+		for i, l := range links {
+			sl := l.Asset.SitelinkAsset
+			descriptions := "-"
+			if sl.Description1 != "" {
+				descriptions = sl.Description1 + " / " + sl.Description2
+			}
+			url := "-"
+			if len(l.Asset.FinalURLs) > 0 {
+				url = l.Asset.FinalURLs[0]
+			}
+			tableRows[i] = []string{
+				l.Asset.ID,
+				extensionScope(l),
+				sl.LinkText,
+				descriptions,
+				url,
+			}
+		}
+		return output.PrintTable(headers, tableRows)
+	},
+}
+
+// ---- extensions sitelinks add ----
+
+var extSitelinksAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Create a sitelink and link it to a campaign or the account",
+	Long: `Create a sitelink asset and link it to --campaign, or to the whole account when
+--campaign is omitted. Link text is limited to 25 characters and each
+description to 35; descriptions are optional but must be given together.
+
+Examples:
+  gads-cli extensions sitelinks add --account=1234567890 --text="Contact us" --url=https://example.com/contact
+  gads-cli extensions sitelinks add --account=1234567890 --campaign=111222333 \
+    --text="Spring sale" --url=https://example.com/sale \
+    --description1="Up to 50% off" --description2="Ends Sunday"`,
+	Annotations: mutatingCommand,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := checkExtensionTarget(); err != nil {
+			return err
+		}
+		if extText == "" {
+			return fmt.Errorf("--text is required")
+		}
+		if extURL == "" {
+			return fmt.Errorf("--url is required")
+		}
+		if err := checkExtensionText("--text", extText, maxSitelinkTextLen); err != nil {
+			return err
+		}
+		if (extDescription1 == "") != (extDescription2 == "") {
+			return fmt.Errorf("--description1 and --description2 must be given together")
+		}
+		if err := checkExtensionText("--description1", extDescription1, maxSitelinkDescriptionLen); err != nil {
+			return err
+		}
+		if err := checkExtensionText("--description2", extDescription2, maxSitelinkDescriptionLen); err != nil {
+			return err
+		}
+
+		sitelink := map[string]any{"linkText": extText}
+		if extDescription1 != "" {
+			sitelink["description1"] = extDescription1
+			sitelink["description2"] = extDescription2
+		}
+		asset := map[string]any{
+			"finalUrls":     []string{extURL},
+			"sitelinkAsset": sitelink,
+		}
+		return addExtension("SITELINK", "Sitelink", asset)
+	},
+}
+
+// ---- extensions sitelinks remove ----
+
+var extSitelinksRemoveCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Unlink a sitelink from a campaign or the account",
+	Long: `Remove the link between a sitelink asset and --campaign (or the account when
+--campaign is omitted). The asset itself is kept for reuse unless
+--delete-asset is given.
+
+Examples:
+  gads-cli extensions sitelinks remove --account=1234567890 --asset=444555666
+  gads-cli extensions sitelinks remove --account=1234567890 --campaign=111222333 --asset=444555666 --delete-asset`,
+	Annotations: mutatingCommand,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return removeExtension("SITELINK", "Sitelink")
+	},
+}
+
+// ---- extensions callouts list ----
+
+var extCalloutsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List callouts linked to the account and its campaigns",
+	Long: `List callouts with the campaign they are linked to ("account" for
+account-level callouts).
+
+Examples:
+  gads-cli extensions callouts list --account=1234567890
+  gads-cli extensions callouts list --account=1234567890 --campaign=111222333 --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		links, err := fetchExtensionLinks("CALLOUT")
+		if err != nil {
+			return err
+		}
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(links, output.IsPretty(cmd))
+		}
+		if len(links) == 0 {
+			fmt.Println("No callouts found.")
+			return nil
+		}
+
+		headers := []string{"ASSET ID", "CAMPAIGN", "TEXT"}
+		tableRows := make([][]string, len(links))
+		for i, l := range links {
+			tableRows[i] = []string{
+				l.Asset.ID,
+				extensionScope(l),
+				l.Asset.CalloutAsset.CalloutText,
+			}
+		}
+		return output.PrintTable(headers, tableRows)
+	},
+}
+
+// ---- extensions callouts add ----
+
+var extCalloutsAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Create a callout and link it to a campaign or the account",
+	Long: `Create a callout asset (at most 25 characters) and link it to --campaign, or to
+the whole account when --campaign is omitted.
+
+Examples:
+  gads-cli extensions callouts add --account=1234567890 --text="Free shipping"
+  gads-cli extensions callouts add --account=1234567890 --campaign=111222333 --text="24/7 support"`,
+	Annotations: mutatingCommand,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := checkExtensionTarget(); err != nil {
+			return err
+		}
+		if extText == "" {
+			return fmt.Errorf("--text is required")
+		}
+		if err := checkExtensionText("--text", extText, maxCalloutTextLen); err != nil {
+			return err
+		}
+		asset := map[string]any{"calloutAsset": map[string]any{"calloutText": extText}}
+		return addExtension("CALLOUT", "Callout", asset)
+	},
+}
+
+// ---- extensions callouts remove ----
+
+var extCalloutsRemoveCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Unlink a callout from a campaign or the account",
+	Long: `Remove the link between a callout asset and --campaign (or the account when
+--campaign is omitted). The asset itself is kept for reuse unless
+--delete-asset is given.
+
+Examples:
+  gads-cli extensions callouts remove --account=1234567890 --asset=444555666
+  gads-cli extensions callouts remove --account=1234567890 --campaign=111222333 --asset=444555666`,
+	Annotations: mutatingCommand,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return removeExtension("CALLOUT", "Callout")
+	},
+}
+
+// checkExtensionTarget validates the optional --campaign and returns the
+// account.
+func checkExtensionTarget() (string, error) {
+	cid, err := accountID()
+	if err != nil {
+		return "", err
+	}
+	if extCampaign != "" && !api.IsNumericID(extCampaign) {
+		return "", fmt.Errorf("--campaign must be a numeric ID")
+	}
+	return cid, nil
+}
+
+// extensionOwner names what an extension is linked to, for confirmations:
+// --campaign or the account.
+func extensionOwner(cid string) string {
+	if extCampaign != "" {
+		return "Campaign " + extCampaign
+	}
+	return "Account " + cid
+}
+
+// checkExtensionText rejects text longer than max characters.
+func checkExtensionText(flag, text string, max int) error {
+	if n := utf8.RuneCountInString(text); n > max {
+		return fmt.Errorf("%s is %d characters; the limit is %d", flag, n, max)
+	}
+	return nil
+}
+
+// extensionScope returns the campaign a link belongs to for the list table.
+func extensionScope(l extensionLink) string {
+	if l.Campaign == "" {
+		return "account"
+	}
+	return l.CampaignName + " (" + l.Campaign + ")"
+}
+
+// fetchExtensionLinks returns the enabled links of the given field type:
+// those of --campaign, or account-level links followed by every campaign's
+// links when --campaign is omitted.
+func fetchExtensionLinks(fieldType string) ([]extensionLink, error) {
+	cid, err := checkExtensionTarget()
+	if err != nil {
+		return nil, err
+	}
+	assetFields := []string{
+		"asset.id", "asset.name", "asset.type", "asset.final_urls",
+		"asset.sitelink_asset.link_text", "asset.sitelink_asset.description1", "asset.sitelink_asset.description2",
+		"asset.callout_asset.callout_text",
+	}
+
+	var links []extensionLink
+	if extCampaign == "" {
+		query := gaql.Select("customer_asset.resource_name", "customer_asset.status").
+			Select(assetFields...).
+			From("customer_asset").
+			Where("customer_asset.field_type = "+gaql.Quote(fieldType)).
+			Where("customer_asset.status != 'REMOVED'").
+			OrderBy("asset.id", false).
+			String()
+		rows, err := apiClient.Search(cid, query)
+		if err != nil {
+			return nil, err
+		}
+		for _, raw := range rows {
+			var row api.CustomerAssetRow
+			if err := json.Unmarshal(raw, &row); err != nil {
+				continue
+			}
+			links = append(links, extensionLink{
+				ResourceName: row.CustomerAsset.ResourceName,
+				Status:       row.CustomerAsset.Status,
+				Asset:        row.Asset,
+			})
+		}
+	}
+
+	query := gaql.Select("campaign_asset.resource_name", "campaign_asset.status", "campaign.id", "campaign.name").
+		Select(assetFields...).
+		From("campaign_asset").
+		Where("campaign_asset.field_type = "+gaql.Quote(fieldType)).
+		Where("campaign_asset.status != 'REMOVED'").
+		WhereIf(extCampaign != "", "campaign.id = "+gaql.Quote(extCampaign)).
+		OrderBy("campaign.name", false).
+		String()
+	rows, err := apiClient.Search(cid, query)
+	if err != nil {
+		return nil, err
+	}
+	for _, raw := range rows {
+		var row api.CampaignAssetRow
+		if err := json.Unmarshal(raw, &row); err != nil {
+			continue
+		}
+		links = append(links, extensionLink{
+			ResourceName: row.CampaignAsset.ResourceName,
+			Campaign:     row.Campaign.ID,
+			CampaignName: row.Campaign.Name,
+			Status:       row.CampaignAsset.Status,
+			Asset:        row.Asset,
+		})
+	}
+	return links, nil
+}
+
+// addExtension creates asset and links it to --campaign or the account as
+// fieldType. The link needs the new asset's resource name, so the two
+// mutates are sent one after the other.
+func addExtension(fieldType, noun string, asset map[string]any) error {
+	cid, err := accountID()
+	if err != nil {
+		return err
+	}
+	change := fmt.Sprintf("%s: add %s %q", extensionOwner(cid), strings.ToLower(noun), extText)
+	if ok, err := confirmChange(fmt.Sprintf("Add this %s?", strings.ToLower(noun)), change); !ok {
+		return err
+	}
+	ops := []map[string]any{{"create": asset}}
+	resp, err := apiClient.MutateAssets(cid, ops)
+	if err != nil {
+		return err
+	}
+	if apiClient.ValidateOnly() {
+		fmt.Fprintln(os.Stderr, "Note: only the asset was validated; the link needs the created asset")
+		return reportDryRun(resp, len(ops))
+	}
+	if len(resp.Results) == 0 {
+		return fmt.Errorf("asset create returned no result")
+	}
+	assetName := resp.Results[0].ResourceName
+
+	link := map[string]any{"asset": assetName, "fieldType": fieldType}
+	linkOps := []map[string]any{{"create": link}}
+	var linkResp *api.MutateResponse
+	if extCampaign != "" {
+		link["campaign"] = fmt.Sprintf("customers/%s/campaigns/%s", cid, extCampaign)
+		linkResp, err = apiClient.MutateCampaignAssets(cid, linkOps)
+	} else {
+		linkResp, err = apiClient.MutateCustomerAssets(cid, linkOps)
+	}
+	if err != nil {
+		return fmt.Errorf("%s asset %s was created but could not be linked: %w", noun, assetName, err)
+	}
+	if jsonResult() {
+		return printMutateResult(len(ops)+len(linkOps), resp, linkResp)
+	}
+
+	fmt.Printf("%s created: %s\n", noun, extText)
+	fmt.Printf("Asset: %s\n", assetName)
+	if len(linkResp.Results) > 0 {
+		fmt.Printf("Resource: %s\n", linkResp.Results[0].ResourceName)
+	}
+	return nil
+}
+
+// removeExtension removes the fieldType link of --asset from --campaign or the
+// account, and with --delete-asset the asset too.
+func removeExtension(fieldType, noun string) error {
+	cid, err := checkExtensionTarget()
+	if err != nil {
+		return err
+	}
+	if extAssetID == "" {
+		return fmt.Errorf("--asset is required")
+	}
+	if !api.IsNumericID(extAssetID) {
+		return fmt.Errorf("--asset must be a numeric ID")
+	}
+	changes := []string{fmt.Sprintf("%s: unlink %s %s", extensionOwner(cid), strings.ToLower(noun), extAssetID)}
+	if extDeleteAsset {
+		changes = append(changes, fmt.Sprintf("Asset %s: delete", extAssetID))
+	}
+	if ok, err := confirmChange(fmt.Sprintf("Remove this %s?", strings.ToLower(noun)), changes...); !ok {
+		return err
+	}
+
+	var resp *api.MutateResponse
+	if extCampaign != "" {
+		ops := []map[string]any{{"remove": api.CampaignAssetResourceName(cid, extCampaign, extAssetID, fieldType)}}
+		resp, err = apiClient.MutateCampaignAssets(cid, ops)
+	} else {
+		ops := []map[string]any{{"remove": api.CustomerAssetResourceName(cid, extAssetID, fieldType)}}
+		resp, err = apiClient.MutateCustomerAssets(cid, ops)
+	}
+	if err != nil {
+		return err
+	}
+	if apiClient.ValidateOnly() {
+		if extDeleteAsset {
+			fmt.Fprintln(os.Stderr, "Note: only the link removal was validated, not --delete-asset")
+		}
+		return reportDryRun(resp, 1)
+	}
+	resps := []*api.MutateResponse{resp}
+	if extDeleteAsset {
+		ops := []map[string]any{{"remove": fmt.Sprintf("customers/%s/assets/%s", cid, extAssetID)}}
+		deleteResp, err := apiClient.MutateAssets(cid, ops)
+		if err != nil {
+			return fmt.Errorf("the link was removed but asset %s could not be deleted: %w", extAssetID, err)
+		}
+		resps = append(resps, deleteResp)
+	}
+	if jsonResult() {
+		return printMutateResult(len(resps), resps...)
+	}
+
+	if extCampaign != "" {
+		fmt.Printf("%s %s unlinked from campaign %s.\n", noun, extAssetID, extCampaign)
+	} else {
+		fmt.Printf("%s %s unlinked from account %s.\n", noun, extAssetID, cid)
+	}
+	if extDeleteAsset {
+		fmt.Printf("Asset %s deleted.\n", extAssetID)
+	}
+	return nil
+}
+
+func init() {
+	all := []*cobra.Command{
+		extSitelinksListCmd, extSitelinksAddCmd, extSitelinksRemoveCmd,
+		extCalloutsListCmd, extCalloutsAddCmd, extCalloutsRemoveCmd,
+	}
+	for _, c := range all {
+		c.Flags().StringVar(&extCampaign, "campaign", "", "Campaign ID (omit for account-level extensions)")
+	}
+	extSitelinksAddCmd.Flags().StringVar(&extText, "text", "", "Link text, at most 25 characters (required)")
+	extSitelinksAddCmd.Flags().StringVar(&extURL, "url", "", "Final URL (required)")
+	extSitelinksAddCmd.Flags().StringVar(&extDescription1, "description1", "", "First description line, at most 35 characters")
+	extSitelinksAddCmd.Flags().StringVar(&extDescription2, "description2", "", "Second description line, at most 35 characters")
+	extCalloutsAddCmd.Flags().StringVar(&extText, "text", "", "Callout text, at most 25 characters (required)")
+	for _, c := range []*cobra.Command{extSitelinksRemoveCmd, extCalloutsRemoveCmd} {
+		c.Flags().StringVar(&extAssetID, "asset", "", "Asset ID, as shown by list (required)")
+		c.Flags().BoolVar(&extDeleteAsset, "delete-asset", false, "Also delete the asset after unlinking it")
+	}
+
+	extSitelinksCmd.AddCommand(extSitelinksListCmd, extSitelinksAddCmd, extSitelinksRemoveCmd)
+	extCalloutsCmd.AddCommand(extCalloutsListCmd, extCalloutsAddCmd, extCalloutsRemoveCmd)
+	extensionsCmd.AddCommand(extSitelinksCmd, extCalloutsCmd)
+	rootCmd.AddCommand(extensionsCmd)
+}
@@ -110,6 +110,9 @@ const (
 	FidCostPerConv     = "cost_per_conv"
 	FidConvRate        = "conv_rate"
 	FidSearchImpShare  = "search_imp_share"
+
+	// Segments
+	FidNetwork = "network"
 )
 
 // FieldGAQL maps field IDs to their Google Ads Query Language (GAQL) field names.
@@ -205,6 +208,8 @@ var FieldGAQL = map[string]string{
 	FidCostPerConv:     "metrics.cost_per_conversion",
 	FidConvRate:        "metrics.conversions_from_interactions_rate",
 	FidSearchImpShare:  "metrics.search_impression_share",
+
+	FidNetwork: "segments.ad_network_type",
 }
 
 // parseFieldList splits a comma-separated fields string into trimmed IDs.
@@ -232,28 +237,31 @@ type CampaignCol struct {
 var campaignColDefs = []CampaignCol{
 	{FidCampaignID, "CAMPAIGN ID", func(r *api.InsightsCampaignRow) string { return r.Campaign.ID }},
 	{FidCampaignName, "CAMPAIGN", func(r *api.InsightsCampaignRow) string {
-		return output.Truncate(r.Campaign.Name, 30)
+		return r.Campaign.Name
 	}},
 	{FidCampaignStatus, "STATUS", func(r *api.InsightsCampaignRow) string {
-		return strings.ToLower(r.Campaign.Status)
+		return output.Colorize(strings.ToLower(r.Campaign.Status))
 	}},
 	{FidCampaignType, "TYPE", func(r *api.InsightsCampaignRow) string {
 		return strings.ToLower(r.Campaign.AdvertisingChannelType)
 	}},
+	{FidNetwork, "NETWORK", func(r *api.InsightsCampaignRow) string {
+		return formatNetwork(r.Segments)
+	}},
 	{FidImpressions, "IMPR", func(r *api.InsightsCampaignRow) string {
-		return api.FormatMetricInt(r.Metrics.Impressions)
+		return api.FormatMetricInt(int64(r.Metrics.Impressions))
 	}},
 	{FidClicks, "CLICKS", func(r *api.InsightsCampaignRow) string {
-		return api.FormatMetricInt(r.Metrics.Clicks)
+		return api.FormatMetricInt(int64(r.Metrics.Clicks))
 	}},
 	{FidCost, "COST", func(r *api.InsightsCampaignRow) string {
-		return api.MicrosToCurrency(r.Metrics.CostMicros)
+		return api.FormatMoney(float64(r.Metrics.CostMicros), insightsCurrency)
 	}},
 	{FidCTR, "CTR", func(r *api.InsightsCampaignRow) string {
 		return api.FormatCTR(r.Metrics.Ctr)
 	}},
 	{FidCPC, "CPC", func(r *api.InsightsCampaignRow) string {
-		return api.MicrosFloatToCurrency(r.Metrics.AverageCpc)
+		return api.FormatMoney(r.Metrics.AverageCpc, insightsCurrency)
 	}},
 	{FidConversions, "CONV", func(r *api.InsightsCampaignRow) string {
 		return fmt.Sprintf("%.1f", r.Metrics.Conversions)
@@ -262,7 +270,7 @@ var campaignColDefs = []CampaignCol{
 		return fmt.Sprintf("%.2f", r.Metrics.ConversionsValue)
 	}},
 	{FidROAS, "ROAS", func(r *api.InsightsCampaignRow) string {
-		return api.FormatROAS(r.Metrics.ConversionsValue, r.Metrics.CostMicros)
+		return api.FormatROAS(r.Metrics.ConversionsValue, int64(r.Metrics.CostMicros))
 	}},
 	{FidAbsTopImpPct, "ABS TOP%", func(r *api.InsightsCampaignRow) string {
 		return api.FormatPct(r.Metrics.AbsoluteTopImpressionPercentage)
@@ -271,13 +279,10 @@ var campaignColDefs = []CampaignCol{
 		return api.FormatPct(r.Metrics.TopImpressionPercentage)
 	}},
 	{FidViewThroughConv, "VIEW CONV", func(r *api.InsightsCampaignRow) string {
-		if r.Metrics.ViewThroughConversions == "" {
-			return "0"
-		}
-		return r.Metrics.ViewThroughConversions
+		return api.FormatMetricInt(int64(r.Metrics.ViewThroughConversions))
 	}},
 	{FidCostPerConv, "COST/CONV", func(r *api.InsightsCampaignRow) string {
-		return api.MicrosFloatToCurrency(r.Metrics.CostPerConversion)
+		return api.FormatMoney(r.Metrics.CostPerConversion, insightsCurrency)
 	}},
 	{FidConvRate, "CONV%", func(r *api.InsightsCampaignRow) string {
 		return api.FormatPct(r.Metrics.ConversionsFromInteractionsRate)
@@ -346,29 +351,29 @@ type AdGroupCol struct {
 
 var adGroupColDefs = []AdGroupCol{
 	{FidCampaignName, "CAMPAIGN", func(r *api.InsightsAdGroupRow) string {
-		return output.Truncate(r.Campaign.Name, 24)
+		return r.Campaign.Name
 	}},
 	{FidAdGroupID, "ADGROUP ID", func(r *api.InsightsAdGroupRow) string { return r.AdGroup.ID }},
 	{FidAdGroupName, "ADGROUP", func(r *api.InsightsAdGroupRow) string {
-		return output.Truncate(r.AdGroup.Name, 30)
+		return r.AdGroup.Name
 	}},
 	{FidAdGroupStatus, "STATUS", func(r *api.InsightsAdGroupRow) string {
-		return strings.ToLower(r.AdGroup.Status)
+		return output.Colorize(strings.ToLower(r.AdGroup.Status))
 	}},
 	{FidImpressions, "IMPR", func(r *api.InsightsAdGroupRow) string {
-		return api.FormatMetricInt(r.Metrics.Impressions)
+		return api.FormatMetricInt(int64(r.Metrics.Impressions))
 	}},
 	{FidClicks, "CLICKS", func(r *api.InsightsAdGroupRow) string {
-		return api.FormatMetricInt(r.Metrics.Clicks)
+		return api.FormatMetricInt(int64(r.Metrics.Clicks))
 	}},
 	{FidCost, "COST", func(r *api.InsightsAdGroupRow) string {
-		return api.MicrosToCurrency(r.Metrics.CostMicros)
+		return api.FormatMoney(float64(r.Metrics.CostMicros), insightsCurrency)
 	}},
 	{FidCTR, "CTR", func(r *api.InsightsAdGroupRow) string {
 		return api.FormatCTR(r.Metrics.Ctr)
 	}},
 	{FidCPC, "CPC", func(r *api.InsightsAdGroupRow) string {
-		return api.MicrosFloatToCurrency(r.Metrics.AverageCpc)
+		return api.FormatMoney(r.Metrics.AverageCpc, insightsCurrency)
 	}},
 	{FidConversions, "CONV", func(r *api.InsightsAdGroupRow) string {
 		return fmt.Sprintf("%.1f", r.Metrics.Conversions)
@@ -377,7 +382,7 @@ var adGroupColDefs = []AdGroupCol{
 		return fmt.Sprintf("%.2f", r.Metrics.ConversionsValue)
 	}},
 	{FidROAS, "ROAS", func(r *api.InsightsAdGroupRow) string {
-		return api.FormatROAS(r.Metrics.ConversionsValue, r.Metrics.CostMicros)
+		return api.FormatROAS(r.Metrics.ConversionsValue, int64(r.Metrics.CostMicros))
 	}},
 	{FidAbsTopImpPct, "ABS TOP%", func(r *api.InsightsAdGroupRow) string {
 		return api.FormatPct(r.Metrics.AbsoluteTopImpressionPercentage)
@@ -386,13 +391,10 @@ var adGroupColDefs = []AdGroupCol{
 		return api.FormatPct(r.Metrics.TopImpressionPercentage)
 	}},
 	{FidViewThroughConv, "VIEW CONV", func(r *api.InsightsAdGroupRow) string {
-		if r.Metrics.ViewThroughConversions == "" {
-			return "0"
-		}
-		return r.Metrics.ViewThroughConversions
+		return api.FormatMetricInt(int64(r.Metrics.ViewThroughConversions))
 	}},
 	{FidCostPerConv, "COST/CONV", func(r *api.InsightsAdGroupRow) string {
-		return api.MicrosFloatToCurrency(r.Metrics.CostPerConversion)
+		return api.FormatMoney(r.Metrics.CostPerConversion, insightsCurrency)
 	}},
 	{FidConvRate, "CONV%", func(r *api.InsightsAdGroupRow) string {
 		return api.FormatPct(r.Metrics.ConversionsFromInteractionsRate)
@@ -460,37 +462,40 @@ type KeywordCol struct {
 
 var keywordColDefs = []KeywordCol{
 	{FidCampaignName, "CAMPAIGN", func(r *api.InsightsKeywordRow) string {
-		return output.Truncate(r.Campaign.Name, 20)
+		return r.Campaign.Name
 	}},
 	{FidAdGroupName, "ADGROUP", func(r *api.InsightsKeywordRow) string {
-		return output.Truncate(r.AdGroup.Name, 20)
+		return r.AdGroup.Name
 	}},
 	{FidKeywordText, "KEYWORD", func(r *api.InsightsKeywordRow) string {
-		return output.Truncate(r.AdGroupCriterion.Keyword.Text, 30)
+		return r.AdGroupCriterion.Keyword.Text
 	}},
 	{FidKeywordMatchType, "MATCH", func(r *api.InsightsKeywordRow) string {
 		return strings.ToLower(r.AdGroupCriterion.Keyword.MatchType)
 	}},
 	{FidKeywordStatus, "KW STATUS", func(r *api.InsightsKeywordRow) string {
-		return strings.ToLower(r.AdGroupCriterion.Status)
+		return output.Colorize(strings.ToLower(r.AdGroupCriterion.Status))
+	}},
+	{FidNetwork, "NETWORK", func(r *api.InsightsKeywordRow) string {
+		return formatNetwork(r.Segments)
 	}},
 	{FidQualityScore, "QS", func(r *api.InsightsKeywordRow) string {
 		return fmt.Sprintf("%d", r.AdGroupCriterion.QualityInfo.QualityScore)
 	}},
 	{FidImpressions, "IMPR", func(r *api.InsightsKeywordRow) string {
-		return api.FormatMetricInt(r.Metrics.Impressions)
+		return api.FormatMetricInt(int64(r.Metrics.Impressions))
 	}},
 	{FidClicks, "CLICKS", func(r *api.InsightsKeywordRow) string {
-		return api.FormatMetricInt(r.Metrics.Clicks)
+		return api.FormatMetricInt(int64(r.Metrics.Clicks))
 	}},
 	{FidCost, "COST", func(r *api.InsightsKeywordRow) string {
-		return api.MicrosToCurrency(r.Metrics.CostMicros)
+		return api.FormatMoney(float64(r.Metrics.CostMicros), insightsCurrency)
 	}},
 	{FidCTR, "CTR", func(r *api.InsightsKeywordRow) string {
 		return api.FormatCTR(r.Metrics.Ctr)
 	}},
 	{FidCPC, "CPC", func(r *api.InsightsKeywordRow) string {
-		return api.MicrosFloatToCurrency(r.Metrics.AverageCpc)
+		return api.FormatMoney(r.Metrics.AverageCpc, insightsCurrency)
 	}},
 	{FidConversions, "CONV", func(r *api.InsightsKeywordRow) string {
 		return fmt.Sprintf("%.1f", r.Metrics.Conversions)
@@ -499,7 +504,7 @@ var keywordColDefs = []KeywordCol{
 		return fmt.Sprintf("%.2f", r.Metrics.ConversionsValue)
 	}},
 	{FidROAS, "ROAS", func(r *api.InsightsKeywordRow) string {
-		return api.FormatROAS(r.Metrics.ConversionsValue, r.Metrics.CostMicros)
+		return api.FormatROAS(r.Metrics.ConversionsValue, int64(r.Metrics.CostMicros))
 	}},
 	{FidAbsTopImpPct, "ABS TOP%", func(r *api.InsightsKeywordRow) string {
 		return api.FormatPct(r.Metrics.AbsoluteTopImpressionPercentage)
@@ -508,13 +513,10 @@ var keywordColDefs = []KeywordCol{
 		return api.FormatPct(r.Metrics.TopImpressionPercentage)
 	}},
 	{FidViewThroughConv, "VIEW CONV", func(r *api.InsightsKeywordRow) string {
-		if r.Metrics.ViewThroughConversions == "" {
-			return "0"
-		}
-		return r.Metrics.ViewThroughConversions
+		return api.FormatMetricInt(int64(r.Metrics.ViewThroughConversions))
 	}},
 	{FidCostPerConv, "COST/CONV", func(r *api.InsightsKeywordRow) string {
-		return api.MicrosFloatToCurrency(r.Metrics.CostPerConversion)
+		return api.FormatMoney(r.Metrics.CostPerConversion, insightsCurrency)
 	}},
 	{FidConvRate, "CONV%", func(r *api.InsightsKeywordRow) string {
 		return api.FormatPct(r.Metrics.ConversionsFromInteractionsRate)
@@ -584,31 +586,31 @@ type SearchTermCol struct {
 
 var searchTermColDefs = []SearchTermCol{
 	{FidSearchTerm, "SEARCH TERM", func(r *api.SearchTermRow) string {
-		return output.Truncate(r.SearchTermView.SearchTerm, 40)
+		return r.SearchTermView.SearchTerm
 	}},
 	{FidSearchTermStatus, "STATUS", func(r *api.SearchTermRow) string {
-		return strings.ToLower(r.SearchTermView.Status)
+		return output.Colorize(strings.ToLower(r.SearchTermView.Status))
 	}},
 	{FidCampaignName, "CAMPAIGN", func(r *api.SearchTermRow) string {
-		return output.Truncate(r.Campaign.Name, 20)
+		return r.Campaign.Name
 	}},
 	{FidAdGroupName, "ADGROUP", func(r *api.SearchTermRow) string {
-		return output.Truncate(r.AdGroup.Name, 24)
+		return r.AdGroup.Name
 	}},
 	{FidImpressions, "IMPR", func(r *api.SearchTermRow) string {
-		return api.FormatMetricInt(r.Metrics.Impressions)
+		return api.FormatMetricInt(int64(r.Metrics.Impressions))
 	}},
 	{FidClicks, "CLICKS", func(r *api.SearchTermRow) string {
-		return api.FormatMetricInt(r.Metrics.Clicks)
+		return api.FormatMetricInt(int64(r.Metrics.Clicks))
 	}},
 	{FidCost, "COST", func(r *api.SearchTermRow) string {
-		return api.MicrosToCurrency(r.Metrics.CostMicros)
+		return api.FormatMoney(float64(r.Metrics.CostMicros), insightsCurrency)
 	}},
 	{FidCTR, "CTR", func(r *api.SearchTermRow) string {
 		return api.FormatCTR(r.Metrics.Ctr)
 	}},
 	{FidCPC, "CPC", func(r *api.SearchTermRow) string {
-		return api.MicrosFloatToCurrency(r.Metrics.AverageCpc)
+		return api.FormatMoney(r.Metrics.AverageCpc, insightsCurrency)
 	}},
 	{FidConversions, "CONV", func(r *api.SearchTermRow) string {
 		return fmt.Sprintf("%.1f", r.Metrics.Conversions)
@@ -617,16 +619,13 @@ var searchTermColDefs = []SearchTermCol{
 		return fmt.Sprintf("%.2f", r.Metrics.ConversionsValue)
 	}},
 	{FidROAS, "ROAS", func(r *api.SearchTermRow) string {
-		return api.FormatROAS(r.Metrics.ConversionsValue, r.Metrics.CostMicros)
+		return api.FormatROAS(r.Metrics.ConversionsValue, int64(r.Metrics.CostMicros))
 	}},
 	{FidViewThroughConv, "VIEW CONV", func(r *api.SearchTermRow) string {
-		if r.Metrics.ViewThroughConversions == "" {
-			return "0"
-		}
-		return r.Metrics.ViewThroughConversions
+		return api.FormatMetricInt(int64(r.Metrics.ViewThroughConversions))
 	}},
 	{FidCostPerConv, "COST/CONV", func(r *api.SearchTermRow) string {
-		return api.MicrosFloatToCurrency(r.Metrics.CostPerConversion)
+		return api.FormatMoney(r.Metrics.CostPerConversion, insightsCurrency)
 	}},
 	{FidConvRate, "CONV%", func(r *api.SearchTermRow) string {
 		return api.FormatPct(r.Metrics.ConversionsFromInteractionsRate)
@@ -755,41 +754,41 @@ func makeDescPosCol(n int) AdCol {
 var adColDefs func() []AdCol = func() []AdCol {
 	cols := []AdCol{
 		{FidCampaignName, "CAMPAIGN", func(r *api.InsightsAdRow) string {
-			return output.Truncate(r.Campaign.Name, 20)
+			return r.Campaign.Name
 		}},
 		{FidAdGroupName, "ADGROUP", func(r *api.InsightsAdRow) string {
-			return output.Truncate(r.AdGroup.Name, 20)
+			return r.AdGroup.Name
 		}},
 		{FidAdID, "AD ID", func(r *api.InsightsAdRow) string { return r.AdGroupAd.Ad.ID }},
 		{FidAdName, "AD NAME", func(r *api.InsightsAdRow) string {
-			return output.Truncate(r.AdGroupAd.Ad.Name, 30)
+			return r.AdGroupAd.Ad.Name
 		}},
 		{FidAdStatus, "STATUS", func(r *api.InsightsAdRow) string {
-			return strings.ToLower(r.AdGroupAd.Status)
+			return output.Colorize(strings.ToLower(r.AdGroupAd.Status))
 		}},
 		{FidAdType, "AD TYPE", func(r *api.InsightsAdRow) string {
 			return strings.ToLower(r.AdGroupAd.Ad.Type)
 		}},
 		{FidFinalURL, "FINAL URL", func(r *api.InsightsAdRow) string {
 			if len(r.AdGroupAd.Ad.FinalUrls) > 0 {
-				return output.Truncate(r.AdGroupAd.Ad.FinalUrls[0], 50)
+				return r.AdGroupAd.Ad.FinalUrls[0]
 			}
 			return ""
 		}},
 		{FidFinalMobileURL, "MOBILE URL", func(r *api.InsightsAdRow) string {
 			if len(r.AdGroupAd.Ad.FinalMobileUrls) > 0 {
-				return output.Truncate(r.AdGroupAd.Ad.FinalMobileUrls[0], 40)
+				return r.AdGroupAd.Ad.FinalMobileUrls[0]
 			}
 			return ""
 		}},
 		{FidTrackingURL, "TRACKING URL", func(r *api.InsightsAdRow) string {
-			return output.Truncate(r.AdGroupAd.Ad.TrackingUrlTemplate, 40)
+			return r.AdGroupAd.Ad.TrackingUrlTemplate
 		}},
 		{FidFinalURLSuffix, "URL SUFFIX", func(r *api.InsightsAdRow) string {
 			return r.AdGroupAd.Ad.FinalUrlSuffix
 		}},
 		{FidDisplayURL, "DISPLAY URL", func(r *api.InsightsAdRow) string {
-			return output.Truncate(r.AdGroupAd.Ad.DisplayUrl, 30)
+			return r.AdGroupAd.Ad.DisplayUrl
 		}},
 		{FidPath1, "PATH1", func(r *api.InsightsAdRow) string {
 			return r.AdGroupAd.Ad.ResponsiveSearchAd.Path1
@@ -799,35 +798,35 @@ var adColDefs func() []AdCol = func() []AdCol {
 		}},
 		// ETA legacy fields
 		{FidETAHeadline1, "ETA HL1", func(r *api.InsightsAdRow) string {
-			return output.Truncate(r.AdGroupAd.Ad.ExpandedTextAd.HeadlinePart1, 30)
+			return r.AdGroupAd.Ad.ExpandedTextAd.HeadlinePart1
 		}},
 		{FidETAHeadline2, "ETA HL2", func(r *api.InsightsAdRow) string {
-			return output.Truncate(r.AdGroupAd.Ad.ExpandedTextAd.HeadlinePart2, 30)
+			return r.AdGroupAd.Ad.ExpandedTextAd.HeadlinePart2
 		}},
 		{FidETAHeadline3, "ETA HL3", func(r *api.InsightsAdRow) string {
-			return output.Truncate(r.AdGroupAd.Ad.ExpandedTextAd.HeadlinePart3, 30)
+			return r.AdGroupAd.Ad.ExpandedTextAd.HeadlinePart3
 		}},
 		{FidETADesc1, "ETA DESC1", func(r *api.InsightsAdRow) string {
-			return output.Truncate(r.AdGroupAd.Ad.ExpandedTextAd.Description, 40)
+			return r.AdGroupAd.Ad.ExpandedTextAd.Description
 		}},
 		{FidETADesc2, "ETA DESC2", func(r *api.InsightsAdRow) string {
-			return output.Truncate(r.AdGroupAd.Ad.ExpandedTextAd.Description2, 40)
+			return r.AdGroupAd.Ad.ExpandedTextAd.Description2
 		}},
 		// Metrics
 		{FidImpressions, "IMPR", func(r *api.InsightsAdRow) string {
-			return api.FormatMetricInt(r.Metrics.Impressions)
+			return api.FormatMetricInt(int64(r.Metrics.Impressions))
 		}},
 		{FidClicks, "CLICKS", func(r *api.InsightsAdRow) string {
-			return api.FormatMetricInt(r.Metrics.Clicks)
+			return api.FormatMetricInt(int64(r.Metrics.Clicks))
 		}},
 		{FidCost, "COST", func(r *api.InsightsAdRow) string {
-			return api.MicrosToCurrency(r.Metrics.CostMicros)
+			return api.FormatMoney(float64(r.Metrics.CostMicros), insightsCurrency)
 		}},
 		{FidCTR, "CTR", func(r *api.InsightsAdRow) string {
 			return api.FormatCTR(r.Metrics.Ctr)
 		}},
 		{FidCPC, "CPC", func(r *api.InsightsAdRow) string {
-			return api.MicrosFloatToCurrency(r.Metrics.AverageCpc)
+			return api.FormatMoney(r.Metrics.AverageCpc, insightsCurrency)
 		}},
 		{FidConversions, "CONV", func(r *api.InsightsAdRow) string {
 			return fmt.Sprintf("%.1f", r.Metrics.Conversions)
@@ -836,7 +835,7 @@ var adColDefs func() []AdCol = func() []AdCol {
 			return fmt.Sprintf("%.2f", r.Metrics.ConversionsValue)
 		}},
 		{FidROAS, "ROAS", func(r *api.InsightsAdRow) string {
-			return api.FormatROAS(r.Metrics.ConversionsValue, r.Metrics.CostMicros)
+			return api.FormatROAS(r.Metrics.ConversionsValue, int64(r.Metrics.CostMicros))
 		}},
 		{FidAbsTopImpPct, "ABS TOP%", func(r *api.InsightsAdRow) string {
 			return api.FormatPct(r.Metrics.AbsoluteTopImpressionPercentage)
@@ -845,13 +844,10 @@ var adColDefs func() []AdCol = func() []AdCol {
 			return api.FormatPct(r.Metrics.TopImpressionPercentage)
 		}},
 		{FidViewThroughConv, "VIEW CONV", func(r *api.InsightsAdRow) string {
-			if r.Metrics.ViewThroughConversions == "" {
-				return "0"
-			}
-			return r.Metrics.ViewThroughConversions
+			return api.FormatMetricInt(int64(r.Metrics.ViewThroughConversions))
 		}},
 		{FidCostPerConv, "COST/CONV", func(r *api.InsightsAdRow) string {
-			return api.MicrosFloatToCurrency(r.Metrics.CostPerConversion)
+			return api.FormatMoney(r.Metrics.CostPerConversion, insightsCurrency)
 		}},
 		{FidConvRate, "CONV%", func(r *api.InsightsAdRow) string {
 			return api.FormatPct(r.Metrics.ConversionsFromInteractionsRate)
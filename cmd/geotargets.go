@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/output"
+)
+
+var geotargetsCmd = &cobra.Command{
+	Use:   "geotargets",
+	Short: "Look up geo target constants for location targeting",
+}
+
+var (
+	geoQuery   string
+	geoCountry string
+	geoLocale  string
+)
+
+// ---- geotargets search ----
+
+var geotargetsSearchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "Find geo target constant IDs by location name",
+	Long: `Find the geo target constants matching a location name. Every candidate is
+listed, so ambiguous names (e.g. "springfield") show all matching places;
+narrow them down with --country.
+
+--json prints an array of geo target constants whose "id" values can be used
+for location targeting.
+
+Examples:
+  gads-cli geotargets search --query="new york"
+  gads-cli geotargets search --query=springfield --country=US
+  gads-cli geotargets search --query=münchen --locale=de --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if strings.TrimSpace(geoQuery) == "" {
+			return fmt.Errorf("--query is required")
+		}
+		suggestions, err := apiClient.SuggestGeoTargetConstants([]string{geoQuery}, geoLocale, strings.ToUpper(geoCountry))
+		if err != nil {
+			return err
+		}
+
+		targets := make([]api.GeoTargetConstant, 0, len(suggestions))
+		for _, s := range suggestions {
+			targets = append(targets, s.GeoTargetConstant)
+		}
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(targets, output.IsPretty(cmd))
+		}
+		if len(targets) == 0 {
+			fmt.Printf("No locations found for %q.\n", geoQuery)
+			return nil
+		}
+
+		headers := []string{"ID", "NAME", "CANONICAL NAME", "COUNTRY", "TYPE", "STATUS", "REACH"}
+		tableRows := make([][]string, len(suggestions))
+		for i, s := range suggestions {
+			g := s.GeoTargetConstant
+			tableRows[i] = []string{
+				g.ID,
+				g.Name,
+				g.CanonicalName,
+				g.CountryCode,
+				g.TargetType,
+				output.Colorize(g.Status),
+				api.FormatMetricInt(int64(s.Reach)),
+			}
+		}
+		if err := output.PrintTable(headers, tableRows); err != nil {
+			return err
+		}
+		if len(targets) > 1 {
+			fmt.Fprintf(os.Stderr, "\n%d locations match %q — use the ID of the one you mean.\n", len(targets), geoQuery)
+		}
+		return nil
+	},
+}
+
+func init() {
+	geotargetsSearchCmd.Flags().StringVar(&geoQuery, "query", "", "Location name to look up (required)")
+	geotargetsSearchCmd.Flags().StringVar(&geoCountry, "country", "", "Only return locations in this country (ISO code, e.g. US)")
+	geotargetsSearchCmd.Flags().StringVar(&geoLocale, "locale", "en", "Language of the returned names, e.g. en, de, fr")
+
+	geotargetsCmd.AddCommand(geotargetsSearchCmd)
+	rootCmd.AddCommand(geotargetsCmd)
+}
@@ -1,10 +1,27 @@
 package cmd
 
 import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
 	"os/exec"
 	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/output"
+	"golang.org/x/term"
 )
 
+// streamFlag is shared by the list and insights commands that support --stream.
+var streamFlag bool
+
+// includeRemovedFlag is shared by the list and insights commands that
+// support --include-removed.
+var includeRemovedFlag bool
+
 // openBrowser opens a URL in the default system browser.
 func openBrowser(url string) {
 	switch runtime.GOOS {
@@ -16,3 +33,335 @@ func openBrowser(url string) {
 		exec.Command("open", url).Start() //nolint
 	}
 }
+
+// addStreamFlag registers --stream on c.
+func addStreamFlag(c *cobra.Command) {
+	c.Flags().BoolVar(&streamFlag, "stream", false, "Fetch rows with searchStream and print them as JSON lines as they arrive (constant memory, for large exports)")
+}
+
+// addIncludeRemovedFlag registers --include-removed on c.
+func addIncludeRemovedFlag(c *cobra.Command) {
+	c.Flags().BoolVar(&includeRemovedFlag, "include-removed", false, "Also show removed entities (dimmed in tables), e.g. to reconcile historical metrics")
+}
+
+// notRemoved returns the condition leaving out entities whose status field
+// is REMOVED, or nothing with --include-removed.
+func notRemoved(field string) string {
+	if includeRemovedFlag {
+		return ""
+	}
+	return field + " != 'REMOVED'"
+}
+
+// dimRemoved dims the cells of a table row whose entity status is REMOVED;
+// only --include-removed lets such rows through.
+func dimRemoved(status string, row []string) []string {
+	if status != "REMOVED" {
+		return row
+	}
+	for i, cell := range row {
+		row[i] = output.Dim(cell)
+	}
+	return row
+}
+
+// streamRows reports whether a command supporting --stream should stream:
+// with --stream, or with --jsonl, whose rows are printed as they arrive.
+// --quiet prints IDs from the buffered rows instead.
+func streamRows() bool {
+	return (streamFlag || output.IsJSONL()) && !output.IsQuiet()
+}
+
+// streamJSONL runs query with searchStream and writes each row, decoded into
+// T, to stdout as one JSON object per line. Rows that don't decode are skipped,
+// as in the buffered commands.
+func streamJSONL[T any](cid, query string) error {
+	w := bufio.NewWriter(output.Writer())
+	defer w.Flush()
+	enc := json.NewEncoder(w)
+	projection := output.NewProjection()
+	err := apiClient.SearchStream(cid, query, func(raw json.RawMessage) error {
+		var row T
+		if err := json.Unmarshal(raw, &row); err != nil {
+			return nil
+		}
+		if output.HasFormat() {
+			output.AddRows(1)
+			return output.ExecFormat(w, row)
+		}
+		projected, err := projection.Row(row)
+		if err != nil {
+			return err
+		}
+		output.AddRows(1)
+		return enc.Encode(projected)
+	})
+	if err != nil {
+		return err
+	}
+	// Rows are written as they arrive, so a --fields path is only known to
+	// be wrong once none of them had it.
+	return projection.Err()
+}
+
+// activeCmd is the command being run; it names the operation in mutation
+// results.
+var activeCmd *cobra.Command
+
+// mutateResult is the --json output of a mutating command.
+type mutateResult struct {
+	Operation string               `json:"operation"` // e.g. "campaigns pause"
+	DryRun    bool                 `json:"dryRun,omitempty"`
+	Succeeded int                  `json:"succeeded"`
+	Failed    int                  `json:"failed"`
+	Results   []mutatedResource    `json:"results"`
+	Errors    []api.OperationError `json:"errors,omitempty"`
+}
+
+// mutatedResource is a resource created, updated or removed by a mutation.
+type mutatedResource struct {
+	ResourceName string `json:"resourceName"`
+	ID           string `json:"id"`
+}
+
+// jsonResult reports whether a mutating command prints a mutateResult
+// instead of sentences: only when JSON is asked for, since scripts may
+// already parse the piped sentences.
+func jsonResult() bool {
+	return jsonFlag || prettyFlag || output.IsJSONL()
+}
+
+// newMutateResult collects the resource names and operation errors of
+// resps, skipping the empty results of failed operations.
+func newMutateResult(resps ...*api.MutateResponse) mutateResult {
+	res := mutateResult{Results: []mutatedResource{}}
+	if activeCmd != nil {
+		res.Operation = strings.TrimPrefix(activeCmd.CommandPath(), rootCmd.Name()+" ")
+	}
+	if apiClient != nil {
+		res.DryRun = apiClient.ValidateOnly()
+	}
+	for _, resp := range resps {
+		for _, r := range resp.Results {
+			if r.ResourceName != "" {
+				res.add(r.ResourceName)
+			}
+		}
+		res.Errors = append(res.Errors, resp.OperationErrors()...)
+	}
+	res.Failed = len(res.Errors)
+	return res
+}
+
+// add records a mutated resource.
+func (r *mutateResult) add(resourceName string) {
+	r.Results = append(r.Results, mutatedResource{ResourceName: resourceName, ID: api.ResourceID(resourceName)})
+	r.Succeeded++
+}
+
+// printMutateResult prints the result of n operations sent in resps. A dry
+// run returns no resource names, so there the operations that passed
+// validation count as succeeded.
+func printMutateResult(n int, resps ...*api.MutateResponse) error {
+	res := newMutateResult(resps...)
+	if res.DryRun {
+		res.Succeeded = n - res.Failed
+	}
+	return writeMutateResult(res)
+}
+
+// writeMutateResult prints res as JSON. Failed operations are returned as
+// an error, as by reportMutate, so the command exits non-zero.
+func writeMutateResult(res mutateResult) error {
+	if err := output.PrintJSON(res, prettyFlag); err != nil {
+		return err
+	}
+	if res.Failed > 0 {
+		return fmt.Errorf("%d operation(s) failed (%s)", res.Failed, formatOpErrors(res.Errors))
+	}
+	return nil
+}
+
+// reportMutate prints a per-operation summary of a batched mutate, e.g.
+// "42 created, 3 failed (op 7: DUPLICATE_KEYWORD, ...)". When any operation
+// failed the summary is returned as an error so the command exits non-zero.
+func reportMutate(resp *api.MutateResponse, verb string) error {
+	if jsonResult() {
+		return printMutateResult(len(resp.Results), resp)
+	}
+	summary := fmt.Sprintf("%d %s", resp.Succeeded(), verb)
+	opErrs := resp.OperationErrors()
+	if len(opErrs) == 0 {
+		fmt.Println(summary)
+		return nil
+	}
+	return fmt.Errorf("%s, %d failed (%s)", summary, len(opErrs), formatOpErrors(opErrs))
+}
+
+// reportDryRun prints the outcome of a validate-only mutate of n operations.
+// Validation errors reported through partial failure are returned as an error.
+func reportDryRun(resp *api.MutateResponse, n int) error {
+	if jsonResult() {
+		return printMutateResult(n, resp)
+	}
+	opErrs := resp.OperationErrors()
+	fmt.Printf("DRY RUN — would have applied %d operation(s)\n", n-len(opErrs))
+	if len(opErrs) > 0 {
+		return fmt.Errorf("%d operation(s) failed validation (%s)", len(opErrs), formatOpErrors(opErrs))
+	}
+	return nil
+}
+
+// formatOpErrors renders operation errors as "op 7: DUPLICATE_KEYWORD, ...".
+func formatOpErrors(opErrs []api.OperationError) string {
+	parts := make([]string, len(opErrs))
+	for i, e := range opErrs {
+		reason := e.Code
+		if reason == "" {
+			reason = e.Message
+		}
+		if e.Index >= 0 {
+			reason = fmt.Sprintf("op %d: %s", e.Index, reason)
+		}
+		parts[i] = reason
+	}
+	return strings.Join(parts, ", ")
+}
+
+// confirm asks a yes/no question on stderr and reads the answer from stdin.
+// Without a terminal there is no one to ask, so it fails and points at --yes.
+func confirm(question string) (bool, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return false, fmt.Errorf("confirmation required but stdin is not a terminal — re-run with --yes (or set GADS_ASSUME_YES=1)")
+	}
+	fmt.Fprintf(os.Stderr, "%s [y/N]: ", question)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false, fmt.Errorf("reading answer: %w", err)
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
+
+// assumeYes reports whether confirmations are skipped: --yes or
+// GADS_ASSUME_YES.
+func assumeYes() bool {
+	if yesFlag {
+		return true
+	}
+	switch strings.ToLower(os.Getenv("GADS_ASSUME_YES")) {
+	case "1", "true", "yes":
+		return true
+	}
+	return false
+}
+
+// updateOperation returns an operation updating resourceName with fields,
+// keyed by update mask path. Dotted paths set nested fields, e.g.
+// "networkSettings.targetSearchNetwork" → {"networkSettings":
+// {"targetSearchNetwork": ...}}.
+func updateOperation(resourceName string, fields map[string]any) map[string]any {
+	update := map[string]any{"resourceName": resourceName}
+	paths := sortedKeys(fields)
+	for _, path := range paths {
+		parts := strings.Split(path, ".")
+		m := update
+		for _, p := range parts[:len(parts)-1] {
+			next, ok := m[p].(map[string]any)
+			if !ok {
+				next = map[string]any{}
+				m[p] = next
+			}
+			m = next
+		}
+		m[parts[len(parts)-1]] = fields[path]
+	}
+	return map[string]any{
+		"updateMask": strings.Join(paths, ","),
+		"update":     update,
+	}
+}
+
+// fieldAt returns the value at an update mask path of an update, or nil.
+func fieldAt(update map[string]any, path string) any {
+	var v any = update
+	for _, p := range strings.Split(path, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[p]
+	}
+	return v
+}
+
+// confirmChange shows on stderr what a mutating command is about to change,
+// one line per entity (`Campaign 111 "Brand": ENABLED → PAUSED`), then asks
+// question. It returns true without asking under --yes, GADS_ASSUME_YES or
+// --dry-run, and prints "Aborted." when declined.
+func confirmChange(question string, changes ...string) (bool, error) {
+	if assumeYes() || apiClient.ValidateOnly() {
+		return true, nil
+	}
+	for _, c := range changes {
+		fmt.Fprintln(os.Stderr, "  "+c)
+	}
+	ok, err := confirm(question)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		fmt.Println("Aborted.")
+	}
+	return ok, nil
+}
+
+// checkExpectedAmount guards a read-modify-write of an amount in micros
+// (--expect-current): read returns the amount as it is now, and an error
+// says so when it is not expected, e.g. because someone else changed it
+// since. Call it right before the mutate, so nothing overwrites their change.
+func checkExpectedAmount(what string, expected int64, read func() (int64, error)) error {
+	current, err := read()
+	if err != nil {
+		return err
+	}
+	if current != expected {
+		return fmt.Errorf("%s is %s, not %s as expected by --expect-current: nothing was updated",
+			what, api.MicrosToCurrency(current), api.MicrosToCurrency(expected))
+	}
+	return nil
+}
+
+// currencies caches each account's currency code for the run.
+var currencies = map[string]string{}
+
+// currencyOf returns cid's currency code for formatting amounts, fetched
+// once per run. It is only looked up when numbers are formatted for people
+// and is empty when unavailable, so amounts fall back to plain numbers.
+func currencyOf(cid string) string {
+	if !api.DigitGrouping() {
+		return ""
+	}
+	code, ok := currencies[cid]
+	if !ok {
+		code, _ = accountCurrency(cid)
+		currencies[cid] = code
+	}
+	return code
+}
+
+// money formats micros in cid's currency for tables, e.g. "$5.00".
+func money(cid string, micros int64) string {
+	return api.FormatMoney(float64(micros), currencyOf(cid))
+}
+
+// orDash returns s, or "-" when s is empty, for table cells.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
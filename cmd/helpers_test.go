@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckExpectedAmount(t *testing.T) {
+	readErr := errors.New("network down")
+	tests := []struct {
+		name     string
+		expected int64
+		current  int64
+		readErr  error
+		wantErr  string
+	}{
+		{name: "unchanged", expected: 5_500_000, current: 5_500_000},
+		{name: "zero is a value", expected: 0, current: 0},
+		{name: "changed", expected: 5_500_000, current: 6_000_000, wantErr: "the budget is 6.00, not 5.50 as expected by --expect-current: nothing was updated"},
+		{name: "one micro off", expected: 5_500_000, current: 5_500_001, wantErr: "not 5.50 as expected"},
+		{name: "read fails", expected: 5_500_000, readErr: readErr, wantErr: "network down"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reads := 0
+			err := checkExpectedAmount("the budget", tt.expected, func() (int64, error) {
+				reads++
+				return tt.current, tt.readErr
+			})
+			if reads != 1 {
+				t.Errorf("read the amount %d times, want once", reads)
+			}
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error %v, want one containing %q", err, tt.wantErr)
+			}
+			if tt.readErr != nil && !errors.Is(err, tt.readErr) {
+				t.Errorf("error %v does not wrap the read error", err)
+			}
+		})
+	}
+}
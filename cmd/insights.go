@@ -1,14 +1,18 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/gaql"
 	"github.com/the20100/gads-cli/internal/output"
 )
 
@@ -17,18 +21,163 @@ var insightsCmd = &cobra.Command{
 	Short: "Performance reporting via GAQL",
 }
 
-var (
-	insightsAccount    string
-	insightsCampaignID string
-	insightsDays       int
-	insightsStart      string
-	insightsEnd        string
-	insightsPeriod     string
-	insightsAll        bool
-	insightsVerbose    bool
-	insightsPreset     string
-	insightsFields     string
-)
+// insightsFlags holds the flags of one insights subcommand. Each subcommand
+// has its own, so a value set for one never leaks into another.
+type insightsFlags struct {
+	campaignID string
+	days       int
+	start      string
+	end        string
+	period     string
+	all        bool
+	verbose    bool
+	preset     string
+	fields     string
+	watch      time.Duration
+	byDay      bool
+	network    string
+	// searchVolume adds Google's search volume to insights keywords.
+	searchVolume bool
+	labels       []string
+}
+
+// minWatchInterval keeps --watch from eating into the API quota.
+const minWatchInterval = 30 * time.Second
+
+// bind registers the flags shared by the insights subcommands with column
+// presets on c.
+func (f *insightsFlags) bind(c *cobra.Command) {
+	f.bindPeriod(c)
+	c.Flags().BoolVar(&f.all, "all", false, "Include rows with 0 impressions (default: only show rows with activity)")
+	c.Flags().BoolVar(&f.verbose, "verbose", false, "Print the GAQL query, raw row count and API request count for debugging")
+	c.Flags().StringVar(&f.preset, "preset", "default", "Column preset: default, performance, conversions, full (ads also supports: creatives)")
+	c.Flags().StringVar(&f.fields, "fields", "", "Comma-separated field IDs to display, overrides --preset (e.g. campaign_name,impressions,clicks,cost,roas)")
+	c.Flags().DurationVar(&f.watch, "watch", 0, "Re-run every interval (at least 30s), highlighting changed cells; Ctrl-C stops")
+	c.Flags().Lookup("watch").NoOptDefVal = "60s"
+	addStreamFlag(c)
+
+	run := c.RunE
+	c.RunE = func(cmd *cobra.Command, args []string) error {
+		if f.watch == 0 {
+			return run(cmd, args)
+		}
+		if f.watch < minWatchInterval {
+			return fmt.Errorf("--watch must be at least %s", minWatchInterval)
+		}
+		if output.IsJSON(cmd) || output.IsCSV() || output.IsMarkdown() || streamFlag {
+			return fmt.Errorf("--watch refreshes a table in the terminal; it cannot be combined with JSON, CSV, markdown, --stream or piped output")
+		}
+		return watchInsights(f.watch, func() error { return run(cmd, args) })
+	}
+}
+
+// bindPeriod registers the date range flags on c.
+func (f *insightsFlags) bindPeriod(c *cobra.Command) {
+	c.Flags().StringVar(&f.period, "period", "", "Preset period: last7d, last30d, lastWeek, currentWeek, lastMonth, currentMonth, lastYear, currentYear, 2025, last3m, 1y …")
+	c.Flags().IntVar(&f.days, "days", 30, "Number of days to look back (default 30, ignored when --period is set)")
+	c.Flags().StringVar(&f.start, "start", "", "Start date YYYY-MM-DD (overrides --days, ignored when --period is set)")
+	c.Flags().StringVar(&f.end, "end", "", "End date YYYY-MM-DD (overrides --days, ignored when --period is set)")
+}
+
+// bindNetwork registers --network on c.
+func (f *insightsFlags) bindNetwork(c *cobra.Command) {
+	c.Flags().StringVar(&f.network, "network", "", "Only show one network ("+strings.Join(sortedKeys(adNetworks), ", ")+"), or all to split rows by network")
+	c.RegisterFlagCompletionFunc("network", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return append(sortedKeys(adNetworks), "all"), cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// adNetworks maps the --network values to segments.ad_network_type values.
+var adNetworks = map[string]string{
+	"search":          "SEARCH",
+	"search-partners": "SEARCH_PARTNERS",
+	"display":         "CONTENT",
+	"youtube":         "YOUTUBE",
+	"google-tv":       "GOOGLE_TV",
+	"cross-network":   "MIXED",
+}
+
+// networkQuery turns --network (and a network column in --fields) into a
+// segments.ad_network_type condition and whether to select the segment.
+// The API enum names (SEARCH_PARTNERS, CONTENT, ...) are accepted as well.
+func (f *insightsFlags) networkQuery() (cond string, segment bool, err error) {
+	segment = slices.Contains(parseFieldList(f.fields), FidNetwork)
+	v := strings.ToLower(strings.TrimSpace(f.network))
+	if v == "" {
+		return "", segment, nil
+	}
+	if v == "all" {
+		return "", true, nil
+	}
+	for name, enum := range adNetworks {
+		if v == name || v == strings.ToLower(enum) {
+			return "segments.ad_network_type = '" + enum + "'", segment, nil
+		}
+	}
+	return "", false, fmt.Errorf("invalid --network %q: must be one of %s, or all", f.network, strings.Join(sortedKeys(adNetworks), ", "))
+}
+
+// formatNetwork writes the ad network of a row the way --network takes it,
+// "SEARCH_PARTNERS" → "search partners".
+func formatNetwork(s *api.NetworkSegments) string {
+	if s == nil || s.AdNetworkType == "" {
+		return "-"
+	}
+	for name, enum := range adNetworks {
+		if enum == s.AdNetworkType {
+			return strings.ReplaceAll(name, "-", " ")
+		}
+	}
+	return strings.ToLower(strings.ReplaceAll(s.AdNetworkType, "_", " "))
+}
+
+// withNetworkCol adds the network column to cols after the dimension
+// columns, unless --fields already placed it.
+func withNetworkCol[C any](cols []C, id func(C) string, network C) []C {
+	for i, c := range cols {
+		switch cid := id(c); {
+		case cid == FidNetwork:
+			return cols
+		case cid == FidROAS || strings.HasPrefix(FieldGAQL[cid], "metrics."):
+			return slices.Insert(cols, i, network)
+		}
+	}
+	return append(cols, network)
+}
+
+// watchInsights clears the screen and calls run every interval until
+// Ctrl-C. --timeout applies to each refresh rather than the whole session.
+func watchInsights(interval time.Duration, run func() error) error {
+	ctx := rootCmd.Context()
+	client := apiClient
+	output.SetHighlightChanges(true)
+	for {
+		refreshCtx, cancel := ctx, context.CancelFunc(func() {})
+		if timeoutFlag > 0 {
+			refreshCtx, cancel = context.WithTimeout(ctx, timeoutFlag)
+		}
+		apiClient = client.WithContext(refreshCtx)
+		fmt.Print("\x1b[H\x1b[2J")
+		fmt.Printf("Every %s · updated %s · Ctrl-C to stop\n\n", interval, time.Now().Format("15:04:05"))
+		err := run()
+		cancel()
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// insightsCurrency is the account's currency code for the money columns of
+// the insights tables.
+var insightsCurrency string
 
 // parsePeriod converts a period shorthand to (start, end) date strings (YYYY-MM-DD).
 //
@@ -127,26 +276,32 @@ func parsePeriod(period string) (start, end string) {
 func buildDateRange(period string, days int, start, end string) string {
 	if period != "" {
 		if s, e := parsePeriod(period); s != "" {
-			return fmt.Sprintf("segments.date BETWEEN '%s' AND '%s'", s, e)
+			return dateBetween(s, e)
 		}
 	}
 	if start != "" && end != "" {
-		return fmt.Sprintf("segments.date BETWEEN '%s' AND '%s'", start, end)
+		return dateBetween(start, end)
 	}
 	if days <= 0 {
 		days = 30
 	}
 	endDate := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
 	startDate := time.Now().AddDate(0, 0, -days).Format("2006-01-02")
-	return fmt.Sprintf("segments.date BETWEEN '%s' AND '%s'", startDate, endDate)
+	return dateBetween(startDate, endDate)
+}
+
+func dateBetween(start, end string) string {
+	return "segments.date BETWEEN " + gaql.Quote(start) + " AND " + gaql.Quote(end)
 }
 
 // ---- insights campaigns ----
 
-var insightsCampaignsCmd = &cobra.Command{
-	Use:   "campaigns",
-	Short: "Campaign performance: impressions, clicks, cost, CTR, CPC, conversions, ROAS",
-	Long: `Show campaign performance metrics for a given date range.
+func newInsightsCampaignsCmd() *cobra.Command {
+	var f insightsFlags
+	c := &cobra.Command{
+		Use:   "campaigns",
+		Short: "Campaign performance: impressions, clicks, cost, CTR, CPC, conversions, ROAS",
+		Long: `Show campaign performance metrics for a given date range.
 
 Presets (--preset):
   default     Campaign name, status, impressions, clicks, cost, CTR, CPC, conversions, ROAS
@@ -154,8 +309,17 @@ Presets (--preset):
   conversions Focus on conversions, value, view-through, conv rate, cost/conv, ROAS
   full        All available fields
 
+Networks (--network):
+  search, search-partners, display, youtube, google-tv, cross-network
+              Only count that network
+  all         One row per network, with a NETWORK column
+
+--label (name or ID, repeatable) only reports the campaigns carrying one of
+the labels.
+
 Field IDs for --fields (comma-separated):
   Dimensions: campaign_id, campaign_name, campaign_status, campaign_type
+  Segments:   network (same as --network=all)
   Metrics:    impressions, clicks, cost, ctr, cpc, conversions, conv_value, roas,
               abs_top_imp_pct, top_imp_pct, view_through_conv, conv_rate, cost_per_conv,
               search_imp_share
@@ -166,84 +330,114 @@ Examples:
   gads-cli insights campaigns --account=1234567890 --period=2025 --preset=conversions
   gads-cli insights campaigns --account=1234567890 --start=2024-01-01 --end=2024-01-31
   gads-cli insights campaigns --account=1234567890 --days=7 --fields=campaign_name,impressions,clicks,cost,roas
-  gads-cli insights campaigns --account=1234567890 --days=7 --json`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		if insightsAccount == "" {
-			return fmt.Errorf("--account is required")
-		}
-		cid := api.CleanCustomerID(insightsAccount)
-		dateFilter := buildDateRange(insightsPeriod, insightsDays, insightsStart, insightsEnd)
+  gads-cli insights campaigns --account=1234567890 --days=7 --json
+  gads-cli insights campaigns --account=1234567890 --period=last30d --network=search
+  gads-cli insights campaigns --account=1234567890 --period=last30d --network=all
+  gads-cli insights campaigns --account=1234567890 --period=last30d --label="Q4 promo"`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			networkFilter, byNetwork, err := f.networkQuery()
+			if err != nil {
+				return err
+			}
+			dateFilter := buildDateRange(f.period, f.days, f.start, f.end)
+			labelFilter, err := campaignLabelFilter(apiClient, cid, f.labels)
+			if err != nil {
+				return err
+			}
 
-		impressionsFilter := ""
-		if !insightsAll {
-			impressionsFilter = "\n		  AND metrics.impressions > 0"
-		}
-		query := fmt.Sprintf(`SELECT
-			campaign.id, campaign.name, campaign.status, campaign.advertising_channel_type,
-			metrics.impressions, metrics.clicks, metrics.cost_micros,
-			metrics.ctr, metrics.average_cpc,
-			metrics.conversions, metrics.conversions_value,
-			metrics.absolute_top_impression_percentage, metrics.top_impression_percentage,
-			metrics.view_through_conversions, metrics.cost_per_conversion,
-			metrics.conversions_from_interactions_rate, metrics.search_impression_share
-		FROM campaign
-		WHERE %s
-		  AND campaign.status != 'REMOVED'%s
-		ORDER BY metrics.cost_micros DESC`, dateFilter, impressionsFilter)
-
-		if insightsVerbose {
-			fmt.Printf("[verbose] account: %s\n[verbose] query:\n%s\n\n", cid, query)
-		}
-		rows, err := apiClient.Search(cid, query)
-		if err != nil {
-			return err
-		}
-		if insightsVerbose {
-			fmt.Printf("[verbose] API returned %d raw rows\n\n", len(rows))
-		}
+			fields := []string{
+				"campaign.id", "campaign.name", "campaign.status", "campaign.advertising_channel_type",
+				"metrics.impressions", "metrics.clicks", "metrics.cost_micros",
+				"metrics.ctr", "metrics.average_cpc",
+				"metrics.conversions", "metrics.conversions_value",
+				"metrics.absolute_top_impression_percentage", "metrics.top_impression_percentage",
+				"metrics.view_through_conversions", "metrics.cost_per_conversion",
+				"metrics.conversions_from_interactions_rate", "metrics.search_impression_share",
+			}
+			if byNetwork {
+				fields = append(fields, "segments.ad_network_type")
+			}
+			query := gaql.Select(fields...).
+				From("campaign").
+				Where(dateFilter).
+				Where(notRemoved("campaign.status")).
+				Where(networkFilter).
+				Where(labelFilter).
+				WhereIf(!f.all, "metrics.impressions > 0").
+				OrderBy("metrics.cost_micros", true).
+				String()
+
+			if f.verbose {
+				fmt.Printf("[verbose] account: %s\n[verbose] query:\n%s\n\n", cid, query)
+			}
+			if streamRows() {
+				return streamJSONL[api.InsightsCampaignRow](cid, query)
+			}
+			rows, err := apiClient.Search(cid, query)
+			if err != nil {
+				return err
+			}
+			if f.verbose {
+				fmt.Printf("[verbose] API returned %d raw rows\n\n", len(rows))
+			}
 
-		var results []api.InsightsCampaignRow
-		for _, raw := range rows {
-			var row api.InsightsCampaignRow
-			if err := json.Unmarshal(raw, &row); err != nil {
-				if insightsVerbose {
-					fmt.Printf("[verbose] unmarshal error: %v\nraw: %s\n", err, string(raw))
+			var results []api.InsightsCampaignRow
+			for _, raw := range rows {
+				var row api.InsightsCampaignRow
+				if err := json.Unmarshal(raw, &row); err != nil {
+					if f.verbose {
+						fmt.Printf("[verbose] unmarshal error: %v\nraw: %s\n", err, string(raw))
+					}
+					continue
 				}
-				continue
+				results = append(results, row)
 			}
-			results = append(results, row)
-		}
 
-		if output.IsJSON(cmd) {
-			return output.PrintJSON(results, output.IsPretty(cmd))
-		}
-		if len(results) == 0 {
-			fmt.Println("No campaign data found for the specified period.")
-			return nil
-		}
+			if output.IsJSON(cmd) {
+				return output.PrintJSON(results, output.IsPretty(cmd))
+			}
+			if len(results) == 0 {
+				fmt.Println("No campaign data found for the specified period.")
+				return nil
+			}
 
-		cols := resolveCampaignCols(insightsPreset, insightsFields)
-		headers := campaignHeaders(cols)
-		tableRows := make([][]string, len(results))
-		for i, r := range results {
-			r := r
-			row := make([]string, len(cols))
-			for j, col := range cols {
-				row[j] = col.Format(&r)
+			insightsCurrency = currencyOf(cid)
+			cols := resolveCampaignCols(f.preset, f.fields)
+			if byNetwork {
+				cols = withNetworkCol(cols, func(c CampaignCol) string { return c.ID }, campaignColByID[FidNetwork])
 			}
-			tableRows[i] = row
-		}
-		output.PrintTable(headers, tableRows)
-		return nil
-	},
+			headers := campaignHeaders(cols)
+			tableRows := make([][]string, len(results))
+			for i, r := range results {
+				r := r
+				row := make([]string, len(cols))
+				for j, col := range cols {
+					row[j] = col.Format(&r)
+				}
+				tableRows[i] = dimRemoved(r.Campaign.Status, row)
+			}
+			return output.PrintTable(headers, tableRows)
+		},
+	}
+	f.bind(c)
+	f.bindNetwork(c)
+	addIncludeRemovedFlag(c)
+	addCampaignLabelFlag(c, &f.labels)
+	return c
 }
 
 // ---- insights adgroups ----
 
-var insightsAdGroupsCmd = &cobra.Command{
-	Use:   "adgroups",
-	Short: "Ad group performance metrics",
-	Long: `Show ad group performance metrics for a given date range.
+func newInsightsAdGroupsCmd() *cobra.Command {
+	var f insightsFlags
+	c := &cobra.Command{
+		Use:   "adgroups",
+		Short: "Ad group performance metrics",
+		Long: `Show ad group performance metrics for a given date range.
 
 Presets (--preset):
   default     Ad group name, status, impressions, clicks, cost, CTR, CPC, conversions, ROAS
@@ -261,85 +455,94 @@ Examples:
   gads-cli insights adgroups --account=1234567890 --campaign=111222333 --days=30
   gads-cli insights adgroups --account=1234567890 --campaign=111222333 --preset=performance
   gads-cli insights adgroups --account=1234567890 --campaign=111222333 --start=2024-01-01 --end=2024-01-31`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		if insightsAccount == "" {
-			return fmt.Errorf("--account is required")
-		}
-		if insightsCampaignID == "" {
-			return fmt.Errorf("--campaign is required")
-		}
-		cid := api.CleanCustomerID(insightsAccount)
-		dateFilter := buildDateRange(insightsPeriod, insightsDays, insightsStart, insightsEnd)
-
-		impressionsFilter := ""
-		if !insightsAll {
-			impressionsFilter = "\n		  AND metrics.impressions > 0"
-		}
-		query := fmt.Sprintf(`SELECT
-			campaign.id, campaign.name,
-			ad_group.id, ad_group.name, ad_group.status,
-			metrics.impressions, metrics.clicks, metrics.cost_micros,
-			metrics.ctr, metrics.average_cpc,
-			metrics.conversions, metrics.conversions_value,
-			metrics.absolute_top_impression_percentage, metrics.top_impression_percentage,
-			metrics.view_through_conversions, metrics.cost_per_conversion,
-			metrics.conversions_from_interactions_rate, metrics.search_impression_share
-		FROM ad_group
-		WHERE %s
-		  AND campaign.id = '%s'
-		  AND ad_group.status != 'REMOVED'%s
-		ORDER BY metrics.cost_micros DESC`, dateFilter, insightsCampaignID, impressionsFilter)
-
-		if insightsVerbose {
-			fmt.Printf("[verbose] account: %s\n[verbose] query:\n%s\n\n", cid, query)
-		}
-		rows, err := apiClient.Search(cid, query)
-		if err != nil {
-			return err
-		}
-		if insightsVerbose {
-			fmt.Printf("[verbose] API returned %d raw rows\n\n", len(rows))
-		}
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			if !api.IsNumericID(f.campaignID) {
+				return fmt.Errorf("--campaign must be a numeric ID")
+			}
+			dateFilter := buildDateRange(f.period, f.days, f.start, f.end)
+
+			query := gaql.Select(
+				"campaign.id", "campaign.name",
+				"ad_group.id", "ad_group.name", "ad_group.status",
+				"metrics.impressions", "metrics.clicks", "metrics.cost_micros",
+				"metrics.ctr", "metrics.average_cpc",
+				"metrics.conversions", "metrics.conversions_value",
+				"metrics.absolute_top_impression_percentage", "metrics.top_impression_percentage",
+				"metrics.view_through_conversions", "metrics.cost_per_conversion",
+				"metrics.conversions_from_interactions_rate", "metrics.search_impression_share").
+				From("ad_group").
+				Where(dateFilter).
+				Where("campaign.id = "+gaql.Quote(f.campaignID)).
+				Where(notRemoved("ad_group.status")).
+				WhereIf(!f.all, "metrics.impressions > 0").
+				OrderBy("metrics.cost_micros", true).
+				String()
+
+			if f.verbose {
+				fmt.Printf("[verbose] account: %s\n[verbose] query:\n%s\n\n", cid, query)
+			}
+			if streamRows() {
+				return streamJSONL[api.InsightsAdGroupRow](cid, query)
+			}
+			rows, err := apiClient.Search(cid, query)
+			if err != nil {
+				return err
+			}
+			if f.verbose {
+				fmt.Printf("[verbose] API returned %d raw rows\n\n", len(rows))
+			}
 
-		var results []api.InsightsAdGroupRow
-		for _, raw := range rows {
-			var row api.InsightsAdGroupRow
-			if err := json.Unmarshal(raw, &row); err != nil {
-				continue
+			var results []api.InsightsAdGroupRow
+			for _, raw := range rows {
+				var row api.InsightsAdGroupRow
+				if err := json.Unmarshal(raw, &row); err != nil {
+					continue
+				}
+				results = append(results, row)
 			}
-			results = append(results, row)
-		}
 
-		if output.IsJSON(cmd) {
-			return output.PrintJSON(results, output.IsPretty(cmd))
-		}
-		if len(results) == 0 {
-			fmt.Println("No ad group data found for the specified period.")
-			return nil
-		}
+			if output.IsJSON(cmd) {
+				return output.PrintJSON(results, output.IsPretty(cmd))
+			}
+			if len(results) == 0 {
+				fmt.Println("No ad group data found for the specified period.")
+				return nil
+			}
 
-		cols := resolveAdGroupCols(insightsPreset, insightsFields)
-		headers := adGroupHeaders(cols)
-		tableRows := make([][]string, len(results))
-		for i, r := range results {
-			r := r
-			row := make([]string, len(cols))
-			for j, col := range cols {
-				row[j] = col.Format(&r)
+			insightsCurrency = currencyOf(cid)
+			cols := resolveAdGroupCols(f.preset, f.fields)
+			headers := adGroupHeaders(cols)
+			tableRows := make([][]string, len(results))
+			for i, r := range results {
+				r := r
+				row := make([]string, len(cols))
+				for j, col := range cols {
+					row[j] = col.Format(&r)
+				}
+				tableRows[i] = dimRemoved(r.AdGroup.Status, row)
 			}
-			tableRows[i] = row
-		}
-		output.PrintTable(headers, tableRows)
-		return nil
-	},
+			return output.PrintTable(headers, tableRows)
+		},
+	}
+	f.bind(c)
+	addIncludeRemovedFlag(c)
+	c.Flags().StringVar(&f.campaignID, "campaign", "", "Campaign ID (required)")
+	c.MarkFlagRequired("campaign")
+	return c
 }
 
 // ---- insights keywords ----
 
-var insightsKeywordsCmd = &cobra.Command{
-	Use:   "keywords",
-	Short: "Keyword performance metrics",
-	Long: `Show keyword performance metrics for a given date range.
+func newInsightsKeywordsCmd() *cobra.Command {
+	var f insightsFlags
+	c := &cobra.Command{
+		Use:   "keywords",
+		Short: "Keyword performance metrics",
+		Long: `Show keyword performance metrics for a given date range.
 
 Presets (--preset):
   default     Keyword text, match, status, impressions, clicks, cost, CTR, CPC, conversions, quality score
@@ -347,98 +550,146 @@ Presets (--preset):
   conversions Focus on conversions, value, conv rate, cost/conv, ROAS
   full        All available fields
 
+Networks (--network):
+  search, search-partners, display, youtube, google-tv, cross-network
+              Only count that network
+  all         One row per network, with a NETWORK column
+
+--include-search-volume adds each keyword's average monthly searches on
+Google and its advertiser competition (LOW, MEDIUM, HIGH) over the last
+twelve months, from Keyword Planner: the market beside the impressions the
+keyword got. Keywords Google has no data for show "-".
+
 Field IDs for --fields (comma-separated):
   Dimensions: keyword_text, keyword_match, keyword_status, quality_score,
               campaign_name, adgroup_name
+  Segments:   network (same as --network=all)
   Metrics:    impressions, clicks, cost, ctr, cpc, conversions, conv_value, roas,
               abs_top_imp_pct, top_imp_pct, view_through_conv, conv_rate, cost_per_conv,
               search_imp_share
 
 Examples:
   gads-cli insights keywords --account=1234567890 --campaign=111222333 --days=30
-  gads-cli insights keywords --account=1234567890 --campaign=111222333 --preset=performance`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		if insightsAccount == "" {
-			return fmt.Errorf("--account is required")
-		}
-		if insightsCampaignID == "" {
-			return fmt.Errorf("--campaign is required")
-		}
-		cid := api.CleanCustomerID(insightsAccount)
-		dateFilter := buildDateRange(insightsPeriod, insightsDays, insightsStart, insightsEnd)
-
-		impressionsFilter := ""
-		if !insightsAll {
-			impressionsFilter = "\n		  AND metrics.impressions > 0"
-		}
-		query := fmt.Sprintf(`SELECT
-			ad_group_criterion.keyword.text,
-			ad_group_criterion.keyword.match_type,
-			ad_group_criterion.status,
-			ad_group_criterion.quality_info.quality_score,
-			ad_group.id, ad_group.name, campaign.id, campaign.name,
-			metrics.impressions, metrics.clicks, metrics.cost_micros,
-			metrics.ctr, metrics.average_cpc,
-			metrics.conversions, metrics.conversions_value,
-			metrics.absolute_top_impression_percentage, metrics.top_impression_percentage,
-			metrics.view_through_conversions, metrics.cost_per_conversion,
-			metrics.conversions_from_interactions_rate, metrics.search_impression_share
-		FROM keyword_view
-		WHERE %s
-		  AND campaign.id = '%s'
-		  AND ad_group_criterion.status != 'REMOVED'%s
-		ORDER BY metrics.cost_micros DESC`, dateFilter, insightsCampaignID, impressionsFilter)
-
-		if insightsVerbose {
-			fmt.Printf("[verbose] account: %s\n[verbose] query:\n%s\n\n", cid, query)
-		}
-		rows, err := apiClient.Search(cid, query)
-		if err != nil {
-			return err
-		}
-		if insightsVerbose {
-			fmt.Printf("[verbose] API returned %d raw rows\n\n", len(rows))
-		}
+  gads-cli insights keywords --account=1234567890 --campaign=111222333 --preset=performance
+  gads-cli insights keywords --account=1234567890 --campaign=111222333 --network=all
+  gads-cli insights keywords --account=1234567890 --campaign=111222333 --include-search-volume`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			if !api.IsNumericID(f.campaignID) {
+				return fmt.Errorf("--campaign must be a numeric ID")
+			}
+			if f.searchVolume && streamFlag {
+				return fmt.Errorf("--include-search-volume cannot be used with --stream: search volumes are added once every row is fetched")
+			}
+			networkFilter, byNetwork, err := f.networkQuery()
+			if err != nil {
+				return err
+			}
+			dateFilter := buildDateRange(f.period, f.days, f.start, f.end)
+
+			fields := []string{
+				"ad_group_criterion.keyword.text",
+				"ad_group_criterion.keyword.match_type",
+				"ad_group_criterion.status",
+				"ad_group_criterion.quality_info.quality_score",
+				"ad_group.id", "ad_group.name", "campaign.id", "campaign.name",
+				"metrics.impressions", "metrics.clicks", "metrics.cost_micros",
+				"metrics.ctr", "metrics.average_cpc",
+				"metrics.conversions", "metrics.conversions_value",
+				"metrics.absolute_top_impression_percentage", "metrics.top_impression_percentage",
+				"metrics.view_through_conversions", "metrics.cost_per_conversion",
+				"metrics.conversions_from_interactions_rate", "metrics.search_impression_share",
+			}
+			if byNetwork {
+				fields = append(fields, "segments.ad_network_type")
+			}
+			query := gaql.Select(fields...).
+				From("keyword_view").
+				Where(dateFilter).
+				Where("campaign.id = "+gaql.Quote(f.campaignID)).
+				Where(notRemoved("ad_group_criterion.status")).
+				Where(networkFilter).
+				WhereIf(!f.all, "metrics.impressions > 0").
+				OrderBy("metrics.cost_micros", true).
+				String()
+
+			if f.verbose {
+				fmt.Printf("[verbose] account: %s\n[verbose] query:\n%s\n\n", cid, query)
+			}
+			if streamRows() && !f.searchVolume {
+				return streamJSONL[api.InsightsKeywordRow](cid, query)
+			}
+			rows, err := apiClient.Search(cid, query)
+			if err != nil {
+				return err
+			}
+			if f.verbose {
+				fmt.Printf("[verbose] API returned %d raw rows\n\n", len(rows))
+			}
 
-		var results []api.InsightsKeywordRow
-		for _, raw := range rows {
-			var row api.InsightsKeywordRow
-			if err := json.Unmarshal(raw, &row); err != nil {
-				continue
+			var results []api.InsightsKeywordRow
+			for _, raw := range rows {
+				var row api.InsightsKeywordRow
+				if err := json.Unmarshal(raw, &row); err != nil {
+					continue
+				}
+				results = append(results, row)
+			}
+			if f.searchVolume && len(results) > 0 {
+				if err := addSearchVolume(cmd.Context(), cid, results); err != nil {
+					return err
+				}
 			}
-			results = append(results, row)
-		}
 
-		if output.IsJSON(cmd) {
-			return output.PrintJSON(results, output.IsPretty(cmd))
-		}
-		if len(results) == 0 {
-			fmt.Println("No keyword data found for the specified period.")
-			return nil
-		}
+			if output.IsJSON(cmd) {
+				return output.PrintJSON(results, output.IsPretty(cmd))
+			}
+			if len(results) == 0 {
+				fmt.Println("No keyword data found for the specified period.")
+				return nil
+			}
 
-		cols := resolveKeywordCols(insightsPreset, insightsFields)
-		headers := keywordHeaders(cols)
-		tableRows := make([][]string, len(results))
-		for i, r := range results {
-			r := r
-			row := make([]string, len(cols))
-			for j, col := range cols {
-				row[j] = col.Format(&r)
+			insightsCurrency = currencyOf(cid)
+			cols := resolveKeywordCols(f.preset, f.fields)
+			if byNetwork {
+				cols = withNetworkCol(cols, func(c KeywordCol) string { return c.ID }, keywordColByID[FidNetwork])
 			}
-			tableRows[i] = row
-		}
-		output.PrintTable(headers, tableRows)
-		return nil
-	},
+			if f.searchVolume {
+				cols = append(cols, searchVolumeCols...)
+			}
+			headers := keywordHeaders(cols)
+			tableRows := make([][]string, len(results))
+			for i, r := range results {
+				r := r
+				row := make([]string, len(cols))
+				for j, col := range cols {
+					row[j] = col.Format(&r)
+				}
+				tableRows[i] = dimRemoved(r.AdGroupCriterion.Status, row)
+			}
+			return output.PrintTable(headers, tableRows)
+		},
+	}
+	f.bind(c)
+	f.bindNetwork(c)
+	addIncludeRemovedFlag(c)
+	c.Flags().StringVar(&f.campaignID, "campaign", "", "Campaign ID (required)")
+	c.MarkFlagRequired("campaign")
+	c.Flags().BoolVar(&f.searchVolume, "include-search-volume", false, "Add average monthly searches and competition from Keyword Planner")
+	return c
 }
 
 // ---- insights search-terms ----
 
-var insightsSearchTermsCmd = &cobra.Command{
-	Use:   "search-terms",
-	Short: "Search terms report",
-	Long: `Show the search terms that triggered your ads.
+func newInsightsSearchTermsCmd() *cobra.Command {
+	var f insightsFlags
+	c := &cobra.Command{
+		Use:   "search-terms",
+		Short: "Search terms report",
+		Long: `Show the search terms that triggered your ads.
 
 Presets (--preset):
   default     Search term, status, ad group, impressions, clicks, cost, CTR, conversions
@@ -454,78 +705,89 @@ Field IDs for --fields (comma-separated):
 Examples:
   gads-cli insights search-terms --account=1234567890 --campaign=111222333 --days=30
   gads-cli insights search-terms --account=1234567890 --campaign=111222333 --preset=performance`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		if insightsAccount == "" {
-			return fmt.Errorf("--account is required")
-		}
-		if insightsCampaignID == "" {
-			return fmt.Errorf("--campaign is required")
-		}
-		cid := api.CleanCustomerID(insightsAccount)
-		dateFilter := buildDateRange(insightsPeriod, insightsDays, insightsStart, insightsEnd)
-
-		query := fmt.Sprintf(`SELECT
-			search_term_view.search_term, search_term_view.status,
-			campaign.id, campaign.name, ad_group.id, ad_group.name,
-			metrics.impressions, metrics.clicks, metrics.cost_micros, metrics.ctr,
-			metrics.average_cpc, metrics.conversions, metrics.conversions_value,
-			metrics.view_through_conversions, metrics.cost_per_conversion,
-			metrics.conversions_from_interactions_rate
-		FROM search_term_view
-		WHERE %s
-		  AND campaign.id = '%s'
-		ORDER BY metrics.impressions DESC`, dateFilter, insightsCampaignID)
-
-		if insightsVerbose {
-			fmt.Printf("[verbose] account: %s\n[verbose] query:\n%s\n\n", cid, query)
-		}
-		rows, err := apiClient.Search(cid, query)
-		if err != nil {
-			return err
-		}
-		if insightsVerbose {
-			fmt.Printf("[verbose] API returned %d raw rows\n\n", len(rows))
-		}
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			if !api.IsNumericID(f.campaignID) {
+				return fmt.Errorf("--campaign must be a numeric ID")
+			}
+			dateFilter := buildDateRange(f.period, f.days, f.start, f.end)
+
+			query := gaql.Select(
+				"search_term_view.search_term", "search_term_view.status",
+				"campaign.id", "campaign.name", "ad_group.id", "ad_group.name",
+				"metrics.impressions", "metrics.clicks", "metrics.cost_micros", "metrics.ctr",
+				"metrics.average_cpc", "metrics.conversions", "metrics.conversions_value",
+				"metrics.view_through_conversions", "metrics.cost_per_conversion",
+				"metrics.conversions_from_interactions_rate").
+				From("search_term_view").
+				Where(dateFilter).
+				Where("campaign.id = "+gaql.Quote(f.campaignID)).
+				OrderBy("metrics.impressions", true).
+				String()
+
+			if f.verbose {
+				fmt.Printf("[verbose] account: %s\n[verbose] query:\n%s\n\n", cid, query)
+			}
+			if streamRows() {
+				return streamJSONL[api.SearchTermRow](cid, query)
+			}
+			rows, err := apiClient.Search(cid, query)
+			if err != nil {
+				return err
+			}
+			if f.verbose {
+				fmt.Printf("[verbose] API returned %d raw rows\n\n", len(rows))
+			}
 
-		var results []api.SearchTermRow
-		for _, raw := range rows {
-			var row api.SearchTermRow
-			if err := json.Unmarshal(raw, &row); err != nil {
-				continue
+			var results []api.SearchTermRow
+			for _, raw := range rows {
+				var row api.SearchTermRow
+				if err := json.Unmarshal(raw, &row); err != nil {
+					continue
+				}
+				results = append(results, row)
 			}
-			results = append(results, row)
-		}
 
-		if output.IsJSON(cmd) {
-			return output.PrintJSON(results, output.IsPretty(cmd))
-		}
-		if len(results) == 0 {
-			fmt.Println("No search term data found for the specified period.")
-			return nil
-		}
+			if output.IsJSON(cmd) {
+				return output.PrintJSON(results, output.IsPretty(cmd))
+			}
+			if len(results) == 0 {
+				fmt.Println("No search term data found for the specified period.")
+				return nil
+			}
 
-		cols := resolveSearchTermCols(insightsPreset, insightsFields)
-		headers := searchTermHeaders(cols)
-		tableRows := make([][]string, len(results))
-		for i, r := range results {
-			r := r
-			row := make([]string, len(cols))
-			for j, col := range cols {
-				row[j] = col.Format(&r)
+			insightsCurrency = currencyOf(cid)
+			cols := resolveSearchTermCols(f.preset, f.fields)
+			headers := searchTermHeaders(cols)
+			tableRows := make([][]string, len(results))
+			for i, r := range results {
+				r := r
+				row := make([]string, len(cols))
+				for j, col := range cols {
+					row[j] = col.Format(&r)
+				}
+				tableRows[i] = row
 			}
-			tableRows[i] = row
-		}
-		output.PrintTable(headers, tableRows)
-		return nil
-	},
+			return output.PrintTable(headers, tableRows)
+		},
+	}
+	f.bind(c)
+	c.Flags().StringVar(&f.campaignID, "campaign", "", "Campaign ID (required)")
+	c.MarkFlagRequired("campaign")
+	return c
 }
 
 // ---- insights ads ----
 
-var insightsAdsCmd = &cobra.Command{
-	Use:   "ads",
-	Short: "Ad-level performance with creative details (RSA headlines, descriptions, URLs)",
-	Long: `Show ad-level performance metrics and creative details for a given date range.
+func newInsightsAdsCmd() *cobra.Command {
+	var f insightsFlags
+	c := &cobra.Command{
+		Use:   "ads",
+		Short: "Ad-level performance with creative details (RSA headlines, descriptions, URLs)",
+		Long: `Show ad-level performance metrics and creative details for a given date range.
 
 Presets (--preset):
   default     Campaign, ad group, ad name, status, type, final URL + core metrics
@@ -550,126 +812,249 @@ Examples:
   gads-cli insights ads --account=1234567890 --campaign=111222333 --preset=performance --period=last30d
   gads-cli insights ads --account=1234567890 --days=7 --fields=campaign_name,ad_name,headline1,headline2,headline3,desc1,desc2
   gads-cli insights ads --account=1234567890 --days=30 --json`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		if insightsAccount == "" {
-			return fmt.Errorf("--account is required")
-		}
-		cid := api.CleanCustomerID(insightsAccount)
-		dateFilter := buildDateRange(insightsPeriod, insightsDays, insightsStart, insightsEnd)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			dateFilter := buildDateRange(f.period, f.days, f.start, f.end)
 
-		impressionsFilter := ""
-		if !insightsAll {
-			impressionsFilter = "\n		  AND metrics.impressions > 0"
-		}
-		campaignFilter := ""
-		if insightsCampaignID != "" {
-			campaignFilter = fmt.Sprintf("\n		  AND campaign.id = '%s'", insightsCampaignID)
-		}
+			if f.campaignID != "" && !api.IsNumericID(f.campaignID) {
+				return fmt.Errorf("--campaign must be a numeric ID")
+			}
 
-		query := fmt.Sprintf(`SELECT
-			ad_group_ad.ad.id, ad_group_ad.ad.name,
-			ad_group_ad.status, ad_group_ad.ad.type,
-			ad_group_ad.ad.final_urls, ad_group_ad.ad.final_mobile_urls,
-			ad_group_ad.ad.tracking_url_template, ad_group_ad.ad.final_url_suffix,
-			ad_group_ad.ad.display_url,
-			ad_group_ad.ad.responsive_search_ad.headlines,
-			ad_group_ad.ad.responsive_search_ad.descriptions,
-			ad_group_ad.ad.responsive_search_ad.path1,
-			ad_group_ad.ad.responsive_search_ad.path2,
-			ad_group_ad.ad.expanded_text_ad.headline_part1,
-			ad_group_ad.ad.expanded_text_ad.headline_part2,
-			ad_group_ad.ad.expanded_text_ad.headline_part3,
-			ad_group_ad.ad.expanded_text_ad.description,
-			ad_group_ad.ad.expanded_text_ad.description2,
-			ad_group.id, ad_group.name,
-			campaign.id, campaign.name, campaign.status,
-			metrics.impressions, metrics.clicks, metrics.cost_micros,
-			metrics.ctr, metrics.average_cpc,
-			metrics.conversions, metrics.conversions_value,
-			metrics.absolute_top_impression_percentage, metrics.top_impression_percentage,
-			metrics.view_through_conversions, metrics.cost_per_conversion,
-			metrics.conversions_from_interactions_rate, metrics.search_impression_share
-		FROM ad_group_ad
-		WHERE %s
-		  AND ad_group_ad.status != 'REMOVED'%s%s
-		ORDER BY metrics.cost_micros DESC`, dateFilter, campaignFilter, impressionsFilter)
-
-		if insightsVerbose {
-			fmt.Printf("[verbose] account: %s\n[verbose] query:\n%s\n\n", cid, query)
-		}
-		rows, err := apiClient.Search(cid, query)
-		if err != nil {
-			return err
-		}
-		if insightsVerbose {
-			fmt.Printf("[verbose] API returned %d raw rows\n\n", len(rows))
-		}
+			query := gaql.Select(
+				"ad_group_ad.ad.id", "ad_group_ad.ad.name",
+				"ad_group_ad.status", "ad_group_ad.ad.type",
+				"ad_group_ad.ad.final_urls", "ad_group_ad.ad.final_mobile_urls",
+				"ad_group_ad.ad.tracking_url_template", "ad_group_ad.ad.final_url_suffix",
+				"ad_group_ad.ad.display_url",
+				"ad_group_ad.ad.responsive_search_ad.headlines",
+				"ad_group_ad.ad.responsive_search_ad.descriptions",
+				"ad_group_ad.ad.responsive_search_ad.path1",
+				"ad_group_ad.ad.responsive_search_ad.path2",
+				"ad_group_ad.ad.expanded_text_ad.headline_part1",
+				"ad_group_ad.ad.expanded_text_ad.headline_part2",
+				"ad_group_ad.ad.expanded_text_ad.headline_part3",
+				"ad_group_ad.ad.expanded_text_ad.description",
+				"ad_group_ad.ad.expanded_text_ad.description2",
+				"ad_group.id", "ad_group.name",
+				"campaign.id", "campaign.name", "campaign.status",
+				"metrics.impressions", "metrics.clicks", "metrics.cost_micros",
+				"metrics.ctr", "metrics.average_cpc",
+				"metrics.conversions", "metrics.conversions_value",
+				"metrics.absolute_top_impression_percentage", "metrics.top_impression_percentage",
+				"metrics.view_through_conversions", "metrics.cost_per_conversion",
+				"metrics.conversions_from_interactions_rate", "metrics.search_impression_share").
+				From("ad_group_ad").
+				Where(dateFilter).
+				Where(notRemoved("ad_group_ad.status")).
+				WhereIf(f.campaignID != "", "campaign.id = "+gaql.Quote(f.campaignID)).
+				WhereIf(!f.all, "metrics.impressions > 0").
+				OrderBy("metrics.cost_micros", true).
+				String()
+
+			if f.verbose {
+				fmt.Printf("[verbose] account: %s\n[verbose] query:\n%s\n\n", cid, query)
+			}
+			if streamRows() {
+				return streamJSONL[api.InsightsAdRow](cid, query)
+			}
+			rows, err := apiClient.Search(cid, query)
+			if err != nil {
+				return err
+			}
+			if f.verbose {
+				fmt.Printf("[verbose] API returned %d raw rows\n\n", len(rows))
+			}
 
-		var results []api.InsightsAdRow
-		for _, raw := range rows {
-			var row api.InsightsAdRow
-			if err := json.Unmarshal(raw, &row); err != nil {
-				if insightsVerbose {
-					fmt.Printf("[verbose] unmarshal error: %v\nraw: %s\n", err, string(raw))
+			var results []api.InsightsAdRow
+			for _, raw := range rows {
+				var row api.InsightsAdRow
+				if err := json.Unmarshal(raw, &row); err != nil {
+					if f.verbose {
+						fmt.Printf("[verbose] unmarshal error: %v\nraw: %s\n", err, string(raw))
+					}
+					continue
 				}
-				continue
+				results = append(results, row)
 			}
-			results = append(results, row)
-		}
 
-		if output.IsJSON(cmd) {
-			return output.PrintJSON(results, output.IsPretty(cmd))
-		}
-		if len(results) == 0 {
-			fmt.Println("No ad data found for the specified period.")
-			return nil
-		}
+			if output.IsJSON(cmd) {
+				return output.PrintJSON(results, output.IsPretty(cmd))
+			}
+			if len(results) == 0 {
+				fmt.Println("No ad data found for the specified period.")
+				return nil
+			}
 
-		cols := resolveAdCols(insightsPreset, insightsFields)
-		headers := adHeaders(cols)
-		tableRows := make([][]string, len(results))
-		for i, r := range results {
-			r := r
-			row := make([]string, len(cols))
-			for j, col := range cols {
-				row[j] = col.Format(&r)
+			insightsCurrency = currencyOf(cid)
+			cols := resolveAdCols(f.preset, f.fields)
+			headers := adHeaders(cols)
+			tableRows := make([][]string, len(results))
+			for i, r := range results {
+				r := r
+				row := make([]string, len(cols))
+				for j, col := range cols {
+					row[j] = col.Format(&r)
+				}
+				tableRows[i] = dimRemoved(r.AdGroupAd.Status, row)
 			}
-			tableRows[i] = row
-		}
-		output.PrintTable(headers, tableRows)
-		return nil
-	},
+			return output.PrintTable(headers, tableRows)
+		},
+	}
+	f.bind(c)
+	addIncludeRemovedFlag(c)
+	c.Flags().StringVar(&f.campaignID, "campaign", "", "Campaign ID (optional — filters to a single campaign)")
+	return c
 }
 
-func init() {
-	allInsightsCmds := []*cobra.Command{
-		insightsCampaignsCmd, insightsAdGroupsCmd,
-		insightsKeywordsCmd, insightsSearchTermsCmd, insightsAdsCmd,
+// ---- insights conversions ----
+
+func newInsightsConversionsCmd() *cobra.Command {
+	var f insightsFlags
+	c := &cobra.Command{
+		Use:   "conversions",
+		Short: "Conversions and value per conversion action, optionally per day",
+		Long: `Show conversions and conversion value per conversion action for a given
+date range, for the account or one campaign. With --by-day there is one row
+per action per day, with conversions also counted by the date they happened
+(BY CONV DATE) rather than the date of the click.
+
+JSON output keeps the raw segment values (the conversion action resource
+name and the category enum, e.g. SUBMIT_LEAD_FORM) for joining with other
+data.
+
+Examples:
+  gads-cli insights conversions --account=1234567890 --days=30
+  gads-cli insights conversions --account=1234567890 --campaign=111222333 --period=lastMonth
+  gads-cli insights conversions --account=1234567890 --days=14 --by-day --csv`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			if f.campaignID != "" && !api.IsNumericID(f.campaignID) {
+				return fmt.Errorf("--campaign must be a numeric ID")
+			}
+			dateFilter := buildDateRange(f.period, f.days, f.start, f.end)
+
+			fields := []string{
+				"segments.conversion_action", "segments.conversion_action_name",
+				"segments.conversion_action_category",
+				"metrics.conversions", "metrics.conversions_value",
+			}
+			if f.byDay {
+				fields = append(fields, "segments.date", "metrics.conversions_by_conversion_date")
+			}
+			// Campaign rows are per campaign, so the account total comes
+			// from customer.
+			from := "customer"
+			if f.campaignID != "" {
+				from = "campaign"
+			}
+			query := gaql.Select(fields...).
+				From(from).
+				Where(dateFilter).
+				WhereIf(f.campaignID != "", "campaign.id = "+gaql.Quote(f.campaignID)).
+				String()
+
+			if f.verbose {
+				fmt.Printf("[verbose] account: %s\n[verbose] query:\n%s\n\n", cid, query)
+			}
+			if streamRows() {
+				return streamJSONL[api.InsightsConversionRow](cid, query)
+			}
+			rows, err := apiClient.Search(cid, query)
+			if err != nil {
+				return err
+			}
+			if f.verbose {
+				fmt.Printf("[verbose] API returned %d raw rows\n\n", len(rows))
+			}
+
+			results := []api.InsightsConversionRow{}
+			for _, raw := range rows {
+				var row api.InsightsConversionRow
+				if err := json.Unmarshal(raw, &row); err != nil {
+					continue
+				}
+				results = append(results, row)
+			}
+			// Most conversions first; by day, each action's days in order.
+			sort.SliceStable(results, func(i, j int) bool {
+				a, b := results[i], results[j]
+				if f.byDay {
+					if a.Segments.ConversionActionName != b.Segments.ConversionActionName {
+						return a.Segments.ConversionActionName < b.Segments.ConversionActionName
+					}
+					return a.Segments.Date < b.Segments.Date
+				}
+				return a.Metrics.Conversions > b.Metrics.Conversions
+			})
+
+			if output.IsJSON(cmd) {
+				return output.PrintJSON(results, output.IsPretty(cmd))
+			}
+			if len(results) == 0 {
+				fmt.Println("No conversions found for the specified period.")
+				return nil
+			}
+
+			headers := []string{"CONVERSION ACTION", "CATEGORY", "CONVERSIONS", "VALUE"}
+			if f.byDay {
+				headers = []string{"DATE", "CONVERSION ACTION", "CATEGORY", "CONVERSIONS", "BY CONV DATE", "VALUE"}
+			}
+			tableRows := make([][]string, len(results))
+			for i, r := range results {
+				row := []string{
+					r.Segments.ConversionActionName,
+					formatEnum(r.Segments.ConversionActionCategory),
+					fmt.Sprintf("%.1f", r.Metrics.Conversions),
+					fmt.Sprintf("%.2f", r.Metrics.ConversionsValue),
+				}
+				if f.byDay {
+					row = []string{
+						r.Segments.Date, row[0], row[1], row[2],
+						fmt.Sprintf("%.1f", derefFloat(r.Metrics.ConversionsByConversionDate)), row[3],
+					}
+				}
+				tableRows[i] = row
+			}
+			return output.PrintTable(headers, tableRows)
+		},
 	}
+	f.bindPeriod(c)
+	c.Flags().StringVar(&f.campaignID, "campaign", "", "Only this campaign's conversions")
+	c.Flags().BoolVar(&f.byDay, "by-day", false, "One row per conversion action per day")
+	c.Flags().BoolVar(&f.verbose, "verbose", false, "Print the GAQL query, raw row count and API request count for debugging")
+	addStreamFlag(c)
+	return c
+}
 
-	// Flags shared by all insights subcommands
-	for _, c := range allInsightsCmds {
-		c.Flags().StringVar(&insightsAccount, "account", "", "Customer account ID (required)")
-		c.Flags().StringVar(&insightsPeriod, "period", "", "Preset period: last7d, last30d, lastWeek, currentWeek, lastMonth, currentMonth, lastYear, currentYear, 2025, last3m, 1y …")
-		c.Flags().IntVar(&insightsDays, "days", 30, "Number of days to look back (default 30, ignored when --period is set)")
-		c.Flags().StringVar(&insightsStart, "start", "", "Start date YYYY-MM-DD (overrides --days, ignored when --period is set)")
-		c.Flags().StringVar(&insightsEnd, "end", "", "End date YYYY-MM-DD (overrides --days, ignored when --period is set)")
-		c.Flags().BoolVar(&insightsAll, "all", false, "Include rows with 0 impressions (default: only show rows with activity)")
-		c.Flags().BoolVar(&insightsVerbose, "verbose", false, "Print the GAQL query and raw row count for debugging")
-		c.Flags().StringVar(&insightsPreset, "preset", "default", "Column preset: default, performance, conversions, full (ads also supports: creatives)")
-		c.Flags().StringVar(&insightsFields, "fields", "", "Comma-separated field IDs to display, overrides --preset (e.g. campaign_name,impressions,clicks,cost,roas)")
+func derefFloat(f *float64) float64 {
+	if f == nil {
+		return 0
 	}
+	return *f
+}
 
-	// --campaign flag for subcommands that require a campaign filter
-	for _, c := range []*cobra.Command{insightsAdGroupsCmd, insightsKeywordsCmd, insightsSearchTermsCmd} {
-		c.Flags().StringVar(&insightsCampaignID, "campaign", "", "Campaign ID (required)")
+// formatEnum writes an API enum value for people: "SUBMIT_LEAD_FORM" →
+// "Submit lead form".
+func formatEnum(v string) string {
+	if v == "" {
+		return "-"
 	}
-	// --campaign is optional for ads (filters to a specific campaign if provided)
-	insightsAdsCmd.Flags().StringVar(&insightsCampaignID, "campaign", "", "Campaign ID (optional — filters to a single campaign)")
+	s := strings.ToLower(strings.ReplaceAll(v, "_", " "))
+	return strings.ToUpper(s[:1]) + s[1:]
+}
 
+func init() {
 	insightsCmd.AddCommand(
-		insightsCampaignsCmd, insightsAdGroupsCmd,
-		insightsKeywordsCmd, insightsSearchTermsCmd, insightsAdsCmd,
+		newInsightsCampaignsCmd(), newInsightsAdGroupsCmd(),
+		newInsightsKeywordsCmd(), newInsightsSearchTermsCmd(), newInsightsAdsCmd(),
+		newInsightsConversionsCmd(), newInsightsShoppingCmd(), newInsightsBidLandscapeCmd(),
+		newInsightsChangeImpactCmd(),
 	)
 	rootCmd.AddCommand(insightsCmd)
 }
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestInsightsFlagsDoNotLeak runs insights subcommands one after the other in
+// one process: flags set on one must not change the next one's defaults.
+func TestInsightsFlagsDoNotLeak(t *testing.T) {
+	h, queries := searchQueries(t)
+	useTestAPI(t, h)
+	accountFlag = "1234567890"
+
+	if _, _, err := execute(t, newInsightsCampaignsCmd(), "--days=7", "--all"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := execute(t, newInsightsAdGroupsCmd(), "--campaign=111"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := execute(t, newInsightsCampaignsCmd()); err != nil {
+		t.Fatal(err)
+	}
+	if len(*queries) != 3 {
+		t.Fatalf("got %d queries, want 3", len(*queries))
+	}
+
+	since := func(days int) string {
+		return "'" + time.Now().AddDate(0, 0, -days).Format("2006-01-02") + "'"
+	}
+	for i, want := range []struct {
+		start      string
+		activeOnly bool
+	}{
+		{since(7), false},
+		{since(30), true},
+		{since(30), true},
+	} {
+		q := (*queries)[i]
+		if !strings.Contains(q, "segments.date BETWEEN "+want.start) {
+			t.Errorf("query %d does not start on %s:\n%s", i+1, want.start, q)
+		}
+		if got := strings.Contains(q, "metrics.impressions > 0"); got != want.activeOnly {
+			t.Errorf("query %d filters inactive rows = %v, want %v:\n%s", i+1, got, want.activeOnly, q)
+		}
+	}
+	if !strings.Contains((*queries)[1], "campaign.id = '111'") {
+		t.Errorf("adgroups query ignores --campaign:\n%s", (*queries)[1])
+	}
+}
+
+func TestInsightsAdGroupsRequiresCampaign(t *testing.T) {
+	h, queries := searchQueries(t)
+	useTestAPI(t, h)
+	accountFlag = "1234567890"
+
+	_, _, err := execute(t, newInsightsAdGroupsCmd())
+	if err == nil || !strings.Contains(err.Error(), `"campaign" not set`) {
+		t.Errorf("error %v, want the required --campaign flag", err)
+	}
+	if len(*queries) != 0 {
+		t.Errorf("queried the API without --campaign: %v", *queries)
+	}
+}
@@ -1,12 +1,22 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/audit"
+	"github.com/the20100/gads-cli/internal/gaql"
 	"github.com/the20100/gads-cli/internal/output"
 )
 
@@ -15,204 +25,287 @@ var keywordsCmd = &cobra.Command{
 	Short: "Manage Google Ads keywords",
 }
 
-var (
-	keywordAccount    string
-	keywordCampaignID string
-	keywordAdGroupID  string
-	keywordText       string
-	keywordMatchType  string
-	keywordID         string // format: <adGroupId>~<criterionId>
-)
+// keywordFlags holds the flags of one keywords subcommand; each subcommand
+// has its own, as with insightsFlags.
+type keywordFlags struct {
+	campaignID string
+	adGroupID  string
+	texts      []string
+	matchType  string
+	id         string // format: <adGroupId>~<criterionId>
+}
 
 // ---- keywords list ----
 
-var keywordsListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List keywords in a campaign",
-	Long: `List keywords with match type, status, and quality score.
+func newKeywordsListCmd() *cobra.Command {
+	var f keywordFlags
+	c := &cobra.Command{
+		Use:   "list",
+		Short: "List keywords in a campaign",
+		Long: `List keywords with match type, status, and quality score.
 
 Examples:
   gads-cli keywords list --account=1234567890 --campaign=111222333
-  gads-cli keywords list --account=1234567890 --campaign=111222333 --json`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		if keywordAccount == "" {
-			return fmt.Errorf("--account is required")
-		}
-		if keywordCampaignID == "" {
-			return fmt.Errorf("--campaign is required")
-		}
-		cid := api.CleanCustomerID(keywordAccount)
-
-		query := fmt.Sprintf(`SELECT ad_group_criterion.criterion_id,
-			ad_group_criterion.keyword.text, ad_group_criterion.keyword.match_type,
-			ad_group_criterion.status, ad_group_criterion.negative,
-			ad_group_criterion.quality_info.quality_score,
-			ad_group_criterion.cpc_bid_micros,
-			ad_group.id, ad_group.name, campaign.id
-		FROM keyword_view
-		WHERE ad_group_criterion.status != 'REMOVED'
-		  AND campaign.id = '%s'
-		ORDER BY ad_group_criterion.criterion_id`, keywordCampaignID)
-
-		rows, err := apiClient.Search(cid, query)
-		if err != nil {
-			return err
-		}
+  gads-cli keywords list --account=1234567890 --campaign=111222333 --json
+  gads-cli keywords list --account=1234567890 --campaign=111222333 --include-removed`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			if !api.IsNumericID(f.campaignID) {
+				return fmt.Errorf("--campaign must be a numeric ID")
+			}
+
+			query := gaql.Select(
+				"ad_group_criterion.criterion_id",
+				"ad_group_criterion.keyword.text", "ad_group_criterion.keyword.match_type",
+				"ad_group_criterion.status", "ad_group_criterion.negative",
+				"ad_group_criterion.quality_info.quality_score",
+				"ad_group_criterion.cpc_bid_micros",
+				"ad_group.id", "ad_group.name", "campaign.id").
+				From("keyword_view").
+				Where(notRemoved("ad_group_criterion.status")).
+				Where("campaign.id = "+gaql.Quote(f.campaignID)).
+				OrderBy("ad_group_criterion.criterion_id", false).
+				String()
 
-		var keywords []api.KeywordRow
-		for _, raw := range rows {
-			var row api.KeywordRow
-			if err := json.Unmarshal(raw, &row); err != nil {
-				continue
+			if streamRows() {
+				return streamJSONL[api.KeywordRow](cid, query)
+			}
+			rows, err := apiClient.Search(cid, query)
+			if err != nil {
+				return err
 			}
-			keywords = append(keywords, row)
-		}
 
-		if output.IsJSON(cmd) {
-			return output.PrintJSON(keywords, output.IsPretty(cmd))
-		}
-		if len(keywords) == 0 {
-			fmt.Println("No keywords found.")
-			return nil
-		}
+			var keywords []api.KeywordRow
+			for _, raw := range rows {
+				var row api.KeywordRow
+				if err := json.Unmarshal(raw, &row); err != nil {
+					continue
+				}
+				keywords = append(keywords, row)
+			}
 
-		headers := []string{"ID", "KEYWORD", "MATCH", "STATUS", "QS", "BID", "AD GROUP"}
-		tableRows := make([][]string, len(keywords))
-		for i, r := range keywords {
-			qs := "-"
-			if r.AdGroupCriterion.QualityInfo.QualityScore > 0 {
-				qs = fmt.Sprintf("%d", r.AdGroupCriterion.QualityInfo.QualityScore)
-			}
-			negLabel := ""
-			if r.AdGroupCriterion.Negative {
-				negLabel = " [neg]"
-			}
-			tableRows[i] = []string{
-				r.AdGroupCriterion.CriterionID,
-				output.Truncate(r.AdGroupCriterion.Keyword.Text+negLabel, 40),
-				r.AdGroupCriterion.Keyword.MatchType,
-				r.AdGroupCriterion.Status,
-				qs,
-				api.MicrosToCurrency(r.AdGroupCriterion.CpcBidMicros),
-				output.Truncate(r.AdGroup.Name, 24),
+			if output.IsQuiet() {
+				// The compound key keywords pause and remove take.
+				ids := make([]string, len(keywords))
+				for i, r := range keywords {
+					ids[i] = r.AdGroup.ID + "~" + r.AdGroupCriterion.CriterionID
+				}
+				return output.PrintIDs(ids)
 			}
-		}
-		output.PrintTable(headers, tableRows)
-		return nil
-	},
+			if output.IsJSON(cmd) {
+				return output.PrintJSON(keywords, output.IsPretty(cmd))
+			}
+			if len(keywords) == 0 {
+				fmt.Println("No keywords found.")
+				return nil
+			}
+
+			headers := []string{"ID", "KEYWORD", "MATCH", "STATUS", "QS", "BID", "AD GROUP"}
+			tableRows := make([][]string, len(keywords))
+			for i, r := range keywords {
+				qs := "-"
+				if r.AdGroupCriterion.QualityInfo.QualityScore > 0 {
+					qs = fmt.Sprintf("%d", r.AdGroupCriterion.QualityInfo.QualityScore)
+				}
+				negLabel := ""
+				if r.AdGroupCriterion.Negative {
+					negLabel = " [neg]"
+				}
+				tableRows[i] = dimRemoved(r.AdGroupCriterion.Status, []string{
+					r.AdGroupCriterion.CriterionID,
+					r.AdGroupCriterion.Keyword.Text + negLabel,
+					r.AdGroupCriterion.Keyword.MatchType,
+					output.Colorize(r.AdGroupCriterion.Status),
+					qs,
+					money(cid, int64(r.AdGroupCriterion.CpcBidMicros)),
+					r.AdGroup.Name,
+				})
+			}
+			return output.PrintTable(headers, tableRows)
+		},
+	}
+	c.Flags().StringVar(&f.campaignID, "campaign", "", "Campaign ID (required)")
+	c.MarkFlagRequired("campaign")
+	addStreamFlag(c)
+	addIncludeRemovedFlag(c)
+	return c
 }
 
 // ---- keywords add ----
 
-var keywordsAddCmd = &cobra.Command{
-	Use:   "add",
-	Short: "Add a keyword to an ad group",
-	Long: `Add a new keyword to an ad group.
+func newKeywordsAddCmd() *cobra.Command {
+	var f keywordFlags
+	c := &cobra.Command{
+		Use:   "add",
+		Short: "Add keywords to an ad group",
+		Long: `Add one or more keywords to an ad group. Repeat --keyword to add several
+in one request; valid keywords are created even if others fail (e.g.
+duplicates) unless --no-partial-failure is set.
 
 Examples:
   gads-cli keywords add --account=1234567890 --adgroup=444555666 --keyword="running shoes" --match-type=PHRASE
-  gads-cli keywords add --account=1234567890 --adgroup=444555666 --keyword="buy sneakers" --match-type=EXACT`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		if keywordAccount == "" {
-			return fmt.Errorf("--account is required")
-		}
-		if keywordAdGroupID == "" {
-			return fmt.Errorf("--adgroup is required")
-		}
-		if keywordText == "" {
-			return fmt.Errorf("--keyword is required")
-		}
-		if keywordMatchType == "" {
-			return fmt.Errorf("--match-type is required (BROAD, PHRASE, or EXACT)")
-		}
-		mt := strings.ToUpper(keywordMatchType)
-		if mt != "BROAD" && mt != "PHRASE" && mt != "EXACT" {
-			return fmt.Errorf("--match-type must be BROAD, PHRASE, or EXACT")
-		}
+  gads-cli keywords add --account=1234567890 --adgroup=444555666 --keyword="buy sneakers" --match-type=EXACT
+  gads-cli keywords add --account=1234567890 --adgroup=444555666 --keyword="red shoes" --keyword="blue shoes" --match-type=PHRASE`,
+		Annotations: mutatingCommand,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			if !api.IsNumericID(f.adGroupID) {
+				return fmt.Errorf("--adgroup must be a numeric ID")
+			}
+			mt := strings.ToUpper(f.matchType)
+			if mt != "BROAD" && mt != "PHRASE" && mt != "EXACT" {
+				return fmt.Errorf("--match-type must be BROAD, PHRASE, or EXACT")
+			}
 
-		cid := api.CleanCustomerID(keywordAccount)
-		adGroupResourceName := fmt.Sprintf("customers/%s/adGroups/%s", cid, keywordAdGroupID)
-
-		ops := []map[string]any{
-			{
-				"create": map[string]any{
-					"adGroup": adGroupResourceName,
-					"status":  "ENABLED",
-					"keyword": map[string]any{
-						"text":      keywordText,
-						"matchType": mt,
+			adGroupResourceName := fmt.Sprintf("customers/%s/adGroups/%s", cid, f.adGroupID)
+			changes := make([]string, len(f.texts))
+			for i, text := range f.texts {
+				changes[i] = fmt.Sprintf("Ad group %s: add keyword %q [%s]", f.adGroupID, text, mt)
+			}
+			if ok, err := confirmChange(fmt.Sprintf("Add %d keyword(s)?", len(f.texts)), changes...); !ok {
+				return err
+			}
+
+			var ops []map[string]any
+			for _, text := range f.texts {
+				ops = append(ops, map[string]any{
+					"create": map[string]any{
+						"adGroup": adGroupResourceName,
+						"status":  "ENABLED",
+						"keyword": map[string]any{
+							"text":      text,
+							"matchType": mt,
+						},
 					},
-				},
-			},
-		}
-		resp, err := apiClient.MutateAdGroupCriteria(cid, ops)
-		if err != nil {
-			return err
-		}
-		if len(resp.Results) > 0 {
-			fmt.Printf("Keyword added: \"%s\" [%s]\n", keywordText, mt)
-			fmt.Printf("Resource: %s\n", resp.Results[0].ResourceName)
-		}
-		return nil
-	},
+				})
+			}
+			recordUndo("adGroupCriteria", &audit.Undo{RemoveCreated: true})
+			resp, err := apiClient.MutateAdGroupCriteria(cid, ops)
+			if err != nil {
+				return err
+			}
+			if apiClient.ValidateOnly() {
+				return reportDryRun(resp, len(ops))
+			}
+			if jsonResult() {
+				return printMutateResult(len(ops), resp)
+			}
+			for i, r := range resp.Results {
+				if r.ResourceName == "" || i >= len(f.texts) {
+					continue
+				}
+				fmt.Printf("Keyword added: \"%s\" [%s]\n", f.texts[i], mt)
+				fmt.Printf("Resource: %s\n", r.ResourceName)
+			}
+			if len(ops) > 1 || resp.PartialFailureError != nil {
+				return reportMutate(resp, "created")
+			}
+			return nil
+		},
+	}
+	c.Flags().StringVar(&f.adGroupID, "adgroup", "", "Ad group ID (required)")
+	c.Flags().StringArrayVar(&f.texts, "keyword", nil, "Keyword text (required, repeatable)")
+	c.Flags().StringVar(&f.matchType, "match-type", "", "Match type: BROAD, PHRASE, or EXACT (required)")
+	for _, name := range []string{"adgroup", "keyword", "match-type"} {
+		c.MarkFlagRequired(name)
+	}
+	return c
 }
 
 // ---- keywords pause ----
 
-var keywordsPauseCmd = &cobra.Command{
-	Use:   "pause",
-	Short: "Pause a keyword",
-	Long: `Pause a keyword. Provide the keyword ID in the format <adGroupId>~<criterionId>.
+func newKeywordsPauseCmd() *cobra.Command {
+	var f keywordFlags
+	c := &cobra.Command{
+		Use:   "pause",
+		Short: "Pause a keyword",
+		Long: `Pause a keyword. Provide the keyword ID in the format <adGroupId>~<criterionId>.
 
 The keyword ID is shown in the 'ID' column of 'keywords list' — use the
 compound key format: <adGroupId>~<criterionId>
 
 Examples:
   gads-cli keywords pause --account=1234567890 --keyword=444555666~12345`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		return setKeywordStatus(keywordAccount, keywordID, "PAUSED")
-	},
+		Annotations: mutatingCommand,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setKeywordStatus(f.id, "PAUSED")
+		},
+	}
+	c.Flags().StringVar(&f.id, "keyword", "", "Keyword ID in format <adGroupId>~<criterionId> (required)")
+	c.MarkFlagRequired("keyword")
+	return c
 }
 
 // ---- keywords remove ----
 
-var keywordsRemoveCmd = &cobra.Command{
-	Use:   "remove",
-	Short: "Remove a keyword",
-	Long: `Remove (soft-delete) a keyword. Provide the keyword ID as <adGroupId>~<criterionId>.
+func newKeywordsRemoveCmd() *cobra.Command {
+	var f keywordFlags
+	c := &cobra.Command{
+		Use:   "remove",
+		Short: "Remove a keyword",
+		Long: `Remove (soft-delete) a keyword. Provide the keyword ID as <adGroupId>~<criterionId>.
 
 Examples:
   gads-cli keywords remove --account=1234567890 --keyword=444555666~12345`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		if keywordAccount == "" {
-			return fmt.Errorf("--account is required")
-		}
-		if keywordID == "" {
-			return fmt.Errorf("--keyword is required (format: <adGroupId>~<criterionId>)")
-		}
-		cid := api.CleanCustomerID(keywordAccount)
-		resourceName := fmt.Sprintf("customers/%s/adGroupCriteria/%s", cid, keywordID)
+		Annotations: mutatingCommand,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			resourceName := fmt.Sprintf("customers/%s/adGroupCriteria/%s", cid, f.id)
+			kw, err := lookupKeyword(cid, resourceName)
+			if err != nil {
+				return err
+			}
+			change := fmt.Sprintf("Keyword %s %q [%s]: %s → REMOVED", f.id, kw.Keyword.Text, kw.Keyword.MatchType, kw.Status)
+			if ok, err := confirmChange("Remove this keyword?", change); !ok {
+				return err
+			}
 
-		ops := []map[string]any{
-			{"remove": resourceName},
-		}
-		if _, err := apiClient.MutateAdGroupCriteria(cid, ops); err != nil {
-			return err
-		}
-		fmt.Printf("Keyword %s removed.\n", keywordID)
-		return nil
-	},
+			ops := []map[string]any{
+				{"remove": resourceName},
+			}
+			recordUndo("adGroupCriteria", audit.NotUndoable("a removed keyword cannot be restored; add it again with keywords add"))
+			resp, err := apiClient.MutateAdGroupCriteria(cid, ops)
+			if err != nil {
+				return err
+			}
+			if apiClient.ValidateOnly() {
+				return reportDryRun(resp, len(ops))
+			}
+			if jsonResult() {
+				return printMutateResult(len(ops), resp)
+			}
+			fmt.Printf("Keyword %s removed.\n", f.id)
+			return nil
+		},
+	}
+	c.Flags().StringVar(&f.id, "keyword", "", "Keyword ID in format <adGroupId>~<criterionId> (required)")
+	c.MarkFlagRequired("keyword")
+	return c
 }
 
-func setKeywordStatus(account, kwID, status string) error {
-	if account == "" {
-		return fmt.Errorf("--account is required")
-	}
-	if kwID == "" {
-		return fmt.Errorf("--keyword is required (format: <adGroupId>~<criterionId>)")
+func setKeywordStatus(kwID, status string) error {
+	cid, err := accountID()
+	if err != nil {
+		return err
 	}
-	cid := api.CleanCustomerID(account)
 	resourceName := fmt.Sprintf("customers/%s/adGroupCriteria/%s", cid, kwID)
+	kw, err := lookupKeyword(cid, resourceName)
+	if err != nil {
+		return err
+	}
+	change := fmt.Sprintf("Keyword %s %q [%s]: %s → %s", kwID, kw.Keyword.Text, kw.Keyword.MatchType, kw.Status, status)
+	if ok, err := confirmChange("Apply this change?", change); !ok {
+		return err
+	}
 
 	ops := []map[string]any{
 		{
@@ -223,27 +316,529 @@ func setKeywordStatus(account, kwID, status string) error {
 			},
 		},
 	}
-	if _, err := apiClient.MutateAdGroupCriteria(cid, ops); err != nil {
+	recordUndo("adGroupCriteria", audit.Restore([]map[string]any{undoUpdate(resourceName, "status", kw.Status)}))
+	resp, err := apiClient.MutateAdGroupCriteria(cid, ops)
+	if err != nil {
 		return err
 	}
+	if apiClient.ValidateOnly() {
+		return reportDryRun(resp, len(ops))
+	}
+	if jsonResult() {
+		return printMutateResult(len(ops), resp)
+	}
 	fmt.Printf("Keyword %s status set to %s.\n", kwID, status)
 	return nil
 }
 
-func init() {
-	keywordsListCmd.Flags().StringVar(&keywordAccount, "account", "", "Customer account ID (required)")
-	keywordsListCmd.Flags().StringVar(&keywordCampaignID, "campaign", "", "Campaign ID (required)")
+// lookupKeyword fetches the keyword at resourceName, for the confirmation
+// of pause, remove and clear-url.
+func lookupKeyword(cid, resourceName string) (api.AdGroupCriterion, error) {
+	query := gaql.Select("ad_group_criterion.criterion_id", "ad_group_criterion.status",
+		"ad_group_criterion.keyword.text", "ad_group_criterion.keyword.match_type",
+		"ad_group_criterion.final_urls").
+		From("ad_group_criterion").
+		Where("ad_group_criterion.resource_name = " + gaql.Quote(resourceName)).
+		String()
+	rows, err := apiClient.Search(cid, query)
+	if err != nil {
+		return api.AdGroupCriterion{}, err
+	}
+	if len(rows) == 0 {
+		return api.AdGroupCriterion{}, fmt.Errorf("keyword %s not found", api.ResourceID(resourceName))
+	}
+	var row api.KeywordRow
+	if err := json.Unmarshal(rows[0], &row); err != nil {
+		return api.AdGroupCriterion{}, fmt.Errorf("parsing response: %w", err)
+	}
+	return row.AdGroupCriterion, nil
+}
+
+// ---- keywords forecast ----
+
+// keywordForecast is a keyword's projected metrics, for keywords forecast.
+type keywordForecast struct {
+	api.ForecastKeyword
+	Metrics api.ForecastMetrics `json:"metrics"`
+}
 
-	keywordsAddCmd.Flags().StringVar(&keywordAccount, "account", "", "Customer account ID (required)")
-	keywordsAddCmd.Flags().StringVar(&keywordAdGroupID, "adgroup", "", "Ad group ID (required)")
-	keywordsAddCmd.Flags().StringVar(&keywordText, "keyword", "", "Keyword text (required)")
-	keywordsAddCmd.Flags().StringVar(&keywordMatchType, "match-type", "", "Match type: BROAD, PHRASE, or EXACT (required)")
+func newKeywordsForecastCmd() *cobra.Command {
+	var f keywordFlags
+	var (
+		file      string
+		cpcBid    string
+		days      int
+		locations []string
+		languages []string
+	)
+	c := &cobra.Command{
+		Use:   "forecast",
+		Short: "Project impressions, clicks and cost of keywords",
+		Long: `Project the impressions, clicks, cost, CTR and average CPC of keywords over
+the next --days days, with Keyword Planner forecasts, per keyword and for
+all of them together. The totals are a separate forecast of every keyword
+in one ad group, so they can differ from the sum of the rows.
 
-	for _, c := range []*cobra.Command{keywordsPauseCmd, keywordsRemoveCmd} {
-		c.Flags().StringVar(&keywordAccount, "account", "", "Customer account ID (required)")
-		c.Flags().StringVar(&keywordID, "keyword", "", "Keyword ID in format <adGroupId>~<criterionId> (required)")
+The keywords are those of --adgroup (enabled and paused, not negatives),
+or the candidates in --file ("-" for stdin): one per line, with the match
+type written as [exact], "phrase" or plain text for --match-type (default
+BROAD). Lines starting with # are skipped.
+
+Each keyword is bid at --cpc-bid, in the account's currency, or at its
+current bid when it comes from --adgroup. Forecasts are for Google Search in
+every location and language unless --location and --language give
+geo target constant IDs (see: gads-cli geotargets) and language constant
+IDs (1000 = English). Nothing is created in the account.
+
+Examples:
+  gads-cli keywords forecast --account=1234567890 --adgroup=444555666
+  gads-cli keywords forecast --account=1234567890 --adgroup=444555666 --cpc-bid=2.00 --days=7
+  gads-cli keywords forecast --account=1234567890 --file=candidates.txt --cpc-bid=1.50 --location=2840 --language=1000`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			if (f.adGroupID == "") == (file == "") {
+				return fmt.Errorf("exactly one of --adgroup or --file is required")
+			}
+			if f.adGroupID != "" && !api.IsNumericID(f.adGroupID) {
+				return fmt.Errorf("--adgroup must be a numeric ID")
+			}
+			if days <= 0 {
+				return fmt.Errorf("--days must be positive")
+			}
+			var bid int64
+			if cpcBid != "" {
+				if bid, err = api.ParseCurrencyToMicros(cpcBid); err != nil {
+					return fmt.Errorf("--cpc-bid: %w", err)
+				}
+			}
+			mt := strings.ToUpper(f.matchType)
+			if mt != "BROAD" && mt != "PHRASE" && mt != "EXACT" {
+				return fmt.Errorf("--match-type must be BROAD, PHRASE, or EXACT")
+			}
+
+			var keywords []api.ForecastKeyword
+			if file != "" {
+				keywords, err = readForecastKeywords(file, mt)
+			} else {
+				keywords, err = adGroupForecastKeywords(cid, f.adGroupID)
+			}
+			if err != nil {
+				return err
+			}
+			if len(keywords) == 0 {
+				return fmt.Errorf("no keywords to forecast")
+			}
+			for i := range keywords {
+				if bid > 0 {
+					keywords[i].MaxCpcBidMicros = bid
+				}
+				if keywords[i].MaxCpcBidMicros == 0 {
+					return fmt.Errorf("keyword %q has no CPC bid; set one with --cpc-bid", keywords[i].Text)
+				}
+			}
+
+			currency, err := accountCurrency(cid)
+			if err != nil {
+				return err
+			}
+			start := time.Now().AddDate(0, 0, 1)
+			spec := api.KeywordForecast{
+				CurrencyCode: currency,
+				StartDate:    start.Format("2006-01-02"),
+				EndDate:      start.AddDate(0, 0, days-1).Format("2006-01-02"),
+			}
+			for _, id := range locations {
+				spec.GeoTargets = append(spec.GeoTargets, "geoTargetConstants/"+id)
+			}
+			for _, id := range languages {
+				spec.Languages = append(spec.Languages, "languageConstants/"+id)
+			}
+
+			forecasts, total, err := forecastKeywords(cmd.Context(), cid, spec, keywords)
+			if err != nil {
+				return err
+			}
+
+			if output.IsJSON(cmd) {
+				return output.PrintJSON(map[string]any{
+					"startDate": spec.StartDate,
+					"endDate":   spec.EndDate,
+					"currency":  currency,
+					"keywords":  forecasts,
+					"total":     total,
+				}, output.IsPretty(cmd))
+			}
+			fmt.Printf("Forecast for %s to %s (%d days):\n\n", spec.StartDate, spec.EndDate, days)
+			headers := []string{"KEYWORD", "MATCH", "MAX CPC", "IMPRESSIONS", "CLICKS", "COST", "CTR", "AVG CPC"}
+			row := func(text, match, bid string, m api.ForecastMetrics) []string {
+				return []string{
+					text, match, bid,
+					api.FormatMetricInt(int64(math.Round(m.Impressions))),
+					api.FormatMetricInt(int64(math.Round(m.Clicks))),
+					money(cid, int64(m.CostMicros)),
+					api.FormatCTR(m.ClickThroughRate),
+					money(cid, int64(m.AverageCpcMicros)),
+				}
+			}
+			tableRows := make([][]string, 0, len(forecasts)+1)
+			for _, k := range forecasts {
+				tableRows = append(tableRows, row(k.Text, k.MatchType, money(cid, k.MaxCpcBidMicros), k.Metrics))
+			}
+			tableRows = append(tableRows, row("TOTAL", "", "", *total))
+			return output.PrintTable(headers, tableRows)
+		},
+	}
+	c.Flags().StringVar(&f.adGroupID, "adgroup", "", "Ad group whose keywords to forecast")
+	c.Flags().StringVar(&file, "file", "", `File of candidate keywords, one per line ("-" for stdin)`)
+	c.Flags().StringVar(&f.matchType, "match-type", "BROAD", "Match type of --file keywords written as plain text: BROAD, PHRASE, or EXACT")
+	c.Flags().StringVar(&cpcBid, "cpc-bid", "", "Max CPC bid for every keyword, in the account's currency (e.g. 2.00; default: the keyword's current bid)")
+	c.Flags().IntVar(&days, "days", 30, "Number of days to forecast, starting tomorrow")
+	c.Flags().StringArrayVar(&locations, "location", nil, "Geo target constant ID to forecast for (repeatable; default: everywhere)")
+	c.Flags().StringArrayVar(&languages, "language", nil, "Language constant ID to forecast for, e.g. 1000 for English (repeatable; default: every language)")
+	return c
+}
+
+// forecastKeywords forecasts each keyword on its own, at most
+// --concurrency at a time, then all of them together for the total. The
+// forecast endpoint only projects whole campaigns, hence one request per
+// keyword.
+func forecastKeywords(ctx context.Context, cid string, spec api.KeywordForecast, keywords []api.ForecastKeyword) ([]keywordForecast, *api.ForecastMetrics, error) {
+	forecasts := make([]keywordForecast, len(keywords))
+	errs := make([]error, len(keywords))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, max(concurrencyFlag, 1))
+	for i, k := range keywords {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			one := spec
+			one.Keywords = []api.ForecastKeyword{k}
+			m, err := apiClient.GenerateKeywordForecastMetrics(ctx, cid, one)
+			if err != nil {
+				errs[i] = fmt.Errorf("forecasting %q: %w", k.Text, err)
+				return
+			}
+			forecasts[i] = keywordForecast{ForecastKeyword: k, Metrics: *m}
+		}()
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, nil, err
+	}
+	if len(keywords) == 1 {
+		return forecasts, &forecasts[0].Metrics, nil
+	}
+	spec.Keywords = keywords
+	total, err := apiClient.GenerateKeywordForecastMetrics(ctx, cid, spec)
+	if err != nil {
+		return nil, nil, fmt.Errorf("forecasting the total: %w", err)
+	}
+	return forecasts, total, nil
+}
+
+// adGroupForecastKeywords returns the enabled and paused keywords of an ad
+// group with their current bids.
+func adGroupForecastKeywords(cid, adGroupID string) ([]api.ForecastKeyword, error) {
+	query := gaql.Select(
+		"ad_group_criterion.keyword.text", "ad_group_criterion.keyword.match_type",
+		"ad_group_criterion.effective_cpc_bid_micros").
+		From("ad_group_criterion").
+		Where("ad_group.id = "+gaql.Quote(adGroupID)).
+		Where("ad_group_criterion.type = 'KEYWORD'").
+		Where("ad_group_criterion.negative = FALSE").
+		Where("ad_group_criterion.status != 'REMOVED'").
+		OrderBy("ad_group_criterion.keyword.text", false).
+		String()
+	rows, err := apiClient.Search(cid, query)
+	if err != nil {
+		return nil, err
+	}
+	var keywords []api.ForecastKeyword
+	for _, raw := range rows {
+		var row api.KeywordRow
+		if err := json.Unmarshal(raw, &row); err != nil {
+			continue
+		}
+		c := row.AdGroupCriterion
+		keywords = append(keywords, api.ForecastKeyword{
+			Text:            c.Keyword.Text,
+			MatchType:       c.Keyword.MatchType,
+			MaxCpcBidMicros: int64(c.EffectiveCpcBidMicros),
+		})
+	}
+	if len(keywords) == 0 {
+		return nil, fmt.Errorf("ad group %s has no keywords", adGroupID)
 	}
+	return keywords, nil
+}
+
+// readForecastKeywords reads candidate keywords, one per line ("-" for
+// stdin): [exact], "phrase", or plain text with matchType.
+func readForecastKeywords(path, matchType string) ([]api.ForecastKeyword, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	var keywords []api.ForecastKeyword
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		k := api.ForecastKeyword{Text: line, MatchType: matchType}
+		switch {
+		case len(line) > 2 && line[0] == '[' && line[len(line)-1] == ']':
+			k.Text, k.MatchType = line[1:len(line)-1], "EXACT"
+		case len(line) > 2 && line[0] == '"' && line[len(line)-1] == '"':
+			k.Text, k.MatchType = line[1:len(line)-1], "PHRASE"
+		}
+		keywords = append(keywords, k)
+	}
+	return keywords, nil
+}
+
+// ---- keywords search ----
+
+// keywordHit is a keyword found by keywords search. Campaign-level
+// negatives have no ad group.
+type keywordHit struct {
+	AccountID    string `json:"accountId"`
+	AccountName  string `json:"accountName,omitempty"`
+	CampaignID   string `json:"campaignId"`
+	CampaignName string `json:"campaignName"`
+	AdGroupID    string `json:"adGroupId,omitempty"`
+	AdGroupName  string `json:"adGroupName,omitempty"`
+	CriterionID  string `json:"criterionId"`
+	Text         string `json:"text"`
+	MatchType    string `json:"matchType"`
+	Status       string `json:"status"`
+	Negative     bool   `json:"negative"`
+}
+
+// campaignNegativeRow decodes a campaign-level negative keyword with its
+// campaign.
+type campaignNegativeRow struct {
+	CampaignCriterion struct {
+		CriterionID string `json:"criterionId"`
+		Status      string `json:"status"`
+		Keyword     struct {
+			Text      string `json:"text"`
+			MatchType string `json:"matchType"`
+		} `json:"keyword"`
+	} `json:"campaignCriterion"`
+	Campaign api.Campaign `json:"campaign"`
+}
+
+func newKeywordsSearchCmd() *cobra.Command {
+	var f keywordFlags
+	var (
+		text             string
+		allAccounts      bool
+		includeNegatives bool
+	)
+	c := &cobra.Command{
+		Use:   "search",
+		Short: "Find keywords containing a term, in one account or all of them",
+		Long: `Find the keywords whose text contains --text (case-sensitive), in the
+account or, with --all-accounts, in every enabled client account under the
+manager account, queried in parallel (see --concurrency). Removed keywords
+and keywords of removed campaigns and ad groups are skipped.
+
+With --include-negatives, ad group and campaign negative keywords are
+searched too, flagged [neg]. With --all-accounts, an account that cannot be
+queried is reported as a warning and skipped.
 
-	keywordsCmd.AddCommand(keywordsListCmd, keywordsAddCmd, keywordsPauseCmd, keywordsRemoveCmd)
+Examples:
+  gads-cli keywords search --account=1234567890 --text="running shoes"
+  gads-cli keywords search --all-accounts --text=acme --match-type=EXACT
+  gads-cli keywords search --all-accounts --text=free --include-negatives --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if text == "" {
+				return fmt.Errorf("--text is required")
+			}
+			mt := strings.ToUpper(f.matchType)
+			if mt != "" && mt != "BROAD" && mt != "PHRASE" && mt != "EXACT" {
+				return fmt.Errorf("--match-type must be BROAD, PHRASE, or EXACT")
+			}
+
+			if allAccounts && accountFlag != "" {
+				return fmt.Errorf("--all-accounts and --account cannot be combined")
+			}
+
+			var accounts []api.CustomerClient
+			if allAccounts {
+				var err error
+				if accounts, err = clientAccounts(); err != nil {
+					return err
+				}
+			} else {
+				cid, err := accountID()
+				if err != nil {
+					return err
+				}
+				accounts = []api.CustomerClient{{ID: cid}}
+			}
+			names := make(map[string]string, len(accounts))
+			ids := make([]string, len(accounts))
+			for i, a := range accounts {
+				ids[i] = api.CleanCustomerID(a.ID)
+				names[ids[i]] = a.DescriptiveName
+			}
+
+			adGroupQuery := gaql.Select(
+				"campaign.id", "campaign.name", "ad_group.id", "ad_group.name",
+				"ad_group_criterion.criterion_id", "ad_group_criterion.keyword.text",
+				"ad_group_criterion.keyword.match_type", "ad_group_criterion.status",
+				"ad_group_criterion.negative").
+				From("ad_group_criterion").
+				Where("ad_group_criterion.type = 'KEYWORD'").
+				Where("ad_group_criterion.status != 'REMOVED'").
+				Where("ad_group.status != 'REMOVED'").
+				Where("campaign.status != 'REMOVED'").
+				Where("ad_group_criterion.keyword.text LIKE "+gaql.Contains(text)).
+				WhereIf(mt != "", "ad_group_criterion.keyword.match_type = "+gaql.Quote(mt)).
+				WhereIf(!includeNegatives, "ad_group_criterion.negative = FALSE").
+				String()
+			rowsByID, errs := apiClient.SearchMany(cmd.Context(), ids, adGroupQuery, concurrencyFlag)
+
+			var hits []keywordHit
+			for _, id := range ids {
+				for _, raw := range rowsByID[id] {
+					var row api.KeywordRow
+					if err := json.Unmarshal(raw, &row); err != nil {
+						continue
+					}
+					k := row.AdGroupCriterion
+					hits = append(hits, keywordHit{
+						AccountID: id, AccountName: names[id],
+						CampaignID: row.Campaign.ID, CampaignName: row.Campaign.Name,
+						AdGroupID: row.AdGroup.ID, AdGroupName: row.AdGroup.Name,
+						CriterionID: k.CriterionID, Text: k.Keyword.Text, MatchType: k.Keyword.MatchType,
+						Status: k.Status, Negative: k.Negative,
+					})
+				}
+			}
+			if includeNegatives {
+				negativeQuery := gaql.Select(
+					"campaign.id", "campaign.name",
+					"campaign_criterion.criterion_id", "campaign_criterion.keyword.text",
+					"campaign_criterion.keyword.match_type", "campaign_criterion.status").
+					From("campaign_criterion").
+					Where("campaign_criterion.type = 'KEYWORD'").
+					Where("campaign_criterion.negative = TRUE").
+					Where("campaign_criterion.status != 'REMOVED'").
+					Where("campaign.status != 'REMOVED'").
+					Where("campaign_criterion.keyword.text LIKE "+gaql.Contains(text)).
+					WhereIf(mt != "", "campaign_criterion.keyword.match_type = "+gaql.Quote(mt)).
+					String()
+				var pending []string
+				for _, id := range ids {
+					if errs[id] == nil {
+						pending = append(pending, id)
+					}
+				}
+				negRows, negErrs := apiClient.SearchMany(cmd.Context(), pending, negativeQuery, concurrencyFlag)
+				for id, err := range negErrs {
+					errs[id] = err
+				}
+				for _, id := range pending {
+					for _, raw := range negRows[id] {
+						var row campaignNegativeRow
+						if err := json.Unmarshal(raw, &row); err != nil {
+							continue
+						}
+						k := row.CampaignCriterion
+						hits = append(hits, keywordHit{
+							AccountID: id, AccountName: names[id],
+							CampaignID: row.Campaign.ID, CampaignName: row.Campaign.Name,
+							CriterionID: k.CriterionID, Text: k.Keyword.Text, MatchType: k.Keyword.MatchType,
+							Status: k.Status, Negative: true,
+						})
+					}
+				}
+			}
+			if !allAccounts {
+				if err := errs[ids[0]]; err != nil {
+					return err
+				}
+			}
+			for _, id := range ids {
+				if err := errs[id]; err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: skipping account %s: %v\n", id, err)
+				}
+			}
+			sort.SliceStable(hits, func(i, j int) bool {
+				a, b := hits[i], hits[j]
+				if a.AccountID != b.AccountID {
+					return a.AccountID < b.AccountID
+				}
+				if a.CampaignName != b.CampaignName {
+					return a.CampaignName < b.CampaignName
+				}
+				if a.AdGroupName != b.AdGroupName {
+					return a.AdGroupName < b.AdGroupName
+				}
+				return a.Text < b.Text
+			})
+
+			if output.IsJSON(cmd) {
+				if hits == nil {
+					hits = []keywordHit{}
+				}
+				return output.PrintJSON(hits, output.IsPretty(cmd))
+			}
+			if len(hits) == 0 {
+				fmt.Printf("No keywords match %q.\n", text)
+				return nil
+			}
+
+			headers := []string{"ACCOUNT", "CAMPAIGN", "AD GROUP", "KEYWORD", "MATCH", "STATUS"}
+			tableRows := make([][]string, len(hits))
+			for i, h := range hits {
+				account := h.AccountID
+				if h.AccountName != "" {
+					account += " " + h.AccountName
+				}
+				keyword := h.Text
+				if h.Negative {
+					keyword += " [neg]"
+				}
+				tableRows[i] = []string{
+					account,
+					h.CampaignName,
+					orDash(h.AdGroupName),
+					keyword,
+					h.MatchType,
+					output.Colorize(h.Status),
+				}
+			}
+			return output.PrintTable(headers, tableRows)
+		},
+	}
+	c.Flags().StringVar(&text, "text", "", "Text the keywords contain (required)")
+	c.Flags().StringVar(&f.matchType, "match-type", "", "Only keywords of this match type: BROAD, PHRASE, or EXACT")
+	c.Flags().BoolVar(&allAccounts, "all-accounts", false, "Search every enabled client account under the manager account")
+	c.Flags().BoolVar(&includeNegatives, "include-negatives", false, "Also search ad group and campaign negative keywords")
+	c.MarkFlagRequired("text")
+	return c
+}
+
+func init() {
+	keywordsCmd.AddCommand(
+		newKeywordsListCmd(), newKeywordsAddCmd(), newKeywordsPauseCmd(), newKeywordsRemoveCmd(),
+		newKeywordsForecastCmd(), newKeywordsSearchCmd(), newKeywordsNegativeConflictsCmd(),
+		newKeywordsURLsCmd(), newKeywordsClearURLCmd(),
+	)
 	rootCmd.AddCommand(keywordsCmd)
 }
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/audit"
+	"github.com/the20100/gads-cli/internal/gaql"
+	"github.com/the20100/gads-cli/internal/output"
+)
+
+// keywordIDRe matches the <adGroupId>~<criterionId> key of a keyword.
+var keywordIDRe = regexp.MustCompile(`^\d+~\d+$`)
+
+// ---- keywords urls ----
+
+func newKeywordsURLsCmd() *cobra.Command {
+	var f keywordFlags
+	c := &cobra.Command{
+		Use:   "urls",
+		Short: "List keywords with their own final URLs",
+		Long: `List the keywords that have keyword-level final URLs. These legacy URLs
+override the final URLs of the ads, so clicks on the keyword may not land
+where its ads say. Clear them with keywords clear-url.
+
+--quiet prints the <adGroupId>~<criterionId> keys that clear-url takes, and
+JSON keeps each keyword's resource name, for building operations in bulk.
+
+Examples:
+  gads-cli keywords urls --account=1234567890
+  gads-cli keywords urls --account=1234567890 --campaign=111222333 --json
+  gads-cli keywords urls --account=1234567890 --quiet \
+    | xargs -I{} gads-cli keywords clear-url --account=1234567890 --keyword={} --yes`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			if f.campaignID != "" && !api.IsNumericID(f.campaignID) {
+				return fmt.Errorf("--campaign must be a numeric ID")
+			}
+
+			// Repeated fields cannot be tested for emptiness in GAQL, so
+			// keywords without URLs are left out here.
+			query := gaql.Select(
+				"campaign.id", "campaign.name", "ad_group.id", "ad_group.name",
+				"ad_group_criterion.resource_name", "ad_group_criterion.criterion_id",
+				"ad_group_criterion.keyword.text", "ad_group_criterion.keyword.match_type",
+				"ad_group_criterion.status", "ad_group_criterion.final_urls").
+				From("ad_group_criterion").
+				Where("ad_group_criterion.type = 'KEYWORD'").
+				Where("ad_group_criterion.negative = FALSE").
+				Where("ad_group_criterion.status != 'REMOVED'").
+				Where("ad_group.status != 'REMOVED'").
+				Where("campaign.status != 'REMOVED'").
+				WhereIf(f.campaignID != "", "campaign.id = "+gaql.Quote(f.campaignID)).
+				OrderBy("ad_group_criterion.criterion_id", false).
+				String()
+			rows, err := apiClient.Search(cid, query)
+			if err != nil {
+				return err
+			}
+			keywords := []api.KeywordRow{}
+			for _, raw := range rows {
+				var row api.KeywordRow
+				if err := json.Unmarshal(raw, &row); err != nil {
+					continue
+				}
+				if len(row.AdGroupCriterion.FinalURLs) > 0 {
+					keywords = append(keywords, row)
+				}
+			}
+
+			if output.IsQuiet() {
+				ids := make([]string, len(keywords))
+				for i, r := range keywords {
+					ids[i] = r.AdGroup.ID + "~" + r.AdGroupCriterion.CriterionID
+				}
+				return output.PrintIDs(ids)
+			}
+			if output.IsJSON(cmd) {
+				return output.PrintJSON(keywords, output.IsPretty(cmd))
+			}
+			if len(keywords) == 0 {
+				fmt.Println("No keywords have keyword-level final URLs.")
+				return nil
+			}
+
+			headers := []string{"ID", "KEYWORD", "MATCH", "CAMPAIGN", "AD GROUP", "FINAL URL"}
+			tableRows := make([][]string, len(keywords))
+			for i, r := range keywords {
+				k := r.AdGroupCriterion
+				tableRows[i] = []string{
+					r.AdGroup.ID + "~" + k.CriterionID,
+					k.Keyword.Text,
+					k.Keyword.MatchType,
+					r.Campaign.Name,
+					r.AdGroup.Name,
+					strings.Join(k.FinalURLs, ", "),
+				}
+			}
+			return output.PrintTable(headers, tableRows)
+		},
+	}
+	c.Flags().StringVar(&f.campaignID, "campaign", "", "Only list this campaign's keywords")
+	return c
+}
+
+// ---- keywords clear-url ----
+
+func newKeywordsClearURLCmd() *cobra.Command {
+	var f keywordFlags
+	c := &cobra.Command{
+		Use:   "clear-url",
+		Short: "Remove the keyword-level final URLs of a keyword",
+		Long: `Remove the final URLs set on a keyword, so its clicks land on the final
+URLs of the ads. Provide the keyword ID as <adGroupId>~<criterionId>, as
+listed by keywords urls. The change can be undone.
+
+Examples:
+  gads-cli keywords clear-url --account=1234567890 --keyword=444555666~12345`,
+		Annotations: mutatingCommand,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			if !keywordIDRe.MatchString(f.id) {
+				return fmt.Errorf("--keyword must be <adGroupId>~<criterionId>, e.g. 444555666~12345")
+			}
+			resourceName := fmt.Sprintf("customers/%s/adGroupCriteria/%s", cid, f.id)
+			kw, err := lookupKeyword(cid, resourceName)
+			if err != nil {
+				return err
+			}
+			if len(kw.FinalURLs) == 0 {
+				fmt.Printf("Keyword %s has no keyword-level final URLs.\n", f.id)
+				return nil
+			}
+			change := fmt.Sprintf("Keyword %s %q [%s]: final URLs %s → none", f.id, kw.Keyword.Text, kw.Keyword.MatchType, strings.Join(kw.FinalURLs, ", "))
+			if ok, err := confirmChange("Clear the final URLs?", change); !ok {
+				return err
+			}
+
+			ops := []map[string]any{undoUpdate(resourceName, "finalUrls", []string{})}
+			recordUndo("adGroupCriteria", audit.Restore([]map[string]any{undoUpdate(resourceName, "finalUrls", kw.FinalURLs)}))
+			resp, err := apiClient.MutateAdGroupCriteria(cid, ops)
+			if err != nil {
+				return err
+			}
+			if apiClient.ValidateOnly() {
+				return reportDryRun(resp, len(ops))
+			}
+			if jsonResult() {
+				return printMutateResult(len(ops), resp)
+			}
+			fmt.Printf("Keyword %s final URLs cleared.\n", f.id)
+			return nil
+		},
+	}
+	c.Flags().StringVar(&f.id, "keyword", "", "Keyword ID in format <adGroupId>~<criterionId> (required)")
+	c.MarkFlagRequired("keyword")
+	return c
+}
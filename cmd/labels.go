@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/gaql"
+	"github.com/the20100/gads-cli/internal/output"
+)
+
+var labelsCmd = &cobra.Command{
+	Use:   "labels",
+	Short: "Manage labels",
+}
+
+var (
+	labelRef         string
+	labelName        string
+	labelColor       string
+	labelDescription string
+)
+
+// labelColorRe matches the #RRGGBB colors the API accepts.
+var labelColorRe = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// ---- labels list ----
+
+var labelsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List labels in an account",
+	Long: `List labels with their color and description.
+
+Examples:
+  gads-cli labels list --account=1234567890
+  gads-cli labels list --account=1234567890 --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, err := accountID()
+		if err != nil {
+			return err
+		}
+
+		labels, err := fetchLabels(apiClient, cid, "")
+		if err != nil {
+			return err
+		}
+		if output.IsJSON(cmd) {
+			return output.PrintJSON(labels, output.IsPretty(cmd))
+		}
+		if len(labels) == 0 {
+			fmt.Println("No labels found.")
+			return nil
+		}
+
+		headers := []string{"ID", "NAME", "COLOR", "DESCRIPTION"}
+		tableRows := make([][]string, len(labels))
+		for i, l := range labels {
+			tableRows[i] = []string{
+				l.ID,
+				l.Name,
+				l.TextLabel.BackgroundColor,
+				l.TextLabel.Description,
+			}
+		}
+		return output.PrintTable(headers, tableRows)
+	},
+}
+
+// ---- labels create ----
+
+var labelsCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a label",
+	Long: `Create a label that can be attached to campaigns.
+
+Examples:
+  gads-cli labels create --account=1234567890 --name="Brand"
+  gads-cli labels create --account=1234567890 --name="Q4 promo" --color="#FF9900" --description="Holiday campaigns"`,
+	Annotations: mutatingCommand,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, err := accountID()
+		if err != nil {
+			return err
+		}
+		if labelName == "" {
+			return fmt.Errorf("--name is required")
+		}
+		if labelColor != "" && !labelColorRe.MatchString(labelColor) {
+			return fmt.Errorf("--color must be a hex color like #FF9900")
+		}
+
+		label := map[string]any{"name": labelName}
+		textLabel := map[string]any{}
+		if labelColor != "" {
+			textLabel["backgroundColor"] = labelColor
+		}
+		if labelDescription != "" {
+			textLabel["description"] = labelDescription
+		}
+		if len(textLabel) > 0 {
+			label["textLabel"] = textLabel
+		}
+		change := fmt.Sprintf("Account %s: create label %q", cid, labelName)
+		if ok, err := confirmChange("Create this label?", change); !ok {
+			return err
+		}
+		ops := []map[string]any{{"create": label}}
+
+		resp, err := apiClient.MutateLabels(cid, ops)
+		if err != nil {
+			return err
+		}
+		if apiClient.ValidateOnly() {
+			return reportDryRun(resp, len(ops))
+		}
+		if jsonResult() {
+			return printMutateResult(len(ops), resp)
+		}
+		fmt.Printf("Label created: %s\n", labelName)
+		if len(resp.Results) > 0 {
+			fmt.Printf("Resource: %s\n", resp.Results[0].ResourceName)
+		}
+		return nil
+	},
+}
+
+// ---- labels remove ----
+
+var labelsRemoveCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Remove a label",
+	Long: `Remove a label by name or ID. It is detached from everything it was attached to.
+
+Examples:
+  gads-cli labels remove --account=1234567890 --label="Q4 promo"
+  gads-cli labels remove --account=1234567890 --label=555666777`,
+	Annotations: mutatingCommand,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, err := accountID()
+		if err != nil {
+			return err
+		}
+		if labelRef == "" {
+			return fmt.Errorf("--label is required")
+		}
+
+		label, err := resolveLabel(apiClient, cid, labelRef)
+		if err != nil {
+			return err
+		}
+		change := fmt.Sprintf("Label %s %q: remove, detaching it from everything it is attached to", label.ID, label.Name)
+		if ok, err := confirmChange("Remove this label?", change); !ok {
+			return err
+		}
+		ops := []map[string]any{{"remove": label.ResourceName}}
+		resp, err := apiClient.MutateLabels(cid, ops)
+		if err != nil {
+			return err
+		}
+		if apiClient.ValidateOnly() {
+			return reportDryRun(resp, len(ops))
+		}
+		if jsonResult() {
+			return printMutateResult(len(ops), resp)
+		}
+		fmt.Printf("Label %s (%s) removed.\n", label.Name, label.ID)
+		return nil
+	},
+}
+
+// fetchLabels returns the account's labels that are not removed, optionally
+// only those matching cond.
+func fetchLabels(s api.Searcher, cid, cond string) ([]api.Label, error) {
+	query := gaql.Select(
+		"label.id", "label.name", "label.status",
+		"label.text_label.background_color", "label.text_label.description").
+		From("label").
+		Where("label.status != 'REMOVED'").
+		Where(cond).
+		OrderBy("label.name", false).
+		String()
+
+	rows, err := s.Search(cid, query)
+	if err != nil {
+		return nil, err
+	}
+	var labels []api.Label
+	for _, raw := range rows {
+		var row api.LabelRow
+		if err := json.Unmarshal(raw, &row); err != nil {
+			continue
+		}
+		labels = append(labels, row.Label)
+	}
+	return labels, nil
+}
+
+// resolveLabel finds a label by ID or exact name. Commands that take
+// --label=<name or id> share it.
+func resolveLabel(s api.Searcher, cid, ref string) (*api.Label, error) {
+	cond := "label.name = " + gaql.Quote(ref)
+	if api.IsNumericID(ref) {
+		cond = "label.id = " + gaql.Quote(ref)
+	}
+	labels, err := fetchLabels(s, cid, cond)
+	if err != nil {
+		return nil, err
+	}
+	if len(labels) == 0 {
+		return nil, fmt.Errorf("label %q not found", ref)
+	}
+	return &labels[0], nil
+}
+
+// campaignLabelFilter resolves the labels given by repeated --label flags,
+// by name or ID, and returns the condition keeping the campaigns that carry
+// any of them, or "" without labels.
+func campaignLabelFilter(s api.Searcher, cid string, refs []string) (string, error) {
+	if len(refs) == 0 {
+		return "", nil
+	}
+	quoted := make([]string, len(refs))
+	for i, ref := range refs {
+		label, err := resolveLabel(s, cid, ref)
+		if err != nil {
+			return "", err
+		}
+		quoted[i] = gaql.Quote(label.ResourceName)
+	}
+	return "campaign.labels CONTAINS ANY (" + strings.Join(quoted, ", ") + ")", nil
+}
+
+// addCampaignLabelFlag registers the repeatable --label filter of list and
+// insights commands on c.
+func addCampaignLabelFlag(c *cobra.Command, labels *[]string) {
+	c.Flags().StringArrayVar(labels, "label", nil, "Only campaigns with this label, by name or ID (repeatable: campaigns with any of them)")
+}
+
+func init() {
+	labelsCreateCmd.Flags().StringVar(&labelName, "name", "", "Label name (required)")
+	labelsCreateCmd.Flags().StringVar(&labelColor, "color", "", "Background color as #RRGGBB")
+	labelsCreateCmd.Flags().StringVar(&labelDescription, "description", "", "Label description")
+	labelsRemoveCmd.Flags().StringVar(&labelRef, "label", "", "Label name or ID (required)")
+
+	labelsCmd.AddCommand(labelsListCmd, labelsCreateCmd, labelsRemoveCmd)
+	rootCmd.AddCommand(labelsCmd)
+}
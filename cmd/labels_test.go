@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// labelsAPI serves the label 555 "Q4 promo" of account 1234567890 to label
+// searches by name or ID, and records the mutates it receives.
+type labelsAPI struct {
+	queries []string
+	mutates []string // "<service>: <operations>"
+}
+
+func (a *labelsAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Query      string          `json:"query"`
+		Operations json.RawMessage `json:"operations"`
+	}
+	json.NewDecoder(r.Body).Decode(&body)
+	if service, ok := strings.CutSuffix(r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:], ":mutate"); ok {
+		a.mutates = append(a.mutates, service+": "+string(body.Operations))
+		io.WriteString(w, `{"results":[{"resourceName":"customers/1234567890/`+service+`/1"}]}`)
+		return
+	}
+	a.queries = append(a.queries, body.Query)
+	listAll := !strings.Contains(body.Query, "label.name =") && !strings.Contains(body.Query, "label.id =")
+	if listAll || strings.Contains(body.Query, "label.name = 'Q4 promo'") || strings.Contains(body.Query, "label.id = '555'") {
+		io.WriteString(w, `{"results":[{"label":{"resourceName":"customers/1234567890/labels/555","id":"555",`+
+			`"name":"Q4 promo","status":"ENABLED","textLabel":{"backgroundColor":"#FF9900","description":"Holiday"}}}]}`)
+		return
+	}
+	io.WriteString(w, `{"results":[]}`)
+}
+
+// useLabelsAPI points apiClient at a labelsAPI for one test, and restores
+// the labels command flags when it ends.
+func useLabelsAPI(t *testing.T) *labelsAPI {
+	t.Helper()
+	a := &labelsAPI{}
+	useTestAPI(t, a.ServeHTTP)
+	accountFlag = "1234567890"
+	saved := []string{labelRef, labelName, labelColor, labelDescription}
+	t.Cleanup(func() {
+		labelRef, labelName, labelColor, labelDescription = saved[0], saved[1], saved[2], saved[3]
+	})
+	labelRef, labelName, labelColor, labelDescription = "", "", "", ""
+	return a
+}
+
+func TestLabelsList(t *testing.T) {
+	a := useLabelsAPI(t)
+	stdout, _, err := runOutput(t, func() error { return labelsListCmd.RunE(labelsListCmd, nil) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stdout, `"name":"Q4 promo"`) || !strings.Contains(stdout, `"backgroundColor":"#FF9900"`) {
+		t.Errorf("label not listed:\n%s", stdout)
+	}
+	if len(a.queries) != 1 || !strings.Contains(a.queries[0], "label.status != 'REMOVED'") {
+		t.Errorf("queries %q, want one skipping removed labels", a.queries)
+	}
+}
+
+func TestLabelsCreate(t *testing.T) {
+	tests := []struct {
+		name, labelName, color, description string
+		wantOps                             string
+		wantErr                             string
+	}{
+		{name: "name only", labelName: "Brand", wantOps: `labels: [{"create":{"name":"Brand"}}]`},
+		{
+			name: "color and description", labelName: "Q4", color: "#ff9900", description: "Holiday campaigns",
+			wantOps: `labels: [{"create":{"name":"Q4","textLabel":{"backgroundColor":"#ff9900","description":"Holiday campaigns"}}}]`,
+		},
+		{name: "no name", wantErr: "--name is required"},
+		{name: "color name", labelName: "Q4", color: "orange", wantErr: "--color must be a hex color"},
+		{name: "short color", labelName: "Q4", color: "#F90", wantErr: "--color must be a hex color"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := useLabelsAPI(t)
+			labelName, labelColor, labelDescription = tt.labelName, tt.color, tt.description
+
+			_, _, err := runOutput(t, func() error { return labelsCreateCmd.RunE(labelsCreateCmd, nil) })
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("error %v, want one containing %q", err, tt.wantErr)
+				}
+				if len(a.mutates) != 0 {
+					t.Errorf("sent %q", a.mutates)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(a.mutates) != 1 || a.mutates[0] != tt.wantOps {
+				t.Errorf("sent %q, want %q", a.mutates, tt.wantOps)
+			}
+		})
+	}
+}
+
+func TestLabelsRemove(t *testing.T) {
+	tests := []struct {
+		name, ref string
+		wantErr   string
+	}{
+		{name: "by name", ref: "Q4 promo"},
+		{name: "by ID", ref: "555"},
+		{name: "unknown", ref: "Nope", wantErr: `label "Nope" not found`},
+		{name: "no label", wantErr: "--label is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := useLabelsAPI(t)
+			labelRef = tt.ref
+
+			_, _, err := runOutput(t, func() error { return labelsRemoveCmd.RunE(labelsRemoveCmd, nil) })
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("error %v, want one containing %q", err, tt.wantErr)
+				}
+				if len(a.mutates) != 0 {
+					t.Errorf("sent %q", a.mutates)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := `labels: [{"remove":"customers/1234567890/labels/555"}]`
+			if len(a.mutates) != 1 || a.mutates[0] != want {
+				t.Errorf("sent %q, want %q", a.mutates, want)
+			}
+		})
+	}
+}
+
+func TestCampaignsLabel(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantOps string
+		wantErr string
+	}{
+		{
+			name:    "attach by name",
+			args:    []string{"--campaign=111", "--label=Q4 promo"},
+			wantOps: `campaignLabels: [{"create":{"campaign":"customers/1234567890/campaigns/111","label":"customers/1234567890/labels/555"}}]`,
+		},
+		{
+			name:    "detach by ID",
+			args:    []string{"--campaign=111", "--remove-label=555"},
+			wantOps: `campaignLabels: [{"remove":"customers/1234567890/campaignLabels/111~555"}]`,
+		},
+		{name: "both", args: []string{"--campaign=111", "--label=555", "--remove-label=555"}, wantErr: "exactly one of --label or --remove-label"},
+		{name: "neither", args: []string{"--campaign=111"}, wantErr: "exactly one of --label or --remove-label"},
+		{name: "campaign name", args: []string{"--campaign=Brand", "--label=555"}, wantErr: "--campaign must be a numeric ID"},
+		{name: "unknown label", args: []string{"--campaign=111", "--label=Nope"}, wantErr: `label "Nope" not found`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := useLabelsAPI(t)
+			_, _, err := execute(t, newCampaignsLabelCmd(), tt.args...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("error %v, want one containing %q", err, tt.wantErr)
+				}
+				if len(a.mutates) != 0 {
+					t.Errorf("sent %q", a.mutates)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(a.mutates) != 1 || a.mutates[0] != tt.wantOps {
+				t.Errorf("sent %q, want %q", a.mutates, tt.wantOps)
+			}
+		})
+	}
+}
+
+func TestCampaignLabelFilter(t *testing.T) {
+	useLabelsAPI(t)
+	cond, err := campaignLabelFilter(apiClient, "1234567890", nil)
+	if err != nil || cond != "" {
+		t.Errorf("without labels: %q, %v", cond, err)
+	}
+	cond, err = campaignLabelFilter(apiClient, "1234567890", []string{"Q4 promo", "555"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "campaign.labels CONTAINS ANY ('customers/1234567890/labels/555', 'customers/1234567890/labels/555')"
+	if cond != want {
+		t.Errorf("got  %s\nwant %s", cond, want)
+	}
+	if _, err := campaignLabelFilter(apiClient, "1234567890", []string{"Nope"}); err == nil {
+		t.Error("unknown label accepted")
+	}
+}
+
+func TestResolveLabelQuotesName(t *testing.T) {
+	a := useLabelsAPI(t)
+	resolveLabel(apiClient, "1234567890", `it's' OR label.id > '0`)
+	if len(a.queries) != 1 || !strings.Contains(a.queries[0], `label.name = 'it\'s\' OR label.id > \'0'`) {
+		t.Errorf("name not quoted: %q", a.queries)
+	}
+}
@@ -0,0 +1,333 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/gaql"
+	"github.com/the20100/gads-cli/internal/output"
+)
+
+// ---- keywords negative-conflicts ----
+
+// negativeKeyword is a negative keyword with where it is set: an ad group,
+// a campaign, or a negative keyword list attached to campaigns.
+type negativeKeyword struct {
+	Text      string `json:"text"`
+	MatchType string `json:"matchType"`
+	Level     string `json:"level"` // ad group, campaign or list
+	AdGroupID string `json:"adGroupId,omitempty"`
+	ListID    string `json:"listId,omitempty"`
+	ListName  string `json:"listName,omitempty"`
+}
+
+// negativeConflict is a keyword that a negative keyword blocks.
+type negativeConflict struct {
+	CampaignID   string          `json:"campaignId"`
+	CampaignName string          `json:"campaignName"`
+	AdGroupID    string          `json:"adGroupId"`
+	AdGroupName  string          `json:"adGroupName"`
+	CriterionID  string          `json:"criterionId"`
+	Text         string          `json:"text"`
+	MatchType    string          `json:"matchType"`
+	Negative     negativeKeyword `json:"negative"`
+}
+
+func newKeywordsNegativeConflictsCmd() *cobra.Command {
+	var f keywordFlags
+	c := &cobra.Command{
+		Use:   "negative-conflicts",
+		Short: "Find keywords blocked by the account's own negative keywords",
+		Long: `Find the enabled and paused keywords that a negative keyword blocks, so
+they cannot show for their own text. Negatives are read from the keyword's
+ad group, its campaign, and the negative keyword lists attached to the
+campaign; the SET IN column says where each one is set.
+
+Negatives are matched the way Google Ads applies them, without close
+variants (plurals, misspellings and synonyms do not count), ignoring case
+and punctuation around words:
+  broad   every word of the negative is in the keyword, in any order
+  phrase  the negative's words are in the keyword, in order and together
+  exact   the keyword is the negative's words, in order, and nothing else
+
+The command exits with status 1 when there are conflicts, for use in CI.
+
+Examples:
+  gads-cli keywords negative-conflicts --account=1234567890
+  gads-cli keywords negative-conflicts --account=1234567890 --campaign=111222333 --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			if f.campaignID != "" && !api.IsNumericID(f.campaignID) {
+				return fmt.Errorf("--campaign must be a numeric ID")
+			}
+			campaignCond := ""
+			if f.campaignID != "" {
+				campaignCond = "campaign.id = " + gaql.Quote(f.campaignID)
+			}
+
+			query := gaql.Select(
+				"campaign.id", "campaign.name", "ad_group.id", "ad_group.name",
+				"ad_group_criterion.criterion_id", "ad_group_criterion.keyword.text",
+				"ad_group_criterion.keyword.match_type", "ad_group_criterion.negative").
+				From("ad_group_criterion").
+				Where("ad_group_criterion.type = 'KEYWORD'").
+				Where("ad_group_criterion.status != 'REMOVED'").
+				Where("ad_group.status != 'REMOVED'").
+				Where("campaign.status != 'REMOVED'").
+				Where(campaignCond).
+				String()
+			rows, err := apiClient.Search(cid, query)
+			if err != nil {
+				return err
+			}
+			var keywords []api.KeywordRow
+			adGroupNegatives := map[string][]negativeKeyword{}
+			for _, raw := range rows {
+				var row api.KeywordRow
+				if err := json.Unmarshal(raw, &row); err != nil {
+					continue
+				}
+				k := row.AdGroupCriterion
+				if k.Negative {
+					adGroupNegatives[row.AdGroup.ID] = append(adGroupNegatives[row.AdGroup.ID], negativeKeyword{
+						Text: k.Keyword.Text, MatchType: k.Keyword.MatchType,
+						Level: "ad group", AdGroupID: row.AdGroup.ID,
+					})
+					continue
+				}
+				keywords = append(keywords, row)
+			}
+			campaignNegatives, err := fetchCampaignNegatives(cid, campaignCond)
+			if err != nil {
+				return err
+			}
+			listNegatives, err := fetchListNegatives(cid, campaignCond)
+			if err != nil {
+				return err
+			}
+
+			conflicts := []negativeConflict{}
+			for _, row := range keywords {
+				k := row.AdGroupCriterion
+				negatives := slices.Concat(adGroupNegatives[row.AdGroup.ID],
+					campaignNegatives[row.Campaign.ID], listNegatives[row.Campaign.ID])
+				for _, n := range negatives {
+					if !negativeBlocks(k.Keyword.Text, n.Text, n.MatchType) {
+						continue
+					}
+					conflicts = append(conflicts, negativeConflict{
+						CampaignID: row.Campaign.ID, CampaignName: row.Campaign.Name,
+						AdGroupID: row.AdGroup.ID, AdGroupName: row.AdGroup.Name,
+						CriterionID: k.CriterionID, Text: k.Keyword.Text, MatchType: k.Keyword.MatchType,
+						Negative: n,
+					})
+				}
+			}
+			sort.SliceStable(conflicts, func(i, j int) bool {
+				a, b := conflicts[i], conflicts[j]
+				if a.CampaignName != b.CampaignName {
+					return a.CampaignName < b.CampaignName
+				}
+				if a.AdGroupName != b.AdGroupName {
+					return a.AdGroupName < b.AdGroupName
+				}
+				return a.Text < b.Text
+			})
+
+			if output.IsJSON(cmd) {
+				if err := output.PrintJSON(conflicts, output.IsPretty(cmd)); err != nil {
+					return err
+				}
+			} else if len(conflicts) == 0 {
+				fmt.Printf("No keywords are blocked by negative keywords (%d checked).\n", len(keywords))
+			} else {
+				headers := []string{"CAMPAIGN", "AD GROUP", "KEYWORD", "MATCH", "NEGATIVE", "NEG MATCH", "SET IN"}
+				tableRows := make([][]string, len(conflicts))
+				for i, c := range conflicts {
+					tableRows[i] = []string{
+						c.CampaignName, c.AdGroupName, c.Text, c.MatchType,
+						c.Negative.Text, c.Negative.MatchType, negativeLevel(c.Negative),
+					}
+				}
+				if err := output.PrintTable(headers, tableRows); err != nil {
+					return err
+				}
+			}
+			if len(conflicts) > 0 {
+				return fmt.Errorf("%d keyword conflict(s) with negative keywords", len(conflicts))
+			}
+			return nil
+		},
+	}
+	c.Flags().StringVar(&f.campaignID, "campaign", "", "Only check this campaign's keywords")
+	return c
+}
+
+// fetchCampaignNegatives returns the campaign-level negative keywords by
+// campaign ID.
+func fetchCampaignNegatives(cid, cond string) (map[string][]negativeKeyword, error) {
+	query := gaql.Select(
+		"campaign.id", "campaign_criterion.keyword.text", "campaign_criterion.keyword.match_type").
+		From("campaign_criterion").
+		Where("campaign_criterion.type = 'KEYWORD'").
+		Where("campaign_criterion.negative = TRUE").
+		Where("campaign_criterion.status != 'REMOVED'").
+		Where("campaign.status != 'REMOVED'").
+		Where(cond).
+		String()
+	rows, err := apiClient.Search(cid, query)
+	if err != nil {
+		return nil, err
+	}
+	negatives := map[string][]negativeKeyword{}
+	for _, raw := range rows {
+		var row campaignNegativeRow
+		if err := json.Unmarshal(raw, &row); err != nil {
+			continue
+		}
+		k := row.CampaignCriterion.Keyword
+		negatives[row.Campaign.ID] = append(negatives[row.Campaign.ID], negativeKeyword{
+			Text: k.Text, MatchType: k.MatchType, Level: "campaign",
+		})
+	}
+	return negatives, nil
+}
+
+// fetchListNegatives returns the keywords of the negative keyword lists
+// attached to each campaign, by campaign ID.
+func fetchListNegatives(cid, cond string) (map[string][]negativeKeyword, error) {
+	query := gaql.Select("campaign.id", "shared_set.id", "shared_set.name").
+		From("campaign_shared_set").
+		Where("shared_set.type = 'NEGATIVE_KEYWORDS'").
+		Where("shared_set.status = 'ENABLED'").
+		Where("campaign_shared_set.status = 'ENABLED'").
+		Where("campaign.status != 'REMOVED'").
+		Where(cond).
+		String()
+	rows, err := apiClient.Search(cid, query)
+	if err != nil {
+		return nil, err
+	}
+	listsByCampaign := map[string][]api.SharedSet{}
+	var quoted []string
+	seen := map[string]bool{}
+	for _, raw := range rows {
+		var row struct {
+			Campaign  api.Campaign  `json:"campaign"`
+			SharedSet api.SharedSet `json:"sharedSet"`
+		}
+		if err := json.Unmarshal(raw, &row); err != nil {
+			continue
+		}
+		listsByCampaign[row.Campaign.ID] = append(listsByCampaign[row.Campaign.ID], row.SharedSet)
+		if !seen[row.SharedSet.ID] {
+			seen[row.SharedSet.ID] = true
+			quoted = append(quoted, gaql.Quote(row.SharedSet.ID))
+		}
+	}
+	negatives := map[string][]negativeKeyword{}
+	if len(quoted) == 0 {
+		return negatives, nil
+	}
+
+	query = gaql.Select("shared_set.id", "shared_criterion.keyword.text", "shared_criterion.keyword.match_type").
+		From("shared_criterion").
+		Where("shared_criterion.type = 'KEYWORD'").
+		Where("shared_set.id IN (" + strings.Join(quoted, ", ") + ")").
+		String()
+	rows, err = apiClient.Search(cid, query)
+	if err != nil {
+		return nil, err
+	}
+	members := map[string][]negativeKeyword{}
+	for _, raw := range rows {
+		var row struct {
+			SharedSet       api.SharedSet `json:"sharedSet"`
+			SharedCriterion struct {
+				Keyword struct {
+					Text      string `json:"text"`
+					MatchType string `json:"matchType"`
+				} `json:"keyword"`
+			} `json:"sharedCriterion"`
+		}
+		if err := json.Unmarshal(raw, &row); err != nil {
+			continue
+		}
+		k := row.SharedCriterion.Keyword
+		members[row.SharedSet.ID] = append(members[row.SharedSet.ID], negativeKeyword{
+			Text: k.Text, MatchType: k.MatchType, Level: "list", ListID: row.SharedSet.ID,
+		})
+	}
+	for campaignID, lists := range listsByCampaign {
+		for _, l := range lists {
+			for _, n := range members[l.ID] {
+				n.ListName = l.Name
+				negatives[campaignID] = append(negatives[campaignID], n)
+			}
+		}
+	}
+	return negatives, nil
+}
+
+// negativeLevel writes where a negative keyword is set, for the SET IN
+// column: "ad group", "campaign" or `list "Brand terms"`.
+func negativeLevel(n negativeKeyword) string {
+	if n.Level == "list" {
+		return fmt.Sprintf("list %q", n.ListName)
+	}
+	return n.Level
+}
+
+// negativeBlocks reports whether a negative keyword of matchType blocks
+// searches for the text of a keyword. Negatives do not match close
+// variants, so words must be the same, ignoring case and punctuation (see
+// keywordWords):
+//   - BROAD: every word of the negative is in the keyword, in any order;
+//   - PHRASE: the negative's words are in the keyword, in order and
+//     together;
+//   - EXACT: the keyword is the negative's words, in order, with no others.
+func negativeBlocks(keyword, negative, matchType string) bool {
+	kw, neg := keywordWords(keyword), keywordWords(negative)
+	if len(neg) == 0 || len(kw) == 0 {
+		return false
+	}
+	switch strings.ToUpper(matchType) {
+	case "EXACT":
+		return slices.Equal(kw, neg)
+	case "PHRASE":
+		for i := 0; i+len(neg) <= len(kw); i++ {
+			if slices.Equal(kw[i:i+len(neg)], neg) {
+				return true
+			}
+		}
+		return false
+	default:
+		for _, w := range neg {
+			if !slices.Contains(kw, w) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// keywordWords splits keyword text into lowercase words. Punctuation around
+// a word is dropped, as Google ignores it ("shoes," is "shoes"), and so is the
+// + of legacy broad match modifier keywords (+running +shoes). Punctuation
+// inside a word (men's, e-bike) is kept.
+func keywordWords(text string) []string {
+	words := strings.Fields(strings.ToLower(text))
+	for i, w := range words {
+		words[i] = strings.TrimFunc(w, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsNumber(r) })
+	}
+	return slices.DeleteFunc(words, func(w string) bool { return w == "" })
+}
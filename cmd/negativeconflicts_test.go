@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNegativeBlocks(t *testing.T) {
+	tests := []struct {
+		keyword, negative, matchType string
+		want                         bool
+	}{
+		// EXACT: the same words, in the same order, and no others.
+		{"running shoes", "running shoes", "EXACT", true},
+		{"Running Shoes", "running SHOES", "EXACT", true},
+		{"shoes running", "running shoes", "EXACT", false},
+		{"red running shoes", "running shoes", "EXACT", false},
+		{"running shoes", "running", "EXACT", false},
+		{"+running +shoes", "running shoes", "EXACT", true},
+		{"running shoes,", "running shoes", "EXACT", true},
+		{"running  shoes", " running shoes ", "exact", true},
+		{"running running shoes", "running shoes", "EXACT", false},
+
+		// PHRASE: the negative's words together and in order, anywhere.
+		{"red running shoes", "running shoes", "PHRASE", true},
+		{"running shoes sale", "running shoes", "PHRASE", true},
+		{"RUNNING SHOES", "running shoes", "PHRASE", true},
+		{"shoes running", "running shoes", "PHRASE", false},
+		{"running red shoes", "running shoes", "PHRASE", false},
+		{"running running shoes", "running shoes", "PHRASE", true},
+		{"+running +shoes", "running shoes", "PHRASE", true},
+		{"shoes, running", "shoes running", "PHRASE", true},
+		{"free shoes!", "shoes", "phrase", true},
+
+		// BROAD: every word of the negative, in any order.
+		{"shoes for running", "running shoes", "BROAD", true},
+		{"Shoes Running", "running shoes", "BROAD", true},
+		{"running", "running shoes", "BROAD", false},
+		{"running shoes", "running running", "BROAD", true},
+		{"+running +shoes", "+shoes", "BROAD", true},
+		{"cheap shoes,", "shoes", "BROAD", true},
+		{"shoe", "shoes", "BROAD", false},
+		{"men's shoes", "mens", "BROAD", false},
+		{"running shoes", "running shoes", "", true},
+
+		// Nothing to match.
+		{"running shoes", "", "BROAD", false},
+		{"", "running", "BROAD", false},
+		{"running shoes", "+ ,", "PHRASE", false},
+	}
+	for _, tt := range tests {
+		if got := negativeBlocks(tt.keyword, tt.negative, tt.matchType); got != tt.want {
+			t.Errorf("negativeBlocks(%q, %q, %q) = %v, want %v", tt.keyword, tt.negative, tt.matchType, got, tt.want)
+		}
+	}
+}
+
+func TestKeywordWords(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"Running Shoes", []string{"running", "shoes"}},
+		{"+running +shoes", []string{"running", "shoes"}},
+		{"shoes, socks; laces.", []string{"shoes", "socks", "laces"}},
+		{`"running shoes"`, []string{"running", "shoes"}},
+		{"[running shoes]", []string{"running", "shoes"}},
+		{"men's e-bike", []string{"men's", "e-bike"}},
+		{"size 42", []string{"size", "42"}},
+		{"Café Zürich", []string{"café", "zürich"}},
+		{" + - ", []string{}},
+	}
+	for _, tt := range tests {
+		if got := keywordWords(tt.text); !slices.Equal(got, tt.want) {
+			t.Errorf("keywordWords(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,370 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/audit"
+	"github.com/the20100/gads-cli/internal/gaql"
+	"github.com/the20100/gads-cli/internal/output"
+)
+
+var negativesCmd = &cobra.Command{
+	Use:   "negatives",
+	Short: "Manage negative keywords shared across campaigns",
+}
+
+var negativesListsCmd = &cobra.Command{
+	Use:   "lists",
+	Short: "Manage shared negative keyword lists",
+	Long: `Manage negative keyword lists: account-level lists of negative keywords
+(shared sets) that apply to every campaign they are attached to.`,
+}
+
+// negativeListFlags holds the flags of one negatives lists subcommand.
+type negativeListFlags struct {
+	list      string // ID or name
+	name      string
+	texts     []string
+	matchType string
+	campaigns []string
+}
+
+// ---- negatives lists list ----
+
+func newNegativeListsListCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "list",
+		Short: "List negative keyword lists",
+		Long: `List negative keyword lists with the number of keywords in each and the
+number of campaigns they are attached to.
+
+Examples:
+  gads-cli negatives lists list --account=1234567890
+  gads-cli negatives lists list --account=1234567890 --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			lists, err := fetchNegativeLists(apiClient, cid, "")
+			if err != nil {
+				return err
+			}
+			if output.IsQuiet() {
+				ids := make([]string, len(lists))
+				for i, l := range lists {
+					ids[i] = l.ID
+				}
+				return output.PrintIDs(ids)
+			}
+			if output.IsJSON(cmd) {
+				return output.PrintJSON(lists, output.IsPretty(cmd))
+			}
+			if len(lists) == 0 {
+				fmt.Println("No negative keyword lists found.")
+				return nil
+			}
+
+			headers := []string{"ID", "NAME", "STATUS", "KEYWORDS", "CAMPAIGNS"}
+			tableRows := make([][]string, len(lists))
+			for i, l := range lists {
+				tableRows[i] = []string{
+					l.ID,
+					l.Name,
+					output.Colorize(l.Status),
+					fmt.Sprintf("%d", l.MemberCount),
+					fmt.Sprintf("%d", l.ReferenceCount),
+				}
+			}
+			return output.PrintTable(headers, tableRows)
+		},
+	}
+	return c
+}
+
+// ---- negatives lists create ----
+
+func newNegativeListsCreateCmd() *cobra.Command {
+	var f negativeListFlags
+	c := &cobra.Command{
+		Use:   "create",
+		Short: "Create a negative keyword list",
+		Long: `Create an empty negative keyword list. Add keywords to it with
+negatives lists add-keywords and attach it to campaigns with negatives
+lists attach.
+
+Examples:
+  gads-cli negatives lists create --account=1234567890 --name="Brand exclusions"`,
+		Annotations: mutatingCommand,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			if f.name == "" {
+				return fmt.Errorf("--name is required")
+			}
+
+			change := fmt.Sprintf("Account %s: create negative keyword list %q", cid, f.name)
+			if ok, err := confirmChange("Create this list?", change); !ok {
+				return err
+			}
+			ops := []map[string]any{{
+				"create": map[string]any{
+					"name": f.name,
+					"type": "NEGATIVE_KEYWORDS",
+				},
+			}}
+			recordUndo("sharedSets", &audit.Undo{RemoveCreated: true})
+			resp, err := apiClient.MutateSharedSets(cid, ops)
+			if err != nil {
+				return err
+			}
+			if apiClient.ValidateOnly() {
+				return reportDryRun(resp, len(ops))
+			}
+			if jsonResult() {
+				return printMutateResult(len(ops), resp)
+			}
+			fmt.Printf("Negative keyword list created: %s\n", f.name)
+			if len(resp.Results) > 0 {
+				fmt.Printf("Resource: %s\n", resp.Results[0].ResourceName)
+			}
+			return nil
+		},
+	}
+	c.Flags().StringVar(&f.name, "name", "", "List name (required)")
+	return c
+}
+
+// ---- negatives lists add-keywords ----
+
+func newNegativeListsAddKeywordsCmd() *cobra.Command {
+	var f negativeListFlags
+	c := &cobra.Command{
+		Use:   "add-keywords",
+		Short: "Add negative keywords to a list",
+		Long: `Add one or more negative keywords to a list, given by ID or name. Repeat
+--keyword to add several in one request; valid keywords are added even if
+others fail (e.g. duplicates) unless --no-partial-failure is set.
+
+Examples:
+  gads-cli negatives lists add-keywords --account=1234567890 --list=777888999 --keyword=free --match-type=BROAD
+  gads-cli negatives lists add-keywords --account=1234567890 --list="Brand exclusions" --keyword="cheap shoes" --keyword="used shoes" --match-type=PHRASE`,
+		Annotations: mutatingCommand,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			mt := strings.ToUpper(f.matchType)
+			if mt != "BROAD" && mt != "PHRASE" && mt != "EXACT" {
+				return fmt.Errorf("--match-type must be BROAD, PHRASE, or EXACT")
+			}
+			list, err := resolveNegativeList(apiClient, cid, f.list)
+			if err != nil {
+				return err
+			}
+
+			changes := make([]string, len(f.texts))
+			for i, text := range f.texts {
+				changes[i] = fmt.Sprintf("List %s %q: add negative keyword %q [%s]", list.ID, list.Name, text, mt)
+			}
+			if ok, err := confirmChange(fmt.Sprintf("Add %d negative keyword(s)?", len(f.texts)), changes...); !ok {
+				return err
+			}
+
+			ops := make([]map[string]any, len(f.texts))
+			for i, text := range f.texts {
+				ops[i] = map[string]any{
+					"create": map[string]any{
+						"sharedSet": list.ResourceName,
+						"keyword": map[string]any{
+							"text":      text,
+							"matchType": mt,
+						},
+					},
+				}
+			}
+			recordUndo("sharedCriteria", &audit.Undo{RemoveCreated: true})
+			resp, err := apiClient.MutateSharedCriteria(cid, ops)
+			if err != nil {
+				return err
+			}
+			if apiClient.ValidateOnly() {
+				return reportDryRun(resp, len(ops))
+			}
+			if jsonResult() {
+				return printMutateResult(len(ops), resp)
+			}
+			for i, r := range resp.Results {
+				if r.ResourceName == "" || i >= len(f.texts) {
+					continue
+				}
+				fmt.Printf("Negative keyword added to %s: \"%s\" [%s]\n", list.Name, f.texts[i], mt)
+			}
+			if len(ops) > 1 || resp.PartialFailureError != nil {
+				return reportMutate(resp, "added")
+			}
+			return nil
+		},
+	}
+	c.Flags().StringVar(&f.list, "list", "", "Negative keyword list ID or name (required)")
+	c.Flags().StringArrayVar(&f.texts, "keyword", nil, "Keyword text (required, repeatable)")
+	c.Flags().StringVar(&f.matchType, "match-type", "", "Match type: BROAD, PHRASE, or EXACT (required)")
+	for _, name := range []string{"list", "keyword", "match-type"} {
+		c.MarkFlagRequired(name)
+	}
+	return c
+}
+
+// ---- negatives lists attach / detach ----
+
+func newNegativeListsAttachCmd(detach bool) *cobra.Command {
+	var f negativeListFlags
+	use, short, verb := "attach", "Attach a negative keyword list to campaigns", "attached to"
+	long := `Attach a negative keyword list, given by ID or name, to one or more
+campaigns. Repeat --campaign to attach it to several in one request.
+
+Examples:
+  gads-cli negatives lists attach --account=1234567890 --list=777888999 --campaign=111222333
+  gads-cli negatives lists attach --account=1234567890 --list="Brand exclusions" --campaign=111222333 --campaign=444555666`
+	if detach {
+		use, short, verb = "detach", "Detach a negative keyword list from campaigns", "detached from"
+		long = `Detach a negative keyword list, given by ID or name, from one or more
+campaigns. Repeat --campaign to detach it from several in one request. The
+list itself is kept.
+
+Examples:
+  gads-cli negatives lists detach --account=1234567890 --list=777888999 --campaign=111222333
+  gads-cli negatives lists detach --account=1234567890 --list="Brand exclusions" --campaign=111222333 --campaign=444555666`
+	}
+	c := &cobra.Command{
+		Use:         use,
+		Short:       short,
+		Long:        long,
+		Annotations: mutatingCommand,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			for _, id := range f.campaigns {
+				if !api.IsNumericID(id) {
+					return fmt.Errorf("--campaign must be a numeric ID, got %q", id)
+				}
+			}
+			list, err := resolveNegativeList(apiClient, cid, f.list)
+			if err != nil {
+				return err
+			}
+
+			changes := make([]string, len(f.campaigns))
+			ops := make([]map[string]any, len(f.campaigns))
+			// Reattaching restores a detached list.
+			var reattach []map[string]any
+			for i, id := range f.campaigns {
+				campaign := fmt.Sprintf("customers/%s/campaigns/%s", cid, id)
+				create := map[string]any{"create": map[string]any{
+					"campaign":  campaign,
+					"sharedSet": list.ResourceName,
+				}}
+				if detach {
+					changes[i] = fmt.Sprintf("Campaign %s: detach negative keyword list %q", id, list.Name)
+					ops[i] = map[string]any{"remove": api.CampaignSharedSetResourceName(cid, id, list.ID)}
+					reattach = append(reattach, create)
+				} else {
+					changes[i] = fmt.Sprintf("Campaign %s: attach negative keyword list %q", id, list.Name)
+					ops[i] = create
+				}
+			}
+			if ok, err := confirmChange(fmt.Sprintf("Apply %d change(s)?", len(ops)), changes...); !ok {
+				return err
+			}
+
+			if detach {
+				recordUndo("campaignSharedSets", audit.Restore(reattach))
+			} else {
+				recordUndo("campaignSharedSets", &audit.Undo{RemoveCreated: true})
+			}
+			resp, err := apiClient.MutateCampaignSharedSets(cid, ops)
+			if err != nil {
+				return err
+			}
+			if apiClient.ValidateOnly() {
+				return reportDryRun(resp, len(ops))
+			}
+			if jsonResult() {
+				return printMutateResult(len(ops), resp)
+			}
+			if len(ops) > 1 || resp.PartialFailureError != nil {
+				return reportMutate(resp, "campaign(s) "+strings.Fields(verb)[0])
+			}
+			fmt.Printf("Negative keyword list %s %s campaign %s.\n", list.Name, verb, f.campaigns[0])
+			return nil
+		},
+	}
+	c.Flags().StringVar(&f.list, "list", "", "Negative keyword list ID or name (required)")
+	c.Flags().StringArrayVar(&f.campaigns, "campaign", nil, "Campaign ID (required, repeatable)")
+	c.MarkFlagRequired("list")
+	c.MarkFlagRequired("campaign")
+	return c
+}
+
+// fetchNegativeLists returns the account's enabled negative keyword lists,
+// optionally only those matching cond.
+func fetchNegativeLists(s api.Searcher, cid, cond string) ([]api.SharedSet, error) {
+	query := gaql.Select(
+		"shared_set.id", "shared_set.name", "shared_set.type", "shared_set.status",
+		"shared_set.member_count", "shared_set.reference_count").
+		From("shared_set").
+		Where("shared_set.type = 'NEGATIVE_KEYWORDS'").
+		Where("shared_set.status = 'ENABLED'").
+		Where(cond).
+		OrderBy("shared_set.name", false).
+		String()
+
+	rows, err := s.Search(cid, query)
+	if err != nil {
+		return nil, err
+	}
+	lists := []api.SharedSet{}
+	for _, raw := range rows {
+		var row api.SharedSetRow
+		if err := json.Unmarshal(raw, &row); err != nil {
+			continue
+		}
+		lists = append(lists, row.SharedSet)
+	}
+	return lists, nil
+}
+
+// resolveNegativeList finds a negative keyword list by ID or exact name,
+// like resolveLabel.
+func resolveNegativeList(s api.Searcher, cid, ref string) (*api.SharedSet, error) {
+	cond := "shared_set.name = " + gaql.Quote(ref)
+	if api.IsNumericID(ref) {
+		cond = "shared_set.id = " + gaql.Quote(ref)
+	}
+	lists, err := fetchNegativeLists(s, cid, cond)
+	if err != nil {
+		return nil, err
+	}
+	if len(lists) == 0 {
+		return nil, fmt.Errorf("negative keyword list %q not found", ref)
+	}
+	return &lists[0], nil
+}
+
+func init() {
+	negativesListsCmd.AddCommand(
+		newNegativeListsListCmd(), newNegativeListsCreateCmd(), newNegativeListsAddKeywordsCmd(),
+		newNegativeListsAttachCmd(false), newNegativeListsAttachCmd(true),
+	)
+	negativesCmd.AddCommand(negativesListsCmd)
+	rootCmd.AddCommand(negativesCmd)
+}
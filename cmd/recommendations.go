@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/gaql"
+	"github.com/the20100/gads-cli/internal/output"
+)
+
+var recommendationsCmd = &cobra.Command{
+	Use:   "recommendations",
+	Short: "Triage Google Ads optimization recommendations",
+}
+
+var (
+	recType string
+	recIDs  []string
+)
+
+// recommendationPayloadFields are the type-specific payloads selected by
+// recommendations list so --json carries the full recommendation.
+var recommendationPayloadFields = []string{
+	"recommendation.campaign_budget_recommendation",
+	"recommendation.keyword_recommendation",
+	"recommendation.responsive_search_ad_recommendation",
+	"recommendation.responsive_search_ad_improve_ad_strength_recommendation",
+	"recommendation.target_cpa_opt_in_recommendation",
+	"recommendation.maximize_conversions_opt_in_recommendation",
+}
+
+// ---- recommendations list ----
+
+var recommendationsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List active recommendations with their estimated impact",
+	Long: `List recommendations that have not been dismissed, with the affected
+campaign and the estimated change in clicks and cost if applied.
+
+--json prints the full recommendation, including its type-specific payload.
+
+Examples:
+  gads-cli recommendations list --account=1234567890
+  gads-cli recommendations list --account=1234567890 --type=KEYWORD
+  gads-cli recommendations list --account=1234567890 --type=CAMPAIGN_BUDGET --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, err := accountID()
+		if err != nil {
+			return err
+		}
+
+		query := gaql.Select(
+			"recommendation.resource_name", "recommendation.type",
+			"recommendation.campaign", "recommendation.ad_group", "recommendation.dismissed",
+			"recommendation.impact.base_metrics.impressions", "recommendation.impact.base_metrics.clicks",
+			"recommendation.impact.base_metrics.cost_micros", "recommendation.impact.base_metrics.conversions",
+			"recommendation.impact.potential_metrics.impressions", "recommendation.impact.potential_metrics.clicks",
+			"recommendation.impact.potential_metrics.cost_micros", "recommendation.impact.potential_metrics.conversions").
+			Select(recommendationPayloadFields...).
+			From("recommendation").
+			Where("recommendation.dismissed = FALSE").
+			WhereIf(recType != "", "recommendation.type = "+gaql.Quote(strings.ToUpper(recType))).
+			OrderBy("recommendation.type", false).
+			String()
+
+		rows, err := apiClient.Search(cid, query)
+		if err != nil {
+			return err
+		}
+
+		if output.IsJSON(cmd) {
+			// Raw rows keep the type-specific payloads Recommendation doesn't model.
+			if rows == nil {
+				rows = []json.RawMessage{}
+			}
+			return output.PrintJSON(rows, output.IsPretty(cmd))
+		}
+
+		var recs []api.Recommendation
+		for _, raw := range rows {
+			var row api.RecommendationRow
+			if err := json.Unmarshal(raw, &row); err != nil {
+				continue
+			}
+			recs = append(recs, row.Recommendation)
+		}
+		if len(recs) == 0 {
+			fmt.Println("No recommendations found.")
+			return nil
+		}
+
+		headers := []string{"ID", "TYPE", "CAMPAIGN", "+CLICKS", "+COST"}
+		tableRows := make([][]string, len(recs))
+		for i, r := range recs {
+			base, potential := r.Impact.BaseMetrics, r.Impact.PotentialMetrics
+			campaign := "-"
+			if r.Campaign != "" {
+				campaign = api.ResourceID(r.Campaign)
+			}
+			tableRows[i] = []string{
+				api.ResourceID(r.ResourceName),
+				r.Type,
+				campaign,
+				fmt.Sprintf("%+.0f", potential.Clicks-base.Clicks),
+				money(cid, int64(potential.CostMicros-base.CostMicros)),
+			}
+		}
+		return output.PrintTable(headers, tableRows)
+	},
+}
+
+// ---- recommendations dismiss ----
+
+var recommendationsDismissCmd = &cobra.Command{
+	Use:   "dismiss",
+	Short: "Dismiss recommendations",
+	Long: `Dismiss one or more recommendations by ID (as shown by recommendations list)
+or full resource name.
+
+Examples:
+  gads-cli recommendations dismiss --account=1234567890 --id=REC_ID
+  gads-cli recommendations dismiss --account=1234567890 --id=REC_ID_1 --id=REC_ID_2`,
+	Annotations: mutatingCommand,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, names, err := recommendationTargets()
+		if err != nil {
+			return err
+		}
+		changes, err := recommendationChanges(cid, names, "dismiss")
+		if err != nil {
+			return err
+		}
+		if ok, err := confirmChange(fmt.Sprintf("Dismiss %d recommendation(s)?", len(names)), changes...); !ok {
+			return err
+		}
+		resp, err := apiClient.DismissRecommendations(cid, names)
+		if err != nil {
+			return err
+		}
+		return reportMutate(resp, "dismissed")
+	},
+}
+
+// ---- recommendations apply ----
+
+var recommendationsApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Apply recommendations (changes bids, budgets, keywords or ads)",
+	Long: `Apply one or more recommendations by ID (as shown by recommendations list)
+or full resource name. Applying changes the account, so the command asks
+for confirmation unless --yes is given.
+
+The API has no validate-only mode for this endpoint, so --dry-run is refused.
+
+Examples:
+  gads-cli recommendations apply --account=1234567890 --id=REC_ID
+  gads-cli recommendations apply --account=1234567890 --id=REC_ID --yes`,
+	Annotations: mutatingCommand,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, names, err := recommendationTargets()
+		if err != nil {
+			return err
+		}
+		if apiClient.ValidateOnly() {
+			return fmt.Errorf("--dry-run is not supported: the API cannot validate recommendations without applying them")
+		}
+		changes, err := recommendationChanges(cid, names, "apply")
+		if err != nil {
+			return err
+		}
+		if ok, err := confirmChange(fmt.Sprintf("Apply %d recommendation(s) to account %s?", len(names), cid), changes...); !ok {
+			return err
+		}
+		resp, err := apiClient.ApplyRecommendations(cid, names)
+		if err != nil {
+			return err
+		}
+		return reportMutate(resp, "applied")
+	},
+}
+
+// recommendationTargets validates --account and --id and returns the
+// customer ID and the recommendation resource names.
+func recommendationTargets() (string, []string, error) {
+	cid, err := accountID()
+	if err != nil {
+		return "", nil, err
+	}
+	if len(recIDs) == 0 {
+		return "", nil, fmt.Errorf("--id is required")
+	}
+	names := make([]string, len(recIDs))
+	for i, id := range recIDs {
+		if strings.HasPrefix(id, "customers/") {
+			names[i] = id
+			continue
+		}
+		names[i] = fmt.Sprintf("customers/%s/recommendations/%s", cid, id)
+	}
+	return cid, names, nil
+}
+
+// recommendationChanges describes the recommendations names, with their
+// type and campaign, for the confirmation of verb. IDs the API does not know
+// are listed as-is and left for the mutate to reject.
+func recommendationChanges(cid string, names []string, verb string) ([]string, error) {
+	if assumeYes() || apiClient.ValidateOnly() {
+		return nil, nil
+	}
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = gaql.Quote(n)
+	}
+	query := gaql.Select("recommendation.resource_name", "recommendation.type", "recommendation.campaign").
+		From("recommendation").
+		Where("recommendation.resource_name IN (" + strings.Join(quoted, ", ") + ")").
+		String()
+	rows, err := apiClient.Search(cid, query)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]api.Recommendation, len(rows))
+	for _, raw := range rows {
+		var row api.RecommendationRow
+		if json.Unmarshal(raw, &row) == nil {
+			known[row.Recommendation.ResourceName] = row.Recommendation
+		}
+	}
+	changes := make([]string, len(names))
+	for i, n := range names {
+		line := fmt.Sprintf("Recommendation %s: %s", api.ResourceID(n), verb)
+		if r, ok := known[n]; ok {
+			line = fmt.Sprintf("Recommendation %s (%s", api.ResourceID(n), r.Type)
+			if r.Campaign != "" {
+				line += ", campaign " + api.ResourceID(r.Campaign)
+			}
+			line += "): " + verb
+		}
+		changes[i] = line
+	}
+	return changes, nil
+}
+
+func init() {
+	recommendationsListCmd.Flags().StringVar(&recType, "type", "", "Only list this recommendation type (e.g. KEYWORD, CAMPAIGN_BUDGET)")
+	for _, c := range []*cobra.Command{recommendationsDismissCmd, recommendationsApplyCmd} {
+		c.Flags().StringArrayVar(&recIDs, "id", nil, "Recommendation ID or resource name (repeatable, required)")
+	}
+
+	recommendationsCmd.AddCommand(recommendationsListCmd, recommendationsDismissCmd, recommendationsApplyCmd)
+	rootCmd.AddCommand(recommendationsCmd)
+}
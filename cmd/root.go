@@ -2,20 +2,71 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
 	"runtime"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/cache"
 	"github.com/the20100/gads-cli/internal/config"
+	"github.com/the20100/gads-cli/internal/output"
+	"github.com/the20100/gads-cli/internal/version"
 	"golang.org/x/oauth2"
 )
 
 var (
-	jsonFlag   bool
-	prettyFlag bool
-	apiClient  *api.Client
+	jsonFlag        bool
+	prettyFlag      bool
+	csvFlag         bool
+	jsonlFlag       bool
+	jsonlSummary    bool
+	noColorFlag     bool
+	wideFlag        bool
+	rawNumbers      bool
+	quietFlag       bool
+	markdownFlag    bool
+	noCmdHeader     bool
+	fieldsFlag      string
+	outputFlag      string
+	formatFlag      string
+	profileFlag     string
+	loginIDFlag     string
+	accountFlag     string
+	retriesFlag     int
+	timeoutFlag     time.Duration
+	debugFlag       bool
+	debugLimit      int
+	maxRowsFlag     int
+	noPartial       bool
+	dryRunFlag      bool
+	yesFlag         bool
+	noAuditFlag     bool
+	recordFlag      bool
+	verboseFlag     bool
+	metaFlag        bool
+	apiVersion      string
+	concurrencyFlag int
+	caCertFlag      string
+	cacheTTL        time.Duration
+	apiClient       *api.Client
+
+	// defaultAccount and accountAliases are the profile's default_account
+	// and aliases, loaded with the credentials; see accountID.
+	defaultAccount string
+	accountAliases map[string]string
+
+	// baseTransport carries proxy and --ca-cert settings for every request.
+	baseTransport http.RoundTripper
+
+	// cancelTimeout releases the per-command --timeout context.
+	cancelTimeout context.CancelFunc = func() {}
 )
 
 var rootCmd = &cobra.Command{
@@ -33,51 +84,275 @@ Then explore your accounts:
   gads-cli accounts list
   gads-cli campaigns list --account=<id>
 
-Credential file: ~/.config/gads/credentials.json`,
+Credential file: ~/.config/gads/credentials.json
+
+Credentials can also be supplied via environment variables, which take
+precedence over the file: GADS_CLIENT_ID, GADS_CLIENT_SECRET,
+GADS_DEVELOPER_TOKEN, GADS_REFRESH_TOKEN, GADS_LOGIN_CUSTOMER_ID.
+Set GADS_DEBUG=1 (or pass --debug) to trace HTTP traffic on stderr.
+
+Requests honor HTTPS_PROXY, HTTP_PROXY and NO_PROXY. Behind a proxy with a
+private CA, pass --ca-cert=/path/to/ca.pem (or set GADS_CA_CERT).
+
+Commands act on the account given by --account, then GADS_ACCOUNT, then
+the default set with: gads-cli config set default-account <id>
+
+Use --profile (or GADS_PROFILE) to switch between credential profiles stored
+in ~/.config/gads/profiles/<name>.json.
+
+Exit codes:
+  0  success
+  1  other error
+  2  authentication (missing, expired or revoked credentials)
+  3  permission denied
+  4  invalid argument or resource not found
+  5  quota or rate limit exceeded`,
 	SilenceUsage: true,
 }
 
 // Execute is the entrypoint called by main.
+// SIGINT/SIGTERM cancel the command context so in-flight API calls stop promptly.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	registerCompletions()
+	err := rootCmd.ExecuteContext(ctx)
+	cancelTimeout()
+	stop()
+	if apiClient != nil && verboseRequested() {
+		printUsage(apiClient.Usage())
+	}
+	if jsonlFlag && jsonlSummary {
+		if serr := output.PrintJSONLSummary(err); serr != nil && err == nil {
+			err = serr
+		}
+	}
+	// --output is only written once the command has succeeded.
+	n, ferr := output.Finish(err == nil)
+	if ferr != nil {
+		output.PrintError(ferr)
+		if err == nil {
+			err = ferr
+		}
+	} else if err == nil && outputFlag != "" {
+		fmt.Fprintf(os.Stderr, "wrote %d rows to %s\n", n, outputFlag)
+	}
+	if err != nil {
+		os.Exit(exitCode(err))
+	}
+}
+
+// verboseRequested reports whether --verbose was given. Commands with a
+// --verbose of their own (insights, accounts list) shadow the global flag,
+// so it is looked up on the command that ran.
+func verboseRequested() bool {
+	if activeCmd == nil {
+		return verboseFlag
 	}
+	f := activeCmd.Flags().Lookup("verbose")
+	return f != nil && f.Value.String() == "true"
+}
+
+// printUsage prints the API requests of the command on stderr, e.g.
+// "API calls: 14 search, 2 mutate / 37 operations".
+func printUsage(u api.Usage) {
+	line := fmt.Sprintf("API calls: %d search, %d mutate / %d operations", u.Search, u.Mutate, u.Operations)
+	if u.Other > 0 {
+		line += fmt.Sprintf(", %d other", u.Other)
+	}
+	fmt.Fprintln(os.Stderr, line)
+}
+
+// jsonMeta is the "_meta" object of --meta: the API requests made so far.
+func jsonMeta() any {
+	var u api.Usage
+	if apiClient != nil {
+		u = apiClient.Usage()
+	}
+	return map[string]any{"requests": u}
+}
+
+// Process exit codes, documented in the root command's help.
+const (
+	exitError      = 1
+	exitAuth       = 2
+	exitPermission = 3
+	exitInvalid    = 4
+	exitQuota      = 5
+)
+
+// exitCode maps an error to a process exit code so scripts can tell expired
+// credentials from bad input or exhausted quota.
+func exitCode(err error) int {
+	var gErr *api.GoogleAdsError
+	if errors.As(err, &gErr) {
+		switch gErr.Kind() {
+		case api.KindAuth:
+			return exitAuth
+		case api.KindPermission:
+			return exitPermission
+		case api.KindInvalid:
+			return exitInvalid
+		case api.KindQuota:
+			return exitQuota
+		}
+		return exitError
+	}
+	// A failed token refresh (e.g. invalid_grant) means the credentials are bad.
+	var rErr *oauth2.RetrieveError
+	if errors.As(err, &rErr) {
+		return exitAuth
+	}
+	return exitError
 }
 
 func init() {
 	rootCmd.PersistentFlags().BoolVar(&jsonFlag, "json", false, "Force JSON output")
 	rootCmd.PersistentFlags().BoolVar(&prettyFlag, "pretty", false, "Force pretty-printed JSON output (implies --json)")
+	rootCmd.PersistentFlags().BoolVar(&csvFlag, "csv", false, "Write tables as CSV (RFC 4180), e.g. for spreadsheets")
+	rootCmd.MarkFlagsMutuallyExclusive("json", "csv")
+	rootCmd.MarkFlagsMutuallyExclusive("pretty", "csv")
+	rootCmd.PersistentFlags().BoolVar(&markdownFlag, "markdown", false, "Write tables as GitHub-flavored markdown, headed by the command line")
+	rootCmd.PersistentFlags().BoolVar(&noCmdHeader, "no-command-header", false, "With --markdown, omit the command line above the table")
+	for _, f := range []string{"json", "pretty", "csv"} {
+		rootCmd.MarkFlagsMutuallyExclusive("markdown", f)
+	}
+	rootCmd.PersistentFlags().BoolVar(&jsonlFlag, "jsonl", false, "Print one compact JSON object per row, streaming rows as they arrive where supported")
+	rootCmd.PersistentFlags().BoolVar(&jsonlSummary, "jsonl-summary", false, `With --jsonl, end with a {"summary":{"rows":N,"ok":...}} line`)
+	for _, f := range []string{"pretty", "csv", "markdown"} {
+		rootCmd.MarkFlagsMutuallyExclusive("jsonl", f)
+	}
+	rootCmd.PersistentFlags().BoolVar(&noColorFlag, "no-color", false, "Disable colored statuses in tables (env: NO_COLOR)")
+	rootCmd.PersistentFlags().BoolVar(&wideFlag, "wide", false, "Print table cells in full instead of truncating them to fit the terminal")
+	rootCmd.PersistentFlags().BoolVar(&rawNumbers, "raw-numbers", false, "Print plain numbers in tables: no thousands separators or currency symbols")
+	rootCmd.PersistentFlags().StringVar(&formatFlag, "format", "", "Print each result row with a Go template, e.g. '{{.Campaign.ID}} {{.Campaign.Name}}'")
+	rootCmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", "", "Write the result (table, JSON or CSV) to this file instead of stdout")
+	rootCmd.PersistentFlags().StringVar(&fieldsFlag, "fields", "", "Comma-separated fields to print: JSON dot-paths (e.g. campaign.id,metrics.clicks) or table column names")
+	for _, f := range []string{"json", "pretty", "csv", "jsonl", "markdown", "fields"} {
+		rootCmd.MarkFlagsMutuallyExclusive("format", f)
+	}
+	rootCmd.PersistentFlags().BoolVarP(&quietFlag, "quiet", "q", false, "List commands print only IDs, one per line (keywords: <adGroupId>~<criterionId>)")
+	for _, f := range []string{"json", "pretty", "csv", "jsonl", "markdown", "fields", "format"} {
+		rootCmd.MarkFlagsMutuallyExclusive("quiet", f)
+	}
+	rootCmd.PersistentFlags().StringVar(&profileFlag, "profile", "", "Credentials profile to use (env: GADS_PROFILE, default: default)")
+	rootCmd.PersistentFlags().IntVar(&retriesFlag, "retries", api.DefaultRetries, "Retries for transient API errors (429, 5xx) with exponential backoff")
+	rootCmd.PersistentFlags().DurationVar(&timeoutFlag, "timeout", 120*time.Second, "Maximum time for a command's API calls (0 disables the timeout)")
+	rootCmd.PersistentFlags().BoolVar(&debugFlag, "debug", false, "Log HTTP requests and responses to stderr, with credentials redacted (env: GADS_DEBUG=1)")
+	rootCmd.PersistentFlags().BoolVar(&verboseFlag, "verbose", false, "Print the number of API requests the command made to stderr when it ends")
+	rootCmd.PersistentFlags().BoolVar(&metaFlag, "meta", false, `Add a "_meta" object with the command's API request counts to JSON output`)
+	rootCmd.PersistentFlags().IntVar(&debugLimit, "debug-body-limit", api.DefaultDebugBodyLimit, "Truncate bodies logged by --debug after this many bytes (0 = no limit)")
+	rootCmd.PersistentFlags().IntVar(&concurrencyFlag, "concurrency", api.DefaultConcurrency, "Maximum parallel API queries for multi-account commands")
+	rootCmd.PersistentFlags().IntVar(&maxRowsFlag, "max-rows", 0, "Stop fetching query results after N rows (0 = no limit)")
+	rootCmd.PersistentFlags().BoolVar(&dryRunFlag, "dry-run", false, "Validate mutations with the API (validateOnly) without applying them")
+	rootCmd.PersistentFlags().BoolVar(&noPartial, "no-partial-failure", false, "Reject a whole mutate batch if any operation fails, instead of applying the valid ones")
+	rootCmd.PersistentFlags().StringVar(&apiVersion, "api-version", "", "Google Ads API version, e.g. v23 (default: api_version from the credentials file, then "+api.DefaultAPIVersion+")")
+	rootCmd.PersistentFlags().DurationVar(&cacheTTL, "cache-ttl", 0, "Reuse read query results for this long, e.g. 5m (default: cache_ttl from the credentials file, then off)")
+	rootCmd.PersistentFlags().StringVar(&caCertFlag, "ca-cert", "", "PEM file with extra CA certificates to trust, e.g. for a TLS-intercepting proxy (env: GADS_CA_CERT)")
+	rootCmd.PersistentFlags().BoolVarP(&yesFlag, "yes", "y", false, "Apply changes without asking for confirmation (env: GADS_ASSUME_YES=1)")
+	rootCmd.PersistentFlags().BoolVar(&noAuditFlag, "no-audit", false, "Do not record this command's changes in the audit log (see: gads-cli audit)")
+	rootCmd.PersistentFlags().BoolVar(&recordFlag, "record", false, "Save API responses to GADS_MOCK_DIR for replaying them offline (see: Mock mode in the README)")
+	rootCmd.PersistentFlags().StringVar(&accountFlag, "account", "", "Customer account ID or alias (env: GADS_ACCOUNT; default: default_account from the credentials file)")
+	rootCmd.PersistentFlags().StringVar(&loginIDFlag, "login-customer-id", "", "Manager account ID or alias to send as login-customer-id, overriding the stored MCC (env: GADS_LOGIN_CUSTOMER_ID)")
 
 	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		activeCmd = cmd
+		applyPreferences(cmd)
+		output.SetCSV(csvFlag)
+		header := ""
+		if !noCmdHeader {
+			header = commandLine()
+		}
+		output.SetMarkdown(markdownFlag, header)
+		output.SetJSONL(jsonlFlag)
+		output.SetNoColor(noColorFlag)
+		output.SetWide(wideFlag)
+		output.SetQuiet(quietFlag)
+		if metaFlag {
+			output.SetJSONMeta(jsonMeta)
+		}
+		if jsonlSummary && !jsonlFlag {
+			return fmt.Errorf("--jsonl-summary requires --jsonl")
+		}
+		output.SetFields(strings.Split(fieldsFlag, ","))
+		if err := output.SetFormat(formatFlag); err != nil {
+			return err
+		}
+		if outputFlag != "" {
+			if err := output.SetFile(outputFlag); err != nil {
+				return err
+			}
+		}
+		// Thousands separators and currency symbols are for people; JSON and
+		// CSV stay raw.
+		api.SetDigitGrouping(!rawNumbers && !output.IsJSON(cmd) && !output.IsCSV())
+		if err := selectProfile(); err != nil {
+			return err
+		}
+		if timeoutFlag > 0 {
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeoutFlag)
+			cancelTimeout = cancel
+			cmd.SetContext(ctx)
+		}
 		if isSkipPreRunCommand(cmd) {
 			return nil
 		}
-		return initAPIClient()
+		return initAPIClient(cmd)
+	}
+	rootCmd.PersistentPostRun = func(cmd *cobra.Command, args []string) {
+		// Shell completion output must stay free of notes.
+		if cmd.Name() == cobra.ShellCompRequestCmd || cmd.Name() == cobra.ShellCompNoDescRequestCmd {
+			return
+		}
+		if apiClient != nil && apiClient.Truncated() {
+			fmt.Fprintf(os.Stderr, "Note: results truncated at %d rows (--max-rows)\n", maxRowsFlag)
+		}
+		if apiClient != nil && apiClient.FromCache() {
+			fmt.Fprintln(os.Stderr, "Note: served from cache (--cache-ttl); run `gads-cli cache clear` to refetch")
+		}
 	}
 
 	rootCmd.AddCommand(infoCmd)
 }
 
-// savingTokenSource wraps an oauth2.TokenSource and persists refreshed tokens to disk.
-type savingTokenSource struct {
-	source oauth2.TokenSource
-	creds  *config.Credentials
-}
-
-func (s *savingTokenSource) Token() (*oauth2.Token, error) {
-	token, err := s.source.Token()
+// selectProfile applies --profile (or GADS_PROFILE) and builds
+// baseTransport from --ca-cert (or GADS_CA_CERT).
+func selectProfile() error {
+	profile := profileFlag
+	if profile == "" {
+		profile = resolveEnv("GADS_PROFILE")
+	}
+	if err := config.SetProfile(profile); err != nil {
+		return err
+	}
+	caCert := caCertFlag
+	if caCert == "" {
+		caCert = resolveEnv("GADS_CA_CERT")
+	}
+	t, err := api.NewTransport(caCert)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	if token.AccessToken != s.creds.AccessToken {
-		s.creds.AccessToken = token.AccessToken
-		s.creds.TokenExpiry = token.Expiry
-		if token.TokenType != "" {
-			s.creds.TokenType = token.TokenType
+	baseTransport = t
+	return nil
+}
+
+// commandLine returns the invocation, shell-quoted, for the --markdown
+// header.
+func commandLine() string {
+	return shellJoin(os.Args[1:])
+}
+
+// shellJoin returns the command line running rootCmd with args, quoted for
+// a POSIX shell.
+func shellJoin(args []string) string {
+	parts := []string{rootCmd.Name()}
+	for _, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n'\"\\$`|&;<>()*?[]{}!#~") {
+			arg = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
 		}
-		_ = config.Save(s.creds)
+		parts = append(parts, arg)
 	}
-	return token, nil
+	return strings.Join(parts, " ")
 }
 
 func maskOrEmpty(v string) string {
@@ -100,40 +375,248 @@ func resolveEnv(names ...string) string {
 	return ""
 }
 
-func initAPIClient() error {
+// initAPIClient builds apiClient from the stored credentials for cmd. Its
+// requests are bound to cmd's context, so --timeout and Ctrl-C abort them.
+func initAPIClient(cmd *cobra.Command) error {
 	creds, err := config.Load()
 	if err != nil {
 		return fmt.Errorf("failed to load credentials: %w", err)
 	}
-	if creds.RefreshToken == "" {
-		return fmt.Errorf("not authenticated — run: gads-cli auth login")
+	// With GADS_MOCK_DIR, responses are replayed from the directory, or
+	// recorded to it with --record.
+	mockDir := os.Getenv("GADS_MOCK_DIR")
+	if recordFlag && mockDir == "" {
+		return fmt.Errorf("--record needs GADS_MOCK_DIR set to the directory to record to")
+	}
+	replay := mockDir != "" && !recordFlag
+	if replay {
+		// Replayed changes were never made, so there is nothing to audit.
+		noAuditFlag = true
+	} else {
+		if !creds.Authenticated() {
+			return fmt.Errorf("not authenticated — run: gads-cli auth login")
+		}
+		if creds.DeveloperToken == "" {
+			return fmt.Errorf("developer token not set — run: gads-cli auth login")
+		}
+	}
+
+	defaultAccount = creds.DefaultAccount
+	accountAliases = creds.Aliases
+	loginID, err := loginCustomerID(creds)
+	if err != nil {
+		return err
 	}
-	if creds.DeveloperToken == "" {
-		return fmt.Errorf("developer token not set — run: gads-cli auth login")
+	opts := []api.Option{api.WithAPIVersion(resolveAPIVersion(creds))}
+	if replay {
+		hc := &http.Client{Transport: api.NewReplayTransport(mockDir)}
+		apiClient = api.New(hc, "mock", loginID, opts...).WithContext(cmd.Context())
+		configureAPIClient(loginID)
+		return nil
 	}
 
-	oauthCfg := config.NewOAuthConfig(creds)
-	token := &oauth2.Token{
-		AccessToken:  creds.AccessToken,
-		RefreshToken: creds.RefreshToken,
-		TokenType:    creds.TokenType,
-		Expiry:       creds.TokenExpiry,
+	ts, err := newTokenSource(creds)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, api.WithTokenRefresh(ts.Invalidate))
+	ttl, err := resolveCacheTTL(creds)
+	if err != nil {
+		return err
+	}
+	// Commands that change state always read live data, and recording needs
+	// every response from the API.
+	if ttl > 0 && !isMutatingCommand(cmd) && !isAuthCommand(cmd) && !recordFlag {
+		dir, err := cache.Dir()
+		if err != nil {
+			return err
+		}
+		opts = append(opts, api.WithCache(cache.New(dir, ttl)))
+	}
+	if !noAuditFlag {
+		opts = append(opts, api.WithMutationLog(auditMutation))
 	}
-	ts := oauthCfg.TokenSource(context.Background(), token)
-	savingTS := &savingTokenSource{source: ts, creds: creds}
-	httpClient := oauth2.NewClient(context.Background(), savingTS)
 
-	apiClient = api.New(httpClient, creds.DeveloperToken, creds.ManagerCustomerID)
+	hc := authorizedClient(ts)
+	if recordFlag {
+		hc.Transport = api.NewRecordTransport(hc.Transport, mockDir)
+	}
+	apiClient = api.New(hc, creds.DeveloperToken, loginID, opts...).WithContext(cmd.Context())
+	configureAPIClient(loginID)
 	return nil
 }
 
+// configureAPIClient applies the global flags to apiClient.
+func configureAPIClient(loginID string) {
+	apiClient.SetRetries(retriesFlag)
+	apiClient.SetMaxRows(maxRowsFlag)
+	apiClient.SetPartialFailure(!noPartial)
+	apiClient.SetValidateOnly(dryRunFlag)
+	if debugEnabled() {
+		apiClient.SetDebug(os.Stderr)
+		fmt.Fprintf(os.Stderr, "[debug] login-customer-id: %s\n", orNone(loginID))
+	} else {
+		// A spinner would garble the --debug log on the same stderr.
+		apiClient.SetProgress(output.NewProgress())
+	}
+}
+
+// debugEnabled reports whether --debug or GADS_DEBUG is set.
+func debugEnabled() bool {
+	if debugFlag {
+		return true
+	}
+	switch strings.ToLower(os.Getenv("GADS_DEBUG")) {
+	case "1", "true", "yes":
+		return true
+	}
+	return false
+}
+
+// oauthContext returns the context handed to oauth2, which decides the HTTP
+// client used for API calls and token refreshes. It carries baseTransport,
+// wrapped with --debug in a logging transport so both are traced.
+func oauthContext() context.Context {
+	rt := baseTransport
+	if debugEnabled() {
+		rt = api.NewDebugTransport(rt, os.Stderr, debugLimit)
+	}
+	if rt == nil {
+		return context.Background()
+	}
+	return context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Transport: rt})
+}
+
+func orNone(v string) string {
+	if v == "" {
+		return "(none)"
+	}
+	return v
+}
+
+// resolveAPIVersion returns the API version to use: --api-version, then the
+// credentials file's api_version, then api.DefaultAPIVersion.
+func resolveAPIVersion(creds *config.Credentials) string {
+	if apiVersion != "" {
+		return api.NormalizeAPIVersion(apiVersion)
+	}
+	if creds != nil && creds.APIVersion != "" {
+		return api.NormalizeAPIVersion(creds.APIVersion)
+	}
+	return api.DefaultAPIVersion
+}
+
+// resolveCacheTTL returns how long query results are cached: --cache-ttl,
+// then the credentials file's cache_ttl. Zero disables the cache.
+func resolveCacheTTL(creds *config.Credentials) (time.Duration, error) {
+	if rootCmd.PersistentFlags().Changed("cache-ttl") || creds.CacheTTL == "" {
+		return cacheTTL, nil
+	}
+	ttl, err := time.ParseDuration(creds.CacheTTL)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cache_ttl %q in credentials file: %w", creds.CacheTTL, err)
+	}
+	return ttl, nil
+}
+
+// loginCustomerID returns the manager account used as login-customer-id:
+// --login-customer-id, then GADS_LOGIN_CUSTOMER_ID (applied by config.Load),
+// then the stored manager account. Each may be an alias.
+func loginCustomerID(creds *config.Credentials) (string, error) {
+	id := loginIDFlag
+	if id == "" {
+		id = creds.ManagerCustomerID
+	}
+	if id == "" {
+		return "", nil
+	}
+	return resolveAccount(id, creds.Aliases)
+}
+
+// accountID returns the customer account a command acts on: --account, then
+// GADS_ACCOUNT, then the profile's default_account (config set
+// default-account).
+func accountID() (string, error) {
+	account := accountFlag
+	if account == "" {
+		account = resolveEnv("GADS_ACCOUNT")
+	}
+	if account == "" {
+		account = defaultAccount
+	}
+	if account == "" {
+		return "", fmt.Errorf("--account is required — pass it, set GADS_ACCOUNT, or run: gads-cli config set default-account <id>")
+	}
+	return resolveAccount(account, accountAliases)
+}
+
+// resolveAccount turns an account given by the user into a customer ID: a
+// literal ID such as 123-456-7890, or an alias from config alias add. When
+// an alias looks like an ID, the literal ID wins, with a warning.
+func resolveAccount(ref string, aliases map[string]string) (string, error) {
+	id := api.CleanCustomerID(ref)
+	alias, isAlias := aliases[ref]
+	if api.IsNumericID(id) {
+		if isAlias && alias != id {
+			fmt.Fprintf(os.Stderr, "Warning: %q is a customer ID and an alias of %s; using the customer ID\n", ref, alias)
+		}
+		return id, nil
+	}
+	if isAlias {
+		return alias, nil
+	}
+	return "", fmt.Errorf("%q is neither a customer ID nor an alias — see: gads-cli config alias list", ref)
+}
+
+// newTokenSource returns the token source for the stored credentials; token
+// requests go through oauthContext.
+func newTokenSource(creds *config.Credentials) (*config.TokenSource, error) {
+	return config.NewTokenSource(oauthContext(), creds)
+}
+
+// authorizedClient returns an HTTP client that authorizes requests with ts.
+// Unlike oauth2.NewClient it adds no token cache of its own, so
+// ts.Invalidate takes effect on the next request.
+func authorizedClient(ts oauth2.TokenSource) *http.Client {
+	var base http.RoundTripper
+	if c, ok := oauthContext().Value(oauth2.HTTPClient).(*http.Client); ok {
+		base = c.Transport
+	}
+	return &http.Client{Transport: &oauth2.Transport{Source: ts, Base: base}}
+}
+
 // isSkipPreRunCommand returns true for commands that don't need API authentication.
 func isSkipPreRunCommand(cmd *cobra.Command) bool {
 	if isAuthCommand(cmd) {
 		return true
 	}
-	name := cmd.Name()
-	return name == "update" || name == "info" || name == "help"
+	if cmd.HasParent() && (cmd.Parent().Name() == "cache" || cmd.Parent().Name() == "audit") {
+		return true
+	}
+	for c := cmd; c.HasParent(); c = c.Parent() {
+		if c.Parent() == rootCmd && c.Name() == "config" {
+			return true
+		}
+	}
+	if cmd == diffCmd && diffCurrent != "" {
+		// Two snapshot files are compared offline.
+		return true
+	}
+	switch cmd.Name() {
+	case "update", "info", "version", "help", "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return true
+	}
+	return false
+}
+
+// mutatingCommand is the Annotations value for commands that change account
+// state; see isMutatingCommand.
+var mutatingCommand = map[string]string{"mutates": "true"}
+
+// isMutatingCommand returns true if cmd changes account state. Such commands
+// bypass the response cache so they never act on stale data.
+func isMutatingCommand(cmd *cobra.Command) bool {
+	return cmd.Annotations["mutates"] == "true"
 }
 
 // isAuthCommand returns true if cmd is in the auth subtree.
@@ -151,13 +634,14 @@ var infoCmd = &cobra.Command{
 	Use:   "info",
 	Short: "Show config path, auth status, and environment",
 	Run: func(cmd *cobra.Command, args []string) {
-		printInfo()
+		printInfo(cmd)
 	},
 }
 
-func printInfo() {
+func printInfo(cmd *cobra.Command) {
 	exe, _ := os.Executable()
 	fmt.Printf("gads-cli — Google Ads CLI\n\n")
+	fmt.Printf("  version: %s\n", version.String())
 	fmt.Printf("  binary:  %s\n", exe)
 	fmt.Printf("  os/arch: %s/%s\n", runtime.GOOS, runtime.GOARCH)
 	fmt.Println()
@@ -165,15 +649,30 @@ func printInfo() {
 	fmt.Printf("    macOS:   ~/Library/Application Support/gads/credentials.json\n")
 	fmt.Printf("    Linux:   ~/.config/gads/credentials.json\n")
 	fmt.Printf("    Windows: %%AppData%%\\gads\\credentials.json\n")
+	fmt.Printf("  profile: %s\n", config.ActiveProfile())
 	fmt.Printf("  config:  %s\n", config.Path())
-	fmt.Println()
 
 	creds, err := config.Load()
-	if err != nil || creds.RefreshToken == "" {
+	if err != nil {
+		creds = nil
+	}
+	fmt.Printf("  api:     %s\n", resolveAPIVersion(creds))
+	fmt.Println()
+	prefsPath, _ := config.PreferencesPath()
+	fmt.Printf("  settings (%s):\n", prefsPath)
+	for _, s := range effectiveSettings(cmd) {
+		fmt.Printf("    %-16s %-12s %s\n", s[0], orNone(s[1]), s[2])
+	}
+	fmt.Println()
+	if creds == nil || !creds.Authenticated() {
 		fmt.Println("  status:  not authenticated (run: gads-cli auth login)")
 		return
 	}
 	fmt.Printf("  status:           authenticated\n")
+	if creds.IsServiceAccount() {
+		fmt.Printf("  service account:  %s\n", creds.ServiceAccountKeyFile)
+		fmt.Printf("  impersonating:    %s\n", creds.ImpersonateUser)
+	}
 	fmt.Printf("  manager account:  %s\n", creds.ManagerCustomerID)
 	fmt.Printf("  developer token:  %s\n", maskOrEmpty(creds.DeveloperToken))
 	if !creds.TokenExpiry.IsZero() {
@@ -0,0 +1,279 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/output"
+	"golang.org/x/oauth2"
+)
+
+// handlerTransport answers API requests in process with an http.Handler.
+type handlerTransport struct {
+	http.Handler
+}
+
+func (t handlerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	t.ServeHTTP(rec, req)
+	resp := rec.Result()
+	resp.Request = req
+	return resp, nil
+}
+
+// useTestEnv gives one test an empty config directory, clears GADS_*
+// variables, confirms changes without a prompt, and restores the API client
+// and global flags when it ends.
+func useTestEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	for _, name := range []string{
+		"GADS_CLIENT_ID", "GADS_CLIENT_SECRET", "GADS_DEVELOPER_TOKEN", "GADS_REFRESH_TOKEN",
+		"GADS_LOGIN_CUSTOMER_ID", "GADS_ACCOUNT", "GADS_PROFILE", "GADS_MOCK_DIR",
+	} {
+		t.Setenv(name, "")
+	}
+	savedClient, savedAccount, savedLoginID := apiClient, accountFlag, loginIDFlag
+	savedYes, savedNoAudit, savedRecord := yesFlag, noAuditFlag, recordFlag
+	t.Cleanup(func() {
+		apiClient, accountFlag, loginIDFlag = savedClient, savedAccount, savedLoginID
+		yesFlag, noAuditFlag, recordFlag = savedYes, savedNoAudit, savedRecord
+	})
+	yesFlag = true
+}
+
+// useTestAPI points apiClient at h for one test, in the environment of
+// useTestEnv, without an audit log.
+func useTestAPI(t *testing.T, h http.HandlerFunc) {
+	t.Helper()
+	useTestEnv(t)
+	apiClient = api.New(&http.Client{Transport: handlerTransport{h}}, "dev", "")
+	apiClient.SetRetries(0)
+	noAuditFlag = true
+}
+
+// useMockAPI builds apiClient as a command run with GADS_MOCK_DIR=dir
+// would, in the environment of useTestEnv: without credentials.
+func useMockAPI(t *testing.T, dir string) {
+	t.Helper()
+	useTestEnv(t)
+	t.Setenv("GADS_MOCK_DIR", dir)
+	c := &cobra.Command{}
+	c.SetContext(context.Background())
+	if err := initAPIClient(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// runOutput runs f and returns what it printed to stdout, results included,
+// and to stderr. Results are printed as JSON, as when piped.
+func runOutput(t *testing.T, f func() error) (stdout, stderr string, err error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "out")
+	if err := output.SetFile(path); err != nil {
+		t.Fatal(err)
+	}
+
+	savedStdout, savedStderr := os.Stdout, os.Stderr
+	outR, outW, _ := os.Pipe()
+	errR, errW, _ := os.Pipe()
+	os.Stdout, os.Stderr = outW, errW
+	var outBuf, errBuf bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		io.Copy(&outBuf, outR)
+		done <- struct{}{}
+	}()
+	go func() {
+		io.Copy(&errBuf, errR)
+		done <- struct{}{}
+	}()
+
+	err = f()
+
+	os.Stdout, os.Stderr = savedStdout, savedStderr
+	outW.Close()
+	errW.Close()
+	<-done
+	<-done
+	if _, finishErr := output.Finish(true); finishErr != nil {
+		t.Fatal(finishErr)
+	}
+	results, _ := os.ReadFile(path)
+	return outBuf.String() + string(results), errBuf.String(), err
+}
+
+// execute runs c, a command not attached to rootCmd, with args and returns
+// its output as runOutput does.
+func execute(t *testing.T, c *cobra.Command, args ...string) (stdout, stderr string, err error) {
+	t.Helper()
+	c.SetArgs(args)
+	c.SilenceUsage = true
+	return runOutput(t, c.Execute)
+}
+
+// searchQueries returns a handler answering every search with no rows, and
+// the GAQL queries it received.
+func searchQueries(t *testing.T) (http.HandlerFunc, *[]string) {
+	var queries []string
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query string `json:"query"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("%s %s: %v", r.Method, r.URL, err)
+		}
+		queries = append(queries, body.Query)
+		io.WriteString(w, `{"results":[]}`)
+	}, &queries
+}
+
+// campaignsAPI answers every search with two campaigns and every mutate
+// with success, and counts the requests it receives.
+func campaignsAPI(requests *int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		if strings.HasSuffix(r.URL.Path, ":mutate") {
+			io.WriteString(w, `{"results":[{"resourceName":"customers/1234567890/campaigns/111"}]}`)
+			return
+		}
+		io.WriteString(w, `{"results":[`+
+			`{"campaign":{"resourceName":"customers/1234567890/campaigns/111","id":"111","name":"Brand","status":"ENABLED"}},`+
+			`{"campaign":{"resourceName":"customers/1234567890/campaigns/222","id":"222","name":"Generic","status":"PAUSED"}}]}`)
+	}
+}
+
+// TestMockRecordAndReplay records commands against a fake API, then
+// replays them from the mock directory without credentials or network.
+func TestMockRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	var requests int
+	useTestAPI(t, campaignsAPI(&requests))
+	apiClient = api.New(&http.Client{Transport: api.NewRecordTransport(handlerTransport{campaignsAPI(&requests)}, dir)}, "dev", "")
+	accountFlag = "1234567890"
+
+	recorded, _, err := execute(t, newCampaignsListCmd())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := execute(t, newCampaignsPauseCmd(), "--campaign=111"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(recorded, "Generic") {
+		t.Fatalf("campaigns not listed:\n%s", recorded)
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) == 0 {
+		t.Fatal("nothing recorded")
+	}
+
+	sent := requests
+	useMockAPI(t, dir)
+	accountFlag = "1234567890"
+	replayed, _, err := execute(t, newCampaignsListCmd())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replayed != recorded {
+		t.Errorf("replayed output differs:\n%s\nrecorded:\n%s", replayed, recorded)
+	}
+	if _, _, err := execute(t, newCampaignsPauseCmd(), "--campaign=111"); err != nil {
+		t.Fatal(err)
+	}
+	if requests != sent {
+		t.Errorf("replay sent %d requests to the API", requests-sent)
+	}
+	// A replayed change was never made: it is not audited.
+	var written []string
+	filepath.WalkDir(os.Getenv("XDG_CONFIG_HOME"), func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			written = append(written, path)
+		}
+		return err
+	})
+	if len(written) != 0 {
+		t.Errorf("replay wrote %v", written)
+	}
+}
+
+func TestMockReplayMissingResponse(t *testing.T) {
+	useMockAPI(t, t.TempDir())
+	accountFlag = "1234567890"
+
+	_, _, err := execute(t, newCampaignsListCmd())
+	if !errors.Is(err, api.ErrNoMock) {
+		t.Fatalf("error %v, want %v", err, api.ErrNoMock)
+	}
+	if !strings.Contains(err.Error(), "record it with --record") {
+		t.Errorf("error %v does not say how to record the response", err)
+	}
+}
+
+func TestInitAPIClientMockFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		mockDir string
+		record  bool
+		wantErr string
+	}{
+		{name: "record without a directory", record: true, wantErr: "--record needs GADS_MOCK_DIR"},
+		{name: "record needs credentials", mockDir: "mock", record: true, wantErr: "not authenticated"},
+		{name: "no mock needs credentials", wantErr: "not authenticated"},
+		{name: "replay needs no credentials", mockDir: "mock"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useTestEnv(t)
+			if tt.mockDir != "" {
+				t.Setenv("GADS_MOCK_DIR", filepath.Join(t.TempDir(), tt.mockDir))
+			}
+			recordFlag = tt.record
+			c := &cobra.Command{}
+			c.SetContext(context.Background())
+			err := initAPIClient(c)
+			if tt.wantErr == "" && err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("error %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "plain error", err: errors.New("boom"), want: exitError},
+		{name: "auth", err: &api.GoogleAdsError{StatusCode: 401}, want: exitAuth},
+		{name: "permission", err: &api.GoogleAdsError{StatusCode: 403}, want: exitPermission},
+		{name: "invalid", err: &api.GoogleAdsError{StatusCode: 400}, want: exitInvalid},
+		{name: "quota", err: &api.GoogleAdsError{StatusCode: 400, Code: "QUOTA_ERROR.RESOURCE_EXHAUSTED"}, want: exitQuota},
+		{name: "server error", err: &api.GoogleAdsError{StatusCode: 500}, want: exitError},
+		{name: "wrapped", err: fmt.Errorf("listing campaigns: %w", &api.GoogleAdsError{StatusCode: 403}), want: exitPermission},
+		{name: "token refresh", err: fmt.Errorf("oauth2: %w", &oauth2.RetrieveError{ErrorCode: "invalid_grant"}), want: exitAuth},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.err); got != tt.want {
+				t.Errorf("exitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
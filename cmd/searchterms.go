@@ -0,0 +1,330 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/audit"
+)
+
+var searchTermsCmd = &cobra.Command{
+	Use:   "search-terms",
+	Short: "Act on search terms: add them as keywords or negative keywords",
+	Long: `Act on the search terms of the search terms report (see: gads-cli insights
+search-terms): add them as keywords, or as negative keywords of a campaign or
+ad group.`,
+}
+
+// searchTermFlags holds the flags of one search-terms subcommand.
+type searchTermFlags struct {
+	campaignID string
+	adGroupID  string
+	terms      []string
+	file       string
+	matchType  string
+}
+
+// bind registers the flags shared by the search-terms subcommands on c.
+func (f *searchTermFlags) bind(c *cobra.Command) {
+	c.Flags().StringArrayVar(&f.terms, "term", nil, "Search term (repeatable)")
+	c.Flags().StringVar(&f.file, "file", "", `Read terms from a file ("-" for stdin): one per line, or the JSON of insights search-terms`)
+	c.Flags().StringVar(&f.matchType, "match-type", "EXACT", "Match type: BROAD, PHRASE, or EXACT")
+}
+
+// resolve returns the terms of --term and --file, without duplicates, and
+// the upper-cased match type.
+func (f *searchTermFlags) resolve() ([]string, string, error) {
+	mt := strings.ToUpper(f.matchType)
+	if mt != "BROAD" && mt != "PHRASE" && mt != "EXACT" {
+		return nil, "", fmt.Errorf("--match-type must be BROAD, PHRASE, or EXACT")
+	}
+	terms := f.terms
+	if f.file != "" {
+		read, err := readSearchTerms(f.file)
+		if err != nil {
+			return nil, "", err
+		}
+		terms = append(terms, read...)
+	}
+	var unique []string
+	seen := map[string]bool{}
+	for _, t := range terms {
+		t = strings.TrimSpace(t)
+		if t != "" && !seen[strings.ToLower(t)] {
+			seen[strings.ToLower(t)] = true
+			unique = append(unique, t)
+		}
+	}
+	if len(unique) == 0 {
+		return nil, "", fmt.Errorf("no search terms: pass --term or --file")
+	}
+	return unique, mt, nil
+}
+
+// readSearchTerms reads search terms from path ("-" for stdin): the JSON
+// array or JSON lines printed by insights search-terms, a JSON array of
+// strings, or plain text with one term per line (# starts a comment).
+func readSearchTerms(path string) ([]string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || (trimmed[0] != '[' && trimmed[0] != '{') {
+		var terms []string
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" && !strings.HasPrefix(line, "#") {
+				terms = append(terms, line)
+			}
+		}
+		return terms, nil
+	}
+
+	var terms []string
+	dec := json.NewDecoder(bytes.NewReader(trimmed))
+	for dec.More() {
+		var v json.RawMessage
+		if err := dec.Decode(&v); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		var items []json.RawMessage
+		if v[0] != '[' {
+			items = []json.RawMessage{v}
+		} else if err := json.Unmarshal(v, &items); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		for _, item := range items {
+			term, err := searchTermOf(item)
+			if err != nil {
+				return nil, fmt.Errorf("parsing %s: %w", path, err)
+			}
+			terms = append(terms, term)
+		}
+	}
+	return terms, nil
+}
+
+// searchTermOf returns the search term of a JSON item: a string, a search
+// terms report row ({"searchTermView": {"searchTerm": ...}}) or an object
+// with a searchTerm field.
+func searchTermOf(item json.RawMessage) (string, error) {
+	var s string
+	if json.Unmarshal(item, &s) == nil {
+		return s, nil
+	}
+	var row struct {
+		api.SearchTermRow
+		SearchTerm string `json:"searchTerm"`
+	}
+	if err := json.Unmarshal(item, &row); err != nil {
+		return "", err
+	}
+	if row.SearchTermView.SearchTerm != "" {
+		return row.SearchTermView.SearchTerm, nil
+	}
+	if row.SearchTerm != "" {
+		return row.SearchTerm, nil
+	}
+	return "", fmt.Errorf("no search term in %s", item)
+}
+
+// reportSearchTerms prints the outcome of a mutate creating one criterion
+// per term, naming each failed term (e.g. a duplicate keyword) rather than
+// an operation index. Failures are returned as an error.
+func reportSearchTerms(resp *api.MutateResponse, terms []string, mt, added string) error {
+	if apiClient.ValidateOnly() {
+		return reportDryRun(resp, len(terms))
+	}
+	if jsonResult() {
+		return printMutateResult(len(terms), resp)
+	}
+	for i, r := range resp.Results {
+		if r.ResourceName != "" && i < len(terms) {
+			fmt.Printf("%s: %q [%s]\n", added, terms[i], mt)
+		}
+	}
+	opErrs := resp.OperationErrors()
+	for _, e := range opErrs {
+		reason := e.Code
+		if reason == "" {
+			reason = e.Message
+		}
+		if e.Index >= 0 && e.Index < len(terms) {
+			fmt.Fprintf(os.Stderr, "Failed: %q [%s]: %s\n", terms[e.Index], mt, reason)
+		} else {
+			fmt.Fprintf(os.Stderr, "Failed: %s\n", reason)
+		}
+	}
+	if len(opErrs) > 0 {
+		return fmt.Errorf("%d of %d search term(s) failed", len(opErrs), len(terms))
+	}
+	return nil
+}
+
+// ---- search-terms add-keyword ----
+
+func newSearchTermsAddKeywordCmd() *cobra.Command {
+	var f searchTermFlags
+	c := &cobra.Command{
+		Use:   "add-keyword",
+		Short: "Add search terms as keywords of an ad group",
+		Long: `Add search terms as keywords of an ad group, in one request. Terms come
+from --term (repeatable) and --file: one per line, or the JSON printed by
+insights search-terms ("-" reads stdin, so its output can be piped in). The
+match type defaults to EXACT.
+
+Each term that fails, e.g. because the ad group already has the keyword, is
+reported by name and the others are still added, unless
+--no-partial-failure is set. Piping terms in leaves no terminal to confirm
+on, so pass --yes.
+
+Examples:
+  gads-cli search-terms add-keyword --account=1234567890 --adgroup=444555666 --term="red running shoes"
+  gads-cli search-terms add-keyword --account=1234567890 --adgroup=444555666 --term="trail shoes" --term="trail sneakers" --match-type=PHRASE
+  gads-cli insights search-terms --account=1234567890 --campaign=111222333 --json \
+    | gads-cli search-terms add-keyword --account=1234567890 --adgroup=444555666 --file=- --yes`,
+		Annotations: mutatingCommand,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			if !api.IsNumericID(f.adGroupID) {
+				return fmt.Errorf("--adgroup must be a numeric ID")
+			}
+			terms, mt, err := f.resolve()
+			if err != nil {
+				return err
+			}
+
+			changes := make([]string, len(terms))
+			for i, t := range terms {
+				changes[i] = fmt.Sprintf("Ad group %s: add keyword %q [%s]", f.adGroupID, t, mt)
+			}
+			if ok, err := confirmChange(fmt.Sprintf("Add %d keyword(s)?", len(terms)), changes...); !ok {
+				return err
+			}
+			adGroup := fmt.Sprintf("customers/%s/adGroups/%s", cid, f.adGroupID)
+			ops := make([]map[string]any, len(terms))
+			for i, t := range terms {
+				ops[i] = map[string]any{
+					"create": map[string]any{
+						"adGroup": adGroup,
+						"status":  "ENABLED",
+						"keyword": map[string]any{"text": t, "matchType": mt},
+					},
+				}
+			}
+			recordUndo("adGroupCriteria", &audit.Undo{RemoveCreated: true})
+			resp, err := apiClient.MutateAdGroupCriteria(cid, ops)
+			if err != nil {
+				return err
+			}
+			return reportSearchTerms(resp, terms, mt, "Keyword added")
+		},
+	}
+	f.bind(c)
+	c.Flags().StringVar(&f.adGroupID, "adgroup", "", "Ad group ID (required)")
+	c.MarkFlagRequired("adgroup")
+	return c
+}
+
+// ---- search-terms add-negative ----
+
+func newSearchTermsAddNegativeCmd() *cobra.Command {
+	var f searchTermFlags
+	c := &cobra.Command{
+		Use:   "add-negative",
+		Short: "Add search terms as negative keywords of a campaign or ad group",
+		Long: `Add search terms as negative keywords of a campaign (--campaign) or of an
+ad group (--adgroup), in one request. Terms come from --term (repeatable)
+and --file, as for add-keyword. The match type defaults to EXACT.
+
+Each term that fails, e.g. because the negative already exists, is reported
+by name and the others are still added, unless --no-partial-failure is set.
+For a negative shared by several campaigns, use a list instead (see:
+gads-cli negatives lists add-keywords).
+
+Examples:
+  gads-cli search-terms add-negative --account=1234567890 --campaign=111222333 --term=free --match-type=PHRASE
+  gads-cli search-terms add-negative --account=1234567890 --adgroup=444555666 --term="shoes repair"
+  gads-cli search-terms add-negative --account=1234567890 --campaign=111222333 --file=junk-terms.txt --yes`,
+		Annotations: mutatingCommand,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			if (f.campaignID == "") == (f.adGroupID == "") {
+				return fmt.Errorf("exactly one of --campaign or --adgroup is required")
+			}
+			id, level := f.campaignID, "Campaign"
+			if f.adGroupID != "" {
+				id, level = f.adGroupID, "Ad group"
+			}
+			if !api.IsNumericID(id) {
+				return fmt.Errorf("--%s must be a numeric ID", strings.ToLower(strings.ReplaceAll(level, " ", "")))
+			}
+			terms, mt, err := f.resolve()
+			if err != nil {
+				return err
+			}
+
+			changes := make([]string, len(terms))
+			for i, t := range terms {
+				changes[i] = fmt.Sprintf("%s %s: add negative keyword %q [%s]", level, id, t, mt)
+			}
+			if ok, err := confirmChange(fmt.Sprintf("Add %d negative keyword(s)?", len(terms)), changes...); !ok {
+				return err
+			}
+			ops := make([]map[string]any, len(terms))
+			for i, t := range terms {
+				create := map[string]any{
+					"negative": true,
+					"keyword":  map[string]any{"text": t, "matchType": mt},
+				}
+				if f.campaignID != "" {
+					create["campaign"] = fmt.Sprintf("customers/%s/campaigns/%s", cid, id)
+				} else {
+					create["adGroup"] = fmt.Sprintf("customers/%s/adGroups/%s", cid, id)
+				}
+				ops[i] = map[string]any{"create": create}
+			}
+			var resp *api.MutateResponse
+			if f.campaignID != "" {
+				recordUndo("campaignCriteria", &audit.Undo{RemoveCreated: true})
+				resp, err = apiClient.MutateCampaignCriteria(cid, ops)
+			} else {
+				recordUndo("adGroupCriteria", &audit.Undo{RemoveCreated: true})
+				resp, err = apiClient.MutateAdGroupCriteria(cid, ops)
+			}
+			if err != nil {
+				return err
+			}
+			return reportSearchTerms(resp, terms, mt, "Negative keyword added")
+		},
+	}
+	f.bind(c)
+	c.Flags().StringVar(&f.campaignID, "campaign", "", "Campaign ID to add the negatives to")
+	c.Flags().StringVar(&f.adGroupID, "adgroup", "", "Ad group ID to add the negatives to")
+	c.MarkFlagsMutuallyExclusive("campaign", "adgroup")
+	return c
+}
+
+func init() {
+	searchTermsCmd.AddCommand(newSearchTermsAddKeywordCmd(), newSearchTermsAddNegativeCmd())
+	rootCmd.AddCommand(searchTermsCmd)
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/the20100/gads-cli/internal/api"
+)
+
+// searchVolumeCols are the columns insights keywords --include-search-volume
+// adds after the others.
+var searchVolumeCols = []KeywordCol{
+	{"search_volume", "SEARCHES/MO", func(r *api.InsightsKeywordRow) string {
+		if r.KeywordMetrics == nil {
+			return "-"
+		}
+		return api.FormatMetricInt(int64(r.KeywordMetrics.AvgMonthlySearches))
+	}},
+	{"competition", "COMPETITION", func(r *api.InsightsKeywordRow) string {
+		if r.KeywordMetrics == nil {
+			return "-"
+		}
+		switch c := r.KeywordMetrics.Competition; c {
+		case "LOW", "MEDIUM", "HIGH":
+			return formatEnum(c)
+		}
+		return "-"
+	}},
+}
+
+// addSearchVolume sets the KeywordMetrics of rows from Keyword Planner's
+// historical metrics, matched on the normalized keyword text. Rows of
+// keywords without data are left without.
+func addSearchVolume(ctx context.Context, cid string, rows []api.InsightsKeywordRow) error {
+	texts := make([]string, len(rows))
+	for i, r := range rows {
+		texts[i] = r.AdGroupCriterion.Keyword.Text
+	}
+	metrics, err := apiClient.GenerateKeywordHistoricalMetrics(ctx, cid, texts)
+	if err != nil {
+		return fmt.Errorf("fetching search volume: %w", err)
+	}
+	for i := range rows {
+		if m, ok := metrics[api.NormalizeKeyword(texts[i])]; ok {
+			rows[i].KeywordMetrics = &m
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/gaql"
+	"github.com/the20100/gads-cli/internal/output"
+)
+
+// shoppingGroups are the --group-by values of insights shopping, with the
+// product segments each one selects and the header of its column.
+var shoppingGroups = map[string]struct {
+	segments []string
+	header   string
+}{
+	"item":     {[]string{"segments.product_item_id", "segments.product_title"}, "PRODUCT"},
+	"brand":    {[]string{"segments.product_brand"}, "BRAND"},
+	"category": {[]string{"segments.product_category_level1"}, "CATEGORY"},
+}
+
+// ---- insights shopping ----
+
+func newInsightsShoppingCmd() *cobra.Command {
+	var (
+		f       insightsFlags
+		groupBy string
+	)
+	c := &cobra.Command{
+		Use:   "shopping",
+		Short: "Product performance of Shopping and Performance Max campaigns",
+		Long: `Show the performance of the products advertised by Shopping and Performance
+Max campaigns, from the Merchant Center feed: impressions, clicks, cost,
+conversions and ROAS per product, most expensive first.
+
+--group-by sums the products by brand or by top-level product category
+instead of listing each item. Long product titles are cut to fit the
+terminal; --wide prints them in full.
+
+Examples:
+  gads-cli insights shopping --account=1234567890 --days=30
+  gads-cli insights shopping --account=1234567890 --campaign=111222333 --period=lastMonth
+  gads-cli insights shopping --account=1234567890 --group-by=brand --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cid, err := accountID()
+			if err != nil {
+				return err
+			}
+			if f.campaignID != "" && !api.IsNumericID(f.campaignID) {
+				return fmt.Errorf("--campaign must be a numeric ID")
+			}
+			group, ok := shoppingGroups[strings.ToLower(groupBy)]
+			if !ok {
+				return fmt.Errorf("invalid --group-by %q: must be one of %s", groupBy, strings.Join(sortedKeys(shoppingGroups), ", "))
+			}
+			dateFilter := buildDateRange(f.period, f.days, f.start, f.end)
+
+			fields := slices.Concat(group.segments, []string{
+				"metrics.impressions", "metrics.clicks", "metrics.cost_micros",
+				"metrics.conversions", "metrics.conversions_value"})
+			query := gaql.Select(fields...).
+				From("shopping_performance_view").
+				Where(dateFilter).
+				WhereIf(f.campaignID != "", "campaign.id = "+gaql.Quote(f.campaignID)).
+				OrderBy("metrics.cost_micros", true).
+				String()
+
+			if f.verbose {
+				fmt.Printf("[verbose] account: %s\n[verbose] query:\n%s\n\n", cid, query)
+			}
+			rows, err := apiClient.Search(cid, query)
+			if err != nil {
+				return err
+			}
+			if f.verbose {
+				fmt.Printf("[verbose] API returned %d raw rows\n\n", len(rows))
+			}
+
+			results := []api.ShoppingPerformanceRow{}
+			for _, raw := range rows {
+				var row api.ShoppingPerformanceRow
+				if err := json.Unmarshal(raw, &row); err != nil {
+					continue
+				}
+				results = append(results, row)
+			}
+
+			if output.IsJSON(cmd) {
+				return output.PrintJSON(results, output.IsPretty(cmd))
+			}
+			if len(results) == 0 {
+				fmt.Println("No shopping data found for the specified period. Product performance is only reported for Shopping and Performance Max campaigns with a Merchant Center feed.")
+				return nil
+			}
+
+			headers := []string{group.header, "IMPR", "CLICKS", "COST", "CONV", "ROAS"}
+			if group.header == "PRODUCT" {
+				headers = append([]string{"ITEM ID"}, headers...)
+			}
+			tableRows := make([][]string, len(results))
+			for i, r := range results {
+				s := r.Segments
+				row := []string{
+					api.FormatMetricInt(int64(r.Metrics.Impressions)),
+					api.FormatMetricInt(int64(r.Metrics.Clicks)),
+					money(cid, int64(r.Metrics.CostMicros)),
+					fmt.Sprintf("%.1f", r.Metrics.Conversions),
+					api.FormatROAS(r.Metrics.ConversionsValue, int64(r.Metrics.CostMicros)),
+				}
+				switch group.header {
+				case "PRODUCT":
+					row = append([]string{s.ProductItemID, orDash(s.ProductTitle)}, row...)
+				case "BRAND":
+					row = append([]string{orDash(s.ProductBrand)}, row...)
+				default:
+					row = append([]string{orDash(s.ProductCategoryLevel1)}, row...)
+				}
+				tableRows[i] = row
+			}
+			return output.PrintTable(headers, tableRows)
+		},
+	}
+	f.bindPeriod(c)
+	c.Flags().StringVar(&f.campaignID, "campaign", "", "Only this campaign's products")
+	c.Flags().StringVar(&groupBy, "group-by", "item", "Sum products by: item, brand or category")
+	c.Flags().BoolVar(&f.verbose, "verbose", false, "Print the GAQL query, raw row count and API request count for debugging")
+	c.RegisterFlagCompletionFunc("group-by", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return sortedKeys(shoppingGroups), cobra.ShellCompDirectiveNoFileComp
+	})
+	return c
+}
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/audit"
+)
+
+var undoID string
+
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Reverse the last recorded change to an account",
+	Long: `Reverse a change recorded in the audit log (see: gads-cli audit list).
+
+Without --id, undo reverses the last command that changed the account and
+has not been undone yet: every request it sent, newest first. Running it
+again reverses the command before that. With --id, only that entry is
+reversed.
+
+Only simple changes can be undone, from the state recorded when they were
+made: a status change restores the previous status, a budget or bid change
+the previous amount, a campaign's budget or network settings and an ad
+group's ad rotation the previous ones, created keywords, campaign labels
+and negative keyword lists, keywords and attachments are removed, and
+detached negative keyword lists reattached.
+Removes, ad edits and changes made by other commands are recorded as not
+undoable, and undo says why. Reversing an undo re-applies the change.
+
+Examples:
+  gads-cli undo --account=1234567890
+  gads-cli undo --account=1234567890 --id=3ec86966 --dry-run`,
+	Annotations: mutatingCommand,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cid, err := accountID()
+		if err != nil {
+			return err
+		}
+		log, err := auditLog()
+		if err != nil {
+			return err
+		}
+		entries, err := log.Entries()
+		if err != nil {
+			return err
+		}
+		targets, err := undoTargets(entries, cid, undoID)
+		if err != nil {
+			return err
+		}
+
+		var changes []string
+		requests := make([][]map[string]any, len(targets))
+		for i, e := range targets {
+			if e.Undo == nil {
+				return fmt.Errorf("entry %s cannot be undone: it was recorded before undo was supported", e.ID)
+			}
+			if e.Undo.Reason != "" {
+				return fmt.Errorf("entry %s (%s) cannot be undone: %s", e.ID, e.Command, e.Undo.Reason)
+			}
+			if audit.Service(e.Endpoint) == "" {
+				return fmt.Errorf("entry %s cannot be undone: %s is not a mutate endpoint", e.ID, e.Endpoint)
+			}
+			requests[i] = undoOperations(e)
+			for _, op := range requests[i] {
+				changes = append(changes, describeUndo(op))
+			}
+		}
+		question := fmt.Sprintf("Undo %q (%s)?", targets[0].Command, targets[0].Time.Local().Format("2006-01-02 15:04"))
+		if ok, err := confirmChange(question, changes...); !ok {
+			return err
+		}
+
+		var resps []*api.MutateResponse
+		total := 0
+		defer func() { undoing = "" }()
+		for i, e := range targets {
+			service := audit.Service(e.Endpoint)
+			undoing = e.ID
+			recordUndo(service, redoOf(e))
+			resp, err := apiClient.MutateContext(cmd.Context(), cid, service, requests[i])
+			if err != nil {
+				return fmt.Errorf("undoing entry %s: %w", e.ID, err)
+			}
+			resps = append(resps, resp)
+			total += len(requests[i])
+		}
+		if apiClient.ValidateOnly() && !jsonResult() {
+			for i, resp := range resps {
+				if err := reportDryRun(resp, len(requests[i])); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if jsonResult() {
+			return printMutateResult(total, resps...)
+		}
+		for i, e := range targets {
+			fmt.Printf("Undid entry %s: %d operation(s) on %s.\n", e.ID, len(requests[i]), audit.Service(e.Endpoint))
+			if opErrs := resps[i].OperationErrors(); len(opErrs) > 0 {
+				return fmt.Errorf("%d operation(s) of entry %s failed (%s)", len(opErrs), e.ID, formatOpErrors(opErrs))
+			}
+		}
+		return nil
+	},
+}
+
+// undoTargets returns the entries to reverse, newest first: the entry id,
+// or without one, every entry of the last run on cid that has not been
+// undone, skipping runs of undo itself.
+func undoTargets(entries []audit.Entry, cid, id string) ([]audit.Entry, error) {
+	undone := make(map[string]string) // entry ID → ID of the entry undoing it
+	for _, e := range entries {
+		if e.Undoes != "" {
+			undone[e.Undoes] = e.ID
+		}
+	}
+	if id != "" {
+		for _, e := range entries {
+			if e.ID != id {
+				continue
+			}
+			if e.CustomerID != cid {
+				return nil, fmt.Errorf("entry %s changed account %s, not %s", id, e.CustomerID, cid)
+			}
+			if by, ok := undone[id]; ok {
+				return nil, fmt.Errorf("entry %s was already undone by entry %s", id, by)
+			}
+			return []audit.Entry{e}, nil
+		}
+		return nil, fmt.Errorf("no entry %s in the audit log — see: gads-cli audit list", id)
+	}
+
+	run := ""
+	var targets []audit.Entry
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if e.CustomerID != cid || e.Undoes != "" || undone[e.ID] != "" {
+			continue
+		}
+		if run == "" {
+			run = e.Run
+		}
+		if e.Run == run {
+			targets = append(targets, e)
+		}
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("nothing to undo for account %s — see: gads-cli audit list", cid)
+	}
+	return targets, nil
+}
+
+// undoOperations returns the operations reversing e.
+func undoOperations(e audit.Entry) []map[string]any {
+	if !e.Undo.RemoveCreated {
+		return e.Undo.Operations
+	}
+	ops := make([]map[string]any, len(e.ResourceNames))
+	for i, name := range e.ResourceNames {
+		ops[i] = map[string]any{"remove": name}
+	}
+	return ops
+}
+
+// redoOf returns how to reverse the undo of e: sending e's operations again,
+// unless the undo removes what e created.
+func redoOf(e audit.Entry) *audit.Undo {
+	if e.Undo.RemoveCreated {
+		return audit.NotUndoable(fmt.Sprintf("it removed what entry %s created, which cannot be restored", e.ID))
+	}
+	var ops []map[string]any
+	if err := json.Unmarshal(e.Operations, &ops); err != nil {
+		return audit.NotUndoable(fmt.Sprintf("the operations of entry %s could not be read", e.ID))
+	}
+	return audit.Restore(ops)
+}
+
+// undoUpdate returns the operation setting field of resourceName back to
+// value, for recordUndo.
+func undoUpdate(resourceName, field string, value any) map[string]any {
+	return map[string]any{
+		"updateMask": field,
+		"update": map[string]any{
+			"resourceName": resourceName,
+			field:          value,
+		},
+	}
+}
+
+// describeUndo renders an undo operation for the confirmation, e.g.
+// "customers/1/campaigns/2: status → ENABLED".
+func describeUndo(op map[string]any) string {
+	if name, ok := op["remove"].(string); ok {
+		return "remove " + name
+	}
+	update, _ := op["update"].(map[string]any)
+	mask, _ := op["updateMask"].(string)
+	var parts []string
+	for _, field := range strings.Split(mask, ",") {
+		parts = append(parts, fmt.Sprintf("%s → %v", field, fieldAt(update, field)))
+	}
+	return fmt.Sprintf("%v: %s", update["resourceName"], strings.Join(parts, ", "))
+}
+
+func init() {
+	undoCmd.Flags().StringVar(&undoID, "id", "", "Audit entry to reverse (default: the last command that changed the account)")
+	rootCmd.AddCommand(undoCmd)
+}
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/the20100/gads-cli/internal/api"
+	"github.com/the20100/gads-cli/internal/audit"
+)
+
+func TestUndoTargets(t *testing.T) {
+	entries := []audit.Entry{
+		{ID: "a1", Run: "r1", CustomerID: "111"},
+		{ID: "a2", Run: "r2", CustomerID: "111"},
+		{ID: "b1", Run: "r3", CustomerID: "222"},
+		{ID: "a3", Run: "r2", CustomerID: "111"},
+		{ID: "u1", Run: "r4", CustomerID: "111", Undoes: "x9"},
+	}
+	undone := append(entries[:len(entries):len(entries)],
+		audit.Entry{ID: "u2", Run: "r5", CustomerID: "111", Undoes: "a2"},
+		audit.Entry{ID: "u3", Run: "r5", CustomerID: "111", Undoes: "a3"},
+	)
+
+	tests := []struct {
+		name    string
+		entries []audit.Entry
+		cid, id string
+		want    []string
+		wantErr string
+	}{
+		{name: "last run, newest first", entries: entries, cid: "111", want: []string{"a3", "a2"}},
+		{name: "other account", entries: entries, cid: "222", want: []string{"b1"}},
+		{name: "skips undone runs and undo runs", entries: undone, cid: "111", want: []string{"a1"}},
+		{name: "nothing to undo", entries: undone, cid: "333", wantErr: "nothing to undo for account 333"},
+		{name: "by id", entries: entries, cid: "111", id: "a2", want: []string{"a2"}},
+		{name: "id of another account", entries: entries, cid: "111", id: "b1", wantErr: "entry b1 changed account 222, not 111"},
+		{name: "id already undone", entries: undone, cid: "111", id: "a3", wantErr: "entry a3 was already undone by entry u3"},
+		{name: "unknown id", entries: entries, cid: "111", id: "zz", wantErr: "no entry zz in the audit log"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			targets, err := undoTargets(tt.entries, tt.cid, tt.id)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, e := range targets {
+				got = append(got, e.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("targets %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUndoOperations(t *testing.T) {
+	restore := []map[string]any{undoUpdate("customers/1/campaigns/2", "status", "PAUSED")}
+	tests := []struct {
+		name string
+		e    audit.Entry
+		want []map[string]any
+	}{
+		{
+			name: "restore",
+			e:    audit.Entry{Undo: audit.Restore(restore), ResourceNames: []string{"customers/1/campaigns/2"}},
+			want: restore,
+		},
+		{
+			name: "remove created",
+			e: audit.Entry{
+				Operations:    json.RawMessage(`[{"create":{"text":"shoes"}},{"create":{"text":"boots"}}]`),
+				ResourceNames: []string{"customers/1/adGroupCriteria/3~4", "customers/1/adGroupCriteria/3~5"},
+				Undo:          &audit.Undo{RemoveCreated: true},
+			},
+			want: []map[string]any{
+				{"remove": "customers/1/adGroupCriteria/3~4"},
+				{"remove": "customers/1/adGroupCriteria/3~5"},
+			},
+		},
+		{
+			name: "remove created, nothing returned",
+			e:    audit.Entry{Operations: json.RawMessage(`[{"create":{}}]`), ResourceNames: []string{}, Undo: &audit.Undo{RemoveCreated: true}},
+			want: []map[string]any{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := undoOperations(tt.e); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("undoOperations = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedoOf(t *testing.T) {
+	tests := []struct {
+		name       string
+		e          audit.Entry
+		wantOps    []map[string]any
+		wantReason string
+	}{
+		{
+			name:    "redo of an update",
+			e:       audit.Entry{ID: "a1", Operations: json.RawMessage(`[{"updateMask":"status","update":{"resourceName":"customers/1/campaigns/2","status":"ENABLED"}}]`), Undo: audit.Restore(nil)},
+			wantOps: []map[string]any{undoUpdate("customers/1/campaigns/2", "status", "ENABLED")},
+		},
+		{
+			name:       "redo of a removal of created resources",
+			e:          audit.Entry{ID: "a2", Operations: json.RawMessage(`[{"create":{}}]`), Undo: &audit.Undo{RemoveCreated: true}},
+			wantReason: "it removed what entry a2 created",
+		},
+		{
+			name:       "unreadable operations",
+			e:          audit.Entry{ID: "a3", Operations: json.RawMessage(`{"customerClient":{}}`), Undo: audit.Restore(nil)},
+			wantReason: "the operations of entry a3 could not be read",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := redoOf(tt.e)
+			if tt.wantReason != "" {
+				if !strings.Contains(got.Reason, tt.wantReason) {
+					t.Errorf("reason %q, want %q", got.Reason, tt.wantReason)
+				}
+				return
+			}
+			if got.Reason != "" || !reflect.DeepEqual(got.Operations, tt.wantOps) {
+				t.Errorf("redo %+v, want operations %v", got, tt.wantOps)
+			}
+		})
+	}
+}
+
+func TestAuditMutationUsesUpPendingUndo(t *testing.T) {
+	tests := []struct {
+		name      string
+		m         api.Mutation
+		wantEntry bool
+	}{
+		{name: "succeeded", m: api.Mutation{Response: json.RawMessage(`{"results":[]}`)}, wantEntry: true},
+		{name: "failed", m: api.Mutation{Err: errors.New("INTERNAL")}},
+		{name: "validate only", m: api.Mutation{ValidateOnly: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useTestEnv(t)
+			tt.m.CustomerID = "111"
+			tt.m.Endpoint = "customers/111/campaigns:mutate"
+			tt.m.Request = json.RawMessage(`{"operations":[]}`)
+			restore := audit.Restore([]map[string]any{undoUpdate("customers/111/campaigns/2", "status", "ENABLED")})
+			recordUndo("campaigns", restore)
+
+			auditMutation(tt.m)
+
+			pendingMu.Lock()
+			_, pending := pendingUndo["campaigns"]
+			pendingMu.Unlock()
+			if pending {
+				t.Error("undo still pending for the next request")
+			}
+			log, err := auditLog()
+			if err != nil {
+				t.Fatal(err)
+			}
+			entries, err := log.Entries()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.wantEntry {
+				if len(entries) != 0 {
+					t.Errorf("recorded %+v", entries)
+				}
+				return
+			}
+			if len(entries) != 1 || !reflect.DeepEqual(entries[0].Undo, restore) {
+				t.Errorf("recorded %+v, want one entry with the pending undo", entries)
+			}
+		})
+	}
+}
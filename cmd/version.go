@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/the20100/gads-cli/internal/output"
+	"github.com/the20100/gads-cli/internal/version"
+)
+
+// latestReleaseURL is the GitHub API endpoint of the latest gads-cli release.
+const latestReleaseURL = "https://api.github.com/repos/the20100/gads-cli/releases/latest"
+
+var versionCheck bool
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print the gads-cli version",
+	Long: `Print the version, git commit and build date of gads-cli, as stamped at
+build time (see the README), or recorded by the Go toolchain for source
+builds. gads-cli --version prints the same.
+
+With --check, the latest release on GitHub is looked up and upgrade
+instructions are printed if it is newer. A failed check only prints a
+warning.
+
+Examples:
+  gads-cli version
+  gads-cli version --check`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var rel *githubRelease
+		var checkErr error
+		if versionCheck {
+			rel, checkErr = latestRelease(cmd.Context())
+		}
+		current := version.String()
+
+		if output.IsJSON(cmd) {
+			out := map[string]any{
+				"version":     current,
+				"commit":      version.CommitHash(),
+				"build_date":  version.BuildDate(),
+				"go_version":  runtime.Version(),
+				"user_agent":  version.UserAgent(),
+				"api_version": resolveAPIVersion(nil),
+			}
+			if rel != nil {
+				out["latest"] = rel.TagName
+				out["latest_url"] = rel.HTMLURL
+				out["update_available"] = version.Newer(rel.TagName, current)
+			}
+			if checkErr != nil {
+				out["check_error"] = checkErr.Error()
+			}
+			return output.PrintJSON(out, output.IsPretty(cmd))
+		}
+
+		fmt.Print(versionText())
+		if checkErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not check for a newer version: %v\n", checkErr)
+			return nil
+		}
+		if rel == nil {
+			return nil
+		}
+		switch {
+		case version.Newer(rel.TagName, current):
+			fmt.Printf("\nA newer version is available: %s (you have %s).\n", rel.TagName, current)
+			fmt.Printf("Upgrade with: gads-cli update\nRelease notes: %s\n", rel.HTMLURL)
+		case strings.HasPrefix(current, "dev"):
+			fmt.Printf("\nLatest release: %s (this is a development build).\n", rel.TagName)
+		default:
+			fmt.Printf("\ngads-cli is up to date (latest release: %s).\n", rel.TagName)
+		}
+		return nil
+	},
+}
+
+// versionText is printed by version and --version, e.g.
+//
+//	gads-cli v1.2.3
+//	commit:  3f2a1b9c0d1e...
+//	built:   2026-01-31T09:00:00Z
+//	go:      go1.22.5 linux/amd64
+func versionText() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "gads-cli %s\n", version.String())
+	fmt.Fprintf(&b, "commit:  %s\n", orUnknown(version.CommitHash()))
+	fmt.Fprintf(&b, "built:   %s\n", orUnknown(version.BuildDate()))
+	fmt.Fprintf(&b, "go:      %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	return b.String()
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
+
+// githubRelease is the part of a GitHub release that version --check uses.
+type githubRelease struct {
+	TagName string `json:"tag_name"`
+	HTMLURL string `json:"html_url"`
+}
+
+// latestRelease looks up the latest release on GitHub, through the same
+// proxy and CA settings as API calls.
+func latestRelease(ctx context.Context) (*githubRelease, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, latestReleaseURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", version.UserAgent())
+	resp, err := (&http.Client{Transport: baseTransport}).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("no release has been published yet")
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("GitHub returned %s", resp.Status)
+	}
+	var rel githubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&rel); err != nil {
+		return nil, fmt.Errorf("parsing GitHub release: %w", err)
+	}
+	return &rel, nil
+}
+
+func init() {
+	versionCmd.Flags().BoolVar(&versionCheck, "check", false, "Also check GitHub for a newer release")
+	rootCmd.AddCommand(versionCmd)
+
+	rootCmd.Version = version.String()
+	rootCmd.SetVersionTemplate(versionText())
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Operation is a single create, update or remove on one resource type.
+// Creates may set a negative temporary ID in resourceName (e.g.
+// "customers/123/campaignBudgets/-1") for other operations in the same
+// googleAds:mutate request to reference.
+type Operation struct {
+	Create     map[string]any `json:"create,omitempty"`
+	Update     map[string]any `json:"update,omitempty"`
+	UpdateMask string         `json:"updateMask,omitempty"`
+	Remove     string         `json:"remove,omitempty"`
+}
+
+// MutateOperation is one entry of a googleAds:mutate request. Exactly one
+// field must be set.
+type MutateOperation struct {
+	CampaignBudgetOperation    *Operation `json:"campaignBudgetOperation,omitempty"`
+	CampaignOperation          *Operation `json:"campaignOperation,omitempty"`
+	CampaignCriterionOperation *Operation `json:"campaignCriterionOperation,omitempty"`
+	AdGroupOperation           *Operation `json:"adGroupOperation,omitempty"`
+	AdGroupCriterionOperation  *Operation `json:"adGroupCriterionOperation,omitempty"`
+	AdGroupAdOperation         *Operation `json:"adGroupAdOperation,omitempty"`
+}
+
+// Kind returns the name of the set operation field (e.g. "campaignOperation")
+// and the operation itself, or an error unless exactly one is set.
+func (m MutateOperation) Kind() (string, *Operation, error) {
+	fields := []struct {
+		name string
+		op   *Operation
+	}{
+		{"campaignBudgetOperation", m.CampaignBudgetOperation},
+		{"campaignOperation", m.CampaignOperation},
+		{"campaignCriterionOperation", m.CampaignCriterionOperation},
+		{"adGroupOperation", m.AdGroupOperation},
+		{"adGroupCriterionOperation", m.AdGroupCriterionOperation},
+		{"adGroupAdOperation", m.AdGroupAdOperation},
+	}
+	var name string
+	var op *Operation
+	for _, f := range fields {
+		if f.op == nil {
+			continue
+		}
+		if op != nil {
+			return "", nil, fmt.Errorf("more than one operation type set (%s, %s)", name, f.name)
+		}
+		name, op = f.name, f.op
+	}
+	if op == nil {
+		return "", nil, fmt.Errorf("no operation type set")
+	}
+	n := 0
+	if op.Create != nil {
+		n++
+	}
+	if op.Update != nil {
+		n++
+	}
+	if op.Remove != "" {
+		n++
+	}
+	if n != 1 {
+		return "", nil, fmt.Errorf("%s must have exactly one of create, update or remove", name)
+	}
+	return name, op, nil
+}
+
+// Action returns "create", "update" or "remove".
+func (o *Operation) Action() string {
+	switch {
+	case o.Create != nil:
+		return "create"
+	case o.Update != nil:
+		return "update"
+	default:
+		return "remove"
+	}
+}
+
+// TempID returns the negative temporary ID of a create operation, or "".
+func (o *Operation) TempID() string {
+	name, _ := o.Create["resourceName"].(string)
+	id := ResourceID(name)
+	if strings.HasPrefix(id, "-") {
+		return id
+	}
+	return ""
+}
+
+// MutateOperationResponse is one result of googleAds:mutate, keyed by result
+// type (e.g. {"campaignResult": {"resourceName": "..."}}).
+type MutateOperationResponse map[string]struct {
+	ResourceName string `json:"resourceName"`
+}
+
+// ResourceName returns the resource name of the result.
+func (r MutateOperationResponse) ResourceName() string {
+	for _, v := range r {
+		return v.ResourceName
+	}
+	return ""
+}
+
+// MutateGoogleAdsResponse is the response from googleAds:mutate.
+type MutateGoogleAdsResponse struct {
+	MutateOperationResponses []MutateOperationResponse `json:"mutateOperationResponses"`
+}
+
+// MutateGoogleAds applies operations on several resource types in one atomic
+// request: either all succeed or none are applied.
+func (c *Client) MutateGoogleAds(customerID string, operations []MutateOperation) (*MutateGoogleAdsResponse, error) {
+	return c.MutateGoogleAdsContext(c.ctx, customerID, operations)
+}
+
+// MutateGoogleAdsContext is like MutateGoogleAds but bound to ctx.
+func (c *Client) MutateGoogleAdsContext(ctx context.Context, customerID string, operations []MutateOperation) (*MutateGoogleAdsResponse, error) {
+	url := fmt.Sprintf("%s/customers/%s/googleAds:mutate", c.base, customerID)
+	body, err := c.postMutate(ctx, customerID, url, c.mutatePayload(map[string]any{"mutateOperations": operations}))
+	if err != nil {
+		return nil, err
+	}
+	var resp MutateGoogleAdsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parsing mutate response: %w", err)
+	}
+	return &resp, nil
+}
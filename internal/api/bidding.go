@@ -0,0 +1,141 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BiddingStrategyRow is a GAQL result row for bidding_strategy queries.
+type BiddingStrategyRow struct {
+	BiddingStrategy BiddingStrategy `json:"biddingStrategy"`
+}
+
+// BiddingStrategy is a portfolio bidding strategy, which several campaigns
+// can share. Only the field of its Type is set, and only when selected.
+type BiddingStrategy struct {
+	ResourceName            string                 `json:"resourceName"`
+	ID                      string                 `json:"id"`
+	Name                    string                 `json:"name"`
+	Status                  string                 `json:"status"`
+	Type                    string                 `json:"type"`
+	CampaignCount           Int64                  `json:"campaignCount"`
+	TargetCpa               *TargetCpa             `json:"targetCpa,omitempty"`
+	TargetRoas              *TargetRoas            `json:"targetRoas,omitempty"`
+	MaximizeConversions     *TargetCpa             `json:"maximizeConversions,omitempty"`
+	MaximizeConversionValue *TargetRoas            `json:"maximizeConversionValue,omitempty"`
+	TargetSpend             *TargetSpend           `json:"targetSpend,omitempty"`
+	TargetImpressionShare   *TargetImpressionShare `json:"targetImpressionShare,omitempty"`
+}
+
+// TargetCpa is the target cost per conversion of TARGET_CPA and, optional,
+// MAXIMIZE_CONVERSIONS strategies.
+type TargetCpa struct {
+	TargetCpaMicros Int64 `json:"targetCpaMicros,omitempty"`
+}
+
+// TargetRoas is the target return on ad spend of TARGET_ROAS and, optional,
+// MAXIMIZE_CONVERSION_VALUE strategies, as a ratio: 4.0 is 400%.
+type TargetRoas struct {
+	TargetRoas float64 `json:"targetRoas,omitempty"`
+}
+
+// TargetSpend is the optional max CPC of a TARGET_SPEND (maximize clicks)
+// strategy.
+type TargetSpend struct {
+	CpcBidCeilingMicros Int64 `json:"cpcBidCeilingMicros,omitempty"`
+}
+
+// TargetImpressionShare is the impression share a TARGET_IMPRESSION_SHARE
+// strategy aims for, in micros of a fraction: 500000 is 50%.
+type TargetImpressionShare struct {
+	Location               string `json:"location"`
+	LocationFractionMicros Int64  `json:"locationFractionMicros"`
+	CpcBidCeilingMicros    Int64  `json:"cpcBidCeilingMicros,omitempty"`
+}
+
+// biddingTargets describes the strategy types BiddingStrategyOperation
+// creates: the field holding the strategy's settings, the setting --target
+// goes into, whether the target is a ratio rather than an amount in
+// micros, and whether a target is required.
+var biddingTargets = map[string]struct {
+	field, target string
+	ratio         bool
+	required      bool
+}{
+	"TARGET_CPA":                {"targetCpa", "targetCpaMicros", false, true},
+	"TARGET_ROAS":               {"targetRoas", "targetRoas", true, true},
+	"MAXIMIZE_CONVERSIONS":      {"maximizeConversions", "targetCpaMicros", false, false},
+	"MAXIMIZE_CONVERSION_VALUE": {"maximizeConversionValue", "targetRoas", true, false},
+	"TARGET_SPEND":              {"targetSpend", "cpcBidCeilingMicros", false, false},
+}
+
+// BiddingStrategyTypes returns the strategy types BiddingStrategyOperation
+// creates, sorted.
+func BiddingStrategyTypes() []string {
+	return []string{"MAXIMIZE_CONVERSIONS", "MAXIMIZE_CONVERSION_VALUE", "TARGET_CPA", "TARGET_ROAS", "TARGET_SPEND"}
+}
+
+// BiddingTargetIsRatio reports whether the target of strategyType is a
+// ratio (ROAS) rather than an amount of currency (CPA, max CPC).
+func BiddingTargetIsRatio(strategyType string) bool {
+	return biddingTargets[strings.ToUpper(strategyType)].ratio
+}
+
+// BiddingStrategyOperation builds the operation creating a portfolio
+// bidding strategy of strategyType. target is the strategy's goal as typed
+// on the command line: an amount of currency for CPA and max CPC targets,
+// converted to micros ("12.50" → 12500000), and a ratio for ROAS targets,
+// sent as is ("4" or "4.0" → 4.0, i.e. 400%). An empty target leaves an
+// optional one unset.
+func BiddingStrategyOperation(name, strategyType, target string) (map[string]any, error) {
+	strategyType = strings.ToUpper(strategyType)
+	t, ok := biddingTargets[strategyType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported bidding strategy type %q: must be one of %s", strategyType, strings.Join(BiddingStrategyTypes(), ", "))
+	}
+	settings := map[string]any{}
+	switch {
+	case target == "" && t.required:
+		return nil, fmt.Errorf("%s needs a target", strategyType)
+	case target == "":
+	case t.ratio:
+		roas, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(target), "x"), 64)
+		if err != nil || roas <= 0 {
+			return nil, fmt.Errorf("invalid ROAS target %q: must be a positive ratio, e.g. 4.0 for 400%%", target)
+		}
+		// A ROAS over 100 is almost certainly a percentage.
+		if roas > 100 {
+			return nil, fmt.Errorf("ROAS target %q is a ratio, not a percentage: use %g for %s%%", target, roas/100, target)
+		}
+		settings[t.target] = roas
+	default:
+		micros, err := ParseCurrencyToMicros(target)
+		if err != nil {
+			return nil, err
+		}
+		if micros == 0 {
+			return nil, fmt.Errorf("the target amount must be positive")
+		}
+		settings[t.target] = strconv.FormatInt(micros, 10)
+	}
+	return map[string]any{
+		"create": map[string]any{
+			"name":  name,
+			t.field: settings,
+		},
+	}, nil
+}
+
+// BiddingStrategyResourceName returns the resource name of a portfolio
+// bidding strategy.
+func BiddingStrategyResourceName(customerID, strategyID string) string {
+	return fmt.Sprintf("customers/%s/biddingStrategies/%s", customerID, strategyID)
+}
+
+// MutateBiddingStrategies sends portfolio bidding strategy mutation
+// operations.
+func (c *Client) MutateBiddingStrategies(customerID string, operations []map[string]any) (*MutateResponse, error) {
+	url := fmt.Sprintf("%s/customers/%s/biddingStrategies:mutate", c.base, customerID)
+	return c.mutate(c.ctx, customerID, url, operations)
+}
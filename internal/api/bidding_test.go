@@ -0,0 +1,81 @@
+package api
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestBiddingStrategyOperation(t *testing.T) {
+	tests := []struct {
+		name, strategyType, target string
+		want                       string
+	}{
+		{name: "CPA in micros", strategyType: "TARGET_CPA", target: "12.50", want: `{"create":{"name":"S","targetCpa":{"targetCpaMicros":"12500000"}}}`},
+		{name: "CPA whole units", strategyType: "TARGET_CPA", target: "3", want: `{"create":{"name":"S","targetCpa":{"targetCpaMicros":"3000000"}}}`},
+		{name: "type is case-insensitive", strategyType: "target_cpa", target: "1", want: `{"create":{"name":"S","targetCpa":{"targetCpaMicros":"1000000"}}}`},
+		{name: "ROAS is a ratio", strategyType: "TARGET_ROAS", target: "4", want: `{"create":{"name":"S","targetRoas":{"targetRoas":4}}}`},
+		{name: "ROAS fraction", strategyType: "TARGET_ROAS", target: "2.5", want: `{"create":{"name":"S","targetRoas":{"targetRoas":2.5}}}`},
+		{name: "ROAS with x suffix", strategyType: "TARGET_ROAS", target: "4x", want: `{"create":{"name":"S","targetRoas":{"targetRoas":4}}}`},
+		{name: "maximize conversions without target", strategyType: "MAXIMIZE_CONVERSIONS", want: `{"create":{"maximizeConversions":{},"name":"S"}}`},
+		{name: "maximize conversions with CPA", strategyType: "MAXIMIZE_CONVERSIONS", target: "20", want: `{"create":{"maximizeConversions":{"targetCpaMicros":"20000000"},"name":"S"}}`},
+		{name: "maximize value without target", strategyType: "MAXIMIZE_CONVERSION_VALUE", want: `{"create":{"maximizeConversionValue":{},"name":"S"}}`},
+		{name: "maximize value with ROAS", strategyType: "MAXIMIZE_CONVERSION_VALUE", target: "3", want: `{"create":{"maximizeConversionValue":{"targetRoas":3},"name":"S"}}`},
+		{name: "target spend without ceiling", strategyType: "TARGET_SPEND", want: `{"create":{"name":"S","targetSpend":{}}}`},
+		{name: "target spend with ceiling", strategyType: "TARGET_SPEND", target: "0.75", want: `{"create":{"name":"S","targetSpend":{"cpcBidCeilingMicros":"750000"}}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, err := BiddingStrategyOperation("S", tt.strategyType, tt.target)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := json.Marshal(op)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got  %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBiddingStrategyOperationErrors(t *testing.T) {
+	tests := []struct {
+		name, strategyType, target string
+		wantErr                    string
+	}{
+		{name: "unknown type", strategyType: "MANUAL_CPC", target: "1", wantErr: `unsupported bidding strategy type "MANUAL_CPC"`},
+		{name: "empty type", strategyType: "", wantErr: "unsupported bidding strategy type"},
+		{name: "CPA needs a target", strategyType: "TARGET_CPA", wantErr: "TARGET_CPA needs a target"},
+		{name: "ROAS needs a target", strategyType: "TARGET_ROAS", wantErr: "TARGET_ROAS needs a target"},
+		{name: "ROAS as a percentage", strategyType: "TARGET_ROAS", target: "400", wantErr: "use 4 for 400%"},
+		{name: "ROAS zero", strategyType: "TARGET_ROAS", target: "0", wantErr: "must be a positive ratio"},
+		{name: "ROAS negative", strategyType: "MAXIMIZE_CONVERSION_VALUE", target: "-2", wantErr: "must be a positive ratio"},
+		{name: "ROAS not a number", strategyType: "TARGET_ROAS", target: "high", wantErr: "invalid ROAS target"},
+		{name: "CPA zero", strategyType: "TARGET_CPA", target: "0", wantErr: "must be positive"},
+		{name: "CPA not an amount", strategyType: "TARGET_CPA", target: "cheap", wantErr: "cheap"},
+		{name: "CPA negative", strategyType: "TARGET_SPEND", target: "-1", wantErr: "-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, err := BiddingStrategyOperation("S", tt.strategyType, tt.target)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error %v, want one containing %q", err, tt.wantErr)
+			}
+			if op != nil {
+				t.Errorf("returned an operation with the error: %v", op)
+			}
+		})
+	}
+}
+
+func TestBiddingTargetIsRatio(t *testing.T) {
+	for _, strategyType := range BiddingStrategyTypes() {
+		want := strategyType == "TARGET_ROAS" || strategyType == "MAXIMIZE_CONVERSION_VALUE"
+		if got := BiddingTargetIsRatio(strings.ToLower(strategyType)); got != want {
+			t.Errorf("BiddingTargetIsRatio(%s) = %v, want %v", strategyType, got, want)
+		}
+	}
+}
@@ -2,43 +2,254 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/the20100/gads-cli/internal/version"
 )
 
-const apiBase = "https://googleads.googleapis.com/v23"
+const apiHost = "https://googleads.googleapis.com"
+
+// DefaultAPIVersion is the Google Ads API version used when none is configured.
+const DefaultAPIVersion = "v23"
+
+// ErrCanceled is returned when a request is interrupted (e.g. by Ctrl-C).
+var ErrCanceled = errors.New("canceled")
+
+// DefaultConcurrency is the number of parallel queries SearchMany runs when
+// none is given.
+const DefaultConcurrency = 8
+
+// DefaultRetries is the number of retries for transient errors when none is configured.
+const DefaultRetries = 3
 
 // Client wraps an HTTP client with Google Ads API authentication headers.
 type Client struct {
 	http            *http.Client
 	developerToken  string
 	loginCustomerID string
+	retries         int
+	debug           io.Writer
+	ctx             context.Context
+	maxRows         int
+	partialFailure  bool
+	validateOnly    bool
+	version         string
+	base            string       // apiHost + "/" + version
+	refreshToken    func()       // drops the cached access token; nil when unsupported
+	cache           Cache        // nil disables caching
+	truncated       *atomic.Bool // shared by copies so commands see truncation from any of them
+	fromCache       *atomic.Bool // like truncated, for results served from cache
+	progress        ProgressFunc // nil disables progress reports
+	mutationLog     func(Mutation)
+	usage           *usageCounters // shared by copies, like truncated
+}
+
+// Mutation is a mutate request that was sent, as passed to the function of
+// WithMutationLog. It changed state only when Err is nil and ValidateOnly is
+// false.
+type Mutation struct {
+	CustomerID   string
+	Endpoint     string          // e.g. "customers/123/campaigns:mutate"
+	Request      json.RawMessage // the request body
+	Response     json.RawMessage // the response body
+	Err          error           // why the request failed; it may still have applied
+	ValidateOnly bool
+}
+
+// ProgressFunc receives the status of a long fetch as it advances, e.g.
+// "fetched 20000 rows / page 2" or "account 7/42", and "" once it is over.
+type ProgressFunc func(status string)
+
+// noProgressKey marks a context whose searches don't report progress, for
+// the per-account searches of SearchMany, which reports accounts instead.
+type noProgressKey struct{}
+
+// Cache stores Search results. Entries are grouped by customer ID so a
+// mutation can drop everything cached for its customer.
+type Cache interface {
+	Get(customerID, key string) ([]byte, bool)
+	Put(customerID, key string, data []byte) error
+	Invalidate(customerID string) error
+}
+
+// Option configures a Client in New.
+type Option func(*Client)
+
+// WithAPIVersion selects the API version, e.g. "v23" or "23". Empty keeps
+// DefaultAPIVersion.
+func WithAPIVersion(version string) Option {
+	return func(c *Client) {
+		if v := NormalizeAPIVersion(version); v != "" {
+			c.version = v
+		}
+	}
+}
+
+// WithTokenRefresh sets the function called to discard the cached access
+// token when the API rejects it with 401. The request is then retried once
+// with a freshly refreshed token.
+func WithTokenRefresh(invalidate func()) Option {
+	return func(c *Client) {
+		c.refreshToken = invalidate
+	}
+}
+
+// WithCache makes Search consult cache before querying the API and store
+// complete results in it. Mutations drop the cached entries of their customer.
+func WithCache(cache Cache) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// WithMutationLog calls log after every mutate request sent, whether it
+// succeeded (even with partial failures), failed or was validate-only.
+func WithMutationLog(log func(Mutation)) Option {
+	return func(c *Client) {
+		c.mutationLog = log
+	}
+}
+
+// NormalizeAPIVersion returns version with a leading "v" ("23" → "v23").
+func NormalizeAPIVersion(version string) string {
+	version = strings.ToLower(strings.TrimSpace(version))
+	if version == "" || strings.HasPrefix(version, "v") {
+		return version
+	}
+	return "v" + version
 }
 
 // New creates a new Client. httpClient should already have OAuth2 transport.
-func New(httpClient *http.Client, developerToken, loginCustomerID string) *Client {
-	return &Client{
+func New(httpClient *http.Client, developerToken, loginCustomerID string, opts ...Option) *Client {
+	c := &Client{
 		http:            httpClient,
 		developerToken:  developerToken,
 		loginCustomerID: CleanCustomerID(loginCustomerID),
+		retries:         DefaultRetries,
+		ctx:             context.Background(),
+		truncated:       new(atomic.Bool),
+		fromCache:       new(atomic.Bool),
+		usage:           new(usageCounters),
+		partialFailure:  true,
+		version:         DefaultAPIVersion,
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	c.base = apiHost + "/" + c.version
+	return c
+}
+
+// APIVersion returns the API version the client talks to.
+func (c *Client) APIVersion() string {
+	return c.version
+}
+
+// WithContext returns a shallow copy of the client whose requests are bound to
+// ctx. Methods without an explicit context argument use it.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	cp := *c
+	cp.ctx = ctx
+	return &cp
 }
 
 // WithLoginID returns a shallow copy of the client with a different login-customer-id.
 // Useful for querying accounts that are not sub-accounts of the configured MCC.
 func (c *Client) WithLoginID(loginID string) *Client {
-	return &Client{
-		http:            c.http,
-		developerToken:  c.developerToken,
-		loginCustomerID: CleanCustomerID(loginID),
+	cp := *c
+	cp.loginCustomerID = CleanCustomerID(loginID)
+	return &cp
+}
+
+// LoginCustomerID returns the manager account sent as login-customer-id,
+// or "" when none is configured.
+func (c *Client) LoginCustomerID() string {
+	return c.loginCustomerID
+}
+
+// SetRetries sets how many times transient errors are retried (0 disables retries).
+func (c *Client) SetRetries(n int) {
+	if n < 0 {
+		n = 0
 	}
+	c.retries = n
+}
+
+// SetMaxRows caps the number of rows Search and SearchStream return
+// (0 means no limit). Pagination stops as soon as the cap is reached.
+func (c *Client) SetMaxRows(n int) {
+	if n < 0 {
+		n = 0
+	}
+	c.maxRows = n
+}
+
+// SetProgress sets the function Search and SearchMany report progress to.
+func (c *Client) SetProgress(fn ProgressFunc) {
+	c.progress = fn
 }
 
-func (c *Client) doRequest(req *http.Request) ([]byte, error) {
+// reportProgress reports status unless progress is off for ctx.
+func (c *Client) reportProgress(ctx context.Context, status string) {
+	if c.progress != nil && ctx.Value(noProgressKey{}) == nil {
+		c.progress(status)
+	}
+}
+
+// Truncated reports whether any query was cut short by SetMaxRows.
+func (c *Client) Truncated() bool {
+	return c.truncated.Load()
+}
+
+// FromCache reports whether any Search result was served from the cache.
+func (c *Client) FromCache() bool {
+	return c.fromCache.Load()
+}
+
+// SetPartialFailure controls whether batched mutates apply the valid
+// operations when some fail (the default) or reject the whole batch.
+func (c *Client) SetPartialFailure(enabled bool) {
+	c.partialFailure = enabled
+}
+
+// SetValidateOnly makes every mutate request validate-only: the API checks
+// the operations and reports errors without applying them.
+func (c *Client) SetValidateOnly(enabled bool) {
+	c.validateOnly = enabled
+}
+
+// ValidateOnly reports whether mutates are sent as validate-only (dry run).
+func (c *Client) ValidateOnly() bool {
+	return c.validateOnly
+}
+
+// SetDebug enables diagnostic logging (e.g. retries) to w. A nil w disables it.
+func (c *Client) SetDebug(w io.Writer) {
+	c.debug = w
+}
+
+func (c *Client) debugf(format string, args ...any) {
+	if c.debug != nil {
+		fmt.Fprintf(c.debug, "[debug] "+format+"\n", args...)
+	}
+}
+
+// doRequest executes req with API headers. On success the response body is
+// left open for the caller; on an HTTP error it is consumed into a
+// GoogleAdsError. The response headers are returned in both cases so the
+// retry logic can honor Retry-After.
+func (c *Client) doRequest(req *http.Request) (*http.Response, http.Header, error) {
+	req.Header.Set("User-Agent", version.UserAgent())
 	req.Header.Set("developer-token", c.developerToken)
 	if c.loginCustomerID != "" {
 		req.Header.Set("login-customer-id", c.loginCustomerID)
@@ -46,80 +257,211 @@ func (c *Client) doRequest(req *http.Request) ([]byte, error) {
 
 	resp, err := c.http.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, nil, fmt.Errorf("request failed: %w", err)
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("reading response: %w", err)
+	// An access token that expired in flight (clock skew, stale cache) is
+	// rejected with 401 although the refresh token is fine: refresh and retry
+	// exactly once.
+	reauthed := false
+	if resp.StatusCode == http.StatusUnauthorized && c.refreshToken != nil && (req.Body == nil || req.GetBody != nil) {
+		resp.Body.Close()
+		req = req.Clone(req.Context())
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, nil, err
+			}
+		}
+		c.debugf("401 Unauthorized: refreshing access token and retrying")
+		c.refreshToken()
+		reauthed = true
+		if resp, err = c.http.Do(req); err != nil {
+			return nil, nil, fmt.Errorf("request failed: %w", err)
+		}
 	}
 
 	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, resp.Header, fmt.Errorf("reading response: %w", err)
+		}
 		// Try to extract a human-readable error message from the API response.
-		msg := extractErrorMessage(body)
-		if msg == "" {
-			msg = fmt.Sprintf("HTTP %d", resp.StatusCode)
+		gErr := parseError(resp.StatusCode, body)
+		if id := resp.Header.Get("request-id"); id != "" {
+			gErr.RequestID = id
+		}
+		if gErr.Body == "" {
+			gErr.Body = fmt.Sprintf("HTTP %d", resp.StatusCode)
+		}
+		// A retired version answers every URL with 404.
+		if resp.StatusCode == http.StatusNotFound && bytes.Contains(body, []byte("/"+c.version+"/")) {
+			gErr.Body += fmt.Sprintf(" (API %s may be retired — try a newer one with --api-version)", c.version)
 		}
-		return nil, &GoogleAdsError{StatusCode: resp.StatusCode, Body: msg}
+		if reauthed && resp.StatusCode == http.StatusUnauthorized {
+			gErr.Body += " (rejected again after a token refresh — run: gads-cli auth login)"
+		}
+		return nil, resp.Header, gErr
 	}
-	return body, nil
+	if id := resp.Header.Get("request-id"); id != "" {
+		c.debugf("request-id: %s", id)
+	}
+	return resp, resp.Header, nil
 }
 
-func extractErrorMessage(body []byte) string {
+// parseError builds a GoogleAdsError from an error response body. The
+// message and error code come from the first GoogleAdsFailure error when the
+// body carries one.
+func parseError(statusCode int, body []byte) *GoogleAdsError {
+	gErr := &GoogleAdsError{StatusCode: statusCode}
 	var errResp struct {
 		Error struct {
-			Message string `json:"message"`
-			Details []struct {
-				Errors []struct {
-					Message string `json:"message"`
-				} `json:"errors"`
-			} `json:"details"`
+			Message string            `json:"message"`
+			Status  string            `json:"status"`
+			Details []json.RawMessage `json:"details"`
 		} `json:"error"`
 	}
 	if json.Unmarshal(body, &errResp) != nil {
-		return string(body)
+		gErr.Body = string(body)
+		return gErr
 	}
-	// Prefer inner errors from GoogleAdsFailure
-	for _, detail := range errResp.Error.Details {
-		for _, e := range detail.Errors {
-			if e.Message != "" {
-				return e.Message
-			}
+	gErr.Status = errResp.Error.Status
+	for _, raw := range errResp.Error.Details {
+		var failure googleAdsFailure
+		if json.Unmarshal(raw, &failure) != nil || len(failure.Errors) == 0 {
+			continue
 		}
+		gErr.Body = failure.Errors[0].Message
+		gErr.Code = errorCodeName(failure.Errors[0].ErrorCode)
+		gErr.RequestID = failure.RequestID
+		break
 	}
-	if errResp.Error.Message != "" {
-		return errResp.Error.Message
+	if gErr.Body == "" {
+		gErr.Body = errResp.Error.Message
 	}
-	return string(body)
+	if gErr.Body == "" {
+		gErr.Body = string(body)
+	}
+	return gErr
+}
+
+func (c *Client) get(ctx context.Context, url string) ([]byte, error) {
+	return c.send(ctx, http.MethodGet, url, nil, true)
 }
 
-func (c *Client) get(url string) ([]byte, error) {
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+// post sends a read-only POST (e.g. googleAds:search) that is safe to retry.
+func (c *Client) post(ctx context.Context, url string, payload any) ([]byte, error) {
+	data, err := json.Marshal(payload)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("encoding request: %w", err)
 	}
-	req.Header.Set("Content-Type", "application/json")
-	return c.doRequest(req)
+	return c.send(ctx, http.MethodPost, url, data, true)
 }
 
-func (c *Client) post(url string, payload any) ([]byte, error) {
+// postMutate sends a POST that changes state for customerID. It is only
+// retried when the request clearly did not execute (429 or a failed
+// connection attempt). Unless validate-only, the customer's cached query
+// results are dropped, even on error, since the change may have applied.
+// Every request sent is passed to the mutation log.
+func (c *Client) postMutate(ctx context.Context, customerID, url string, payload any) ([]byte, error) {
 	data, err := json.Marshal(payload)
 	if err != nil {
 		return nil, fmt.Errorf("encoding request: %w", err)
 	}
-	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+	c.countOperations(payload)
+	body, err := c.send(ctx, http.MethodPost, url, data, false)
+	if c.cache != nil && !c.validateOnly {
+		if cErr := c.cache.Invalidate(customerID); cErr != nil {
+			c.debugf("cache: %v", cErr)
+		}
+	}
+	if c.mutationLog != nil {
+		c.mutationLog(Mutation{
+			CustomerID:   customerID,
+			Endpoint:     strings.TrimPrefix(url, c.base+"/"),
+			Request:      data,
+			Response:     body,
+			Err:          err,
+			ValidateOnly: c.validateOnly,
+		})
+	}
+	return body, err
+}
+
+// send builds and executes a request, retrying transient failures, and
+// returns the response body.
+func (c *Client) send(ctx context.Context, method, url string, data []byte, idempotent bool) ([]byte, error) {
+	resp, err := c.open(ctx, method, url, data, idempotent)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
-	return c.doRequest(req)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		if ctxErr := contextError(ctx); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	return body, nil
+}
+
+// open builds and executes a request, retrying transient failures until ctx
+// is done. The caller must close the returned response body.
+func (c *Client) open(ctx context.Context, method, url string, data []byte, idempotent bool) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		var body io.Reader
+		if data != nil {
+			body = bytes.NewReader(data)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, url, body)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		c.countRequest(method, url, idempotent)
+		resp, header, err := c.doRequest(req)
+		if ctxErr := contextError(ctx); ctxErr != nil {
+			if resp != nil {
+				resp.Body.Close()
+			}
+			return nil, ctxErr
+		}
+		if err == nil || attempt >= c.retries || !shouldRetry(err, idempotent) {
+			return resp, err
+		}
+		wait := backoff(attempt, header)
+		c.debugf("retry %d/%d for %s %s in %s: %v", attempt+1, c.retries, method, url, wait.Round(time.Millisecond), err)
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil, contextError(ctx)
+		}
+	}
+}
+
+// contextError maps a finished context to a user-facing error.
+func contextError(ctx context.Context) error {
+	switch ctx.Err() {
+	case nil:
+		return nil
+	case context.DeadlineExceeded:
+		return fmt.Errorf("request timed out: %w", ctx.Err())
+	default:
+		return ErrCanceled
+	}
 }
 
 // ListAccessibleCustomers returns the resource names of all directly accessible customers.
 func (c *Client) ListAccessibleCustomers() ([]string, error) {
-	url := apiBase + "/customers:listAccessibleCustomers"
-	body, err := c.get(url)
+	return c.ListAccessibleCustomersContext(c.ctx)
+}
+
+// ListAccessibleCustomersContext is like ListAccessibleCustomers but bound to ctx.
+func (c *Client) ListAccessibleCustomersContext(ctx context.Context) ([]string, error) {
+	url := c.base + "/customers:listAccessibleCustomers"
+	body, err := c.get(ctx, url)
 	if err != nil {
 		return nil, err
 	}
@@ -130,61 +472,428 @@ func (c *Client) ListAccessibleCustomers() ([]string, error) {
 	return resp.ResourceNames, nil
 }
 
+// CreateCustomerClient creates a new client account under the given manager account.
+func (c *Client) CreateCustomerClient(managerID string, customerClient map[string]any) (*CreateCustomerClientResponse, error) {
+	managerID = CleanCustomerID(managerID)
+	url := fmt.Sprintf("%s/customers/%s:createCustomerClient", c.base, managerID)
+	body, err := c.postMutate(c.ctx, managerID, url, c.mutatePayload(map[string]any{"customerClient": customerClient}))
+	if err != nil {
+		return nil, err
+	}
+	var resp CreateCustomerClientResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+	return &resp, nil
+}
+
+// SuggestGeoTargetConstants looks up locations by name. locale (e.g. "en")
+// sets the language of the returned names; countryCode (e.g. "US") narrows
+// the search and may be empty.
+func (c *Client) SuggestGeoTargetConstants(names []string, locale, countryCode string) ([]GeoTargetConstantSuggestion, error) {
+	payload := map[string]any{
+		"locationNames": map[string]any{"names": names},
+	}
+	if locale != "" {
+		payload["locale"] = locale
+	}
+	if countryCode != "" {
+		payload["countryCode"] = countryCode
+	}
+	body, err := c.post(c.ctx, c.base+"/geoTargetConstants:suggest", payload)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Suggestions []GeoTargetConstantSuggestion `json:"geoTargetConstantSuggestions"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+	return resp.Suggestions, nil
+}
+
+// Searcher runs GAQL queries. *Client is one; code that only reads can take
+// a Searcher so that tests can hand it canned rows.
+type Searcher interface {
+	Search(customerID, query string) ([]json.RawMessage, error)
+}
+
+// Mutator sends mutate operations to a service such as "campaigns". *Client
+// is one.
+type Mutator interface {
+	MutateContext(ctx context.Context, customerID, service string, operations []map[string]any) (*MutateResponse, error)
+}
+
+var (
+	_ Searcher = (*Client)(nil)
+	_ Mutator  = (*Client)(nil)
+)
+
 // Search executes a GAQL query and returns all result rows (handles pagination).
+// Each page is decoded while the next one is being fetched; rows keep the
+// API's order.
 func (c *Client) Search(customerID, query string) ([]json.RawMessage, error) {
-	url := fmt.Sprintf("%s/customers/%s/googleAds:search", apiBase, customerID)
+	return c.SearchContext(c.ctx, customerID, query)
+}
+
+// SearchContext is like Search but bound to ctx, so a canceled context stops
+// pagination, including a page being prefetched.
+func (c *Client) SearchContext(ctx context.Context, customerID, query string) ([]json.RawMessage, error) {
+	cacheKey := c.version + "\n" + c.loginCustomerID + "\n" + query
+	if c.cache != nil {
+		if data, ok := c.cache.Get(customerID, cacheKey); ok {
+			var rows []json.RawMessage
+			if json.Unmarshal(data, &rows) == nil {
+				c.fromCache.Store(true)
+				if c.maxRows > 0 && len(rows) > c.maxRows {
+					c.truncated.Store(true)
+					rows = rows[:c.maxRows]
+				}
+				return rows, nil
+			}
+		}
+	}
+
+	url := fmt.Sprintf("%s/customers/%s/googleAds:search", c.base, customerID)
 	var allResults []json.RawMessage
-	pageToken := ""
+	truncated := false
+	defer c.reportProgress(ctx, "")
+
+	// The next page is requested as soon as its token is known, and the
+	// current page decoded while it is in flight. At most one page is
+	// prefetched; returning early cancels it.
+	pageCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	fetch := func(pageToken string) <-chan searchPage {
+		ch := make(chan searchPage, 1)
+		go func() {
+			payload := map[string]string{"query": query}
+			if pageToken != "" {
+				payload["pageToken"] = pageToken
+			}
+			body, err := c.post(pageCtx, url, payload)
+			ch <- searchPage{body, err}
+		}()
+		return ch
+	}
 
-	for {
-		payload := map[string]string{"query": query}
-		if pageToken != "" {
-			payload["pageToken"] = pageToken
+	// page_size is not accepted by googleAds:search since v17 (pages are a
+	// fixed 10,000 rows), so the row cap is enforced between pages instead.
+	next := fetch("")
+	for page := 1; ; page++ {
+		p := <-next
+		if p.err != nil {
+			return nil, p.err
 		}
-		body, err := c.post(url, payload)
-		if err != nil {
-			return nil, err
+		next = nil
+		// No prefetch when this page may reach the row cap: the next one
+		// would not be used.
+		if token := nextPageToken(p.body); token != "" && (c.maxRows <= 0 || len(allResults)+searchPageSize < c.maxRows) {
+			next = fetch(token)
 		}
 		var resp SearchResponse
-		if err := json.Unmarshal(body, &resp); err != nil {
+		if err := json.Unmarshal(p.body, &resp); err != nil {
 			return nil, fmt.Errorf("parsing search response: %w", err)
 		}
 		allResults = append(allResults, resp.Results...)
+		c.reportProgress(ctx, fmt.Sprintf("fetched %d rows / page %d", len(allResults), page))
+		if c.maxRows > 0 && len(allResults) >= c.maxRows {
+			if len(allResults) > c.maxRows || resp.NextPageToken != "" {
+				c.truncated.Store(true)
+				truncated = true
+				allResults = allResults[:c.maxRows]
+			}
+			break
+		}
 		if resp.NextPageToken == "" {
 			break
 		}
-		pageToken = resp.NextPageToken
+		if next == nil {
+			next = fetch(resp.NextPageToken)
+		}
+	}
+
+	// Only complete results are cached, so a later run without --max-rows
+	// doesn't get a truncated list.
+	if c.cache != nil && !truncated {
+		data, err := json.Marshal(allResults)
+		if err == nil {
+			err = c.cache.Put(customerID, cacheKey, data)
+		}
+		if err != nil {
+			c.debugf("cache: %v", err)
+		}
 	}
 	return allResults, nil
 }
 
+// searchPageSize is the number of rows in a full googleAds:search page.
+const searchPageSize = 10_000
+
+// searchPage is the response to one googleAds:search page request.
+type searchPage struct {
+	body []byte
+	err  error
+}
+
+// nextPageToken returns the nextPageToken of a search response body without
+// decoding its rows, or "" if it has none. Inside rows the key could only
+// appear escaped, so the last match is the response's own.
+func nextPageToken(body []byte) string {
+	key := []byte(`"nextPageToken"`)
+	i := bytes.LastIndex(body, key)
+	if i < 0 {
+		return ""
+	}
+	rest := bytes.TrimLeft(body[i+len(key):], " \t\r\n")
+	if len(rest) == 0 || rest[0] != ':' {
+		return ""
+	}
+	var token string
+	if json.NewDecoder(bytes.NewReader(rest[1:])).Decode(&token) != nil {
+		return ""
+	}
+	return token
+}
+
+// SearchMany runs query against each customer with at most concurrency
+// requests in flight. Results and errors are keyed by customer ID; one
+// account failing does not affect the others. Customers not yet started when
+// ctx is canceled get the cancellation error.
+func (c *Client) SearchMany(ctx context.Context, customerIDs []string, query string, concurrency int) (map[string][]json.RawMessage, map[string]error) {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	results := make(map[string][]json.RawMessage, len(customerIDs))
+	errs := make(map[string]error)
+	done := 0
+	defer c.reportProgress(ctx, "")
+	searchCtx := context.WithValue(ctx, noProgressKey{}, true)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for _, id := range customerIDs {
+		id = CleanCustomerID(id)
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			mu.Lock()
+			errs[id] = contextError(ctx)
+			mu.Unlock()
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			rows, err := c.SearchContext(searchCtx, id, query)
+			mu.Lock()
+			defer mu.Unlock()
+			done++
+			c.reportProgress(ctx, fmt.Sprintf("account %d/%d", done, len(customerIDs)))
+			if err != nil {
+				errs[id] = err
+				return
+			}
+			results[id] = rows
+		}()
+	}
+	wg.Wait()
+	return results, errs
+}
+
+// SearchStream executes a GAQL query via googleAds:searchStream and calls fn
+// for each result row as batches arrive, so memory use stays constant no
+// matter how many rows the query returns. Iteration stops at the first error
+// returned by fn.
+func (c *Client) SearchStream(customerID, query string, fn func(json.RawMessage) error) error {
+	return c.SearchStreamContext(c.ctx, customerID, query, fn)
+}
+
+// SearchStreamContext is like SearchStream but bound to ctx.
+func (c *Client) SearchStreamContext(ctx context.Context, customerID, query string, fn func(json.RawMessage) error) error {
+	url := fmt.Sprintf("%s/customers/%s/googleAds:searchStream", c.base, customerID)
+	data, err := json.Marshal(map[string]string{"query": query})
+	if err != nil {
+		return fmt.Errorf("encoding request: %w", err)
+	}
+	resp, err := c.open(ctx, http.MethodPost, url, data, true)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// The body is a JSON array of batches; decode one batch at a time.
+	dec := json.NewDecoder(resp.Body)
+	if tok, err := dec.Token(); err != nil {
+		return streamError(ctx, err)
+	} else if tok != json.Delim('[') {
+		return fmt.Errorf("parsing search stream: unexpected token %v", tok)
+	}
+	rows := 0
+	for dec.More() {
+		var batch struct {
+			Results []json.RawMessage `json:"results"`
+			Error   json.RawMessage   `json:"error"`
+		}
+		if err := dec.Decode(&batch); err != nil {
+			return streamError(ctx, err)
+		}
+		if batch.Error != nil {
+			// Errors after the stream has started arrive as an array element.
+			gErr := parseError(resp.StatusCode, []byte(`{"error":`+string(batch.Error)+`}`))
+			if gErr.RequestID == "" {
+				gErr.RequestID = resp.Header.Get("request-id")
+			}
+			return gErr
+		}
+		for _, row := range batch.Results {
+			if c.maxRows > 0 && rows >= c.maxRows {
+				c.truncated.Store(true)
+				return nil
+			}
+			rows++
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func streamError(ctx context.Context, err error) error {
+	if ctxErr := contextError(ctx); ctxErr != nil {
+		return ctxErr
+	}
+	return fmt.Errorf("parsing search stream: %w", err)
+}
+
 // MutateCampaigns sends campaign mutation operations.
 func (c *Client) MutateCampaigns(customerID string, operations []map[string]any) (*MutateResponse, error) {
-	url := fmt.Sprintf("%s/customers/%s/campaigns:mutate", apiBase, customerID)
-	return c.mutate(url, operations)
+	url := fmt.Sprintf("%s/customers/%s/campaigns:mutate", c.base, customerID)
+	return c.mutate(c.ctx, customerID, url, operations)
 }
 
 // MutateCampaignBudgets sends campaign budget mutation operations.
 func (c *Client) MutateCampaignBudgets(customerID string, operations []map[string]any) (*MutateResponse, error) {
-	url := fmt.Sprintf("%s/customers/%s/campaignBudgets:mutate", apiBase, customerID)
-	return c.mutate(url, operations)
+	url := fmt.Sprintf("%s/customers/%s/campaignBudgets:mutate", c.base, customerID)
+	return c.mutate(c.ctx, customerID, url, operations)
 }
 
 // MutateAdGroups sends ad group mutation operations.
 func (c *Client) MutateAdGroups(customerID string, operations []map[string]any) (*MutateResponse, error) {
-	url := fmt.Sprintf("%s/customers/%s/adGroups:mutate", apiBase, customerID)
-	return c.mutate(url, operations)
+	url := fmt.Sprintf("%s/customers/%s/adGroups:mutate", c.base, customerID)
+	return c.mutate(c.ctx, customerID, url, operations)
 }
 
 // MutateAdGroupCriteria sends keyword (criterion) mutation operations.
 func (c *Client) MutateAdGroupCriteria(customerID string, operations []map[string]any) (*MutateResponse, error) {
-	url := fmt.Sprintf("%s/customers/%s/adGroupCriteria:mutate", apiBase, customerID)
-	return c.mutate(url, operations)
+	url := fmt.Sprintf("%s/customers/%s/adGroupCriteria:mutate", c.base, customerID)
+	return c.mutate(c.ctx, customerID, url, operations)
+}
+
+// MutateCampaignCriteria sends campaign criterion mutation operations.
+func (c *Client) MutateCampaignCriteria(customerID string, operations []map[string]any) (*MutateResponse, error) {
+	url := fmt.Sprintf("%s/customers/%s/campaignCriteria:mutate", c.base, customerID)
+	return c.mutate(c.ctx, customerID, url, operations)
+}
+
+// MutateConversionActions sends conversion action mutation operations.
+func (c *Client) MutateConversionActions(customerID string, operations []map[string]any) (*MutateResponse, error) {
+	url := fmt.Sprintf("%s/customers/%s/conversionActions:mutate", c.base, customerID)
+	return c.mutate(c.ctx, customerID, url, operations)
+}
+
+// MutateAssets sends asset mutation operations.
+func (c *Client) MutateAssets(customerID string, operations []map[string]any) (*MutateResponse, error) {
+	url := fmt.Sprintf("%s/customers/%s/assets:mutate", c.base, customerID)
+	return c.mutate(c.ctx, customerID, url, operations)
+}
+
+// MutateCampaignAssets sends campaign-asset link operations.
+func (c *Client) MutateCampaignAssets(customerID string, operations []map[string]any) (*MutateResponse, error) {
+	url := fmt.Sprintf("%s/customers/%s/campaignAssets:mutate", c.base, customerID)
+	return c.mutate(c.ctx, customerID, url, operations)
+}
+
+// MutateCustomerAssets sends account-level asset link operations.
+func (c *Client) MutateCustomerAssets(customerID string, operations []map[string]any) (*MutateResponse, error) {
+	url := fmt.Sprintf("%s/customers/%s/customerAssets:mutate", c.base, customerID)
+	return c.mutate(c.ctx, customerID, url, operations)
+}
+
+// MutateLabels sends label mutation operations.
+func (c *Client) MutateLabels(customerID string, operations []map[string]any) (*MutateResponse, error) {
+	url := fmt.Sprintf("%s/customers/%s/labels:mutate", c.base, customerID)
+	return c.mutate(c.ctx, customerID, url, operations)
+}
+
+// MutateCampaignLabels sends campaign-label association operations.
+func (c *Client) MutateCampaignLabels(customerID string, operations []map[string]any) (*MutateResponse, error) {
+	url := fmt.Sprintf("%s/customers/%s/campaignLabels:mutate", c.base, customerID)
+	return c.mutate(c.ctx, customerID, url, operations)
+}
+
+// MutateContext sends mutation operations for the given service (e.g.
+// "campaigns", "adGroupCriteria") bound to ctx.
+func (c *Client) MutateContext(ctx context.Context, customerID, service string, operations []map[string]any) (*MutateResponse, error) {
+	url := fmt.Sprintf("%s/customers/%s/%s:mutate", c.base, customerID, service)
+	return c.mutate(ctx, customerID, url, operations)
+}
+
+// ApplyRecommendations applies recommendations by resource name. The endpoint
+// has no validate-only mode, so it refuses to run under SetValidateOnly.
+func (c *Client) ApplyRecommendations(customerID string, resourceNames []string) (*MutateResponse, error) {
+	return c.recommendationAction(customerID, "apply", resourceNames)
+}
+
+// DismissRecommendations dismisses recommendations by resource name. Like
+// ApplyRecommendations it refuses to run under SetValidateOnly.
+func (c *Client) DismissRecommendations(customerID string, resourceNames []string) (*MutateResponse, error) {
+	return c.recommendationAction(customerID, "dismiss", resourceNames)
 }
 
-func (c *Client) mutate(url string, operations []map[string]any) (*MutateResponse, error) {
-	payload := map[string]any{"operations": operations}
-	body, err := c.post(url, payload)
+func (c *Client) recommendationAction(customerID, action string, resourceNames []string) (*MutateResponse, error) {
+	if c.validateOnly {
+		return nil, fmt.Errorf("recommendations:%s has no validate-only mode; --dry-run is not supported", action)
+	}
+	ops := make([]map[string]any, len(resourceNames))
+	for i, name := range resourceNames {
+		ops[i] = map[string]any{"resourceName": name}
+	}
+	payload := map[string]any{"operations": ops}
+	if c.partialFailure && len(ops) > 1 {
+		payload["partialFailure"] = true
+	}
+	url := fmt.Sprintf("%s/customers/%s/recommendations:%s", c.base, customerID, action)
+	body, err := c.postMutate(c.ctx, customerID, url, payload)
+	if err != nil {
+		return nil, err
+	}
+	var resp MutateResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parsing %s response: %w", action, err)
+	}
+	return &resp, nil
+}
+
+// mutatePayload adds the request options shared by every mutate endpoint.
+// All state-changing requests must go through it so --dry-run is never lost.
+func (c *Client) mutatePayload(payload map[string]any) map[string]any {
+	if c.validateOnly {
+		payload["validateOnly"] = true
+	}
+	return payload
+}
+
+func (c *Client) mutate(ctx context.Context, customerID, url string, operations []map[string]any) (*MutateResponse, error) {
+	payload := c.mutatePayload(map[string]any{"operations": operations})
+	// A single operation fails the same way either way; keep its HTTP error.
+	if c.partialFailure && len(operations) > 1 {
+		payload["partialFailure"] = true
+	}
+	body, err := c.postMutate(ctx, customerID, url, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -205,6 +914,25 @@ func ResourceID(resourceName string) string {
 	return parts[len(parts)-1]
 }
 
+// CampaignLabelResourceName returns the resource name of the association
+// between a campaign and a label.
+// e.g. ("123", "456", "789") → "customers/123/campaignLabels/456~789"
+func CampaignLabelResourceName(customerID, campaignID, labelID string) string {
+	return fmt.Sprintf("customers/%s/campaignLabels/%s~%s", customerID, campaignID, labelID)
+}
+
+// CampaignAssetResourceName returns the resource name of the link between a
+// campaign and an asset for the given field type (e.g. "SITELINK").
+func CampaignAssetResourceName(customerID, campaignID, assetID, fieldType string) string {
+	return fmt.Sprintf("customers/%s/campaignAssets/%s~%s~%s", customerID, campaignID, assetID, fieldType)
+}
+
+// CustomerAssetResourceName returns the resource name of an account-level
+// asset link for the given field type.
+func CustomerAssetResourceName(customerID, assetID, fieldType string) string {
+	return fmt.Sprintf("customers/%s/customerAssets/%s~%s", customerID, assetID, fieldType)
+}
+
 // CleanCustomerID normalises a customer ID: strips Optional[...] wrappers and hyphens.
 // e.g. "Optional[345-734-7709]" → "3457347709", "123-456-7890" → "1234567890"
 func CleanCustomerID(id string) string {
@@ -214,35 +942,83 @@ func CleanCustomerID(id string) string {
 	return strings.ReplaceAll(id, "-", "")
 }
 
-// MicrosToCurrency converts micros (int64-as-string) to a currency string.
-// e.g. "5000000" → "5.00"
-func MicrosToCurrency(micros string) string {
-	if micros == "" {
-		return "0.00"
+// IsNumericID reports whether s is a non-empty string of ASCII digits, as
+// required for campaign, ad group and criterion IDs.
+func IsNumericID(s string) bool {
+	if s == "" {
+		return false
 	}
-	n, err := strconv.ParseInt(micros, 10, 64)
-	if err != nil {
-		return micros
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// groupDigits makes the Format and MicrosTo helpers add thousands
+// separators, and FormatMoney currency symbols. It is set for human output
+// only, so JSON, CSV and --raw-numbers stay raw.
+var groupDigits bool
+
+// SetDigitGrouping turns thousands separators and currency symbols on or
+// off.
+func SetDigitGrouping(enabled bool) {
+	groupDigits = enabled
+}
+
+// DigitGrouping reports whether numbers are formatted for people.
+func DigitGrouping() bool {
+	return groupDigits
+}
+
+// groupThousands adds separators to the integer part of a formatted number
+// when digit grouping is on: "-1234567.50" → "-1,234,567.50".
+func groupThousands(s string) string {
+	if !groupDigits {
+		return s
+	}
+	sign, digits, frac := "", s, ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		digits, frac = digits[:i], digits[i:]
+	}
+	return sign + insertSeparators(digits, ",") + frac
+}
+
+// insertSeparators puts sep between each group of three digits.
+func insertSeparators(digits, sep string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteString(sep)
+		}
+		b.WriteRune(d)
 	}
-	return fmt.Sprintf("%.2f", float64(n)/1_000_000)
+	return b.String()
+}
+
+// MicrosToCurrency converts micros to a currency string.
+// e.g. 5000000 → "5.00"
+func MicrosToCurrency(micros int64) string {
+	return groupThousands(fmt.Sprintf("%.2f", float64(micros)/1_000_000))
 }
 
 // MicrosFloatToCurrency converts micros returned as a float64 to a currency string.
 // e.g. 406585.47 → "0.41"
 func MicrosFloatToCurrency(micros float64) string {
-	return fmt.Sprintf("%.2f", micros/1_000_000)
+	return groupThousands(fmt.Sprintf("%.2f", micros/1_000_000))
 }
 
-// FormatMetricInt formats an int64-as-string metric for display.
-func FormatMetricInt(s string) string {
-	if s == "" {
-		return "0"
-	}
-	n, err := strconv.ParseInt(s, 10, 64)
-	if err != nil {
-		return s
-	}
-	return strconv.FormatInt(n, 10)
+// FormatMetricInt formats an integer metric for display, e.g. 1234567 →
+// "1,234,567" in tables.
+func FormatMetricInt(n int64) string {
+	return groupThousands(strconv.FormatInt(n, 10))
 }
 
 // FormatCTR formats a CTR float as a percentage string.
@@ -257,12 +1033,11 @@ func FormatPct(f float64) string {
 }
 
 // FormatROAS calculates and formats ROAS.
-func FormatROAS(conversionsValue float64, costMicros string) string {
-	n, err := strconv.ParseInt(costMicros, 10, 64)
-	if err != nil || n == 0 {
+func FormatROAS(conversionsValue float64, costMicros int64) string {
+	if costMicros == 0 {
 		return "-"
 	}
-	cost := float64(n) / 1_000_000
+	cost := float64(costMicros) / 1_000_000
 	roas := conversionsValue / cost
 	return fmt.Sprintf("%.2f", roas)
 }
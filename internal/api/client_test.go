@@ -0,0 +1,715 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/the20100/gads-cli/internal/version"
+)
+
+func TestIsNumericID(t *testing.T) {
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{"1234567890", true},
+		{"0", true},
+		{"", false},
+		{"123-456-7890", false},
+		{"12 34", false},
+		{"-1", false},
+		{"1e9", false},
+		{"123' OR '1'='1", false},
+		{"١٢٣", false},
+	}
+	for _, tt := range tests {
+		if got := IsNumericID(tt.s); got != tt.want {
+			t.Errorf("IsNumericID(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+// serverTransport sends every request to srv instead of the API host.
+type serverTransport struct {
+	srv *httptest.Server
+}
+
+func (t serverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	target, _ := url.Parse(t.srv.URL)
+	req = req.Clone(req.Context())
+	req.URL.Scheme, req.URL.Host = target.Scheme, target.Host
+	return t.srv.Client().Transport.RoundTrip(req)
+}
+
+// newTestClient returns a client with opts and without retries whose
+// requests h answers, over HTTP, and the server running h.
+func newTestClient(tb testing.TB, h http.Handler, opts ...Option) (*Client, *httptest.Server) {
+	tb.Helper()
+	srv := httptest.NewServer(h)
+	tb.Cleanup(srv.Close)
+	c := New(&http.Client{Transport: serverTransport{srv}}, "dev", "", opts...)
+	c.SetRetries(0)
+	return c, srv
+}
+
+// searchPageToken returns the pageToken of a googleAds:search request.
+func searchPageToken(r *http.Request) string {
+	var body struct {
+		PageToken string `json:"pageToken"`
+	}
+	json.NewDecoder(r.Body).Decode(&body)
+	return body.PageToken
+}
+
+// pagesHandler serves pages search pages of rows rows each, numbered
+// across pages, after latency. Page tokens are the next page's index.
+func pagesHandler(pages, rows int, latency time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(searchPageToken(r))
+		time.Sleep(latency)
+		var b strings.Builder
+		b.WriteString(`{"results":[`)
+		for i := 0; i < rows; i++ {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			fmt.Fprintf(&b, `{"campaign":{"id":"%d","name":"Campaign %d"}}`, page*rows+i, page*rows+i)
+		}
+		b.WriteString(`]`)
+		if page+1 < pages {
+			fmt.Fprintf(&b, `,"nextPageToken":"%d"`, page+1)
+		}
+		b.WriteString(`}`)
+		w.Write([]byte(b.String()))
+	}
+}
+
+func TestSearchKeepsPageOrder(t *testing.T) {
+	c, _ := newTestClient(t, pagesHandler(5, 3, 0))
+	rows, err := c.Search("1234567890", "SELECT campaign.id FROM campaign")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 15 {
+		t.Fatalf("got %d rows, want 15", len(rows))
+	}
+	for i, row := range rows {
+		if want := fmt.Sprintf(`"id":"%d"`, i); !strings.Contains(string(row), want) {
+			t.Errorf("row %d is %s", i, row)
+		}
+	}
+}
+
+// waitForGoroutines waits for the number of goroutines to drop back to n.
+func waitForGoroutines(t *testing.T, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > n {
+		if time.Now().After(deadline) {
+			buf := make([]byte, 1<<16)
+			t.Fatalf("%d goroutines left, want %d:\n%s", runtime.NumGoroutine(), n, buf[:runtime.Stack(buf, true)])
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// roundTripFunc is an http.RoundTripper calling itself.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// TestSearchStopsPrefetch checks that a page being prefetched when Search
+// returns is canceled, and its goroutine does not outlive the call.
+func TestSearchStopsPrefetch(t *testing.T) {
+	tests := []struct {
+		name      string
+		firstPage string
+		cancel    bool
+		wantErr   error
+	}{
+		// Canceled while page 2 is in flight.
+		{name: "context canceled", firstPage: `{"results":[{"campaign":{"id":"1"}}],"nextPageToken":"2"}`, cancel: true, wantErr: ErrCanceled},
+		// Page 1 fails to decode after page 2 was requested.
+		{name: "page fails to decode", firstPage: `{"results":[{"campaign":}],"nextPageToken":"2"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requested := make(chan struct{})
+			h := func(w http.ResponseWriter, r *http.Request) {
+				if searchPageToken(r) == "" {
+					w.Write([]byte(tt.firstPage))
+					return
+				}
+				close(requested)
+				<-r.Context().Done()
+			}
+			c, srv := newTestClient(t, http.HandlerFunc(h))
+			// The prefetch's round trip ends when its request is canceled,
+			// whether or not it reached the server.
+			prefetched := make(chan error, 1)
+			next := c.http.Transport
+			c.http = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				first := req.Body == nil || !strings.Contains(readBody(t, req), "pageToken")
+				resp, err := next.RoundTrip(req)
+				if !first {
+					prefetched <- err
+				}
+				return resp, err
+			})}
+			before := runtime.NumGoroutine()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancel {
+				go func() {
+					<-requested
+					cancel()
+				}()
+			}
+			done := make(chan error, 1)
+			go func() {
+				_, err := c.SearchContext(ctx, "1234567890", "SELECT campaign.id FROM campaign")
+				done <- err
+			}()
+
+			var err error
+			select {
+			case err = <-done:
+			case <-time.After(2 * time.Second):
+				t.Fatal("Search did not return")
+			}
+			if err == nil {
+				t.Fatal("Search succeeded")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("error %v, want %v", err, tt.wantErr)
+			}
+			select {
+			case err := <-prefetched:
+				if !errors.Is(err, context.Canceled) {
+					t.Errorf("prefetch ended with %v, want it canceled", err)
+				}
+			case <-time.After(2 * time.Second):
+				t.Fatal("the prefetched request was not canceled")
+			}
+			srv.Close()
+			waitForGoroutines(t, before)
+		})
+	}
+}
+
+// readBody returns the body of req and leaves it readable.
+func readBody(t *testing.T, req *http.Request) string {
+	t.Helper()
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	return string(data)
+}
+
+// BenchmarkSearchPages measures Search over a 10-page report, with and
+// without round-trip latency.
+func BenchmarkSearchPages(b *testing.B) {
+	for _, latency := range []time.Duration{0, 5 * time.Millisecond} {
+		b.Run("latency="+latency.String(), func(b *testing.B) {
+			c, _ := newTestClient(b, pagesHandler(10, 1000, latency))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				rows, err := c.Search("1234567890", "SELECT campaign.id FROM campaign")
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(rows) != 10_000 {
+					b.Fatalf("got %d rows, want 10000", len(rows))
+				}
+			}
+		})
+	}
+}
+
+// TestRefreshOn401 checks that a request rejected with 401 is sent again,
+// once, after the access token is refreshed.
+func TestRefreshOn401(t *testing.T) {
+	tests := []struct {
+		name          string
+		mutate        bool
+		status        int  // answered to the first token
+		stillRejected bool // the refreshed token is rejected too
+		noRefresh     bool // no WithTokenRefresh
+		wantRefreshes int
+		wantRequests  int
+		wantErr       string
+	}{
+		{name: "search", status: 401, wantRefreshes: 1, wantRequests: 2},
+		{name: "mutate resends its body", mutate: true, status: 401, wantRefreshes: 1, wantRequests: 2},
+		{name: "rejected again", status: 401, stillRejected: true, wantRefreshes: 1, wantRequests: 2, wantErr: "rejected again after a token refresh"},
+		{name: "mutate rejected again", mutate: true, status: 401, stillRejected: true, wantRefreshes: 1, wantRequests: 2, wantErr: "rejected again"},
+		{name: "without refresh", status: 401, noRefresh: true, wantRequests: 1, wantErr: "HTTP 401"},
+		{name: "403 is not refreshed", status: 403, wantRequests: 1, wantErr: "HTTP 403"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bodies []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, string(body))
+				token := r.Header.Get("Authorization")
+				if token == "Bearer expired" || (token == "Bearer fresh" && tt.stillRejected) {
+					w.WriteHeader(tt.status)
+					return
+				}
+				io.WriteString(w, `{"results":[{"resourceName":"customers/1234567890/campaigns/111"}]}`)
+			}))
+			defer srv.Close()
+
+			token := "expired"
+			var opts []Option
+			refreshes := 0
+			if !tt.noRefresh {
+				opts = append(opts, WithTokenRefresh(func() {
+					refreshes++
+					token = "fresh"
+				}))
+			}
+			rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				req = req.Clone(req.Context())
+				req.Header.Set("Authorization", "Bearer "+token)
+				return serverTransport{srv}.RoundTrip(req)
+			})
+			c := New(&http.Client{Transport: rt}, "dev", "", opts...)
+			// Retries must not add refreshes.
+			c.SetRetries(2)
+
+			var err error
+			if tt.mutate {
+				_, err = c.MutateCampaigns("1234567890", []map[string]any{{"remove": "customers/1234567890/campaigns/111"}})
+			} else {
+				_, err = c.Search("1234567890", "SELECT campaign.id FROM campaign")
+			}
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("error %v, want one containing %q", err, tt.wantErr)
+			}
+			if refreshes != tt.wantRefreshes {
+				t.Errorf("refreshed %d times, want %d", refreshes, tt.wantRefreshes)
+			}
+			if len(bodies) != tt.wantRequests {
+				t.Fatalf("sent %d requests, want %d", len(bodies), tt.wantRequests)
+			}
+			if len(bodies) == 2 && (bodies[1] != bodies[0] || bodies[0] == "") {
+				t.Errorf("retried with body %q, want %q", bodies[1], bodies[0])
+			}
+		})
+	}
+}
+
+func TestSearchMany(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	c, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		id := strings.Split(r.URL.Path, "/")[3]
+		if id == "333" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		fmt.Fprintf(w, `{"results":[{"customer":{"id":"%s"}}]}`, id)
+	}))
+
+	ids := []string{"111", "222", "333", "444", "555-666-7777"}
+	results, errs := c.SearchMany(context.Background(), ids, "SELECT customer.id FROM customer", 2)
+	if maxInFlight > 2 {
+		t.Errorf("%d requests in flight, want at most 2", maxInFlight)
+	}
+	if len(errs) != 1 || errs["333"] == nil {
+		t.Errorf("errors %v, want only 333's", errs)
+	}
+	for _, id := range []string{"111", "222", "444", "5556667777"} {
+		if rows := results[id]; len(rows) != 1 || !strings.Contains(string(rows[0]), id) {
+			t.Errorf("results of %s: %s", id, rows)
+		}
+	}
+	if _, ok := results["333"]; ok {
+		t.Error("333 has results")
+	}
+}
+
+func TestSearchManyCanceled(t *testing.T) {
+	c, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("%s sent after cancellation", r.URL.Path)
+	}))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, errs := c.SearchMany(ctx, []string{"111", "222"}, "SELECT customer.id FROM customer", 1)
+	if len(results) != 0 {
+		t.Errorf("results %v, want none", results)
+	}
+	for _, id := range []string{"111", "222"} {
+		if !errors.Is(errs[id], ErrCanceled) {
+			t.Errorf("error of %s is %v, want %v", id, errs[id], ErrCanceled)
+		}
+	}
+}
+
+// countRequests wraps h to count the requests it answers.
+func countRequests(h http.Handler, n *atomic.Int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		n.Add(1)
+		h.ServeHTTP(w, r)
+	}
+}
+
+func TestSearchMaxRows(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxRows       int
+		wantRows      int
+		wantRequests  int32
+		wantTruncated bool
+	}{
+		{name: "no limit", wantRows: 15, wantRequests: 5},
+		{name: "within the first pages", maxRows: 4, wantRows: 4, wantRequests: 2, wantTruncated: true},
+		{name: "at a page boundary", maxRows: 6, wantRows: 6, wantRequests: 2, wantTruncated: true},
+		{name: "exactly every row", maxRows: 15, wantRows: 15, wantRequests: 5},
+		{name: "more than every row", maxRows: 100, wantRows: 15, wantRequests: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			c, _ := newTestClient(t, countRequests(pagesHandler(5, 3, 0), &requests))
+			c.SetMaxRows(tt.maxRows)
+
+			rows, err := c.Search("1234567890", "SELECT campaign.id FROM campaign")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(rows) != tt.wantRows {
+				t.Errorf("got %d rows, want %d", len(rows), tt.wantRows)
+			}
+			if n := requests.Load(); n != tt.wantRequests {
+				t.Errorf("fetched %d pages, want %d", n, tt.wantRequests)
+			}
+			if c.Truncated() != tt.wantTruncated {
+				t.Errorf("Truncated() = %v, want %v", c.Truncated(), tt.wantTruncated)
+			}
+		})
+	}
+}
+
+// memoryCache is a Cache in a map.
+type memoryCache map[string][]byte
+
+func (m memoryCache) Get(customerID, key string) ([]byte, bool) {
+	data, ok := m[customerID+"\n"+key]
+	return data, ok
+}
+
+func (m memoryCache) Put(customerID, key string, data []byte) error {
+	m[customerID+"\n"+key] = data
+	return nil
+}
+
+func (m memoryCache) Invalidate(customerID string) error {
+	for k := range m {
+		if strings.HasPrefix(k, customerID+"\n") {
+			delete(m, k)
+		}
+	}
+	return nil
+}
+
+func TestSearchMaxRowsCache(t *testing.T) {
+	cache := memoryCache{}
+	var requests atomic.Int32
+	c, _ := newTestClient(t, countRequests(pagesHandler(2, 3, 0), &requests), WithCache(cache))
+	query := "SELECT campaign.id FROM campaign"
+
+	// A truncated result is not cached.
+	c.SetMaxRows(2)
+	if _, err := c.Search("1234567890", query); err != nil {
+		t.Fatal(err)
+	}
+	if len(cache) != 0 {
+		t.Fatal("cached a truncated result")
+	}
+
+	// A complete one is, and the cap applies to it when served from the cache.
+	c.SetMaxRows(0)
+	if _, err := c.Search("1234567890", query); err != nil {
+		t.Fatal(err)
+	}
+	c.SetMaxRows(4)
+	sent := requests.Load()
+	rows, err := c.Search("1234567890", query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests.Load() != sent || !c.FromCache() {
+		t.Error("not served from the cache")
+	}
+	if len(rows) != 4 || !c.Truncated() {
+		t.Errorf("got %d rows from the cache, truncated %v; want 4, truncated", len(rows), c.Truncated())
+	}
+}
+
+func TestSearchStreamMaxRows(t *testing.T) {
+	tests := []struct {
+		maxRows       int
+		wantRows      int
+		wantTruncated bool
+	}{
+		{maxRows: 0, wantRows: 6},
+		{maxRows: 4, wantRows: 4, wantTruncated: true},
+		{maxRows: 6, wantRows: 6},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("max %d", tt.maxRows), func(t *testing.T) {
+			c, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				io.WriteString(w, `[{"results":[{"campaign":{"id":"0"}},{"campaign":{"id":"1"}},{"campaign":{"id":"2"}}]},`+
+					`{"results":[{"campaign":{"id":"3"}},{"campaign":{"id":"4"}},{"campaign":{"id":"5"}}]}]`)
+			}))
+			c.SetMaxRows(tt.maxRows)
+			rows := 0
+			err := c.SearchStream("1234567890", "SELECT campaign.id FROM campaign", func(row json.RawMessage) error {
+				if want := fmt.Sprintf(`"id":"%d"`, rows); !strings.Contains(string(row), want) {
+					t.Errorf("row %d is %s", rows, row)
+				}
+				rows++
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rows != tt.wantRows {
+				t.Errorf("got %d rows, want %d", rows, tt.wantRows)
+			}
+			if c.Truncated() != tt.wantTruncated {
+				t.Errorf("Truncated() = %v, want %v", c.Truncated(), tt.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	failure := func(code, message string) string {
+		return `{"error":{"code":400,"message":"Request contains an invalid argument.","status":"INVALID_ARGUMENT","details":[` +
+			`{"@type":"type.googleapis.com/google.ads.googleads.v23.errors.GoogleAdsFailure","errors":[` +
+			`{"errorCode":` + code + `,"message":"` + message + `"},{"errorCode":{"fieldError":"REQUIRED"},"message":"second"}],` +
+			`"requestId":"abc123"}]}}`
+	}
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		want      GoogleAdsError
+		wantError string
+	}{
+		{
+			name:   "google ads failure",
+			status: 400,
+			body:   failure(`{"queryError":"PROHIBITED_RESOURCE_TYPE_IN_SELECT_CLAUSE"}`, "Cannot select campaign."),
+			want: GoogleAdsError{StatusCode: 400, Status: "INVALID_ARGUMENT", Body: "Cannot select campaign.",
+				Code: "QUERY_ERROR.PROHIBITED_RESOURCE_TYPE_IN_SELECT_CLAUSE", RequestID: "abc123"},
+			wantError: "Cannot select campaign. [QUERY_ERROR.PROHIBITED_RESOURCE_TYPE_IN_SELECT_CLAUSE] (request-id: abc123)",
+		},
+		{
+			name:   "single word error type",
+			status: 401,
+			body:   failure(`{"authenticationError":"OAUTH_TOKEN_EXPIRED"}`, "Expired."),
+			want: GoogleAdsError{StatusCode: 401, Status: "INVALID_ARGUMENT", Body: "Expired.",
+				Code: "AUTHENTICATION_ERROR.OAUTH_TOKEN_EXPIRED", RequestID: "abc123"},
+		},
+		{
+			name:      "status without details",
+			status:    403,
+			body:      `{"error":{"code":403,"message":"The caller does not have permission","status":"PERMISSION_DENIED"}}`,
+			want:      GoogleAdsError{StatusCode: 403, Status: "PERMISSION_DENIED", Body: "The caller does not have permission"},
+			wantError: "The caller does not have permission",
+		},
+		{
+			name:   "not JSON",
+			status: 502,
+			body:   "<html>Bad Gateway</html>",
+			want:   GoogleAdsError{StatusCode: 502, Body: "<html>Bad Gateway</html>"},
+		},
+		{
+			name:   "JSON without an error",
+			status: 500,
+			body:   `{}`,
+			want:   GoogleAdsError{StatusCode: 500, Body: "{}"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseError(tt.status, []byte(tt.body))
+			if *got != tt.want {
+				t.Errorf("got  %+v\nwant %+v", *got, tt.want)
+			}
+			if tt.wantError != "" && got.Error() != tt.wantError {
+				t.Errorf("Error() = %q, want %q", got.Error(), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	const failure = `{"error":{"code":400,"message":"Invalid.","status":"INVALID_ARGUMENT","details":[` +
+		`{"@type":"type.googleapis.com/google.ads.googleads.v23.errors.GoogleAdsFailure",` +
+		`"errors":[{"errorCode":{"queryError":"BAD_FIELD_NAME"},"message":"Bad field."}],"requestId":"from-body"}]}}`
+	tests := []struct {
+		name   string
+		header string
+		body   string
+		stream bool
+		want   string
+	}{
+		{name: "header", header: "from-header", body: `{"error":{"code":403,"message":"Denied.","status":"PERMISSION_DENIED"}}`, want: "from-header"},
+		{name: "header wins over body", header: "from-header", body: failure, want: "from-header"},
+		{name: "body only", body: failure, want: "from-body"},
+		{name: "none", body: `{"error":{"code":403,"message":"Denied.","status":"PERMISSION_DENIED"}}`},
+		{name: "stream error", stream: true, header: "from-header", body: `[{"results":[]},{"error":{"code":400,"message":"Too many rows."}}]`, want: "from-header"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.header != "" {
+					w.Header().Set("request-id", tt.header)
+				}
+				if !tt.stream {
+					w.WriteHeader(http.StatusBadRequest)
+				}
+				io.WriteString(w, tt.body)
+			}))
+			var err error
+			if tt.stream {
+				err = c.SearchStream("1234567890", "SELECT campaign.id FROM campaign", func(json.RawMessage) error { return nil })
+			} else {
+				_, err = c.Search("1234567890", "SELECT campaign.id FROM campaign")
+			}
+			var gErr *GoogleAdsError
+			if !errors.As(err, &gErr) {
+				t.Fatalf("error %v, want a *GoogleAdsError", err)
+			}
+			if gErr.RequestID != tt.want {
+				t.Errorf("RequestID = %q, want %q", gErr.RequestID, tt.want)
+			}
+			if got := strings.Contains(err.Error(), "(request-id: "); got != (tt.want != "") {
+				t.Errorf("error message %q shows a request-id: %v", err, got)
+			}
+		})
+	}
+}
+
+func TestRequestIDDebugLog(t *testing.T) {
+	c, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("request-id", "ok-123")
+		io.WriteString(w, `{"results":[]}`)
+	}))
+	var log strings.Builder
+	c.SetDebug(&log)
+	if _, err := c.Search("1234567890", "SELECT campaign.id FROM campaign"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(log.String(), "[debug] request-id: ok-123\n") {
+		t.Errorf("request-id not logged:\n%s", log.String())
+	}
+}
+
+func TestRequestHeaders(t *testing.T) {
+	tests := []struct {
+		name      string
+		loginID   string
+		wantLogin string
+	}{
+		{name: "with a manager account", loginID: "999-000-1111", wantLogin: "9990001111"},
+		{name: "without"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got http.Header
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Clone()
+				io.WriteString(w, `{"results":[]}`)
+			}))
+			defer srv.Close()
+			c := New(&http.Client{Transport: serverTransport{srv}}, "dev-token", tt.loginID)
+			if _, err := c.Search("1234567890", "SELECT campaign.id FROM campaign"); err != nil {
+				t.Fatal(err)
+			}
+			if ua := got.Get("User-Agent"); ua != version.UserAgent() || !strings.HasPrefix(ua, "gads-cli/") {
+				t.Errorf("User-Agent %q, want %q", ua, version.UserAgent())
+			}
+			if v := got.Get("developer-token"); v != "dev-token" {
+				t.Errorf("developer-token %q", v)
+			}
+			if v := got.Get("login-customer-id"); v != tt.wantLogin {
+				t.Errorf("login-customer-id %q, want %q", v, tt.wantLogin)
+			}
+		})
+	}
+}
+
+func TestMutationLog(t *testing.T) {
+	tests := []struct {
+		name             string
+		status           int
+		validateOnly     bool
+		wantErr          bool
+		wantValidateOnly bool
+	}{
+		{name: "succeeded", status: http.StatusOK},
+		{name: "failed", status: http.StatusBadRequest, wantErr: true},
+		{name: "validate only", status: http.StatusOK, validateOnly: true, wantValidateOnly: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logged []Mutation
+			c, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				io.WriteString(w, `{"results":[{"resourceName":"customers/1/campaigns/2"}]}`)
+			}), WithMutationLog(func(m Mutation) { logged = append(logged, m) }))
+			c.SetValidateOnly(tt.validateOnly)
+
+			_, err := c.MutateCampaigns("1", []map[string]any{{"remove": "customers/1/campaigns/2"}})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error %v, want error %v", err, tt.wantErr)
+			}
+			if len(logged) != 1 {
+				t.Fatalf("logged %d mutations, want every request", len(logged))
+			}
+			m := logged[0]
+			if m.Endpoint != "customers/1/campaigns:mutate" || (m.Err != nil) != tt.wantErr || m.ValidateOnly != tt.wantValidateOnly {
+				t.Errorf("logged %+v", m)
+			}
+		})
+	}
+}
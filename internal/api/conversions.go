@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// MaxClickConversionsPerRequest is the most conversions one
+// uploadClickConversions request accepts.
+const MaxClickConversionsPerRequest = 2000
+
+// ClickConversion is one offline conversion attributed to an ad click.
+type ClickConversion struct {
+	Gclid              string  `json:"gclid"`
+	ConversionAction   string  `json:"conversionAction"`   // resource name
+	ConversionDateTime string  `json:"conversionDateTime"` // "yyyy-mm-dd hh:mm:ss+|-hh:mm"
+	ConversionValue    float64 `json:"conversionValue,omitempty"`
+	CurrencyCode       string  `json:"currencyCode,omitempty"`
+}
+
+// UploadClickConversionsResponse is returned by customers:uploadClickConversions.
+// Failed conversions leave an empty entry in Results.
+type UploadClickConversionsResponse struct {
+	Results []struct {
+		Gclid            string `json:"gclid"`
+		ConversionAction string `json:"conversionAction"`
+	} `json:"results"`
+	PartialFailureError *Status `json:"partialFailureError,omitempty"`
+}
+
+// Succeeded returns the number of conversions that were accepted.
+func (r *UploadClickConversionsResponse) Succeeded() int {
+	n := 0
+	for _, res := range r.Results {
+		if res.Gclid != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// ConversionErrors decodes the partial failure into per-conversion errors,
+// indexed by position in the request. It returns nil when all succeeded.
+func (r *UploadClickConversionsResponse) ConversionErrors() []OperationError {
+	return partialFailureErrors(r.PartialFailureError, "conversions")
+}
+
+// UploadClickConversions uploads up to MaxClickConversionsPerRequest offline
+// click conversions. The API requires partial failure, so it is always on.
+func (c *Client) UploadClickConversions(customerID string, conversions []ClickConversion) (*UploadClickConversionsResponse, error) {
+	return c.UploadClickConversionsContext(c.ctx, customerID, conversions)
+}
+
+// UploadClickConversionsContext is like UploadClickConversions but bound to ctx.
+func (c *Client) UploadClickConversionsContext(ctx context.Context, customerID string, conversions []ClickConversion) (*UploadClickConversionsResponse, error) {
+	if len(conversions) > MaxClickConversionsPerRequest {
+		return nil, fmt.Errorf("too many conversions in one request: %d (max %d)", len(conversions), MaxClickConversionsPerRequest)
+	}
+	url := fmt.Sprintf("%s/customers/%s:uploadClickConversions", c.base, customerID)
+	payload := c.mutatePayload(map[string]any{
+		"conversions":    conversions,
+		"partialFailure": true,
+	})
+	body, err := c.postMutate(ctx, customerID, url, payload)
+	if err != nil {
+		return nil, err
+	}
+	var resp UploadClickConversionsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parsing upload response: %w", err)
+	}
+	return &resp, nil
+}
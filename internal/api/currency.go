@@ -0,0 +1,162 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// currencyFormat describes how amounts in a currency are written.
+type currencyFormat struct {
+	symbol   string
+	decimals int
+	// suffix puts the symbol after the amount, with European separators:
+	// "1.234,50 €".
+	suffix bool
+}
+
+// currencyFormats covers the currencies Google Ads accounts commonly bill
+// in. Other codes are written as "1,234.50 XYZ".
+var currencyFormats = map[string]currencyFormat{
+	"USD": {symbol: "$", decimals: 2},
+	"EUR": {symbol: "€", decimals: 2, suffix: true},
+	"GBP": {symbol: "£", decimals: 2},
+	"JPY": {symbol: "¥", decimals: 0},
+	"CNY": {symbol: "CN¥", decimals: 2},
+	"KRW": {symbol: "₩", decimals: 0},
+	"INR": {symbol: "₹", decimals: 2},
+	"AUD": {symbol: "A$", decimals: 2},
+	"CAD": {symbol: "CA$", decimals: 2},
+	"NZD": {symbol: "NZ$", decimals: 2},
+	"HKD": {symbol: "HK$", decimals: 2},
+	"SGD": {symbol: "S$", decimals: 2},
+	"MXN": {symbol: "MX$", decimals: 2},
+	"BRL": {symbol: "R$", decimals: 2},
+	"CHF": {symbol: "CHF ", decimals: 2},
+	"SEK": {symbol: "kr", decimals: 2, suffix: true},
+	"NOK": {symbol: "kr", decimals: 2, suffix: true},
+	"DKK": {symbol: "kr.", decimals: 2, suffix: true},
+	"PLN": {symbol: "zł", decimals: 2, suffix: true},
+	"CZK": {symbol: "Kč", decimals: 2, suffix: true},
+	"TRY": {symbol: "₺", decimals: 2},
+	"ILS": {symbol: "₪", decimals: 2},
+	"ZAR": {symbol: "R", decimals: 2},
+	"VND": {symbol: "₫", decimals: 0, suffix: true},
+	"CLP": {symbol: "CLP$", decimals: 0},
+	"HUF": {symbol: "Ft", decimals: 0, suffix: true},
+	"TWD": {symbol: "NT$", decimals: 0},
+	"IDR": {symbol: "Rp", decimals: 0},
+}
+
+// FormatMoney writes micros as an amount in currency for people: "$5.00",
+// "¥500", "5,00 €". Unknown codes are appended ("5.00 XYZ"). In script
+// mode (digit grouping off, see SetDigitGrouping) or without a currency it
+// returns the plain MicrosToCurrency form, "5.00".
+func FormatMoney(micros float64, currency string) string {
+	if !groupDigits || currency == "" {
+		return fmt.Sprintf("%.2f", micros/1_000_000)
+	}
+	f, known := currencyFormats[currency]
+	if !known {
+		f = currencyFormat{symbol: currency, decimals: 2, suffix: true}
+	}
+	amount := micros / 1_000_000
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	s := strconv.FormatFloat(amount, 'f', f.decimals, 64)
+	if strings.Trim(s, "0.") == "" {
+		sign = ""
+	}
+	digits, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		digits, frac = s[:i], s[i+1:]
+	}
+	thousands, point := ",", "."
+	if f.suffix && known {
+		thousands, point = ".", ","
+	}
+	s = insertSeparators(digits, thousands)
+	if frac != "" {
+		s += point + frac
+	}
+	if f.suffix {
+		return sign + s + " " + f.symbol
+	}
+	return sign + f.symbol + s
+}
+
+// maxCurrencyUnits is the largest whole amount whose micros fit an int64.
+const maxCurrencyUnits = math.MaxInt64 / 1_000_000
+
+// ParseCurrencyToMicros reads an amount in the account's currency, as typed
+// on the command line, and returns it in micros: "5" → 5000000, "5.5" and
+// "5.50" → 5500000, "5,000.25" → 5000250000. Commas may only separate
+// thousands; a decimal comma ("5,50") is rejected rather than misread. The
+// amount is converted as a decimal, not through a float, so at most 6
+// decimal places (one micro) are accepted and no rounding ever happens.
+// Negative amounts are rejected; zero is left to the caller.
+func ParseCurrencyToMicros(s string) (int64, error) {
+	v := strings.TrimSpace(s)
+	if v == "" {
+		return 0, fmt.Errorf("an amount is required, e.g. 5.50")
+	}
+	if strings.HasPrefix(v, "-") {
+		return 0, fmt.Errorf("amount must not be negative, got %q", s)
+	}
+	whole, frac, hasPoint := strings.Cut(v, ".")
+	if strings.Contains(frac, ",") || (!hasPoint && decimalComma(whole)) {
+		return 0, fmt.Errorf("invalid amount %q: use a point for decimals (5.50), commas only between thousands (5,000.50)", s)
+	}
+	if strings.Contains(whole, ",") {
+		groups := strings.Split(whole, ",")
+		for i, g := range groups {
+			if len(g) > 3 || len(g) == 0 || (i > 0 && len(g) != 3) {
+				return 0, fmt.Errorf("invalid amount %q: commas must separate groups of three digits", s)
+			}
+		}
+		whole = strings.Join(groups, "")
+	}
+	if (whole == "" && frac == "") || !isDigits(whole) || !isDigits(frac) {
+		return 0, fmt.Errorf("invalid amount %q, e.g. 5 or 5.50", s)
+	}
+	if len(frac) > 6 {
+		return 0, fmt.Errorf("invalid amount %q: at most 6 decimal places (one micro)", s)
+	}
+
+	var units int64
+	if whole != "" {
+		n, err := strconv.ParseInt(whole, 10, 64)
+		if err != nil || n > maxCurrencyUnits {
+			return 0, fmt.Errorf("amount %q is too large", s)
+		}
+		units = n
+	}
+	var micros int64
+	if frac != "" {
+		micros, _ = strconv.ParseInt(frac+strings.Repeat("0", 6-len(frac)), 10, 64)
+	}
+	if units == maxCurrencyUnits && micros > math.MaxInt64%1_000_000 {
+		return 0, fmt.Errorf("amount %q is too large", s)
+	}
+	return units*1_000_000 + micros, nil
+}
+
+// decimalComma reports whether the integer part of an amount without a
+// point ends in a comma group that cannot be thousands, as in "5,5" or
+// "5,50".
+func decimalComma(whole string) bool {
+	i := strings.LastIndexByte(whole, ',')
+	return i >= 0 && len(whole)-i-1 != 3
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
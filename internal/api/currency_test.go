@@ -0,0 +1,158 @@
+package api
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestParseCurrencyToMicros(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr string
+	}{
+		// Plain amounts.
+		{in: "5", want: 5_000_000},
+		{in: "5.5", want: 5_500_000},
+		{in: "5.50", want: 5_500_000},
+		{in: " 7 ", want: 7_000_000},
+		{in: "0", want: 0},
+		{in: ".5", want: 500_000},
+		{in: "5.", want: 5_000_000},
+		{in: "0.000001", want: 1},
+		{in: "1.999999", want: 1_999_999},
+
+		// Decimal, not float, conversion: no value is off by a micro.
+		{in: "0.1", want: 100_000},
+		{in: "0.29", want: 290_000},
+		{in: "4.35", want: 4_350_000},
+		{in: "1.005", want: 1_005_000},
+		{in: "19.99", want: 19_990_000},
+		{in: "1234567.891011", want: 1_234_567_891_011},
+
+		// Thousands separators.
+		{in: "5,000", want: 5_000_000_000},
+		{in: "5,000.25", want: 5_000_250_000},
+		{in: "1,234,567", want: 1_234_567_000_000},
+		{in: "12,34.5", wantErr: "groups of three digits"},
+		{in: "1,2345", wantErr: "commas only between thousands"},
+		{in: ",500", wantErr: "groups of three digits"},
+		{in: "5,,000", wantErr: "groups of three digits"},
+
+		// Decimal commas are rejected, not read as thousands.
+		{in: "5,50", wantErr: "use a point for decimals"},
+		{in: "5,5", wantErr: "use a point for decimals"},
+		{in: "1,234,56", wantErr: "use a point for decimals"},
+		{in: "5.000,50", wantErr: "use a point for decimals"},
+
+		// More than 6 decimals is finer than a micro.
+		{in: "1.2345678", wantErr: "at most 6 decimal places"},
+		{in: "0.0000001", wantErr: "at most 6 decimal places"},
+		{in: "5.0000000", wantErr: "at most 6 decimal places"},
+
+		// Limits.
+		{in: "9223372036854.775807", want: math.MaxInt64},
+		{in: "9223372036854.775808", wantErr: "too large"},
+		{in: "9223372036855", wantErr: "too large"},
+		{in: "99999999999999999999", wantErr: "too large"},
+
+		// Not amounts.
+		{in: "", wantErr: "an amount is required"},
+		{in: "  ", wantErr: "an amount is required"},
+		{in: "-5", wantErr: "must not be negative"},
+		{in: "-0.01", wantErr: "must not be negative"},
+		{in: ".", wantErr: "invalid amount"},
+		{in: "abc", wantErr: "invalid amount"},
+		{in: "5e3", wantErr: "invalid amount"},
+		{in: "$5", wantErr: "invalid amount"},
+		{in: "5 000", wantErr: "invalid amount"},
+		{in: "+5", wantErr: "invalid amount"},
+		{in: "5.5.5", wantErr: "invalid amount"},
+	}
+	for _, tt := range tests {
+		got, err := ParseCurrencyToMicros(tt.in)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseCurrencyToMicros(%q) = %d, %v; want an error containing %q", tt.in, got, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseCurrencyToMicros(%q) = %d, %v; want %d", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+// useDigitGrouping sets human number formatting for one test.
+func useDigitGrouping(t *testing.T, enabled bool) {
+	t.Helper()
+	saved := groupDigits
+	t.Cleanup(func() { groupDigits = saved })
+	groupDigits = enabled
+}
+
+func TestFormatMoney(t *testing.T) {
+	useDigitGrouping(t, true)
+	tests := []struct {
+		micros   float64
+		currency string
+		want     string
+	}{
+		{5_000_000, "USD", "$5.00"},
+		{1_234_567_890_000, "USD", "$1,234,567.89"},
+		{0, "USD", "$0.00"},
+		{5_500_000, "GBP", "£5.50"},
+
+		// Zero-decimal currencies.
+		{500_000_000, "JPY", "¥500"},
+		{1_234_567_000_000, "JPY", "¥1,234,567"},
+		{1_400_000, "JPY", "¥1"},
+		{12_000_000_000, "KRW", "₩12,000"},
+
+		// Suffix currencies use European separators.
+		{5_000_000, "EUR", "5,00 €"},
+		{1_234_500_000, "EUR", "1.234,50 €"},
+		{1_234_567_890_000, "EUR", "1.234.567,89 €"},
+		{99_990_000, "SEK", "99,99 kr"},
+		{2_000_000_000, "HUF", "2.000 Ft"},
+
+		// Unknown codes keep a point for decimals.
+		{1_234_500_000, "XYZ", "1,234.50 XYZ"},
+		{5_000_000, "ABC", "5.00 ABC"},
+
+		// Negatives, and amounts that round to zero lose their sign.
+		{-5_000_000, "USD", "-$5.00"},
+		{-1_234_500_000, "EUR", "-1.234,50 €"},
+		{-700_000_000, "JPY", "-¥700"},
+		{-1_000, "USD", "$0.00"},
+		{-400_000, "JPY", "¥0"},
+		{-2_500_000, "XYZ", "-2.50 XYZ"},
+
+		// Without a currency, the plain form.
+		{5_000_000, "", "5.00"},
+	}
+	for _, tt := range tests {
+		if got := FormatMoney(tt.micros, tt.currency); got != tt.want {
+			t.Errorf("FormatMoney(%v, %q) = %q, want %q", tt.micros, tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestFormatMoneyScriptMode(t *testing.T) {
+	useDigitGrouping(t, false)
+	for _, tt := range []struct {
+		micros   float64
+		currency string
+		want     string
+	}{
+		{5_000_000, "USD", "5.00"},
+		{1_234_567_890_000, "EUR", "1234567.89"},
+		{500_000_000, "JPY", "500.00"},
+		{-5_000_000, "XYZ", "-5.00"},
+	} {
+		if got := FormatMoney(tt.micros, tt.currency); got != tt.want {
+			t.Errorf("FormatMoney(%v, %q) = %q, want %q", tt.micros, tt.currency, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// CustomerClientLinkRow is a GAQL result row for customer_client_link
+// queries.
+type CustomerClientLinkRow struct {
+	CustomerClientLink CustomerClientLink `json:"customerClientLink"`
+}
+
+// CustomerClientLink is the link between a manager account and a client
+// account. The manager creates it as an invitation (PENDING), which the
+// client accepts (ACTIVE) or refuses (REFUSED), or the manager cancels
+// (CANCELED); INACTIVE links were ended.
+type CustomerClientLink struct {
+	ResourceName   string `json:"resourceName"`
+	ClientCustomer string `json:"clientCustomer"` // resource name
+	ManagerLinkID  string `json:"managerLinkId"`
+	Status         string `json:"status"`
+	Hidden         bool   `json:"hidden,omitempty"`
+}
+
+// CustomerClientLinkResourceName returns the resource name of the link
+// between a manager account and a client account.
+// e.g. ("123", "456", "789") → "customers/123/customerClientLinks/456~789"
+func CustomerClientLinkResourceName(managerID, clientID, managerLinkID string) string {
+	return fmt.Sprintf("customers/%s/customerClientLinks/%s~%s", managerID, clientID, managerLinkID)
+}
+
+// MutateCustomerClientLink sends one customer client link operation from
+// managerID, e.g. {"create": {"clientCustomer": ..., "status": "PENDING"}}
+// to invite a client account. The endpoint takes a single operation and
+// returns the link's resource name, empty in validate-only mode.
+func (c *Client) MutateCustomerClientLink(managerID string, operation map[string]any) (string, error) {
+	managerID = CleanCustomerID(managerID)
+	url := fmt.Sprintf("%s/customers/%s/customerClientLinks:mutate", c.base, managerID)
+	body, err := c.postMutate(c.ctx, managerID, url, c.mutatePayload(map[string]any{"operation": operation}))
+	if err != nil {
+		return "", err
+	}
+	var resp struct {
+		Result struct {
+			ResourceName string `json:"resourceName"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", fmt.Errorf("parsing response: %w", err)
+	}
+	return resp.Result.ResourceName, nil
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultDebugBodyLimit is the number of body bytes logged per request or
+// response when no limit is configured.
+const DefaultDebugBodyLimit = 4096
+
+// redactedHeaders are never written to the debug log.
+var redactedHeaders = map[string]bool{
+	"Authorization":   true,
+	"Developer-Token": true,
+}
+
+var (
+	// jsonSecretRe matches secret fields in JSON bodies (OAuth token responses).
+	jsonSecretRe = regexp.MustCompile(`("(?:access_token|refresh_token|id_token|client_secret)"\s*:\s*)"[^"]*"`)
+	// formSecretRe matches secret fields in form-encoded bodies (OAuth token requests).
+	formSecretRe = regexp.MustCompile(`((?:^|&)(?:client_secret|refresh_token|code|code_verifier|assertion)=)[^&]*`)
+)
+
+// DebugTransport is an http.RoundTripper that logs each request and response
+// (method, URL, headers, bodies, status, timing) with credentials redacted.
+type DebugTransport struct {
+	Base    http.RoundTripper
+	Out     io.Writer
+	MaxBody int // bodies longer than this are truncated; 0 means no limit
+
+	mu sync.Mutex
+}
+
+// NewDebugTransport wraps base (http.DefaultTransport when nil) and logs to out.
+func NewDebugTransport(base http.RoundTripper, out io.Writer, maxBody int) *DebugTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &DebugTransport{Base: base, Out: out, MaxBody: maxBody}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *DebugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		b, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		reqBody = b
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(b))
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "[debug] → %s %s\n", req.Method, req.URL)
+	writeHeaders(&buf, req.Header)
+	if len(reqBody) > 0 {
+		fmt.Fprintf(&buf, "[debug]   %s\n", t.formatBody(reqBody, req.Header.Get("Content-Type")))
+	}
+
+	start := time.Now()
+	resp, err := t.Base.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		fmt.Fprintf(&buf, "[debug] ← error after %s: %v\n", elapsed, err)
+		t.flush(&buf)
+		return nil, err
+	}
+
+	respBody, readErr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	fmt.Fprintf(&buf, "[debug] ← %s (%s)\n", resp.Status, elapsed)
+	if len(respBody) > 0 {
+		fmt.Fprintf(&buf, "[debug]   %s\n", t.formatBody(respBody, resp.Header.Get("Content-Type")))
+	}
+	t.flush(&buf)
+	if readErr != nil {
+		return nil, readErr
+	}
+	return resp, nil
+}
+
+// flush writes one complete exchange at a time so concurrent requests
+// (e.g. accounts list --with-spend) don't interleave.
+func (t *DebugTransport) flush(buf *bytes.Buffer) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.Out.Write(buf.Bytes()) //nolint
+}
+
+func (t *DebugTransport) formatBody(body []byte, contentType string) string {
+	s := redactBody(string(body), contentType)
+	if t.MaxBody > 0 && len(s) > t.MaxBody {
+		return fmt.Sprintf("%s... (%d bytes truncated)", s[:t.MaxBody], len(s)-t.MaxBody)
+	}
+	return s
+}
+
+func writeHeaders(w io.Writer, h http.Header) {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := strings.Join(h[k], ", ")
+		if redactedHeaders[http.CanonicalHeaderKey(k)] {
+			v = "REDACTED"
+		}
+		fmt.Fprintf(w, "[debug]   %s: %s\n", k, v)
+	}
+}
+
+// redactBody masks OAuth secrets in JSON and form-encoded bodies.
+func redactBody(body, contentType string) string {
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		return formSecretRe.ReplaceAllString(body, "${1}REDACTED")
+	}
+	return jsonSecretRe.ReplaceAllString(body, `${1}"REDACTED"`)
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ExperimentRow is a GAQL result row for experiment queries.
+type ExperimentRow struct {
+	Experiment Experiment `json:"experiment"`
+}
+
+// Experiment is a campaign experiment that splits traffic between a control
+// arm (the base campaign) and a treatment arm (a copy with changes applied).
+type Experiment struct {
+	ResourceName string `json:"resourceName"`
+	ExperimentID string `json:"experimentId"`
+	Name         string `json:"name"`
+	Description  string `json:"description,omitempty"`
+	Suffix       string `json:"suffix,omitempty"`
+	Type         string `json:"type"`
+	Status       string `json:"status"`
+	StartDate    string `json:"startDate,omitempty"`
+	EndDate      string `json:"endDate,omitempty"`
+}
+
+// ExperimentArmRow is a GAQL result row for experiment_arm queries.
+type ExperimentArmRow struct {
+	ExperimentArm ExperimentArm `json:"experimentArm"`
+}
+
+// ExperimentArm is one side of an experiment and the campaigns that serve it.
+// InDesignCampaigns holds the draft treatment campaigns before the experiment
+// is scheduled; Campaigns holds the serving campaigns afterwards.
+type ExperimentArm struct {
+	ResourceName      string   `json:"resourceName"`
+	Experiment        string   `json:"experiment"` // resource name
+	Name              string   `json:"name"`
+	Control           bool     `json:"control"`
+	TrafficSplit      Int64    `json:"trafficSplit"`
+	Campaigns         []string `json:"campaigns,omitempty"`
+	InDesignCampaigns []string `json:"inDesignCampaigns,omitempty"`
+}
+
+// LongRunningOperation is a google.longrunning.Operation returned by actions
+// that complete asynchronously.
+type LongRunningOperation struct {
+	Name  string  `json:"name"`
+	Done  bool    `json:"done"`
+	Error *Status `json:"error,omitempty"`
+}
+
+// MutateExperiments sends experiment mutation operations.
+func (c *Client) MutateExperiments(customerID string, operations []map[string]any) (*MutateResponse, error) {
+	url := fmt.Sprintf("%s/customers/%s/experiments:mutate", c.base, customerID)
+	return c.mutate(c.ctx, customerID, url, operations)
+}
+
+// MutateExperimentArms sends experiment arm mutation operations.
+func (c *Client) MutateExperimentArms(customerID string, operations []map[string]any) (*MutateResponse, error) {
+	url := fmt.Sprintf("%s/customers/%s/experimentArms:mutate", c.base, customerID)
+	return c.mutate(c.ctx, customerID, url, operations)
+}
+
+// ScheduleExperiment starts creating the treatment campaigns of an experiment
+// in SETUP status. The experiment serves once the operation completes.
+func (c *Client) ScheduleExperiment(customerID, experiment string) (*LongRunningOperation, error) {
+	return c.experimentOperation(customerID, experiment, "scheduleExperiment")
+}
+
+// PromoteExperiment starts copying the treatment arm's changes to the base
+// campaign and ends the experiment.
+func (c *Client) PromoteExperiment(customerID, experiment string) (*LongRunningOperation, error) {
+	return c.experimentOperation(customerID, experiment, "promoteExperiment")
+}
+
+// EndExperiment stops a running experiment without applying its changes.
+func (c *Client) EndExperiment(customerID, experiment string) error {
+	url := fmt.Sprintf("%s/%s:endExperiment", c.base, experiment)
+	_, err := c.postMutate(c.ctx, customerID, url, c.mutatePayload(map[string]any{}))
+	return err
+}
+
+// experimentOperation posts an experiment action that returns a long-running
+// operation. In validate-only mode the API returns an empty operation.
+func (c *Client) experimentOperation(customerID, experiment, action string) (*LongRunningOperation, error) {
+	url := fmt.Sprintf("%s/%s:%s", c.base, experiment, action)
+	body, err := c.postMutate(c.ctx, customerID, url, c.mutatePayload(map[string]any{}))
+	if err != nil {
+		return nil, err
+	}
+	var op LongRunningOperation
+	if err := json.Unmarshal(body, &op); err != nil {
+		return nil, fmt.Errorf("parsing %s response: %w", action, err)
+	}
+	if op.Error != nil {
+		return nil, fmt.Errorf("%s failed: %s", action, op.Error.Message)
+	}
+	return &op, nil
+}
+
+// ExperimentResourceName returns the resource name of an experiment.
+func ExperimentResourceName(customerID, experimentID string) string {
+	return fmt.Sprintf("customers/%s/experiments/%s", customerID, experimentID)
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// googleAdsFailure is the GoogleAdsFailure message carried in the details of
+// an error status.
+type googleAdsFailure struct {
+	Errors []struct {
+		ErrorCode map[string]any `json:"errorCode"`
+		Message   string         `json:"message"`
+		Location  struct {
+			FieldPathElements []struct {
+				FieldName string `json:"fieldName"`
+				Index     *int   `json:"index"`
+			} `json:"fieldPathElements"`
+		} `json:"location"`
+	} `json:"errors"`
+	RequestID string `json:"requestId"`
+}
+
+// errorCodeName renders a GoogleAdsFailure errorCode object such as
+// {"quotaError": "RESOURCE_EXHAUSTED"} as "QUOTA_ERROR.RESOURCE_EXHAUSTED".
+func errorCodeName(code map[string]any) string {
+	for k, v := range code {
+		var b strings.Builder
+		for i, r := range k {
+			if unicode.IsUpper(r) && i > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToUpper(r))
+		}
+		return fmt.Sprintf("%s.%v", b.String(), v)
+	}
+	return ""
+}
+
+// Succeeded returns the number of operations that were applied.
+func (r *MutateResponse) Succeeded() int {
+	n := 0
+	for _, res := range r.Results {
+		if res.ResourceName != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// OperationErrors decodes the GoogleAdsFailure details of a partial failure
+// into per-operation errors. It returns nil when every operation succeeded.
+func (r *MutateResponse) OperationErrors() []OperationError {
+	return partialFailureErrors(r.PartialFailureError, "operations")
+}
+
+// partialFailureErrors decodes a partialFailureError status. Indexes come from
+// the field path element named field ("operations" for mutates).
+func partialFailureErrors(status *Status, field string) []OperationError {
+	if status == nil {
+		return nil
+	}
+	var errs []OperationError
+	for _, raw := range status.Details {
+		var detail struct {
+			Type string `json:"@type"`
+			googleAdsFailure
+		}
+		if json.Unmarshal(raw, &detail) != nil || !strings.HasSuffix(detail.Type, ".GoogleAdsFailure") {
+			continue
+		}
+		for _, e := range detail.Errors {
+			opErr := OperationError{Index: -1, Message: e.Message}
+			for _, code := range e.ErrorCode {
+				opErr.Code = fmt.Sprint(code)
+			}
+			for _, el := range e.Location.FieldPathElements {
+				if el.FieldName == field && el.Index != nil {
+					opErr.Index = *el.Index
+					break
+				}
+			}
+			errs = append(errs, opErr)
+		}
+	}
+	if len(errs) == 0 {
+		errs = append(errs, OperationError{Index: -1, Message: status.Message})
+	}
+	return errs
+}
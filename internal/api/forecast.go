@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// KeywordForecast describes a hypothetical manual-CPC Search campaign with
+// one ad group of keywords, for GenerateKeywordForecastMetrics.
+type KeywordForecast struct {
+	CurrencyCode string
+	// StartDate and EndDate (YYYY-MM-DD) bound the forecast; they must be
+	// in the future.
+	StartDate string
+	EndDate   string
+	Keywords  []ForecastKeyword
+	// GeoTargets and Languages are resource names
+	// ("geoTargetConstants/2840", "languageConstants/1000"); empty means
+	// every location or language.
+	GeoTargets []string
+	Languages  []string
+}
+
+// ForecastKeyword is a keyword of a KeywordForecast with its max CPC bid.
+type ForecastKeyword struct {
+	Text            string `json:"text"`
+	MatchType       string `json:"matchType"`
+	MaxCpcBidMicros int64  `json:"maxCpcBidMicros"`
+}
+
+// ForecastMetrics are the projected metrics of a KeywordForecast over its
+// period.
+type ForecastMetrics struct {
+	Impressions      float64 `json:"impressions"`
+	Clicks           float64 `json:"clicks"`
+	CostMicros       Int64   `json:"costMicros"`
+	ClickThroughRate float64 `json:"clickThroughRate"`
+	AverageCpcMicros Int64   `json:"averageCpcMicros"`
+	Conversions      float64 `json:"conversions"`
+}
+
+// GenerateKeywordForecastMetrics projects the performance of f's keywords
+// in customerID. Nothing is created in the account.
+func (c *Client) GenerateKeywordForecastMetrics(ctx context.Context, customerID string, f KeywordForecast) (*ForecastMetrics, error) {
+	keywords := make([]map[string]any, len(f.Keywords))
+	var maxBid int64
+	for i, k := range f.Keywords {
+		keywords[i] = map[string]any{
+			"keyword":         map[string]any{"text": k.Text, "matchType": k.MatchType},
+			"maxCpcBidMicros": strconv.FormatInt(k.MaxCpcBidMicros, 10),
+		}
+		maxBid = max(maxBid, k.MaxCpcBidMicros)
+	}
+	campaign := map[string]any{
+		"keywordPlanNetwork": "GOOGLE_SEARCH",
+		// Keyword bids override the campaign's; it only needs one.
+		"biddingStrategy": map[string]any{
+			"manualCpcBiddingStrategy": map[string]any{"maxCpcBidMicros": strconv.FormatInt(maxBid, 10)},
+		},
+		"adGroups": []map[string]any{{"biddableKeywords": keywords}},
+	}
+	if len(f.Languages) > 0 {
+		campaign["languageConstants"] = f.Languages
+	}
+	if len(f.GeoTargets) > 0 {
+		geos := make([]map[string]any, len(f.GeoTargets))
+		for i, g := range f.GeoTargets {
+			geos[i] = map[string]any{"geoTargetConstant": g}
+		}
+		campaign["geoModifiers"] = geos
+	}
+	payload := map[string]any{
+		"forecastPeriod": map[string]any{"startDate": f.StartDate, "endDate": f.EndDate},
+		"campaign":       campaign,
+	}
+	if f.CurrencyCode != "" {
+		payload["currencyCode"] = f.CurrencyCode
+	}
+
+	url := fmt.Sprintf("%s/customers/%s:generateKeywordForecastMetrics", c.base, customerID)
+	body, err := c.post(ctx, url, payload)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		CampaignForecastMetrics ForecastMetrics `json:"campaignForecastMetrics"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parsing forecast: %w", err)
+	}
+	return &resp.CampaignForecastMetrics, nil
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// HistoricalMetricsBatchSize is the most keywords one
+// generateKeywordHistoricalMetrics request accepts.
+const HistoricalMetricsBatchSize = 10_000
+
+// KeywordHistoricalMetrics is Google's search volume and competition for a
+// keyword on Google Search, over the last twelve months.
+type KeywordHistoricalMetrics struct {
+	AvgMonthlySearches Int64 `json:"avgMonthlySearches"`
+	// Competition is LOW, MEDIUM or HIGH, and CompetitionIndex the same
+	// from 0 to 100.
+	Competition      string `json:"competition,omitempty"`
+	CompetitionIndex Int64  `json:"competitionIndex,omitempty"`
+}
+
+// NormalizeKeyword returns the form keyword texts are matched on: lower
+// case, with single spaces. e.g. "  Running  Shoes" → "running shoes"
+func NormalizeKeyword(text string) string {
+	return strings.Join(strings.Fields(strings.ToLower(text)), " ")
+}
+
+// GenerateKeywordHistoricalMetrics returns the search volume of keywords in
+// every location and language, keyed by NormalizeKeyword of the keyword
+// text and of its close variants, which Google reports together. Keywords
+// are deduplicated and sent HistoricalMetricsBatchSize at a time. Keywords
+// Google has no data for are left out.
+func (c *Client) GenerateKeywordHistoricalMetrics(ctx context.Context, customerID string, keywords []string) (map[string]KeywordHistoricalMetrics, error) {
+	var texts []string
+	seen := map[string]bool{}
+	for _, k := range keywords {
+		if n := NormalizeKeyword(k); n != "" && !seen[n] {
+			seen[n] = true
+			texts = append(texts, n)
+		}
+	}
+
+	url := fmt.Sprintf("%s/customers/%s:generateKeywordHistoricalMetrics", c.base, customerID)
+	metrics := map[string]KeywordHistoricalMetrics{}
+	for start := 0; start < len(texts); start += HistoricalMetricsBatchSize {
+		batch := texts[start:min(start+HistoricalMetricsBatchSize, len(texts))]
+		body, err := c.post(ctx, url, map[string]any{
+			"keywords":           batch,
+			"keywordPlanNetwork": "GOOGLE_SEARCH",
+		})
+		if err != nil {
+			return nil, err
+		}
+		var resp struct {
+			Results []struct {
+				Text           string                    `json:"text"`
+				CloseVariants  []string                  `json:"closeVariants"`
+				KeywordMetrics *KeywordHistoricalMetrics `json:"keywordMetrics"`
+			} `json:"results"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, fmt.Errorf("parsing historical metrics: %w", err)
+		}
+		for _, r := range resp.Results {
+			if r.KeywordMetrics == nil {
+				continue
+			}
+			for _, text := range append([]string{r.Text}, r.CloseVariants...) {
+				metrics[NormalizeKeyword(text)] = *r.KeywordMetrics
+			}
+		}
+	}
+	return metrics, nil
+}
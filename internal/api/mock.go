@@ -0,0 +1,170 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// ErrNoMock is returned by ReplayTransport for a request that has no recorded
+// response. It is never retried.
+var ErrNoMock = errors.New("no mock response")
+
+// MockKey identifies a request in a mock directory: the SHA-256 of its
+// method, URL and body. Responses are stored as <key>.json.
+func MockKey(method, url string, body []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s\n", method, url)
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// mockResponse is a response file of a mock directory. The request is kept
+// so fixtures can be read and edited by hand; only the key is matched.
+type mockResponse struct {
+	Method  string          `json:"method"`
+	URL     string          `json:"url"`
+	Request json.RawMessage `json:"request,omitempty"`
+	Status  int             `json:"status"`
+	Body    json.RawMessage `json:"body,omitempty"`
+	// Text is the body of responses that are not JSON.
+	Text string `json:"text,omitempty"`
+}
+
+// ReplayTransport is an http.RoundTripper that answers requests with the
+// responses recorded in Dir by RecordTransport, without network access. A
+// request with no recorded response fails.
+type ReplayTransport struct {
+	Dir string
+}
+
+// NewReplayTransport returns a ReplayTransport reading from dir.
+func NewReplayTransport(dir string) *ReplayTransport {
+	return &ReplayTransport{Dir: dir}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	key := MockKey(req.Method, req.URL.String(), body)
+	data, err := os.ReadFile(filepath.Join(t.Dir, key+".json"))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w for %s %s in %s (key %s): record it with --record", ErrNoMock, req.Method, req.URL, t.Dir, key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var m mockResponse
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parsing mock response %s: %w", key, err)
+	}
+	respBody := []byte(m.Body)
+	if m.Text != "" {
+		respBody = []byte(m.Text)
+	}
+	status := m.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(respBody)),
+		ContentLength: int64(len(respBody)),
+		Request:       req,
+	}, nil
+}
+
+// RecordTransport is an http.RoundTripper that sends requests through Base
+// and saves each response to Dir for ReplayTransport. Headers are not saved,
+// and OAuth secrets in bodies are redacted.
+type RecordTransport struct {
+	Base http.RoundTripper
+	Dir  string
+}
+
+// NewRecordTransport wraps base (http.DefaultTransport when nil) and records
+// to dir, which is created if needed.
+func NewRecordTransport(base http.RoundTripper, dir string) *RecordTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &RecordTransport{Base: base, Dir: dir}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RecordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if reqBody != nil {
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+	resp, err := t.Base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	m := mockResponse{Method: req.Method, URL: req.URL.String(), Status: resp.StatusCode}
+	if json.Valid(reqBody) {
+		m.Request = redactJSON(reqBody)
+	}
+	if json.Valid(respBody) {
+		m.Body = redactJSON(respBody)
+	} else {
+		m.Text = string(respBody)
+	}
+	if err := t.save(MockKey(req.Method, req.URL.String(), reqBody), m); err != nil {
+		return nil, fmt.Errorf("recording response: %w", err)
+	}
+	return resp, nil
+}
+
+func (t *RecordTransport) save(key string, m mockResponse) error {
+	if err := os.MkdirAll(t.Dir, 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(t.Dir, key+".json"), append(data, '\n'), 0600)
+}
+
+// readRequestBody reads and closes the body of req. It returns nil for
+// requests without a body.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	b, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	return b, err
+}
+
+// redactJSON blanks OAuth secrets in a JSON body, as the debug log does.
+func redactJSON(b []byte) json.RawMessage {
+	return jsonSecretRe.ReplaceAll(b, []byte(`${1}"REDACTED"`))
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// The requests answered by testdata/mock, captured with RecordTransport.
+const fixtureQuery = "SELECT campaign.id, campaign.name, campaign.status FROM campaign ORDER BY campaign.id"
+
+func fixtureKeywords() []map[string]any {
+	var ops []map[string]any
+	for _, text := range []string{"running shoes", "running shoes", "shoes!!"} {
+		ops = append(ops, map[string]any{"create": map[string]any{
+			"adGroup": "customers/1234567890/adGroups/555",
+			"status":  "ENABLED",
+			"keyword": map[string]any{"text": text, "matchType": "EXACT"},
+		}})
+	}
+	return ops
+}
+
+// newFixtureClient returns a client replaying testdata/mock.
+func newFixtureClient() *Client {
+	c := New(&http.Client{Transport: NewReplayTransport("testdata/mock")}, "mock", "")
+	c.SetRetries(0)
+	return c
+}
+
+func TestReplaySearchFixture(t *testing.T) {
+	rows, err := newFixtureClient().Search("1234567890", fixtureQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, row := range rows {
+		var r struct {
+			Campaign struct {
+				ID string `json:"id"`
+			} `json:"campaign"`
+		}
+		if err := json.Unmarshal(row, &r); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, r.Campaign.ID)
+	}
+	// Two pages: the second is requested with the first one's token.
+	if want := []string{"111", "222", "333"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("got campaigns %v, want %v", ids, want)
+	}
+}
+
+func TestReplayPartialFailureFixture(t *testing.T) {
+	resp, err := newFixtureClient().MutateAdGroupCriteria("1234567890", fixtureKeywords())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resp.Succeeded(); got != 1 {
+		t.Errorf("Succeeded() = %d, want 1", got)
+	}
+	want := []OperationError{
+		{Index: 1, Code: "DUPLICATE_KEYWORD", Message: "Duplicate keyword."},
+		{Index: 2, Code: "KEYWORD_HAS_INVALID_CHARS", Message: "The keyword contains invalid characters."},
+	}
+	if got := resp.OperationErrors(); !reflect.DeepEqual(got, want) {
+		t.Errorf("OperationErrors() = %+v, want %+v", got, want)
+	}
+}
+
+func TestReplayAllOrNothingFixture(t *testing.T) {
+	c := newFixtureClient()
+	c.SetPartialFailure(false)
+	resp, err := c.MutateAdGroupCriteria("1234567890", fixtureKeywords())
+	if resp != nil {
+		t.Errorf("got a response with the error: %+v", resp)
+	}
+	var gErr *GoogleAdsError
+	if !errors.As(err, &gErr) {
+		t.Fatalf("error %v, want a *GoogleAdsError", err)
+	}
+	if gErr.StatusCode != http.StatusBadRequest || gErr.RequestID != "q8Zr5TnW0yHcK1vJd4Mx2A" {
+		t.Errorf("got status %d, request-id %q", gErr.StatusCode, gErr.RequestID)
+	}
+	if !strings.Contains(err.Error(), "DUPLICATE_KEYWORD") {
+		t.Errorf("error %v does not name the failure", err)
+	}
+}
+
+func TestReplayMissingFixture(t *testing.T) {
+	_, err := newFixtureClient().Search("1234567890", "SELECT customer.id FROM customer")
+	if !errors.Is(err, ErrNoMock) {
+		t.Fatalf("error %v, want %v", err, ErrNoMock)
+	}
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	retryBaseDelay = 1 * time.Second
+	retryMaxDelay  = 30 * time.Second
+)
+
+// shouldRetry reports whether a failed request may be sent again. Reads are
+// retried on any transient status or transport error; mutations only when the
+// request clearly never executed (429 or a failed connection attempt).
+func shouldRetry(err error, idempotent bool) bool {
+	var gErr *GoogleAdsError
+	if errors.As(err, &gErr) {
+		switch gErr.StatusCode {
+		case http.StatusTooManyRequests:
+			return true
+		case http.StatusInternalServerError, http.StatusBadGateway,
+			http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return idempotent
+		}
+		return false
+	}
+	if errors.Is(err, ErrNoMock) {
+		return false
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	return idempotent
+}
+
+// backoff returns the delay before retry number attempt+1: the Retry-After
+// header when present, otherwise exponential backoff with jitter.
+func backoff(attempt int, header http.Header) time.Duration {
+	if header != nil {
+		if ra := header.Get("Retry-After"); ra != "" {
+			if secs, err := strconv.Atoi(ra); err == nil && secs >= 0 {
+				return time.Duration(secs) * time.Second
+			}
+			if t, err := http.ParseTime(ra); err == nil {
+				if d := time.Until(t); d > 0 {
+					return d
+				}
+				return 0
+			}
+		}
+	}
+	d := retryBaseDelay << attempt
+	if d > retryMaxDelay || d <= 0 {
+		d = retryMaxDelay
+	}
+	// Full jitter in [d/2, d) spreads out concurrent retries.
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)))
+}
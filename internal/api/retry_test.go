@@ -0,0 +1,164 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// statusHandler answers the requests it receives with statuses in turn,
+// then 200, with a Retry-After of retryAfter when it is not empty. It
+// counts the requests.
+func statusHandler(retryAfter string, requests *int, statuses ...int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		if *requests > len(statuses) {
+			fmt.Fprint(w, `{"results":[{"resourceName":"customers/1234567890/campaigns/111"}]}`)
+			return
+		}
+		if retryAfter != "" {
+			w.Header().Set("Retry-After", retryAfter)
+		}
+		status := statuses[*requests-1]
+		w.WriteHeader(status)
+		fmt.Fprintf(w, `{"error":{"code":%d,"message":"%s","status":"ERROR"}}`, status, http.StatusText(status))
+	}
+}
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		mutate       bool
+		statuses     []int
+		wantRequests int
+		wantStatus   int // of the error, 0 for success
+	}{
+		{name: "search 503 then ok", statuses: []int{503, 503}, wantRequests: 3},
+		{name: "search 429 then ok", statuses: []int{429}, wantRequests: 2},
+		{name: "search 5xx exhausts retries", statuses: []int{500, 502, 504}, wantRequests: 3, wantStatus: 504},
+		{name: "search 400", statuses: []int{400}, wantRequests: 1, wantStatus: 400},
+		{name: "search 401", statuses: []int{401}, wantRequests: 1, wantStatus: 401},
+		{name: "search 403", statuses: []int{403}, wantRequests: 1, wantStatus: 403},
+		{name: "search 404", statuses: []int{404}, wantRequests: 1, wantStatus: 404},
+		{name: "mutate 429 then ok", mutate: true, statuses: []int{429}, wantRequests: 2},
+		{name: "mutate 503 may have applied", mutate: true, statuses: []int{503}, wantRequests: 1, wantStatus: 503},
+		{name: "mutate 400", mutate: true, statuses: []int{400}, wantRequests: 1, wantStatus: 400},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			c, _ := newTestClient(t, statusHandler("0", &requests, tt.statuses...))
+			c.SetRetries(2)
+
+			var err error
+			if tt.mutate {
+				_, err = c.MutateCampaigns("1234567890", []map[string]any{{"remove": "customers/1234567890/campaigns/111"}})
+			} else {
+				_, err = c.Search("1234567890", "SELECT campaign.id FROM campaign")
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("sent %d requests, want %d", requests, tt.wantRequests)
+			}
+			if tt.wantStatus == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			var gErr *GoogleAdsError
+			if !errors.As(err, &gErr) || gErr.StatusCode != tt.wantStatus {
+				t.Errorf("error %v, want status %d", err, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestRetryDisabled(t *testing.T) {
+	var requests int
+	c, _ := newTestClient(t, statusHandler("0", &requests, 503))
+	if _, err := c.Search("1234567890", "SELECT campaign.id FROM campaign"); err == nil {
+		t.Error("no error")
+	}
+	if requests != 1 {
+		t.Errorf("sent %d requests, want 1", requests)
+	}
+}
+
+// TestRetryStopsOnCancel cancels the context while a retry waits: the wait
+// ends at once, without another request.
+func TestRetryStopsOnCancel(t *testing.T) {
+	var requests int
+	first := make(chan struct{})
+	h := statusHandler("30", &requests, 503, 503)
+	c, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h(w, r)
+		if requests == 1 {
+			close(first)
+		}
+	}))
+	c.SetRetries(2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-first
+		cancel()
+	}()
+	start := time.Now()
+	_, err := c.SearchContext(ctx, "1234567890", "SELECT campaign.id FROM campaign")
+	if !errors.Is(err, ErrCanceled) {
+		t.Errorf("error %v, want %v", err, ErrCanceled)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("returned after %s, not when canceled", elapsed)
+	}
+	if requests != 1 {
+		t.Errorf("sent %d requests, want 1", requests)
+	}
+}
+
+func TestRetryTimeout(t *testing.T) {
+	var requests int
+	c, _ := newTestClient(t, statusHandler("30", &requests, 503, 503))
+	c.SetRetries(2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := c.SearchContext(ctx, "1234567890", "SELECT campaign.id FROM campaign")
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("error %v, want a timeout", err)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	header := func(v string) http.Header { return http.Header{"Retry-After": {v}} }
+	tests := []struct {
+		name     string
+		attempt  int
+		header   http.Header
+		min, max time.Duration
+	}{
+		{name: "retry-after seconds", header: header("7"), min: 7 * time.Second, max: 7 * time.Second},
+		{name: "retry-after zero", header: header("0")},
+		{name: "retry-after date passed", header: header("Mon, 02 Jan 2006 15:04:05 GMT")},
+		{name: "retry-after date", header: header(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)), min: 58 * time.Second, max: time.Minute},
+		{name: "invalid retry-after", header: header("soon"), min: 500 * time.Millisecond, max: time.Second},
+		{name: "first retry", min: 500 * time.Millisecond, max: time.Second},
+		{name: "third retry", attempt: 2, min: 2 * time.Second, max: 4 * time.Second},
+		{name: "capped", attempt: 10, min: 15 * time.Second, max: 30 * time.Second},
+		{name: "overflow", attempt: 70, min: 15 * time.Second, max: 30 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				if d := backoff(tt.attempt, tt.header); d < tt.min || d > tt.max {
+					t.Fatalf("backoff = %s, want between %s and %s", d, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
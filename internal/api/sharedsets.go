@@ -0,0 +1,51 @@
+package api
+
+import "fmt"
+
+// SharedSetRow is a GAQL result row for shared_set queries.
+type SharedSetRow struct {
+	SharedSet SharedSet `json:"sharedSet"`
+}
+
+// SharedSet is an account-level list of criteria, such as negative keywords,
+// that campaigns share. MemberCount is the number of criteria in the set and
+// ReferenceCount the number of campaigns it is attached to.
+type SharedSet struct {
+	ResourceName   string `json:"resourceName"`
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Type           string `json:"type"`
+	Status         string `json:"status"`
+	MemberCount    Int64  `json:"memberCount"`
+	ReferenceCount Int64  `json:"referenceCount"`
+}
+
+// MutateSharedSets sends shared set mutation operations.
+func (c *Client) MutateSharedSets(customerID string, operations []map[string]any) (*MutateResponse, error) {
+	url := fmt.Sprintf("%s/customers/%s/sharedSets:mutate", c.base, customerID)
+	return c.mutate(c.ctx, customerID, url, operations)
+}
+
+// MutateSharedCriteria sends shared criterion mutation operations.
+func (c *Client) MutateSharedCriteria(customerID string, operations []map[string]any) (*MutateResponse, error) {
+	url := fmt.Sprintf("%s/customers/%s/sharedCriteria:mutate", c.base, customerID)
+	return c.mutate(c.ctx, customerID, url, operations)
+}
+
+// MutateCampaignSharedSets sends campaign-shared set association operations.
+func (c *Client) MutateCampaignSharedSets(customerID string, operations []map[string]any) (*MutateResponse, error) {
+	url := fmt.Sprintf("%s/customers/%s/campaignSharedSets:mutate", c.base, customerID)
+	return c.mutate(c.ctx, customerID, url, operations)
+}
+
+// SharedSetResourceName returns the resource name of a shared set.
+func SharedSetResourceName(customerID, sharedSetID string) string {
+	return fmt.Sprintf("customers/%s/sharedSets/%s", customerID, sharedSetID)
+}
+
+// CampaignSharedSetResourceName returns the resource name of a campaign's
+// attachment to a shared set,
+// e.g. ("123", "456", "789") → "customers/123/campaignSharedSets/456~789"
+func CampaignSharedSetResourceName(customerID, campaignID, sharedSetID string) string {
+	return fmt.Sprintf("customers/%s/campaignSharedSets/%s~%s", customerID, campaignID, sharedSetID)
+}
@@ -0,0 +1,94 @@
+package api
+
+import "time"
+
+// SnapshotVersion is the schema version written by gads-cli export. It
+// changes when a field is removed or changes meaning, not when one is added.
+const SnapshotVersion = 1
+
+// Snapshot is the structure of an account, as written by gads-cli export:
+// campaigns with their budgets and settings, ad groups, keywords (negatives
+// included) and responsive search ads. Entities point at their parents by
+// ID, so each list can be written as it is read. Removed entities and
+// metrics are never included.
+type Snapshot struct {
+	SchemaVersion int                `json:"schemaVersion"`
+	ExportedAt    time.Time          `json:"exportedAt"`
+	CustomerID    string             `json:"customerId"`
+	Campaigns     []SnapshotCampaign `json:"campaigns"`
+	AdGroups      []SnapshotAdGroup  `json:"adGroups"`
+	Keywords      []SnapshotKeyword  `json:"keywords"`
+	Ads           []SnapshotAd       `json:"ads"`
+}
+
+// SnapshotCampaign is a campaign with its budget and settings.
+type SnapshotCampaign struct {
+	ID                     string           `json:"id"`
+	Name                   string           `json:"name"`
+	Status                 string           `json:"status"`
+	AdvertisingChannelType string           `json:"advertisingChannelType"`
+	BiddingStrategyType    string           `json:"biddingStrategyType"`
+	TargetCpaMicros        int64            `json:"targetCpaMicros,omitempty"`
+	TargetRoas             float64          `json:"targetRoas,omitempty"`
+	Networks               SnapshotNetworks `json:"networks"`
+	Budget                 SnapshotBudget   `json:"budget"`
+}
+
+// SnapshotNetworks are the networks a campaign serves on.
+type SnapshotNetworks struct {
+	GoogleSearch   bool `json:"googleSearch"`
+	SearchNetwork  bool `json:"searchNetwork"`
+	ContentNetwork bool `json:"contentNetwork"`
+}
+
+// SnapshotBudget is the budget a campaign draws from. A shared budget
+// appears under every campaign using it.
+type SnapshotBudget struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	AmountMicros   int64  `json:"amountMicros"`
+	DeliveryMethod string `json:"deliveryMethod"`
+	Shared         bool   `json:"shared"`
+}
+
+// SnapshotAdGroup is an ad group of a campaign.
+type SnapshotAdGroup struct {
+	ID           string `json:"id"`
+	CampaignID   string `json:"campaignId"`
+	Name         string `json:"name"`
+	Status       string `json:"status"`
+	Type         string `json:"type"`
+	CpcBidMicros int64  `json:"cpcBidMicros"`
+}
+
+// SnapshotKeyword is a keyword or negative keyword of an ad group, or a
+// negative keyword of a campaign, which has no AdGroupID (nor status or bid).
+type SnapshotKeyword struct {
+	CriterionID  string `json:"criterionId"`
+	CampaignID   string `json:"campaignId"`
+	AdGroupID    string `json:"adGroupId,omitempty"`
+	Text         string `json:"text"`
+	MatchType    string `json:"matchType"`
+	Negative     bool   `json:"negative"`
+	Status       string `json:"status,omitempty"`
+	CpcBidMicros int64  `json:"cpcBidMicros,omitempty"`
+}
+
+// SnapshotAd is a responsive search ad.
+type SnapshotAd struct {
+	ID           string           `json:"id"`
+	CampaignID   string           `json:"campaignId"`
+	AdGroupID    string           `json:"adGroupId"`
+	Status       string           `json:"status"`
+	FinalURLs    []string         `json:"finalUrls"`
+	Headlines    []SnapshotAdText `json:"headlines"`
+	Descriptions []SnapshotAdText `json:"descriptions"`
+	Path1        string           `json:"path1,omitempty"`
+	Path2        string           `json:"path2,omitempty"`
+}
+
+// SnapshotAdText is a headline or description, with its pin if any.
+type SnapshotAdText struct {
+	Text        string `json:"text"`
+	PinnedField string `json:"pinnedField,omitempty"`
+}
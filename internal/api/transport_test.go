@@ -0,0 +1,92 @@
+package api
+
+import (
+	"encoding/pem"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewTransportCA(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	}))
+	// The client without the CA fails the handshake on purpose.
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+	srv.StartTLS()
+	defer srv.Close()
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	block := &pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(block), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		caFile  string
+		wantErr string
+	}{
+		{name: "with the CA", caFile: caFile},
+		{name: "system roots only", wantErr: "certificate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr, err := NewTransport(tt.caFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer tr.CloseIdleConnections()
+			resp, err := (&http.Client{Transport: tr}).Get(srv.URL)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("error %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if body, _ := io.ReadAll(resp.Body); string(body) != "ok" {
+				t.Errorf("got %q", body)
+			}
+		})
+	}
+}
+
+func TestNewTransportInvalidCA(t *testing.T) {
+	dir := t.TempDir()
+	notPEM := filepath.Join(dir, "ca.der")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := NewTransport(filepath.Join(dir, "missing.pem"))
+	if !errors.Is(err, os.ErrNotExist) || !strings.Contains(err.Error(), "reading CA certificate") {
+		t.Errorf("missing file: error %v", err)
+	}
+	_, err = NewTransport(notPEM)
+	if err == nil || !strings.Contains(err.Error(), "no PEM certificates found in "+notPEM) {
+		t.Errorf("not PEM: error %v", err)
+	}
+}
+
+func TestNewTransportKeepsDefaults(t *testing.T) {
+	tr, err := NewTransport("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr.Proxy == nil {
+		t.Error("proxy environment variables are ignored")
+	}
+	if tr == http.DefaultTransport {
+		t.Error("returned the shared default transport")
+	}
+}
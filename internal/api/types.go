@@ -1,6 +1,41 @@
 package api
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Int64 is an int64 that decodes from both the int64-as-string encoding the
+// API uses ("1234") and plain JSON numbers. Missing or null values are 0;
+// malformed values are a decoding error rather than a silent zero.
+type Int64 int64
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *Int64) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if s == "null" {
+		return nil
+	}
+	if strings.HasPrefix(s, `"`) {
+		var err error
+		if s, err = strconv.Unquote(s); err != nil {
+			return fmt.Errorf("invalid int64 %s", b)
+		}
+		if s == "" {
+			*n = 0
+			return nil
+		}
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid int64 %s", b)
+	}
+	*n = Int64(v)
+	return nil
+}
 
 // SearchResponse is the response from googleAds:search.
 type SearchResponse struct {
@@ -12,10 +47,54 @@ type SearchResponse struct {
 type GoogleAdsError struct {
 	StatusCode int
 	Body       string
+	Status     string // gRPC status, e.g. "UNAUTHENTICATED"
+	Code       string // first GoogleAdsFailure error code, e.g. "QUOTA_ERROR.RESOURCE_EXHAUSTED"
+	RequestID  string // for Google support
 }
 
 func (e *GoogleAdsError) Error() string {
-	return e.Body
+	msg := e.Body
+	if e.Code != "" {
+		msg = fmt.Sprintf("%s [%s]", msg, e.Code)
+	}
+	if e.RequestID != "" {
+		msg = fmt.Sprintf("%s (request-id: %s)", msg, e.RequestID)
+	}
+	return msg
+}
+
+// ErrorKind is a coarse classification of API errors, used for exit codes.
+type ErrorKind int
+
+const (
+	KindOther      ErrorKind = iota
+	KindAuth                 // credentials missing, expired or revoked
+	KindPermission           // authenticated but not allowed
+	KindInvalid              // bad request or unknown resource
+	KindQuota                // rate limit or quota exhausted
+)
+
+// Kind classifies the error by its error code, falling back to the HTTP status.
+func (e *GoogleAdsError) Kind() ErrorKind {
+	switch {
+	case strings.HasPrefix(e.Code, "AUTHENTICATION_ERROR."):
+		return KindAuth
+	case strings.HasPrefix(e.Code, "AUTHORIZATION_ERROR."):
+		return KindPermission
+	case strings.HasPrefix(e.Code, "QUOTA_ERROR."):
+		return KindQuota
+	}
+	switch e.StatusCode {
+	case http.StatusUnauthorized:
+		return KindAuth
+	case http.StatusForbidden:
+		return KindPermission
+	case http.StatusBadRequest, http.StatusNotFound:
+		return KindInvalid
+	case http.StatusTooManyRequests:
+		return KindQuota
+	}
+	return KindOther
 }
 
 // AccessibleCustomersResponse is returned by customers:listAccessibleCustomers.
@@ -40,6 +119,32 @@ type CustomerClient struct {
 	TestAccount     bool   `json:"testAccount"`
 }
 
+// CreateCustomerClientResponse is returned by customers:createCustomerClient.
+type CreateCustomerClientResponse struct {
+	ResourceName   string `json:"resourceName"`
+	InvitationLink string `json:"invitationLink,omitempty"`
+}
+
+// CustomerMetricsRow is a GAQL result row for customer-level metric queries.
+type CustomerMetricsRow struct {
+	Customer Customer `json:"customer"`
+	Metrics  Metrics  `json:"metrics"`
+}
+
+// CustomerRow is a GAQL result row for customer queries.
+type CustomerRow struct {
+	Customer Customer `json:"customer"`
+}
+
+// Customer is an account as read from the customer resource.
+type Customer struct {
+	CustomerClient
+	// OptimizationScore (0 to 1) is Google's estimate of how well the
+	// account is set to perform. Only set when selected, and never for
+	// manager accounts.
+	OptimizationScore *float64 `json:"optimizationScore,omitempty"`
+}
+
 // CampaignRow is a GAQL result row for campaign queries.
 type CampaignRow struct {
 	Campaign       Campaign       `json:"campaign"`
@@ -49,20 +154,65 @@ type CampaignRow struct {
 
 // Campaign represents a Google Ads campaign.
 type Campaign struct {
-	ResourceName           string `json:"resourceName"`
-	ID                     string `json:"id"`
-	Name                   string `json:"name"`
-	Status                 string `json:"status"`
-	AdvertisingChannelType string `json:"advertisingChannelType"`
-	BiddingStrategyType    string `json:"biddingStrategyType"`
-	CampaignBudget         string `json:"campaignBudget"` // resource name string
+	ResourceName           string            `json:"resourceName"`
+	ID                     string            `json:"id"`
+	Name                   string            `json:"name"`
+	Status                 string            `json:"status"`
+	AdvertisingChannelType string            `json:"advertisingChannelType"`
+	BiddingStrategyType    string            `json:"biddingStrategyType"`
+	CampaignBudget         string            `json:"campaignBudget"` // resource name string
+	TargetingSetting       *TargetingSetting `json:"targetingSetting,omitempty"`
+	NetworkSettings        *NetworkSettings  `json:"networkSettings,omitempty"`
+	// PrimaryStatus is whether the campaign serves (ELIGIBLE, NOT_ELIGIBLE,
+	// LIMITED, ...) and PrimaryStatusReasons why. Only set when selected.
+	PrimaryStatus        string   `json:"primaryStatus,omitempty"`
+	PrimaryStatusReasons []string `json:"primaryStatusReasons,omitempty"`
+	// OptimizationScore (0 to 1) is Google's estimate of how well the
+	// campaign is set to perform, and OptimizationScoreWeight its weight in
+	// the account's score. Only set when selected, and never for campaign
+	// types without a score.
+	OptimizationScore       *float64 `json:"optimizationScore,omitempty"`
+	OptimizationScoreWeight *float64 `json:"optimizationScoreWeight,omitempty"`
+	// BiddingStrategy is the resource name of the campaign's portfolio
+	// bidding strategy, empty with a standard one. Only set when selected.
+	BiddingStrategy string `json:"biddingStrategy,omitempty"`
+}
+
+// NetworkSettings are the networks a campaign's ads show on: Google Search,
+// search partners and the Display Network. Only set when selected; the API
+// leaves out networks that are off.
+type NetworkSettings struct {
+	TargetGoogleSearch   bool `json:"targetGoogleSearch"`
+	TargetSearchNetwork  bool `json:"targetSearchNetwork"`
+	TargetContentNetwork bool `json:"targetContentNetwork"`
+}
+
+// TargetingSetting controls which criteria dimensions restrict reach. Only set
+// when selected.
+type TargetingSetting struct {
+	TargetRestrictions []TargetRestriction `json:"targetRestrictions"`
+}
+
+// TargetRestriction sets whether criteria of one dimension (e.g. AUDIENCE)
+// only adjust bids (observation) or also limit who sees ads (targeting).
+type TargetRestriction struct {
+	TargetingDimension string `json:"targetingDimension"`
+	BidOnly            bool   `json:"bidOnly"`
+}
+
+// CampaignBudgetRow is a GAQL result row for campaign_budget queries.
+type CampaignBudgetRow struct {
+	CampaignBudget CampaignBudget `json:"campaignBudget"`
 }
 
 // CampaignBudget represents a campaign budget.
 type CampaignBudget struct {
-	ResourceName string `json:"resourceName"`
-	ID           string `json:"id"`
-	AmountMicros string `json:"amountMicros"`
+	ResourceName     string `json:"resourceName"`
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	Status           string `json:"status"`
+	AmountMicros     Int64  `json:"amountMicros"`
+	ExplicitlyShared bool   `json:"explicitlyShared"`
 }
 
 // AdGroupRow is a GAQL result row for ad_group queries.
@@ -74,13 +224,21 @@ type AdGroupRow struct {
 
 // AdGroup represents a Google Ads ad group.
 type AdGroup struct {
-	ResourceName string `json:"resourceName"`
-	ID           string `json:"id"`
-	Name         string `json:"name"`
-	Status       string `json:"status"`
-	Type         string `json:"type"`
-	CpcBidMicros string `json:"cpcBidMicros"`
-	Campaign     string `json:"campaign"` // resource name string
+	ResourceName     string            `json:"resourceName"`
+	ID               string            `json:"id"`
+	Name             string            `json:"name"`
+	Status           string            `json:"status"`
+	Type             string            `json:"type"`
+	CpcBidMicros     Int64             `json:"cpcBidMicros"`
+	Campaign         string            `json:"campaign"` // resource name string
+	TargetingSetting *TargetingSetting `json:"targetingSetting,omitempty"`
+	// AdRotationMode (OPTIMIZE, ROTATE_FOREVER) and OptimizedTargetingEnabled
+	// are only set when selected.
+	AdRotationMode            string `json:"adRotationMode,omitempty"`
+	OptimizedTargetingEnabled bool   `json:"optimizedTargetingEnabled,omitempty"`
+	// PrimaryStatus and PrimaryStatusReasons are as in Campaign.
+	PrimaryStatus        string   `json:"primaryStatus,omitempty"`
+	PrimaryStatusReasons []string `json:"primaryStatusReasons,omitempty"`
 }
 
 // KeywordRow is a GAQL result row for keyword queries.
@@ -104,7 +262,13 @@ type AdGroupCriterion struct {
 	QualityInfo struct {
 		QualityScore int `json:"qualityScore"`
 	} `json:"qualityInfo"`
-	CpcBidMicros string `json:"cpcBidMicros"`
+	CpcBidMicros Int64 `json:"cpcBidMicros"`
+	// EffectiveCpcBidMicros is the bid in use, inherited from the ad group
+	// when the keyword has none. Only set when selected.
+	EffectiveCpcBidMicros Int64 `json:"effectiveCpcBidMicros,omitempty"`
+	// FinalURLs are legacy keyword-level final URLs, which override the
+	// ad's. Only set when selected.
+	FinalURLs []string `json:"finalUrls,omitempty"`
 }
 
 // AdRow is a GAQL result row for ad_group_ad queries (non-insights).
@@ -120,6 +284,9 @@ type AdGroupAd struct {
 	ResourceName string `json:"resourceName"`
 	Status       string `json:"status"`
 	Ad           Ad     `json:"ad"`
+	// PrimaryStatus and PrimaryStatusReasons are as in Campaign.
+	PrimaryStatus        string   `json:"primaryStatus,omitempty"`
+	PrimaryStatusReasons []string `json:"primaryStatusReasons,omitempty"`
 }
 
 // Ad represents the ad itself.
@@ -156,8 +323,121 @@ type AdTextAsset struct {
 
 // InsightsCampaignRow is a GAQL result row for campaign insights.
 type InsightsCampaignRow struct {
-	Campaign Campaign `json:"campaign"`
-	Metrics  Metrics  `json:"metrics"`
+	Campaign Campaign         `json:"campaign"`
+	Metrics  Metrics          `json:"metrics"`
+	Segments *NetworkSegments `json:"segments,omitempty"`
+}
+
+// NetworkSegments are the network segments of a row, only set when the
+// query selects segments.ad_network_type.
+type NetworkSegments struct {
+	AdNetworkType string `json:"adNetworkType"`
+}
+
+// InsightsConversionRow is a GAQL result row for conversion action insights.
+type InsightsConversionRow struct {
+	Segments ConversionSegments `json:"segments"`
+	Metrics  ConversionMetrics  `json:"metrics"`
+}
+
+// ConversionSegments are the conversion action segments of a row, as
+// returned by the API. Date is only set when segmented by day.
+type ConversionSegments struct {
+	Date                     string `json:"date,omitempty"`
+	ConversionAction         string `json:"conversionAction"` // resource name
+	ConversionActionName     string `json:"conversionActionName"`
+	ConversionActionCategory string `json:"conversionActionCategory"`
+}
+
+// CampaignDayRow is a GAQL result row of campaign metrics segmented by date.
+type CampaignDayRow struct {
+	Segments DateSegments `json:"segments"`
+	Metrics  Metrics      `json:"metrics"`
+}
+
+// DateSegments are the date segments of a row (YYYY-MM-DD).
+type DateSegments struct {
+	Date string `json:"date"`
+}
+
+// BidSimulationRow is a GAQL result row for ad_group_criterion_simulation
+// and ad_group_simulation queries; only the queried resource is set.
+type BidSimulationRow struct {
+	AdGroupCriterionSimulation *BidSimulation `json:"adGroupCriterionSimulation,omitempty"`
+	AdGroupSimulation          *BidSimulation `json:"adGroupSimulation,omitempty"`
+}
+
+// BidSimulation is a bid simulation of a keyword or an ad group: what its
+// ads would have got over StartDate to EndDate at other CPC bids.
+// CriterionID is empty for ad groups.
+type BidSimulation struct {
+	AdGroupID          string `json:"adGroupId"`
+	CriterionID        string `json:"criterionId,omitempty"`
+	Type               string `json:"type"`
+	ModificationMethod string `json:"modificationMethod"`
+	StartDate          string `json:"startDate"`
+	EndDate            string `json:"endDate"`
+	CpcBidPointList    struct {
+		Points []CpcBidSimulationPoint `json:"points"`
+	} `json:"cpcBidPointList"`
+}
+
+// CpcBidSimulationPoint is the estimated performance of a bid simulation at
+// one CPC bid.
+type CpcBidSimulationPoint struct {
+	CpcBidMicros             Int64   `json:"cpcBidMicros"`
+	Clicks                   Int64   `json:"clicks"`
+	CostMicros               Int64   `json:"costMicros"`
+	Impressions              Int64   `json:"impressions"`
+	TopSlotImpressions       Int64   `json:"topSlotImpressions"`
+	BiddableConversions      float64 `json:"biddableConversions"`
+	BiddableConversionsValue float64 `json:"biddableConversionsValue"`
+}
+
+// ShoppingPerformanceRow is a GAQL result row for shopping_performance_view
+// queries.
+type ShoppingPerformanceRow struct {
+	Segments ProductSegments `json:"segments"`
+	Metrics  Metrics         `json:"metrics"`
+}
+
+// ProductSegments are the product segments of a row, from the Merchant
+// Center feed. Only the segments the query selects are set.
+type ProductSegments struct {
+	ProductItemID         string `json:"productItemId,omitempty"`
+	ProductTitle          string `json:"productTitle,omitempty"`
+	ProductBrand          string `json:"productBrand,omitempty"`
+	ProductCategoryLevel1 string `json:"productCategoryLevel1,omitempty"`
+}
+
+// ChangeEventRow is a GAQL result row for change_event queries.
+type ChangeEventRow struct {
+	ChangeEvent ChangeEvent `json:"changeEvent"`
+}
+
+// ChangeEvent is an entry of the account's change history. OldResource and
+// NewResource hold the changed resource before and after the change, keyed
+// by its type ("campaign", "campaignBudget", ...); ChangedFields lists the
+// camelCase paths that differ, comma-separated.
+type ChangeEvent struct {
+	ResourceName            string         `json:"resourceName"`
+	ChangeDateTime          string         `json:"changeDateTime"` // account time zone
+	ChangeResourceType      string         `json:"changeResourceType"`
+	ChangeResourceName      string         `json:"changeResourceName"`
+	ResourceChangeOperation string         `json:"resourceChangeOperation"`
+	ChangedFields           string         `json:"changedFields,omitempty"`
+	UserEmail               string         `json:"userEmail,omitempty"`
+	ClientType              string         `json:"clientType,omitempty"`
+	OldResource             map[string]any `json:"oldResource,omitempty"`
+	NewResource             map[string]any `json:"newResource,omitempty"`
+}
+
+// ConversionMetrics are the metrics available with conversion action
+// segments. ConversionsByConversionDate is only set when selected.
+type ConversionMetrics struct {
+	Conversions                 float64  `json:"conversions"`
+	ConversionsValue            float64  `json:"conversionsValue"`
+	ConversionsByConversionDate *float64 `json:"conversionsByConversionDate,omitempty"`
 }
 
 // InsightsAdGroupRow is a GAQL result row for ad group insights.
@@ -173,6 +453,10 @@ type InsightsKeywordRow struct {
 	AdGroup          AdGroup          `json:"adGroup"`
 	Campaign         Campaign         `json:"campaign"`
 	Metrics          Metrics          `json:"metrics"`
+	Segments         *NetworkSegments `json:"segments,omitempty"`
+	// KeywordMetrics is the keyword's search volume, added by insights
+	// keywords --include-search-volume; it is not a GAQL field.
+	KeywordMetrics *KeywordHistoricalMetrics `json:"keywordMetrics,omitempty"`
 }
 
 // SearchTermRow is a GAQL result row for search term reports.
@@ -198,13 +482,237 @@ type InsightsAdRow struct {
 	Metrics   Metrics   `json:"metrics"`
 }
 
+// ConversionActionRow is a GAQL result row for conversion_action queries.
+type ConversionActionRow struct {
+	ConversionAction ConversionAction `json:"conversionAction"`
+}
+
+// ConversionAction describes what counts as a conversion and how it is valued.
+type ConversionAction struct {
+	ResourceName               string `json:"resourceName"`
+	ID                         string `json:"id"`
+	Name                       string `json:"name"`
+	Status                     string `json:"status"`
+	Type                       string `json:"type"`
+	Category                   string `json:"category"`
+	CountingType               string `json:"countingType"`
+	IncludeInConversionsMetric bool   `json:"includeInConversionsMetric"`
+	AttributionModelSettings   struct {
+		AttributionModel string `json:"attributionModel"`
+	} `json:"attributionModelSettings"`
+	ValueSettings struct {
+		DefaultValue          float64 `json:"defaultValue"`
+		DefaultCurrencyCode   string  `json:"defaultCurrencyCode"`
+		AlwaysUseDefaultValue bool    `json:"alwaysUseDefaultValue"`
+	} `json:"valueSettings"`
+}
+
+// AssetRow is a GAQL result row for asset queries.
+type AssetRow struct {
+	Asset Asset `json:"asset"`
+}
+
+// Asset is a reusable piece of ad content such as a text, image, sitelink or
+// callout. Only the field matching Type is set.
+type Asset struct {
+	ResourceName string   `json:"resourceName"`
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Type         string   `json:"type"`
+	FinalURLs    []string `json:"finalUrls"`
+	TextAsset    struct {
+		Text string `json:"text"`
+	} `json:"textAsset"`
+	ImageAsset struct {
+		MimeType string `json:"mimeType"`
+		FileSize Int64  `json:"fileSize"`
+		FullSize struct {
+			WidthPixels  Int64  `json:"widthPixels"`
+			HeightPixels Int64  `json:"heightPixels"`
+			URL          string `json:"url"`
+		} `json:"fullSize"`
+	} `json:"imageAsset"`
+	SitelinkAsset struct {
+		LinkText     string `json:"linkText"`
+		Description1 string `json:"description1"`
+		Description2 string `json:"description2"`
+	} `json:"sitelinkAsset"`
+	CalloutAsset struct {
+		CalloutText string `json:"calloutText"`
+	} `json:"calloutAsset"`
+}
+
+// CampaignAssetRow is a GAQL result row for campaign_asset queries.
+type CampaignAssetRow struct {
+	CampaignAsset CampaignAsset `json:"campaignAsset"`
+	Campaign      Campaign      `json:"campaign"`
+	Asset         Asset         `json:"asset"`
+}
+
+// CampaignAsset links an asset to a campaign for one field type (e.g.
+// SITELINK or CALLOUT).
+type CampaignAsset struct {
+	ResourceName string `json:"resourceName"`
+	Campaign     string `json:"campaign"` // resource name
+	Asset        string `json:"asset"`    // resource name
+	FieldType    string `json:"fieldType"`
+	Status       string `json:"status"`
+}
+
+// CustomerAssetRow is a GAQL result row for customer_asset queries.
+type CustomerAssetRow struct {
+	CustomerAsset CustomerAsset `json:"customerAsset"`
+	Asset         Asset         `json:"asset"`
+}
+
+// CustomerAsset links an asset to every eligible campaign in the account for
+// one field type.
+type CustomerAsset struct {
+	ResourceName string `json:"resourceName"`
+	Asset        string `json:"asset"` // resource name
+	FieldType    string `json:"fieldType"`
+	Status       string `json:"status"`
+}
+
+// UserListRow is a GAQL result row for user_list queries.
+type UserListRow struct {
+	UserList UserList `json:"userList"`
+}
+
+// UserList is an audience of users (e.g. remarketing visitors or customer
+// match uploads) that can be attached to campaigns and ad groups.
+type UserList struct {
+	ResourceName       string `json:"resourceName"`
+	ID                 string `json:"id"`
+	Name               string `json:"name"`
+	Type               string `json:"type"`
+	MembershipStatus   string `json:"membershipStatus"`
+	SizeForSearch      Int64  `json:"sizeForSearch"`
+	SizeForDisplay     Int64  `json:"sizeForDisplay"`
+	EligibleForSearch  bool   `json:"eligibleForSearch"`
+	EligibleForDisplay bool   `json:"eligibleForDisplay"`
+}
+
+// AccountBudgetRow is a GAQL result row for account_budget queries.
+type AccountBudgetRow struct {
+	AccountBudget AccountBudget `json:"accountBudget"`
+}
+
+// AccountBudget is an approved budget order for an account on monthly
+// invoicing. Accounts paid by card have none.
+type AccountBudget struct {
+	ResourceName                string `json:"resourceName"`
+	ID                          string `json:"id"`
+	Name                        string `json:"name"`
+	Status                      string `json:"status"`
+	BillingSetup                string `json:"billingSetup"` // resource name
+	PurchaseOrderNumber         string `json:"purchaseOrderNumber"`
+	ApprovedSpendingLimitMicros Int64  `json:"approvedSpendingLimitMicros"`
+	ApprovedSpendingLimitType   string `json:"approvedSpendingLimitType"` // INFINITE when unlimited
+	AdjustedSpendingLimitMicros Int64  `json:"adjustedSpendingLimitMicros"`
+	AdjustedSpendingLimitType   string `json:"adjustedSpendingLimitType"`
+	TotalAdjustmentsMicros      Int64  `json:"totalAdjustmentsMicros"`
+	AmountServedMicros          Int64  `json:"amountServedMicros"`
+	ApprovedStartDateTime       string `json:"approvedStartDateTime"`
+	ApprovedEndDateTime         string `json:"approvedEndDateTime"`
+	ApprovedEndTimeType         string `json:"approvedEndTimeType"` // FOREVER when open-ended
+}
+
+// BillingSetupRow is a GAQL result row for billing_setup queries.
+type BillingSetupRow struct {
+	BillingSetup BillingSetup `json:"billingSetup"`
+}
+
+// BillingSetup links an account to a payments account and profile.
+type BillingSetup struct {
+	ResourceName        string `json:"resourceName"`
+	ID                  string `json:"id"`
+	Status              string `json:"status"`
+	PaymentsAccount     string `json:"paymentsAccount"` // resource name
+	PaymentsAccountInfo struct {
+		PaymentsAccountID   string `json:"paymentsAccountId"`
+		PaymentsAccountName string `json:"paymentsAccountName"`
+		PaymentsProfileID   string `json:"paymentsProfileId"`
+		PaymentsProfileName string `json:"paymentsProfileName"`
+	} `json:"paymentsAccountInfo"`
+	StartDateTime string `json:"startDateTime"`
+	EndDateTime   string `json:"endDateTime"`
+	EndTimeType   string `json:"endTimeType"`
+}
+
+// LabelRow is a GAQL result row for label queries.
+type LabelRow struct {
+	Label Label `json:"label"`
+}
+
+// Label is a tag that can be attached to campaigns, ad groups, ads and keywords.
+type Label struct {
+	ResourceName string `json:"resourceName"`
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Status       string `json:"status"`
+	TextLabel    struct {
+		BackgroundColor string `json:"backgroundColor"`
+		Description     string `json:"description"`
+	} `json:"textLabel"`
+}
+
+// GeoTargetConstant is a location that can be targeted, e.g. a country or city.
+type GeoTargetConstant struct {
+	ResourceName  string `json:"resourceName"`
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	CanonicalName string `json:"canonicalName"`
+	CountryCode   string `json:"countryCode"`
+	TargetType    string `json:"targetType"`
+	Status        string `json:"status"`
+}
+
+// GeoTargetConstantSuggestion is one match returned by geoTargetConstants:suggest.
+type GeoTargetConstantSuggestion struct {
+	Locale            string            `json:"locale"`
+	Reach             Int64             `json:"reach"`
+	SearchTerm        string            `json:"searchTerm"`
+	GeoTargetConstant GeoTargetConstant `json:"geoTargetConstant"`
+}
+
+// RecommendationRow is a GAQL result row for recommendation queries.
+type RecommendationRow struct {
+	Recommendation Recommendation `json:"recommendation"`
+}
+
+// Recommendation is an optimization suggestion generated by Google Ads. The
+// type-specific payload (e.g. keywordRecommendation) is only kept in the raw
+// JSON row.
+type Recommendation struct {
+	ResourceName string `json:"resourceName"`
+	Type         string `json:"type"`
+	Campaign     string `json:"campaign"` // resource name string
+	AdGroup      string `json:"adGroup"`  // resource name string
+	Dismissed    bool   `json:"dismissed"`
+	Impact       struct {
+		BaseMetrics      RecommendationMetrics `json:"baseMetrics"`
+		PotentialMetrics RecommendationMetrics `json:"potentialMetrics"`
+	} `json:"impact"`
+}
+
+// RecommendationMetrics are the metrics of a recommendation's impact
+// estimate, before (base) or after (potential) applying it.
+type RecommendationMetrics struct {
+	Impressions float64 `json:"impressions"`
+	Clicks      float64 `json:"clicks"`
+	CostMicros  Int64   `json:"costMicros"`
+	Conversions float64 `json:"conversions"`
+}
+
 // Metrics holds performance metrics returned by GAQL.
-// Integer fields (impressions, clicks, costMicros) are returned as strings.
-// Float fields (ctr, averageCpc, conversions, conversionsValue, etc.) are returned as numbers.
+// Integer fields (impressions, clicks, costMicros) are returned as strings and
+// decoded into Int64. Float fields (ctr, averageCpc, conversions,
+// conversionsValue, etc.) are returned as numbers.
 type Metrics struct {
-	Impressions      string  `json:"impressions"`
-	Clicks           string  `json:"clicks"`
-	CostMicros       string  `json:"costMicros"`
+	Impressions      Int64   `json:"impressions"`
+	Clicks           Int64   `json:"clicks"`
+	CostMicros       Int64   `json:"costMicros"`
 	Ctr              float64 `json:"ctr"`
 	AverageCpc       float64 `json:"averageCpc"`
 	Conversions      float64 `json:"conversions"`
@@ -213,15 +721,32 @@ type Metrics struct {
 	// Extended metrics
 	AbsoluteTopImpressionPercentage float64 `json:"absoluteTopImpressionPercentage"`
 	TopImpressionPercentage         float64 `json:"topImpressionPercentage"`
-	ViewThroughConversions          string  `json:"viewThroughConversions"`
+	ViewThroughConversions          Int64   `json:"viewThroughConversions"`
 	CostPerConversion               float64 `json:"costPerConversion"`
 	ConversionsFromInteractionsRate float64 `json:"conversionsFromInteractionsRate"`
 	SearchImpressionShare           float64 `json:"searchImpressionShare"`
 }
 
 // MutateResponse is the response from mutate endpoints.
+// With partial failure enabled, failed operations leave an empty entry in
+// Results and are described by PartialFailureError.
 type MutateResponse struct {
 	Results []struct {
 		ResourceName string `json:"resourceName"`
 	} `json:"results"`
+	PartialFailureError *Status `json:"partialFailureError,omitempty"`
+}
+
+// Status is a google.rpc.Status, as returned in partialFailureError.
+type Status struct {
+	Code    int               `json:"code"`
+	Message string            `json:"message"`
+	Details []json.RawMessage `json:"details,omitempty"`
+}
+
+// OperationError describes one failed operation of a partial-failure mutate.
+type OperationError struct {
+	Index   int    `json:"index"` // operation index, or -1 when unknown
+	Code    string `json:"code"`  // e.g. "DUPLICATE_KEYWORD"
+	Message string `json:"message"`
 }
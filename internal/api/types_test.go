@@ -0,0 +1,100 @@
+package api
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestGoogleAdsErrorKind(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		code   string
+		want   ErrorKind
+	}{
+		{name: "401", status: 401, want: KindAuth},
+		{name: "403", status: 403, want: KindPermission},
+		{name: "400", status: 400, want: KindInvalid},
+		{name: "404", status: 404, want: KindInvalid},
+		{name: "429", status: 429, want: KindQuota},
+		{name: "500", status: 500, want: KindOther},
+		{name: "503", status: 503, want: KindOther},
+		// The error code wins over the status.
+		{name: "authentication code", status: 400, code: "AUTHENTICATION_ERROR.OAUTH_TOKEN_REVOKED", want: KindAuth},
+		{name: "authorization code", status: 400, code: "AUTHORIZATION_ERROR.USER_PERMISSION_DENIED", want: KindPermission},
+		{name: "quota code", status: 400, code: "QUOTA_ERROR.RESOURCE_EXHAUSTED", want: KindQuota},
+		{name: "other code", status: 400, code: "QUERY_ERROR.BAD_FIELD_NAME", want: KindInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &GoogleAdsError{StatusCode: tt.status, Code: tt.code}
+			if got := e.Kind(); got != tt.want {
+				t.Errorf("Kind() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInt64UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Int64
+		wantErr bool
+	}{
+		{in: `"1234"`, want: 1234},
+		{in: `1234`, want: 1234},
+		{in: `"-5"`, want: -5},
+		{in: `"0"`, want: 0},
+		{in: `""`, want: 0},
+		{in: `null`, want: 0},
+		{in: `"9223372036854775807"`, want: 9223372036854775807},
+		{in: `"9223372036854775808"`, wantErr: true},
+		{in: `"12.5"`, wantErr: true},
+		{in: `12.5`, wantErr: true},
+		{in: `1e3`, wantErr: true},
+		{in: `"abc"`, wantErr: true},
+		{in: `true`, wantErr: true},
+		{in: `"12`, wantErr: true},
+	}
+	for _, tt := range tests {
+		var got Int64
+		err := got.UnmarshalJSON([]byte(tt.in))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("UnmarshalJSON(%s) error = %v, want error %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("UnmarshalJSON(%s) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMetricsUnmarshalJSON(t *testing.T) {
+	var row struct {
+		Metrics Metrics `json:"metrics"`
+	}
+	in := `{"metrics":{"impressions":"1200","clicks":"34","costMicros":"56780000","ctr":0.0283,` +
+		`"conversions":2.5,"viewThroughConversions":3}}`
+	if err := json.Unmarshal([]byte(in), &row); err != nil {
+		t.Fatal(err)
+	}
+	want := Metrics{Impressions: 1200, Clicks: 34, CostMicros: 56_780_000, Ctr: 0.0283, Conversions: 2.5, ViewThroughConversions: 3}
+	if row.Metrics != want {
+		t.Errorf("got  %+v\nwant %+v", row.Metrics, want)
+	}
+
+	// Integer metrics encode as plain numbers.
+	out, err := json.Marshal(Metrics{Clicks: 34})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), `"clicks":34,`) {
+		t.Errorf("encoded as %s", out)
+	}
+
+	err = json.Unmarshal([]byte(`{"metrics":{"clicks":"many"}}`), &row)
+	if err == nil || !strings.Contains(err.Error(), `invalid int64 "many"`) {
+		t.Errorf("error %v, want the malformed value", err)
+	}
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"sync/atomic"
+)
+
+// Usage counts the requests a Client sent to the API, for keeping an eye on
+// the developer token's daily limits: search requests (one per page or
+// stream), mutate requests and the operations they carried, and other
+// requests. Retries count as requests; results served from the cache do
+// not.
+type Usage struct {
+	Search     int64 `json:"search"`
+	Mutate     int64 `json:"mutate"`
+	Operations int64 `json:"operations"`
+	Other      int64 `json:"other"`
+}
+
+// usageCounters back Usage. They are shared by the copies of a Client and
+// safe for concurrent use, e.g. by SearchMany.
+type usageCounters struct {
+	search, mutate, operations, other atomic.Int64
+}
+
+// Usage returns the requests sent so far by c and its copies.
+func (c *Client) Usage() Usage {
+	return Usage{
+		Search:     c.usage.search.Load(),
+		Mutate:     c.usage.mutate.Load(),
+		Operations: c.usage.operations.Load(),
+		Other:      c.usage.other.Load(),
+	}
+}
+
+// countRequest counts a request about to be sent. Mutates are the
+// non-idempotent POSTs, see postMutate.
+func (c *Client) countRequest(method, url string, idempotent bool) {
+	switch {
+	case strings.Contains(url, "googleAds:search"):
+		c.usage.search.Add(1)
+	case method == http.MethodPost && !idempotent:
+		c.usage.mutate.Add(1)
+	default:
+		c.usage.other.Add(1)
+	}
+}
+
+// countOperations counts the operations of a mutate payload: the length of
+// its operations (or mutateOperations, conversions) list, or one for
+// requests that change a single thing.
+func (c *Client) countOperations(payload any) {
+	n := int64(1)
+	if m, ok := payload.(map[string]any); ok {
+		for _, key := range []string{"operations", "mutateOperations", "conversions"} {
+			if v := reflect.ValueOf(m[key]); v.Kind() == reflect.Slice {
+				n = int64(v.Len())
+				break
+			}
+		}
+	}
+	c.usage.operations.Add(n)
+}
@@ -0,0 +1,220 @@
+// Package audit keeps a local, append-only log of the changes made with
+// gads-cli, one JSON entry per line.
+package audit
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/the20100/gads-cli/internal/api"
+)
+
+// DefaultMaxSize is the size at which the log is rotated: the current file
+// becomes <name>.1, replacing the previous one, so the log never takes more
+// than about twice this.
+const DefaultMaxSize = 10 << 20
+
+// FileName is the name of the log in the configuration directory.
+const FileName = "audit.jsonl"
+
+// Entry is one successful mutate request. A command sending several
+// requests records one entry per request, with the same Run.
+type Entry struct {
+	ID            string          `json:"id"`
+	Run           string          `json:"run"`
+	Time          time.Time       `json:"time"`
+	Command       string          `json:"command"` // secrets redacted
+	CustomerID    string          `json:"customerId"`
+	Endpoint      string          `json:"endpoint"` // e.g. "customers/123/campaigns:mutate"
+	Operations    json.RawMessage `json:"operations"`
+	ResourceNames []string        `json:"resourceNames"`
+	Undo          *Undo           `json:"undo,omitempty"`   // nil in entries older than undo
+	Undoes        string          `json:"undoes,omitempty"` // ID of the entry this one reversed
+}
+
+// Undo says how to reverse an entry, as known when it was recorded: the
+// operations restoring the previous state, removing what the entry
+// created, or why it cannot be reversed.
+type Undo struct {
+	Operations    []map[string]any `json:"operations,omitempty"` // sent to the entry's service
+	RemoveCreated bool             `json:"removeCreated,omitempty"`
+	Reason        string           `json:"reason,omitempty"` // set when the entry cannot be undone
+}
+
+// Restore returns an Undo sending ops.
+func Restore(ops []map[string]any) *Undo {
+	return &Undo{Operations: ops}
+}
+
+// NotUndoable returns an Undo refusing with reason, e.g. "removed keywords
+// cannot be restored".
+func NotUndoable(reason string) *Undo {
+	return &Undo{Reason: reason}
+}
+
+// Service returns the mutate service of an endpoint, e.g. "campaigns" for
+// "customers/123/campaigns:mutate", or "" for other endpoints such as
+// googleAds:mutate.
+func Service(endpoint string) string {
+	name, ok := strings.CutSuffix(endpoint[strings.LastIndex(endpoint, "/")+1:], ":mutate")
+	if !ok || name == "googleAds" {
+		return ""
+	}
+	return name
+}
+
+// Log is an audit log file.
+type Log struct {
+	path    string
+	maxSize int64
+}
+
+// New returns the log at path, rotated once it reaches maxSize bytes.
+func New(path string, maxSize int64) *Log {
+	return &Log{path: path, maxSize: maxSize}
+}
+
+// Path returns the file of the log.
+func (l *Log) Path() string {
+	return l.path
+}
+
+// NewRun returns an ID grouping the entries of one command.
+func NewRun() string {
+	return newID()
+}
+
+// NewEntry describes m, made by command during run, as an entry with a new
+// ID.
+func NewEntry(m api.Mutation, run, command string, at time.Time) Entry {
+	return Entry{
+		ID:            newID(),
+		Run:           run,
+		Time:          at.UTC(),
+		Command:       command,
+		CustomerID:    m.CustomerID,
+		Endpoint:      m.Endpoint,
+		Operations:    operations(m.Request),
+		ResourceNames: resourceNames(m.Response),
+	}
+}
+
+// Append adds e to the end of the log, rotating it first if it is full.
+func (l *Log) Append(e Entry) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o700); err != nil {
+		return err
+	}
+	if info, err := os.Stat(l.path); err == nil && l.maxSize > 0 && info.Size()+int64(len(line)) > l.maxSize {
+		if err := os.Rename(l.path, l.path+".1"); err != nil {
+			return fmt.Errorf("rotating %s: %w", l.path, err)
+		}
+	}
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	// One write per entry, so concurrent commands never interleave lines.
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Entries returns the entries of the log, oldest first, including those of
+// the rotated file. A line that cannot be parsed (e.g. cut short by a full
+// disk) is skipped.
+func (l *Log) Entries() ([]Entry, error) {
+	var entries []Entry
+	for _, path := range []string{l.path + ".1", l.path} {
+		f, err := os.Open(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sc := bufio.NewScanner(f)
+		sc.Buffer(make([]byte, 64*1024), 64<<20)
+		for sc.Scan() {
+			var e Entry
+			if json.Unmarshal(sc.Bytes(), &e) == nil && e.ID != "" {
+				entries = append(entries, e)
+			}
+		}
+		err = sc.Err()
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+	}
+	return entries, nil
+}
+
+func newID() string {
+	b := make([]byte, 4)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// operations returns the operations of a mutate request body, or the whole
+// body for endpoints without a list of operations (e.g. createCustomerClient).
+func operations(request json.RawMessage) json.RawMessage {
+	var body map[string]json.RawMessage
+	if json.Unmarshal(request, &body) != nil {
+		return request
+	}
+	for _, key := range []string{"operations", "mutateOperations", "conversions"} {
+		if ops, ok := body[key]; ok {
+			return ops
+		}
+	}
+	return request
+}
+
+// resourceNames returns every resourceName in a response body, in order.
+func resourceNames(response json.RawMessage) []string {
+	var v any
+	if json.Unmarshal(response, &v) != nil {
+		return nil
+	}
+	names := []string{}
+	var walk func(v any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			if name, ok := v["resourceName"].(string); ok && name != "" {
+				names = append(names, name)
+			}
+			keys := make([]string, 0, len(v))
+			for key := range v {
+				if key != "resourceName" {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				walk(v[key])
+			}
+		case []any:
+			for _, child := range v {
+				walk(child)
+			}
+		}
+	}
+	walk(v)
+	return names
+}
@@ -0,0 +1,71 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func entryIDs(t *testing.T, l *Log) []string {
+	t.Helper()
+	entries, err := l.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, e := range entries {
+		ids = append(ids, e.ID)
+	}
+	return ids
+}
+
+func TestAppendRotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", FileName)
+	// Room for two entries (about 140 bytes each) per file.
+	l := New(path, 300)
+	for _, id := range []string{"e1", "e2", "e3", "e4", "e5"} {
+		if err := l.Append(Entry{ID: id, Run: "r", CustomerID: "111"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// e1 and e2 were rotated out with the first rotation, replaced by e3 and
+	// e4 with the second.
+	if got, want := entryIDs(t, l), []string{"e3", "e4", "e5"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("entries %v, want %v", got, want)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() > 300 {
+		t.Errorf("log is %d bytes, over its max size", info.Size())
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("log mode %v, want 0600", info.Mode().Perm())
+	}
+}
+
+func TestEntriesSkipsBadLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	rotated := `{"id":"old","run":"r"}` + "\n" + `{"id":"cut","ru`
+	current := "\n" + `{"id":"new","run":"r"}` + "\n" + `{"run":"no id"}` + "\n" + `not json` + "\n"
+	if err := os.WriteFile(path+".1", []byte(rotated), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(current), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := entryIDs(t, New(path, DefaultMaxSize)), []string{"old", "new"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("entries %v, want %v", got, want)
+	}
+}
+
+func TestEntriesNoLog(t *testing.T) {
+	l := New(filepath.Join(t.TempDir(), FileName), DefaultMaxSize)
+	if ids := entryIDs(t, l); len(ids) != 0 {
+		t.Errorf("entries %v, want none", ids)
+	}
+}
@@ -0,0 +1,38 @@
+// Package auth holds the pieces of gads-cli authentication that do not
+// depend on the credentials file: PKCE for the OAuth login flow and the
+// store that keeps secrets out of the file.
+package auth
+
+import "golang.org/x/oauth2"
+
+// PKCE is the proof key (RFC 7636) of one OAuth authorization. The S256
+// challenge of Verifier goes in the authorization URL and the verifier
+// itself with the code exchange, so an intercepted code is useless even to
+// someone holding the client secret.
+type PKCE struct {
+	Verifier string
+}
+
+// NewPKCE returns the proof key of a new authorization, with a verifier made
+// by newVerifier, or a random one when newVerifier is nil.
+func NewPKCE(newVerifier func() string) PKCE {
+	if newVerifier == nil {
+		newVerifier = oauth2.GenerateVerifier
+	}
+	return PKCE{Verifier: newVerifier()}
+}
+
+// Challenge returns the S256 code challenge of the verifier.
+func (p PKCE) Challenge() string {
+	return oauth2.S256ChallengeFromVerifier(p.Verifier)
+}
+
+// AuthCodeOption adds the challenge to an authorization URL.
+func (p PKCE) AuthCodeOption() oauth2.AuthCodeOption {
+	return oauth2.S256ChallengeOption(p.Verifier)
+}
+
+// ExchangeOption adds the verifier to a code exchange.
+func (p PKCE) ExchangeOption() oauth2.AuthCodeOption {
+	return oauth2.VerifierOption(p.Verifier)
+}
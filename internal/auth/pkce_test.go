@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+// rfcVerifier and rfcChallenge are the example of RFC 7636, appendix B.
+const (
+	rfcVerifier  = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	rfcChallenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+)
+
+func TestNewPKCE(t *testing.T) {
+	p := NewPKCE(func() string { return rfcVerifier })
+	if p.Verifier != rfcVerifier {
+		t.Errorf("verifier %q, want the injected one", p.Verifier)
+	}
+	if got := p.Challenge(); got != rfcChallenge {
+		t.Errorf("challenge %q, want %q", got, rfcChallenge)
+	}
+
+	a, b := NewPKCE(nil), NewPKCE(nil)
+	if len(a.Verifier) < 43 || a.Verifier == b.Verifier {
+		t.Errorf("random verifiers %q and %q: want two different ones of 43+ characters", a.Verifier, b.Verifier)
+	}
+}
+
+func TestPKCEOAuthFlow(t *testing.T) {
+	var gotVerifier string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		gotVerifier = r.PostForm.Get("code_verifier")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"at","refresh_token":"rt","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer srv.Close()
+
+	cfg := &oauth2.Config{
+		ClientID:    "id",
+		Endpoint:    oauth2.Endpoint{AuthURL: srv.URL + "/auth", TokenURL: srv.URL + "/token"},
+		RedirectURL: "http://127.0.0.1:1234",
+	}
+	p := NewPKCE(func() string { return rfcVerifier })
+
+	authURL, err := url.Parse(cfg.AuthCodeURL("state", p.AuthCodeOption()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := authURL.Query()
+	if q.Get("code_challenge") != rfcChallenge || q.Get("code_challenge_method") != "S256" {
+		t.Errorf("authorization URL %s: want the S256 challenge", authURL)
+	}
+	if q.Has("code_verifier") {
+		t.Errorf("authorization URL %s leaks the verifier", authURL)
+	}
+
+	if _, err := cfg.Exchange(context.Background(), "code", p.ExchangeOption()); err != nil {
+		t.Fatal(err)
+	}
+	if gotVerifier != rfcVerifier {
+		t.Errorf("code exchange sent verifier %q, want %q", gotVerifier, rfcVerifier)
+	}
+}
@@ -0,0 +1,25 @@
+package auth
+
+import "github.com/zalando/go-keyring"
+
+// keyringService is the service name of gads-cli entries in the OS keychain.
+const keyringService = "gads-cli"
+
+// ErrNotFound is returned by a SecretStore for a profile with no entry.
+var ErrNotFound = keyring.ErrNotFound
+
+// SecretStore keeps the secrets of each credentials profile, as one opaque
+// string, outside the credentials file.
+type SecretStore interface {
+	Get(profile string) (string, error)
+	Set(profile, data string) error
+	Delete(profile string) error
+}
+
+// Keyring is the SecretStore of the OS keychain: Keychain on macOS, Secret
+// Service on Linux and Credential Manager on Windows.
+type Keyring struct{}
+
+func (Keyring) Get(profile string) (string, error) { return keyring.Get(keyringService, profile) }
+func (Keyring) Set(profile, data string) error     { return keyring.Set(keyringService, profile, data) }
+func (Keyring) Delete(profile string) error        { return keyring.Delete(keyringService, profile) }
@@ -0,0 +1,83 @@
+// Package cache stores query responses on disk for a limited time.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Cache is a directory of responses grouped by customer ID, so everything
+// cached for one customer can be dropped at once.
+type Cache struct {
+	dir string
+	ttl time.Duration
+}
+
+// Dir returns the default cache directory (e.g. ~/.cache/gads-cli on Linux).
+func Dir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("locating cache directory: %w", err)
+	}
+	return filepath.Join(base, "gads-cli"), nil
+}
+
+// New returns a cache in dir whose entries expire after ttl.
+func New(dir string, ttl time.Duration) *Cache {
+	return &Cache{dir: dir, ttl: ttl}
+}
+
+// Get returns the entry stored under key for customerID, if it has not expired.
+func (c *Cache) Get(customerID, key string) ([]byte, bool) {
+	path := c.path(customerID, key)
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > c.ttl {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// Put stores data under key for customerID. The file is written atomically so
+// a concurrent Get never sees a partial entry.
+func (c *Cache) Put(customerID, key string, data []byte) error {
+	path := c.path(customerID, key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Invalidate drops every entry cached for customerID.
+func (c *Cache) Invalidate(customerID string) error {
+	return os.RemoveAll(filepath.Join(c.dir, customerID))
+}
+
+// Clear removes the whole cache directory.
+func Clear(dir string) error {
+	return os.RemoveAll(dir)
+}
+
+func (c *Cache) path(customerID, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, customerID, hex.EncodeToString(sum[:])+".json")
+}
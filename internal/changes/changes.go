@@ -0,0 +1,163 @@
+// Package changes reads bulk change files, the input of gads-cli apply, and
+// translates their rows into mutate operations.
+//
+// A changes file is a CSV file with the columns entity, id, action and value
+// (in any order, value optional), or a JSON array of objects with the same
+// keys:
+//
+//	entity,id,action,value
+//	campaign,111222333,pause,
+//	keyword,444555666~12345,set-bid,1.50
+//	budget,999888777,set-budget,20
+package changes
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Row is one requested change. Line is the row's line in a CSV file, or its
+// position (from 1) in a JSON array.
+type Row struct {
+	Line   int    `json:"line"`
+	Entity string `json:"entity"`
+	ID     string `json:"id"`
+	Action string `json:"action"`
+	Value  string `json:"value,omitempty"`
+}
+
+// String describes the row for confirmations and errors, e.g.
+// "keyword 444555666~12345 set-bid 1.50".
+func (r Row) String() string {
+	s := r.Entity + " " + r.ID + " " + r.Action
+	if r.Value != "" {
+		s += " " + r.Value
+	}
+	return s
+}
+
+// Read reads a changes file ("-" for stdin). JSON is recognized by a .json
+// extension or a leading "["; anything else is read as CSV.
+func Read(path string) ([]Row, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	var rows []Row
+	trimmed := bytes.TrimSpace(data)
+	if strings.EqualFold(filepath.Ext(path), ".json") || bytes.HasPrefix(trimmed, []byte("[")) {
+		rows, err = parseJSON(trimmed)
+	} else {
+		rows, err = parseCSV(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%s contains no changes", path)
+	}
+	return rows, nil
+}
+
+func parseJSON(data []byte) ([]Row, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var rows []Row
+	if err := dec.Decode(&rows); err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		rows[i].Line = i + 1
+		rows[i] = normalize(rows[i])
+	}
+	return rows, nil
+}
+
+// csvColumns are the columns of a CSV changes file; value may be omitted.
+var csvColumns = []string{"entity", "id", "action", "value"}
+
+func parseCSV(data []byte) ([]Row, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.TrimLeadingSpace = true
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+	col := map[string]int{}
+	for i, h := range header {
+		col[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	for _, name := range csvColumns[:3] {
+		if _, ok := col[name]; !ok {
+			return nil, fmt.Errorf("missing %q column (want: %s)", name, strings.Join(csvColumns, ","))
+		}
+	}
+	field := func(rec []string, name string) string {
+		if i, ok := col[name]; ok && i < len(rec) {
+			return strings.TrimSpace(rec[i])
+		}
+		return ""
+	}
+
+	var rows []Row
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(strings.Join(rec, "")) == "" {
+			continue // blank spreadsheet row
+		}
+		line, _ := r.FieldPos(0)
+		rows = append(rows, normalize(Row{
+			Line:   line,
+			Entity: field(rec, "entity"),
+			ID:     field(rec, "id"),
+			Action: field(rec, "action"),
+			Value:  field(rec, "value"),
+		}))
+	}
+	return rows, nil
+}
+
+// entityNames maps the other spellings accepted in the entity column to
+// the entity they name.
+var entityNames = map[string]string{
+	"ad_group":        EntityAdGroup,
+	"ad-group":        EntityAdGroup,
+	"campaign_budget": EntityBudget,
+	"campaign-budget": EntityBudget,
+}
+
+// normalize lower-cases the entity and action and puts entity spellings in
+// their canonical form; unknown ones are left for Translate to reject.
+func normalize(r Row) Row {
+	r.Entity = strings.ToLower(strings.TrimSpace(r.Entity))
+	if e, ok := entityNames[r.Entity]; ok {
+		r.Entity = e
+	}
+	r.ID = strings.TrimSpace(r.ID)
+	r.Action = strings.ToLower(strings.TrimSpace(r.Action))
+	r.Value = strings.TrimSpace(r.Value)
+	return r
+}
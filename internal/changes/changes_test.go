@@ -0,0 +1,116 @@
+package changes
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeFile writes data to name in a temp dir and returns its path.
+func writeFile(t *testing.T, name, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		data    string
+		want    []Row
+		wantErr string
+	}{
+		{
+			name: "CSV",
+			file: "changes.csv",
+			data: "entity,id,action,value\ncampaign,111,pause,\nkeyword,444~12,set-bid,1.50\n",
+			want: []Row{
+				{Line: 2, Entity: "campaign", ID: "111", Action: "pause"},
+				{Line: 3, Entity: "keyword", ID: "444~12", Action: "set-bid", Value: "1.50"},
+			},
+		},
+		{
+			name: "CSV columns in any order, value omitted, spellings normalized",
+			file: "changes.csv",
+			data: "Action, ID, Entity\nPAUSE, 222 ,Ad_Group\n",
+			want: []Row{{Line: 2, Entity: "adgroup", ID: "222", Action: "pause"}},
+		},
+		{
+			name: "CSV blank rows skipped, lines kept",
+			file: "changes.csv",
+			data: "entity,id,action,value\n\ncampaign,111,enable,\n,,,\nbudget,9,set-budget,\"5,000.25\"\n",
+			want: []Row{
+				{Line: 3, Entity: "campaign", ID: "111", Action: "enable"},
+				{Line: 5, Entity: "budget", ID: "9", Action: "set-budget", Value: "5,000.25"},
+			},
+		},
+		{
+			name:    "CSV missing column",
+			file:    "changes.csv",
+			data:    "entity,id,value\ncampaign,111,\n",
+			wantErr: `missing "action" column`,
+		},
+		{
+			name:    "CSV header only",
+			file:    "changes.csv",
+			data:    "entity,id,action,value\n",
+			wantErr: "contains no changes",
+		},
+		{
+			name: "JSON",
+			file: "changes.json",
+			data: `[{"entity":"Campaign-Budget","id":"9","action":"Set-Budget","value":"20"},{"entity":"keyword","id":"1~2","action":"remove"}]`,
+			want: []Row{
+				{Line: 1, Entity: "budget", ID: "9", Action: "set-budget", Value: "20"},
+				{Line: 2, Entity: "keyword", ID: "1~2", Action: "remove"},
+			},
+		},
+		{
+			name: "JSON recognized without the extension",
+			file: "changes.txt",
+			data: "  \n[{\"entity\":\"campaign\",\"id\":\"1\",\"action\":\"pause\"}]",
+			want: []Row{{Line: 1, Entity: "campaign", ID: "1", Action: "pause"}},
+		},
+		{
+			name:    "JSON unknown key",
+			file:    "changes.json",
+			data:    `[{"entity":"campaign","id":"1","action":"pause","amount":"5"}]`,
+			wantErr: `unknown field "amount"`,
+		},
+		{
+			name:    "JSON empty array",
+			file:    "changes.json",
+			data:    `[]`,
+			wantErr: "contains no changes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := Read(writeFile(t, tt.file, tt.data))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(rows, tt.want) {
+				t.Errorf("rows:\n%+v\nwant:\n%+v", rows, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadMissingFile(t *testing.T) {
+	if _, err := Read(filepath.Join(t.TempDir(), "nope.csv")); err == nil {
+		t.Error("Read of a missing file succeeded")
+	}
+}
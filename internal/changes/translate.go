@@ -0,0 +1,221 @@
+package changes
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/the20100/gads-cli/internal/api"
+)
+
+// Entities, as written in the entity column.
+const (
+	EntityCampaign = "campaign"
+	EntityAdGroup  = "adgroup"
+	EntityKeyword  = "keyword"
+	EntityBudget   = "budget"
+)
+
+// Actions, as written in the action column. Amounts (set-bid, set-budget)
+// are in the account's currency, like the --amount flags, and read with
+// api.ParseCurrencyToMicros.
+const (
+	ActionPause     = "pause"
+	ActionEnable    = "enable"
+	ActionRemove    = "remove"
+	ActionSetStatus = "set-status" // value: ENABLED or PAUSED
+	ActionSetBid    = "set-bid"    // value: max CPC, e.g. 1.50
+	ActionSetBudget = "set-budget" // value: daily amount, e.g. 20
+)
+
+// actions lists the actions each entity supports.
+var actions = map[string][]string{
+	EntityCampaign: {ActionPause, ActionEnable, ActionRemove, ActionSetStatus, ActionSetBudget},
+	EntityAdGroup:  {ActionPause, ActionEnable, ActionRemove, ActionSetStatus, ActionSetBid},
+	EntityKeyword:  {ActionPause, ActionEnable, ActionRemove, ActionSetStatus, ActionSetBid},
+	EntityBudget:   {ActionSetBudget},
+}
+
+// Services are the mutate services operations are sent to, in sending
+// order.
+var Services = []string{"campaignBudgets", "campaigns", "adGroups", "adGroupCriteria"}
+
+// Operation is a row translated into one operation of a mutate request to
+// Service (e.g. "campaigns").
+type Operation struct {
+	Row      Row            `json:"-"`
+	Service  string         `json:"service"`
+	Resource string         `json:"resource"`
+	Op       map[string]any `json:"operation"`
+
+	// field is what the operation changes on Resource, to catch rows that
+	// contradict each other; "" for a remove.
+	field string
+}
+
+// Resolver looks up resources a row needs but does not name.
+type Resolver interface {
+	// CampaignBudget returns the resource name of a campaign's budget.
+	CampaignBudget(campaignID string) (string, error)
+}
+
+// Translate turns rows into operations on customer cid. Every row is
+// checked before anything is returned: when any is invalid, the error lists
+// all of them ("line 4: ...") and no operations are returned.
+func Translate(cid string, rows []Row, res Resolver) ([]Operation, error) {
+	var ops []Operation
+	var errs []error
+	// seen maps resource → field → line, so a resource is not changed twice
+	// in the same way, or removed and changed.
+	seen := map[string]map[string]int{}
+	for _, r := range rows {
+		op, err := translate(cid, r, res)
+		if err == nil {
+			err = checkConflict(seen, op)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", r.Line, err))
+			continue
+		}
+		ops = append(ops, op)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return ops, nil
+}
+
+func checkConflict(seen map[string]map[string]int, op Operation) error {
+	fields := seen[op.Resource]
+	if fields == nil {
+		fields = map[string]int{}
+		seen[op.Resource] = fields
+	}
+	for field, line := range fields {
+		if field == op.field || field == "" || op.field == "" {
+			return fmt.Errorf("%s %s is already changed on line %d", op.Row.Entity, op.Row.ID, line)
+		}
+	}
+	fields[op.field] = op.Row.Line
+	return nil
+}
+
+func translate(cid string, r Row, res Resolver) (Operation, error) {
+	supported, ok := actions[r.Entity]
+	if !ok {
+		return Operation{}, fmt.Errorf("unknown entity %q (want: campaign, adgroup, keyword or budget)", r.Entity)
+	}
+	if !contains(supported, r.Action) {
+		return Operation{}, fmt.Errorf("%s does not support action %q (want: %s)", r.Entity, r.Action, strings.Join(supported, ", "))
+	}
+	service, resource, err := resourceName(cid, r)
+	if err != nil {
+		return Operation{}, err
+	}
+
+	switch r.Action {
+	case ActionPause, ActionEnable, ActionRemove:
+		if r.Value != "" {
+			return Operation{}, fmt.Errorf("%s takes no value, got %q", r.Action, r.Value)
+		}
+	}
+	switch r.Action {
+	case ActionPause:
+		return update(r, service, resource, "status", "PAUSED"), nil
+	case ActionEnable:
+		return update(r, service, resource, "status", "ENABLED"), nil
+	case ActionSetStatus:
+		status := strings.ToUpper(r.Value)
+		if status != "ENABLED" && status != "PAUSED" {
+			return Operation{}, fmt.Errorf("set-status value must be ENABLED or PAUSED, got %q (use the remove action to remove)", r.Value)
+		}
+		return update(r, service, resource, "status", status), nil
+	case ActionRemove:
+		return Operation{Row: r, Service: service, Resource: resource, Op: map[string]any{"remove": resource}}, nil
+	case ActionSetBid:
+		micros, err := parseAmount(r.Value)
+		if err != nil {
+			return Operation{}, err
+		}
+		return update(r, service, resource, "cpcBidMicros", micros), nil
+	case ActionSetBudget:
+		micros, err := parseAmount(r.Value)
+		if err != nil {
+			return Operation{}, err
+		}
+		if r.Entity == EntityCampaign {
+			budget, err := res.CampaignBudget(r.ID)
+			if err != nil {
+				return Operation{}, err
+			}
+			service, resource = "campaignBudgets", budget
+		}
+		return update(r, service, resource, "amountMicros", micros), nil
+	}
+	return Operation{}, fmt.Errorf("unsupported action %q", r.Action)
+}
+
+// resourceName returns the mutate service and resource name of the row's
+// entity, checking the form of its ID.
+func resourceName(cid string, r Row) (string, string, error) {
+	switch r.Entity {
+	case EntityKeyword:
+		adGroup, criterion, ok := strings.Cut(r.ID, "~")
+		if !ok || !api.IsNumericID(adGroup) || !api.IsNumericID(criterion) {
+			return "", "", fmt.Errorf("keyword id must be <adGroupId>~<criterionId>, got %q", r.ID)
+		}
+		return "adGroupCriteria", fmt.Sprintf("customers/%s/adGroupCriteria/%s", cid, r.ID), nil
+	case EntityCampaign, EntityAdGroup, EntityBudget:
+		if !api.IsNumericID(r.ID) {
+			return "", "", fmt.Errorf("%s id must be numeric, got %q", r.Entity, r.ID)
+		}
+	}
+	switch r.Entity {
+	case EntityCampaign:
+		return "campaigns", fmt.Sprintf("customers/%s/campaigns/%s", cid, r.ID), nil
+	case EntityAdGroup:
+		return "adGroups", fmt.Sprintf("customers/%s/adGroups/%s", cid, r.ID), nil
+	default:
+		return "campaignBudgets", fmt.Sprintf("customers/%s/campaignBudgets/%s", cid, r.ID), nil
+	}
+}
+
+// update returns an operation setting field of resource to value.
+func update(r Row, service, resource, field string, value any) Operation {
+	return Operation{
+		Row:      r,
+		Service:  service,
+		Resource: resource,
+		Op: map[string]any{
+			"updateMask": field,
+			"update": map[string]any{
+				"resourceName": resource,
+				field:          value,
+			},
+		},
+		field: field,
+	}
+}
+
+// parseAmount reads a positive amount in the account's currency ("1.50")
+// and returns it in micros, in the API's int64-as-string encoding.
+func parseAmount(v string) (string, error) {
+	micros, err := api.ParseCurrencyToMicros(v)
+	if err != nil {
+		return "", err
+	}
+	if micros == 0 {
+		return "", fmt.Errorf("amount must be positive, got %q", v)
+	}
+	return strconv.FormatInt(micros, 10), nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
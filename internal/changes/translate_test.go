@@ -0,0 +1,200 @@
+package changes
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// budgets is a Resolver knowing the budget of campaign 111.
+type budgets struct{}
+
+func (budgets) CampaignBudget(campaignID string) (string, error) {
+	if campaignID == "111" {
+		return "customers/1/campaignBudgets/900", nil
+	}
+	return "", errors.New("campaign " + campaignID + " not found")
+}
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		name         string
+		row          Row
+		wantService  string
+		wantResource string
+		wantOp       map[string]any
+		wantErr      string
+	}{
+		{
+			name:         "pause campaign",
+			row:          Row{Entity: "campaign", ID: "111", Action: "pause"},
+			wantService:  "campaigns",
+			wantResource: "customers/1/campaigns/111",
+			wantOp: map[string]any{"updateMask": "status", "update": map[string]any{
+				"resourceName": "customers/1/campaigns/111", "status": "PAUSED"}},
+		},
+		{
+			name:         "enable ad group",
+			row:          Row{Entity: "adgroup", ID: "222", Action: "enable"},
+			wantService:  "adGroups",
+			wantResource: "customers/1/adGroups/222",
+			wantOp: map[string]any{"updateMask": "status", "update": map[string]any{
+				"resourceName": "customers/1/adGroups/222", "status": "ENABLED"}},
+		},
+		{
+			name:         "set-status lower case",
+			row:          Row{Entity: "keyword", ID: "222~333", Action: "set-status", Value: "paused"},
+			wantService:  "adGroupCriteria",
+			wantResource: "customers/1/adGroupCriteria/222~333",
+			wantOp: map[string]any{"updateMask": "status", "update": map[string]any{
+				"resourceName": "customers/1/adGroupCriteria/222~333", "status": "PAUSED"}},
+		},
+		{
+			name:         "remove keyword",
+			row:          Row{Entity: "keyword", ID: "222~333", Action: "remove"},
+			wantService:  "adGroupCriteria",
+			wantResource: "customers/1/adGroupCriteria/222~333",
+			wantOp:       map[string]any{"remove": "customers/1/adGroupCriteria/222~333"},
+		},
+		{
+			name:         "set-bid in currency",
+			row:          Row{Entity: "keyword", ID: "222~333", Action: "set-bid", Value: "1.50"},
+			wantService:  "adGroupCriteria",
+			wantResource: "customers/1/adGroupCriteria/222~333",
+			wantOp: map[string]any{"updateMask": "cpcBidMicros", "update": map[string]any{
+				"resourceName": "customers/1/adGroupCriteria/222~333", "cpcBidMicros": "1500000"}},
+		},
+		{
+			name:         "set-budget on a budget, with thousands",
+			row:          Row{Entity: "budget", ID: "900", Action: "set-budget", Value: "5,000.25"},
+			wantService:  "campaignBudgets",
+			wantResource: "customers/1/campaignBudgets/900",
+			wantOp: map[string]any{"updateMask": "amountMicros", "update": map[string]any{
+				"resourceName": "customers/1/campaignBudgets/900", "amountMicros": "5000250000"}},
+		},
+		{
+			name:         "set-budget on a campaign changes its budget",
+			row:          Row{Entity: "campaign", ID: "111", Action: "set-budget", Value: "20"},
+			wantService:  "campaignBudgets",
+			wantResource: "customers/1/campaignBudgets/900",
+			wantOp: map[string]any{"updateMask": "amountMicros", "update": map[string]any{
+				"resourceName": "customers/1/campaignBudgets/900", "amountMicros": "20000000"}},
+		},
+		{name: "unknown entity", row: Row{Entity: "ad", ID: "1", Action: "pause"}, wantErr: `unknown entity "ad"`},
+		{name: "unsupported action", row: Row{Entity: "budget", ID: "1", Action: "pause"}, wantErr: `budget does not support action "pause"`},
+		{name: "campaign bid", row: Row{Entity: "campaign", ID: "1", Action: "set-bid", Value: "1"}, wantErr: "does not support"},
+		{name: "non-numeric id", row: Row{Entity: "campaign", ID: "abc", Action: "pause"}, wantErr: "id must be numeric"},
+		{name: "keyword id without ~", row: Row{Entity: "keyword", ID: "222", Action: "pause"}, wantErr: "<adGroupId>~<criterionId>"},
+		{name: "value on pause", row: Row{Entity: "campaign", ID: "1", Action: "pause", Value: "x"}, wantErr: "takes no value"},
+		{name: "bad status", row: Row{Entity: "campaign", ID: "1", Action: "set-status", Value: "REMOVED"}, wantErr: "ENABLED or PAUSED"},
+		{name: "missing amount", row: Row{Entity: "budget", ID: "1", Action: "set-budget"}, wantErr: "an amount is required"},
+		{name: "zero amount", row: Row{Entity: "budget", ID: "1", Action: "set-budget", Value: "0"}, wantErr: "must be positive"},
+		{name: "negative amount", row: Row{Entity: "adgroup", ID: "1", Action: "set-bid", Value: "-1"}, wantErr: "must not be negative"},
+		{name: "decimal comma", row: Row{Entity: "adgroup", ID: "1", Action: "set-bid", Value: "1,50"}, wantErr: "use a point for decimals"},
+		{name: "sub-micro amount", row: Row{Entity: "adgroup", ID: "1", Action: "set-bid", Value: "0.0000001"}, wantErr: "at most 6 decimal places"},
+		{name: "unknown campaign budget", row: Row{Entity: "campaign", ID: "222", Action: "set-budget", Value: "5"}, wantErr: "campaign 222 not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.row.Line = 2
+			ops, err := Translate("1", []Row{tt.row}, budgets{})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) || !strings.HasPrefix(err.Error(), "line 2: ") {
+					t.Fatalf("error %v, want \"line 2: ...%s...\"", err, tt.wantErr)
+				}
+				if ops != nil {
+					t.Errorf("operations returned with an error: %+v", ops)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(ops) != 1 {
+				t.Fatalf("got %d operations, want 1", len(ops))
+			}
+			op := ops[0]
+			if op.Service != tt.wantService || op.Resource != tt.wantResource {
+				t.Errorf("operation on %s %s, want %s %s", op.Service, op.Resource, tt.wantService, tt.wantResource)
+			}
+			if !reflect.DeepEqual(op.Op, tt.wantOp) {
+				t.Errorf("operation:\n%v\nwant:\n%v", op.Op, tt.wantOp)
+			}
+		})
+	}
+}
+
+func TestTranslateReportsEveryInvalidRow(t *testing.T) {
+	rows := []Row{
+		{Line: 2, Entity: "campaign", ID: "111", Action: "pause"},
+		{Line: 3, Entity: "campaign", ID: "x", Action: "pause"},
+		{Line: 4, Entity: "adgroup", ID: "222", Action: "set-bid", Value: "1500000.0000001"},
+	}
+	ops, err := Translate("1", rows, budgets{})
+	if err == nil || ops != nil {
+		t.Fatalf("Translate returned %v, %v; want only an error", ops, err)
+	}
+	for _, want := range []string{"line 3: ", "line 4: "} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not report %q:\n%v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "line 2") {
+		t.Errorf("error reports the valid row:\n%v", err)
+	}
+}
+
+func TestTranslateConflicts(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    []Row
+		wantErr string
+	}{
+		{
+			name: "status and bid of one ad group",
+			rows: []Row{
+				{Line: 2, Entity: "adgroup", ID: "222", Action: "pause"},
+				{Line: 3, Entity: "adgroup", ID: "222", Action: "set-bid", Value: "1"},
+			},
+		},
+		{
+			name: "status twice",
+			rows: []Row{
+				{Line: 2, Entity: "campaign", ID: "111", Action: "pause"},
+				{Line: 3, Entity: "campaign", ID: "111", Action: "set-status", Value: "ENABLED"},
+			},
+			wantErr: "line 3: campaign 111 is already changed on line 2",
+		},
+		{
+			name: "removed and changed",
+			rows: []Row{
+				{Line: 2, Entity: "keyword", ID: "1~2", Action: "remove"},
+				{Line: 3, Entity: "keyword", ID: "1~2", Action: "set-bid", Value: "1"},
+			},
+			wantErr: "line 3: keyword 1~2 is already changed on line 2",
+		},
+		{
+			name: "a campaign's budget and the budget itself",
+			rows: []Row{
+				{Line: 2, Entity: "campaign", ID: "111", Action: "set-budget", Value: "20"},
+				{Line: 3, Entity: "budget", ID: "900", Action: "set-budget", Value: "30"},
+			},
+			wantErr: "line 3: budget 900 is already changed on line 2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops, err := Translate("1", tt.rows, budgets{})
+			if tt.wantErr == "" {
+				if err != nil || len(ops) != len(tt.rows) {
+					t.Errorf("got %d operations and %v, want %d and no error", len(ops), err, len(tt.rows))
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("error %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,21 +1,38 @@
 package config
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
+	"golang.org/x/oauth2/jwt"
 )
 
 const (
-	OAuthScope  = "https://www.googleapis.com/auth/adwords"
+	OAuthScope = "https://www.googleapis.com/auth/adwords"
+	RevokeURL  = "https://oauth2.googleapis.com/revoke"
+	// RedirectURL is the default OAuth redirect URI, used when no callback
+	// server is running (--no-browser) and for token refreshes.
 	RedirectURL = "http://localhost:8080"
+
+	// DefaultProfile is the profile backed by the legacy credentials.json file.
+	DefaultProfile = "default"
 )
 
+// activeProfile is the profile used by Load, Save, Clear, and Path.
+var activeProfile = DefaultProfile
+
 // Credentials holds all authentication data for the Google Ads API.
 type Credentials struct {
 	ClientID          string    `json:"client_id"`
@@ -26,6 +43,99 @@ type Credentials struct {
 	AccessToken       string    `json:"access_token"`
 	TokenType         string    `json:"token_type"`
 	TokenExpiry       time.Time `json:"token_expiry,omitempty"`
+
+	// APIVersion pins the Google Ads API version (e.g. "v23"); empty uses the
+	// CLI's default.
+	APIVersion string `json:"api_version,omitempty"`
+
+	// CacheTTL enables the read query cache for this profile (e.g. "5m");
+	// --cache-ttl overrides it.
+	CacheTTL string `json:"cache_ttl,omitempty"`
+
+	// DefaultAccount is the customer account commands act on when neither
+	// --account nor GADS_ACCOUNT is given.
+	DefaultAccount string `json:"default_account,omitempty"`
+
+	// Aliases maps names (e.g. "acme") to customer IDs, accepted wherever
+	// an account is.
+	Aliases map[string]string `json:"aliases,omitempty"`
+
+	// Service account mode (domain-wide delegation) replaces the refresh token.
+	ServiceAccountKeyFile string `json:"service_account_key_file,omitempty"`
+	ImpersonateUser       string `json:"impersonate_user,omitempty"`
+
+	// Storage is the secret backend: StorageFile (default) or StorageKeyring.
+	Storage string `json:"storage,omitempty"`
+
+	// Encrypted marks the file as passphrase-encrypted. It is detected from the
+	// file header on Load rather than stored in the JSON.
+	Encrypted bool `json:"-"`
+
+	// envSources maps a JSON key to the environment variable that overrode it.
+	envSources map[string]string
+	// fileValues holds the on-disk values of overridden keys so Save never
+	// writes environment-provided secrets to the credentials file.
+	fileValues map[string]string
+}
+
+// envOverlay describes one credential field that can be set from the environment.
+type envOverlay struct {
+	env   string
+	key   string
+	field func(*Credentials) *string
+}
+
+// envOverlays lists the supported environment variables. Environment values
+// take precedence over the credentials file.
+var envOverlays = []envOverlay{
+	{"GADS_CLIENT_ID", "client_id", func(c *Credentials) *string { return &c.ClientID }},
+	{"GADS_CLIENT_SECRET", "client_secret", func(c *Credentials) *string { return &c.ClientSecret }},
+	{"GADS_DEVELOPER_TOKEN", "developer_token", func(c *Credentials) *string { return &c.DeveloperToken }},
+	{"GADS_REFRESH_TOKEN", "refresh_token", func(c *Credentials) *string { return &c.RefreshToken }},
+	{"GADS_LOGIN_CUSTOMER_ID", "manager_customer_id", func(c *Credentials) *string { return &c.ManagerCustomerID }},
+}
+
+// applyEnv overlays environment variables on top of file-loaded credentials.
+func (c *Credentials) applyEnv() {
+	for _, o := range envOverlays {
+		v := os.Getenv(o.env)
+		if v == "" {
+			continue
+		}
+		if c.envSources == nil {
+			c.envSources = make(map[string]string)
+			c.fileValues = make(map[string]string)
+		}
+		p := o.field(c)
+		c.fileValues[o.key] = *p
+		*p = v
+		c.envSources[o.key] = o.env
+	}
+}
+
+// StorageBackend returns the effective secret backend name.
+func (c *Credentials) StorageBackend() string {
+	if c.Storage == StorageKeyring {
+		return StorageKeyring
+	}
+	return StorageFile
+}
+
+// IsServiceAccount reports whether credentials use a service account key.
+func (c *Credentials) IsServiceAccount() bool {
+	return c.ServiceAccountKeyFile != ""
+}
+
+// Authenticated reports whether credentials can obtain an access token,
+// either from a user refresh token or a service account key.
+func (c *Credentials) Authenticated() bool {
+	return c.RefreshToken != "" || c.IsServiceAccount()
+}
+
+// EnvSource returns the environment variable that supplied the given JSON key
+// (e.g. "refresh_token"), or "" if the value came from the credentials file.
+func (c *Credentials) EnvSource(key string) string {
+	return c.envSources[key]
 }
 
 // GoogleCredentialsFile represents the JSON downloaded from Google Cloud Console.
@@ -39,35 +149,118 @@ type googleCredentialsEntry struct {
 	ClientSecret string `json:"client_secret"`
 }
 
-func credentialsPath() (string, error) {
+// SetProfile selects the credentials profile. An empty name selects the default profile.
+func SetProfile(name string) error {
+	if name == "" {
+		name = DefaultProfile
+	}
+	if strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return fmt.Errorf("invalid profile name %q", name)
+	}
+	activeProfile = name
+	return nil
+}
+
+// ActiveProfile returns the name of the selected profile.
+func ActiveProfile() string {
+	return activeProfile
+}
+
+// Dir returns the configuration directory (e.g. ~/.config/gads on Linux),
+// which also holds the audit log.
+func Dir() (string, error) {
+	return configDir()
+}
+
+func configDir() (string, error) {
 	dir, err := os.UserConfigDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(dir, "gads", "credentials.json"), nil
+	return filepath.Join(dir, "gads"), nil
+}
+
+func credentialsPath() (string, error) {
+	return profilePath(activeProfile)
+}
+
+// profilePath returns the credentials file for a profile. The default profile
+// keeps using the legacy credentials.json so existing setups keep working.
+func profilePath(name string) (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	if name == DefaultProfile {
+		return filepath.Join(dir, "credentials.json"), nil
+	}
+	return filepath.Join(dir, "profiles", name+".json"), nil
 }
 
-// Load reads the credentials file. Returns empty Credentials (not error) if file doesn't exist.
+// ListProfiles returns the names of all profiles that have a credentials file, sorted.
+func ListProfiles() ([]string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	if _, err := os.Stat(filepath.Join(dir, "credentials.json")); err == nil {
+		names = append(names, DefaultProfile)
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, "profiles"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(e.Name(), ".json"))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ProfilePath returns the credentials file path of a named profile for display.
+func ProfilePath(name string) string {
+	p, _ := profilePath(name)
+	return p
+}
+
+// Load reads the credentials file and overlays GADS_* environment variables,
+// which take precedence. Returns empty Credentials (not error) if neither exists.
 func Load() (*Credentials, error) {
 	path, err := credentialsPath()
 	if err != nil {
 		return nil, err
 	}
+	var creds Credentials
 	data, err := os.ReadFile(path)
 	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			return &Credentials{}, nil
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	} else {
+		if isEncrypted(data) {
+			if data, err = decrypt(data); err != nil {
+				return nil, err
+			}
+			creds.Encrypted = true
+		}
+		if err := json.Unmarshal(data, &creds); err != nil {
+			return nil, err
 		}
-		return nil, err
 	}
-	var creds Credentials
-	if err := json.Unmarshal(data, &creds); err != nil {
-		return nil, err
+	if creds.Storage == StorageKeyring {
+		loadSecrets(&creds)
 	}
+	creds.applyEnv()
 	return &creds, nil
 }
 
-// Save writes the credentials file with 0600 permissions.
+// Save writes the credentials file with 0600 permissions. In keyring mode the
+// secret fields go to the OS keychain; if no keychain is available, Save
+// returns ErrKeyringUnavailable and writes nothing.
 func Save(creds *Credentials) error {
 	path, err := credentialsPath()
 	if err != nil {
@@ -76,19 +269,37 @@ func Save(creds *Credentials) error {
 	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
 		return err
 	}
-	data, err := json.MarshalIndent(creds, "", "  ")
+	out := *creds
+	for _, o := range envOverlays {
+		// Restore the file value unless the field was changed after loading.
+		if v, ok := creds.fileValues[o.key]; ok && *o.field(creds) == os.Getenv(o.env) {
+			*o.field(&out) = v
+		}
+	}
+	if out.Storage == StorageKeyring {
+		if err := storeSecrets(&out); err != nil {
+			return fmt.Errorf("%w: %v", ErrKeyringUnavailable, err)
+		}
+	}
+	data, err := json.MarshalIndent(&out, "", "  ")
 	if err != nil {
 		return err
 	}
+	if creds.Encrypted {
+		if data, err = encrypt(data); err != nil {
+			return fmt.Errorf("encrypting credentials: %w", err)
+		}
+	}
 	return os.WriteFile(path, data, 0600)
 }
 
-// Clear removes the credentials file.
+// Clear removes the credentials file and any keychain entry of the profile.
 func Clear() error {
 	path, err := credentialsPath()
 	if err != nil {
 		return err
 	}
+	_ = deleteSecrets()
 	err = os.Remove(path)
 	if errors.Is(err, os.ErrNotExist) {
 		return nil
@@ -102,15 +313,106 @@ func Path() string {
 	return p
 }
 
-// NewOAuthConfig creates an oauth2.Config for the Google Ads API.
-func NewOAuthConfig(creds *Credentials) *oauth2.Config {
+// NewOAuthConfig creates an oauth2.Config for the Google Ads API using the given redirect URL.
+func NewOAuthConfig(creds *Credentials, redirectURL string) *oauth2.Config {
 	return &oauth2.Config{
 		ClientID:     creds.ClientID,
 		ClientSecret: creds.ClientSecret,
 		Endpoint:     google.Endpoint,
 		Scopes:       []string{OAuthScope},
-		RedirectURL:  RedirectURL,
+		RedirectURL:  redirectURL,
+	}
+}
+
+// NewServiceAccountConfig builds a JWT config from the service account key file,
+// impersonating ImpersonateUser via domain-wide delegation when set.
+func NewServiceAccountConfig(creds *Credentials) (*jwt.Config, error) {
+	data, err := os.ReadFile(creds.ServiceAccountKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading service account key: %w", err)
+	}
+	cfg, err := google.JWTConfigFromJSON(data, OAuthScope)
+	if err != nil {
+		return nil, fmt.Errorf("parsing service account key: %w", err)
+	}
+	cfg.Subject = creds.ImpersonateUser
+	return cfg, nil
+}
+
+// RevokeToken revokes an OAuth token at Google. Revoking a refresh token also
+// invalidates the access tokens issued from it. The request uses ctx's
+// oauth2.HTTPClient when set.
+func RevokeToken(ctx context.Context, token string) error {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, RevokeURL, strings.NewReader(url.Values{"token": {token}}.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := oauth2.NewClient(ctx, nil).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// googleAdsKeys maps google-ads.yaml keys (as used by the official client
+// libraries) to credential fields.
+var googleAdsKeys = map[string]func(*Credentials) *string{
+	"client_id":          func(c *Credentials) *string { return &c.ClientID },
+	"client_secret":      func(c *Credentials) *string { return &c.ClientSecret },
+	"developer_token":    func(c *Credentials) *string { return &c.DeveloperToken },
+	"refresh_token":      func(c *Credentials) *string { return &c.RefreshToken },
+	"login_customer_id":  func(c *Credentials) *string { return &c.ManagerCustomerID },
+	"json_key_file_path": func(c *Credentials) *string { return &c.ServiceAccountKeyFile },
+	"impersonated_email": func(c *Credentials) *string { return &c.ImpersonateUser },
+}
+
+// ParseGoogleAdsYAML reads a google-ads.yaml file. Only the flat "key: value"
+// subset used for credentials is supported; comments and quotes are stripped.
+func ParseGoogleAdsYAML(path string) (*Credentials, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var creds Credentials
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || line == "---" {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected \"key: value\"", i+1)
+		}
+		field, known := googleAdsKeys[strings.TrimSpace(key)]
+		if !known {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if j := strings.Index(value, " #"); j >= 0 {
+			value = strings.TrimSpace(value[:j])
+		}
+		value = strings.Trim(value, `"'`)
+		*field(&creds) = value
+	}
+	return &creds, nil
+}
+
+// GoogleAdsFromEnv reads the GOOGLE_ADS_* environment variables used by the
+// official client libraries (e.g. GOOGLE_ADS_DEVELOPER_TOKEN).
+func GoogleAdsFromEnv() *Credentials {
+	var creds Credentials
+	for key, field := range googleAdsKeys {
+		*field(&creds) = os.Getenv("GOOGLE_ADS_" + strings.ToUpper(key))
 	}
+	return &creds
 }
 
 // ParseCredentialsFile parses a Google Cloud Console credentials JSON file.
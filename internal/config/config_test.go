@@ -0,0 +1,205 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// useConfigDir points the configuration directory at an empty temp dir and
+// selects the default profile for one test.
+func useConfigDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	for _, o := range envOverlays {
+		t.Setenv(o.env, "")
+	}
+	saved := activeProfile
+	t.Cleanup(func() { activeProfile = saved })
+	activeProfile = DefaultProfile
+	return dir
+}
+
+// writeCredentials writes a plain credentials file for the active profile.
+func writeCredentials(t *testing.T, data string) {
+	t.Helper()
+	path, err := credentialsPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadEnvOverlay(t *testing.T) {
+	tests := []struct {
+		name       string
+		file       string
+		env        map[string]string
+		want       Credentials
+		wantSource map[string]string
+	}{
+		{
+			name: "file only",
+			file: `{"client_id":"file-id","refresh_token":"file-token"}`,
+			want: Credentials{ClientID: "file-id", RefreshToken: "file-token"},
+		},
+		{
+			name:       "env overrides the file",
+			file:       `{"client_id":"file-id","refresh_token":"file-token","developer_token":"file-dev"}`,
+			env:        map[string]string{"GADS_REFRESH_TOKEN": "env-token", "GADS_LOGIN_CUSTOMER_ID": "1234567890"},
+			want:       Credentials{ClientID: "file-id", RefreshToken: "env-token", DeveloperToken: "file-dev", ManagerCustomerID: "1234567890"},
+			wantSource: map[string]string{"refresh_token": "GADS_REFRESH_TOKEN", "manager_customer_id": "GADS_LOGIN_CUSTOMER_ID"},
+		},
+		{
+			name: "env without a file",
+			env: map[string]string{
+				"GADS_CLIENT_ID": "env-id", "GADS_CLIENT_SECRET": "env-secret",
+				"GADS_DEVELOPER_TOKEN": "env-dev", "GADS_REFRESH_TOKEN": "env-token",
+			},
+			want: Credentials{ClientID: "env-id", ClientSecret: "env-secret", DeveloperToken: "env-dev", RefreshToken: "env-token"},
+			wantSource: map[string]string{
+				"client_id": "GADS_CLIENT_ID", "client_secret": "GADS_CLIENT_SECRET",
+				"developer_token": "GADS_DEVELOPER_TOKEN", "refresh_token": "GADS_REFRESH_TOKEN",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useConfigDir(t)
+			if tt.file != "" {
+				writeCredentials(t, tt.file)
+			}
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			creds, err := Load()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if creds.ClientID != tt.want.ClientID || creds.ClientSecret != tt.want.ClientSecret ||
+				creds.DeveloperToken != tt.want.DeveloperToken || creds.RefreshToken != tt.want.RefreshToken ||
+				creds.ManagerCustomerID != tt.want.ManagerCustomerID {
+				t.Errorf("loaded %+v, want %+v", *creds, tt.want)
+			}
+			for _, o := range envOverlays {
+				if got := creds.EnvSource(o.key); got != tt.wantSource[o.key] {
+					t.Errorf("EnvSource(%q) = %q, want %q", o.key, got, tt.wantSource[o.key])
+				}
+			}
+		})
+	}
+}
+
+func TestSaveKeepsEnvSecretsOutOfTheFile(t *testing.T) {
+	useConfigDir(t)
+	writeCredentials(t, `{"client_id":"file-id","refresh_token":"file-token"}`)
+	t.Setenv("GADS_REFRESH_TOKEN", "env-token")
+	t.Setenv("GADS_CLIENT_SECRET", "env-secret")
+
+	creds, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	creds.DefaultAccount = "1234567890"
+	if err := Save(creds); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"env-token", "env-secret"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("Save wrote the environment's %q to the file:\n%s", secret, data)
+		}
+	}
+	if !strings.Contains(string(data), "file-token") || !strings.Contains(string(data), "1234567890") {
+		t.Errorf("Save lost the file values or the change:\n%s", data)
+	}
+
+	// A value changed after loading is the user's and is saved.
+	creds.RefreshToken = "new-token"
+	if err := Save(creds); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(Path()); !strings.Contains(string(data), "new-token") {
+		t.Errorf("Save dropped the new refresh token:\n%s", data)
+	}
+}
+
+func TestProfilesAreIsolated(t *testing.T) {
+	dir := useConfigDir(t)
+	for _, p := range []struct{ profile, token string }{
+		{DefaultProfile, "default-token"},
+		{"work", "work-token"},
+		{"home", "home-token"},
+	} {
+		if err := SetProfile(p.profile); err != nil {
+			t.Fatal(err)
+		}
+		if err := Save(&Credentials{ClientID: p.profile, RefreshToken: p.token}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, p := range []struct{ profile, token, path string }{
+		{DefaultProfile, "default-token", filepath.Join(dir, "gads", "credentials.json")},
+		{"work", "work-token", filepath.Join(dir, "gads", "profiles", "work.json")},
+		{"home", "home-token", filepath.Join(dir, "gads", "profiles", "home.json")},
+	} {
+		if err := SetProfile(p.profile); err != nil {
+			t.Fatal(err)
+		}
+		if Path() != p.path {
+			t.Errorf("profile %s: path %s, want %s", p.profile, Path(), p.path)
+		}
+		creds, err := Load()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if creds.ClientID != p.profile || creds.RefreshToken != p.token {
+			t.Errorf("profile %s loaded %s/%s", p.profile, creds.ClientID, creds.RefreshToken)
+		}
+		data, err := os.ReadFile(p.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n := strings.Count(string(data), "-token"); n != 1 {
+			t.Errorf("profile %s file holds %d tokens:\n%s", p.profile, n, data)
+		}
+	}
+
+	// Clearing a profile leaves the others alone.
+	if err := SetProfile("work"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Clear(); err != nil {
+		t.Fatal(err)
+	}
+	profiles, err := ListProfiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(profiles, ",") != "default,home" {
+		t.Errorf("profiles after clearing work: %v", profiles)
+	}
+}
+
+func TestSetProfileRejectsPaths(t *testing.T) {
+	useConfigDir(t)
+	for _, name := range []string{"../default", "a/b", `a\b`, ".hidden"} {
+		if err := SetProfile(name); err == nil {
+			t.Errorf("SetProfile(%q) accepted a name outside the profiles directory", name)
+		}
+	}
+	if ActiveProfile() != DefaultProfile {
+		t.Errorf("a rejected name changed the profile to %q", ActiveProfile())
+	}
+}
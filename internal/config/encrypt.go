@@ -0,0 +1,137 @@
+package config
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// encryptedHeader prefixes passphrase-encrypted credential files. The rest of
+// the file is base64(salt || nonce || AES-256-GCM ciphertext).
+var encryptedHeader = []byte("gads-encrypted:v1\n")
+
+const (
+	saltSize   = 16
+	scryptN    = 1 << 15
+	scryptR    = 8
+	scryptP    = 1
+	aesKeySize = 32
+)
+
+// ErrWrongPassphrase is returned when an encrypted credentials file cannot be decrypted.
+var ErrWrongPassphrase = errors.New("wrong passphrase (or corrupted credentials file)")
+
+// PassphraseFunc supplies the passphrase for encrypted credentials. The CLI
+// replaces it with an interactive prompt; the default only reads GADS_PASSPHRASE.
+var PassphraseFunc = func() (string, error) {
+	if p := os.Getenv("GADS_PASSPHRASE"); p != "" {
+		return p, nil
+	}
+	return "", errors.New("credentials file is encrypted — set GADS_PASSPHRASE")
+}
+
+// cachedPassphrase avoids prompting more than once per process.
+var cachedPassphrase string
+
+func passphrase() (string, error) {
+	if cachedPassphrase != "" {
+		return cachedPassphrase, nil
+	}
+	p, err := PassphraseFunc()
+	if err != nil {
+		return "", err
+	}
+	if p == "" {
+		return "", errors.New("empty passphrase")
+	}
+	cachedPassphrase = p
+	return p, nil
+}
+
+// SetPassphrase sets the passphrase used for encryption, bypassing PassphraseFunc.
+func SetPassphrase(p string) {
+	cachedPassphrase = p
+}
+
+func isEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, encryptedHeader)
+}
+
+func deriveKey(pass string, salt []byte) ([]byte, error) {
+	return scrypt.Key([]byte(pass), salt, scryptN, scryptR, scryptP, aesKeySize)
+}
+
+func encrypt(plaintext []byte) ([]byte, error) {
+	pass, err := passphrase()
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	key, err := deriveKey(pass, salt)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	payload := append(append(salt, nonce...), gcm.Seal(nil, nonce, plaintext, encryptedHeader)...)
+
+	out := append([]byte{}, encryptedHeader...)
+	out = append(out, base64.StdEncoding.EncodeToString(payload)...)
+	return append(out, '\n'), nil
+}
+
+func decrypt(data []byte) ([]byte, error) {
+	payload, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(data[len(encryptedHeader):])))
+	if err != nil {
+		return nil, fmt.Errorf("corrupted encrypted credentials file: %w", err)
+	}
+	pass, err := passphrase()
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) < saltSize {
+		return nil, ErrWrongPassphrase
+	}
+	key, err := deriveKey(pass, payload[:saltSize])
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	rest := payload[saltSize:]
+	if len(rest) < gcm.NonceSize() {
+		return nil, ErrWrongPassphrase
+	}
+	plaintext, err := gcm.Open(nil, rest[:gcm.NonceSize()], rest[gcm.NonceSize():], encryptedHeader)
+	if err != nil {
+		cachedPassphrase = ""
+		return nil, ErrWrongPassphrase
+	}
+	return plaintext, nil
+}
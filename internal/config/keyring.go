@@ -0,0 +1,88 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/the20100/gads-cli/internal/auth"
+)
+
+const (
+	// StorageFile keeps every credential field in the JSON file (default).
+	StorageFile = "file"
+	// StorageKeyring keeps secret fields in the OS keychain and only
+	// non-secret metadata in the JSON file.
+	StorageKeyring = "keyring"
+)
+
+// ErrKeyringUnavailable is returned by Save when keyring mode secrets cannot
+// be stored in the OS keychain. Nothing is written then: moving the secrets
+// to the file takes setting Storage to StorageFile.
+var ErrKeyringUnavailable = errors.New("OS keychain not available")
+
+// secrets keeps the secret fields in keyring mode.
+var secrets auth.SecretStore = auth.Keyring{}
+
+// secretFields are the credential values moved to the keychain in keyring mode.
+type secretFields struct {
+	ClientSecret   string `json:"client_secret,omitempty"`
+	DeveloperToken string `json:"developer_token,omitempty"`
+	RefreshToken   string `json:"refresh_token,omitempty"`
+	AccessToken    string `json:"access_token,omitempty"`
+}
+
+// loadSecrets merges keychain secrets into creds. Missing entries or an
+// unavailable keychain leave the file values untouched.
+func loadSecrets(creds *Credentials) {
+	data, err := secrets.Get(activeProfile)
+	if err != nil {
+		return
+	}
+	var sf secretFields
+	if json.Unmarshal([]byte(data), &sf) != nil {
+		return
+	}
+	if sf.ClientSecret != "" {
+		creds.ClientSecret = sf.ClientSecret
+	}
+	if sf.DeveloperToken != "" {
+		creds.DeveloperToken = sf.DeveloperToken
+	}
+	if sf.RefreshToken != "" {
+		creds.RefreshToken = sf.RefreshToken
+	}
+	if sf.AccessToken != "" {
+		creds.AccessToken = sf.AccessToken
+	}
+}
+
+// storeSecrets moves the secret fields of creds into the keychain and blanks
+// them in creds. On failure creds is left unchanged.
+func storeSecrets(creds *Credentials) error {
+	data, err := json.Marshal(secretFields{
+		ClientSecret:   creds.ClientSecret,
+		DeveloperToken: creds.DeveloperToken,
+		RefreshToken:   creds.RefreshToken,
+		AccessToken:    creds.AccessToken,
+	})
+	if err != nil {
+		return err
+	}
+	if err := secrets.Set(activeProfile, string(data)); err != nil {
+		return err
+	}
+	creds.ClientSecret = ""
+	creds.DeveloperToken = ""
+	creds.RefreshToken = ""
+	creds.AccessToken = ""
+	return nil
+}
+
+// deleteSecrets removes the keychain entry of the active profile, if any.
+func deleteSecrets() error {
+	err := secrets.Delete(activeProfile)
+	if errors.Is(err, auth.ErrNotFound) {
+		return nil
+	}
+	return err
+}
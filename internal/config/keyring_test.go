@@ -0,0 +1,108 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/the20100/gads-cli/internal/auth"
+)
+
+// memoryStore is a SecretStore in memory, failing every call when err is set.
+type memoryStore struct {
+	entries map[string]string
+	err     error
+}
+
+func (m *memoryStore) Get(profile string) (string, error) {
+	if m.err != nil {
+		return "", m.err
+	}
+	v, ok := m.entries[profile]
+	if !ok {
+		return "", auth.ErrNotFound
+	}
+	return v, nil
+}
+
+func (m *memoryStore) Set(profile, data string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.entries[profile] = data
+	return nil
+}
+
+func (m *memoryStore) Delete(profile string) error {
+	if m.err != nil {
+		return m.err
+	}
+	delete(m.entries, profile)
+	return nil
+}
+
+// useSecretStore replaces the OS keychain with store for one test.
+func useSecretStore(t *testing.T, store auth.SecretStore) {
+	t.Helper()
+	saved := secrets
+	t.Cleanup(func() { secrets = saved })
+	secrets = store
+}
+
+func TestSaveKeyring(t *testing.T) {
+	useConfigDir(t)
+	store := &memoryStore{entries: map[string]string{}}
+	useSecretStore(t, store)
+
+	creds := &Credentials{ClientID: "id", ClientSecret: "client-secret", RefreshToken: "refresh-token", Storage: StorageKeyring}
+	if err := Save(creds); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"client-secret", "refresh-token"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("credentials file holds %q:\n%s", secret, data)
+		}
+		if !strings.Contains(store.entries[DefaultProfile], secret) {
+			t.Errorf("keychain entry lacks %q", secret)
+		}
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.ClientSecret != "client-secret" || loaded.RefreshToken != "refresh-token" {
+		t.Errorf("Load did not read the secrets back from the keychain: %+v", loaded)
+	}
+}
+
+func TestSaveKeyringUnavailable(t *testing.T) {
+	useConfigDir(t)
+	useSecretStore(t, &memoryStore{err: errors.New("no secret service")})
+
+	creds := &Credentials{ClientID: "id", ClientSecret: "client-secret", RefreshToken: "refresh-token", Storage: StorageKeyring}
+	err := Save(creds)
+	if !errors.Is(err, ErrKeyringUnavailable) {
+		t.Fatalf("Save returned %v, want ErrKeyringUnavailable", err)
+	}
+	if _, statErr := os.Stat(Path()); !os.IsNotExist(statErr) {
+		t.Errorf("Save wrote %s after the keychain failed", Path())
+	}
+	if creds.Storage != StorageKeyring {
+		t.Errorf("Save switched storage to %q", creds.Storage)
+	}
+
+	// Downgrading is the caller's explicit choice.
+	creds.Storage = StorageFile
+	if err := Save(creds); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(Path()); !strings.Contains(string(data), "refresh-token") {
+		t.Errorf("file storage did not keep the secrets:\n%s", data)
+	}
+}
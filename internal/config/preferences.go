@@ -0,0 +1,61 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Preferences are defaults for command-line flags (output format, --days,
+// ...), keyed by setting name. They are stored in config.json next to the
+// credentials and shared by every profile. Values are validated by the
+// commands that set and apply them.
+type Preferences map[string]string
+
+// PreferencesPath returns the preferences file path.
+func PreferencesPath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.json"), nil
+}
+
+// LoadPreferences reads the preferences file. A missing file yields empty
+// Preferences.
+func LoadPreferences() (Preferences, error) {
+	path, err := PreferencesPath()
+	if err != nil {
+		return nil, err
+	}
+	prefs := Preferences{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return prefs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &prefs); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return prefs, nil
+}
+
+// SavePreferences writes the preferences file.
+func SavePreferences(prefs Preferences) error {
+	path, err := PreferencesPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(prefs, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0600)
+}
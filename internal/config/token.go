@@ -0,0 +1,75 @@
+package config
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+// TokenSource caches access tokens for creds and, for user credentials,
+// persists refreshed tokens to disk. Invalidate drops the cached token so the
+// next call fetches a new one even if the old one has not expired yet.
+type TokenSource struct {
+	mu        sync.Mutex
+	creds     *Credentials
+	source    oauth2.TokenSource
+	newSource func(*oauth2.Token) oauth2.TokenSource
+	save      bool
+}
+
+// NewTokenSource returns the token source for the configured auth mode: a JWT
+// source for service accounts, or a refreshing source seeded with the stored
+// access token for user credentials. ctx supplies the HTTP client used for
+// token requests (see oauth2.HTTPClient).
+func NewTokenSource(ctx context.Context, creds *Credentials) (*TokenSource, error) {
+	s := &TokenSource{creds: creds}
+	if creds.IsServiceAccount() {
+		jwtCfg, err := NewServiceAccountConfig(creds)
+		if err != nil {
+			return nil, err
+		}
+		s.newSource = func(*oauth2.Token) oauth2.TokenSource { return jwtCfg.TokenSource(ctx) }
+	} else {
+		oauthCfg := NewOAuthConfig(creds, RedirectURL)
+		s.newSource = func(t *oauth2.Token) oauth2.TokenSource { return oauthCfg.TokenSource(ctx, t) }
+		// Env-only sessions (CI, containers) must not write a credentials file.
+		s.save = creds.EnvSource("refresh_token") == ""
+	}
+	s.source = s.newSource(&oauth2.Token{
+		AccessToken:  creds.AccessToken,
+		RefreshToken: creds.RefreshToken,
+		TokenType:    creds.TokenType,
+		Expiry:       creds.TokenExpiry,
+	})
+	return s, nil
+}
+
+// Token implements oauth2.TokenSource.
+func (s *TokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token, err := s.source.Token()
+	if err != nil {
+		return nil, err
+	}
+	if !s.creds.IsServiceAccount() && token.AccessToken != s.creds.AccessToken {
+		s.creds.AccessToken = token.AccessToken
+		s.creds.TokenExpiry = token.Expiry
+		if token.TokenType != "" {
+			s.creds.TokenType = token.TokenType
+		}
+		if s.save {
+			_ = Save(s.creds)
+		}
+	}
+	return token, nil
+}
+
+// Invalidate discards the cached access token, e.g. after the API rejected
+// it, so the next Token call refreshes.
+func (s *TokenSource) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.source = s.newSource(&oauth2.Token{RefreshToken: s.creds.RefreshToken})
+}
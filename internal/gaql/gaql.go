@@ -0,0 +1,113 @@
+// Package gaql builds Google Ads Query Language statements.
+package gaql
+
+import (
+	"fmt"
+	"strings"
+)
+
+var quoteEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`, `"`, `\"`)
+
+// Quote returns s as a single-quoted GAQL string literal, escaping
+// backslashes and quotes so user input cannot terminate the literal.
+// e.g. `O'Brien` → `'O\'Brien'`
+func Quote(s string) string {
+	return "'" + quoteEscaper.Replace(s) + "'"
+}
+
+// likeEscaper brackets the LIKE wildcards so they match literally.
+var likeEscaper = strings.NewReplacer(`[`, `[[]`, `%`, `[%]`, `_`, `[_]`)
+
+// Contains returns a quoted LIKE pattern matching strings that contain s,
+// with the wildcards in s matched literally.
+// e.g. `50% off` → `'%50[%] off%'`
+func Contains(s string) string {
+	return Quote("%" + likeEscaper.Replace(s) + "%")
+}
+
+// Builder assembles a GAQL query clause by clause. Clauses are always
+// rendered in GAQL order regardless of the order the methods are called.
+//
+//	q := gaql.Select("campaign.id", "campaign.name").
+//		From("campaign").
+//		Where("campaign.status != 'REMOVED'").
+//		WhereIf(id != "", "campaign.id = "+gaql.Quote(id)).
+//		OrderBy("campaign.id", false).
+//		String()
+type Builder struct {
+	fields   []string
+	resource string
+	where    []string
+	orderBy  []string
+	limit    int
+}
+
+// Select starts a query selecting fields.
+func Select(fields ...string) *Builder {
+	return (&Builder{}).Select(fields...)
+}
+
+// Select adds fields to the SELECT clause.
+func (b *Builder) Select(fields ...string) *Builder {
+	b.fields = append(b.fields, fields...)
+	return b
+}
+
+// From sets the resource to query.
+func (b *Builder) From(resource string) *Builder {
+	b.resource = resource
+	return b
+}
+
+// Where adds a condition; multiple conditions are joined with AND. Values
+// taken from user input must be wrapped with Quote.
+func (b *Builder) Where(cond string) *Builder {
+	if cond != "" {
+		b.where = append(b.where, cond)
+	}
+	return b
+}
+
+// WhereIf adds cond only when ok is true, for optional filters.
+func (b *Builder) WhereIf(ok bool, cond string) *Builder {
+	if ok {
+		return b.Where(cond)
+	}
+	return b
+}
+
+// OrderBy adds a sort field, descending when desc is true.
+func (b *Builder) OrderBy(field string, desc bool) *Builder {
+	if desc {
+		field += " DESC"
+	}
+	b.orderBy = append(b.orderBy, field)
+	return b
+}
+
+// Limit caps the number of rows; n <= 0 means no LIMIT clause.
+func (b *Builder) Limit(n int) *Builder {
+	b.limit = n
+	return b
+}
+
+// String renders the query, one clause per line.
+func (b *Builder) String() string {
+	var sb strings.Builder
+	sb.WriteString("SELECT " + strings.Join(b.fields, ", "))
+	sb.WriteString("\nFROM " + b.resource)
+	for i, cond := range b.where {
+		if i == 0 {
+			sb.WriteString("\nWHERE " + cond)
+		} else {
+			sb.WriteString("\n  AND " + cond)
+		}
+	}
+	if len(b.orderBy) > 0 {
+		sb.WriteString("\nORDER BY " + strings.Join(b.orderBy, ", "))
+	}
+	if b.limit > 0 {
+		sb.WriteString(fmt.Sprintf("\nLIMIT %d", b.limit))
+	}
+	return sb.String()
+}
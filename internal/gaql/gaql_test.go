@@ -0,0 +1,178 @@
+package gaql
+
+import (
+	"strings"
+	"testing"
+)
+
+// readLiteral reads the string literal at the start of q as GAQL does and
+// returns its value and the rest of q.
+func readLiteral(t *testing.T, q string) (value, rest string) {
+	t.Helper()
+	if !strings.HasPrefix(q, "'") {
+		t.Fatalf("%s does not start with a quote", q)
+	}
+	var b strings.Builder
+	for i := 1; i < len(q); i++ {
+		switch q[i] {
+		case '\\':
+			i++
+			if i < len(q) {
+				b.WriteByte(q[i])
+			}
+		case '\'':
+			return b.String(), q[i+1:]
+		default:
+			b.WriteByte(q[i])
+		}
+	}
+	t.Fatalf("%s is not terminated", q)
+	return "", ""
+}
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", `''`},
+		{"Brand", `'Brand'`},
+		{"O'Brien", `'O\'Brien'`},
+		{`say "hi"`, `'say \"hi\"'`},
+		{`C:\ads`, `'C:\\ads'`},
+		{`ends with \`, `'ends with \\'`},
+		{`\'`, `'\\\''`},
+		{"x' OR campaign.id > '0", `'x\' OR campaign.id > \'0'`},
+		{`x\' OR 1=1 --`, `'x\\\' OR 1=1 --'`},
+		{"Café 東京", `'Café 東京'`},
+	}
+	for _, tt := range tests {
+		got := Quote(tt.in)
+		if got != tt.want {
+			t.Errorf("Quote(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+		// Whatever the input, the result is exactly one literal holding it.
+		value, rest := readLiteral(t, got)
+		if value != tt.in || rest != "" {
+			t.Errorf("Quote(%q) reads back as %q followed by %q", tt.in, value, rest)
+		}
+	}
+}
+
+func TestContains(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"shoes", `'%shoes%'`},
+		{"50% off", `'%50[%] off%'`},
+		{"snake_case", `'%snake[_]case%'`},
+		{"[brand]", `'%[[]brand]%'`},
+		{"O'Brien", `'%O\'Brien%'`},
+	}
+	for _, tt := range tests {
+		if got := Contains(tt.in); got != tt.want {
+			t.Errorf("Contains(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestQuoteInQuery(t *testing.T) {
+	name := "x' OR campaign.status = 'ENABLED"
+	q := Select("campaign.id").
+		From("campaign").
+		Where("campaign.name = " + Quote(name)).
+		String()
+	where := q[strings.Index(q, "WHERE campaign.name = ")+len("WHERE campaign.name = "):]
+	value, rest := readLiteral(t, where)
+	if value != name || rest != "" {
+		t.Errorf("the name escaped its literal:\n%s", q)
+	}
+}
+
+func TestBuilder(t *testing.T) {
+	tests := []struct {
+		name string
+		b    *Builder
+		want string
+	}{
+		{
+			name: "select and from",
+			b:    Select("campaign.id", "campaign.name").From("campaign"),
+			want: "SELECT campaign.id, campaign.name\nFROM campaign",
+		},
+		{
+			name: "select called twice",
+			b:    Select("campaign.id").Select("metrics.clicks", "metrics.impressions").From("campaign"),
+			want: "SELECT campaign.id, metrics.clicks, metrics.impressions\nFROM campaign",
+		},
+		{
+			name: "conditions joined with AND",
+			b: Select("ad_group.id").From("ad_group").
+				Where("campaign.id = 1").
+				Where("ad_group.status != 'REMOVED'"),
+			want: "SELECT ad_group.id\nFROM ad_group\nWHERE campaign.id = 1\n  AND ad_group.status != 'REMOVED'",
+		},
+		{
+			name: "empty where is dropped",
+			b:    Select("campaign.id").From("campaign").Where("").Where("campaign.id = 1").Where(""),
+			want: "SELECT campaign.id\nFROM campaign\nWHERE campaign.id = 1",
+		},
+		{
+			name: "only empty wheres",
+			b:    Select("campaign.id").From("campaign").Where(""),
+			want: "SELECT campaign.id\nFROM campaign",
+		},
+		{
+			name: "WhereIf",
+			b: Select("customer_client.id").From("customer_client").
+				WhereIf(false, "customer_client.manager = true").
+				WhereIf(true, "customer_client.hidden = false").
+				WhereIf(true, ""),
+			want: "SELECT customer_client.id\nFROM customer_client\nWHERE customer_client.hidden = false",
+		},
+		{
+			name: "order by",
+			b: Select("campaign.id").From("campaign").
+				OrderBy("metrics.cost_micros", true).
+				OrderBy("campaign.id", false),
+			want: "SELECT campaign.id\nFROM campaign\nORDER BY metrics.cost_micros DESC, campaign.id",
+		},
+		{
+			name: "limit",
+			b:    Select("campaign.id").From("campaign").Limit(10),
+			want: "SELECT campaign.id\nFROM campaign\nLIMIT 10",
+		},
+		{
+			name: "no limit",
+			b:    Select("campaign.id").From("campaign").Limit(10).Limit(0),
+			want: "SELECT campaign.id\nFROM campaign",
+		},
+		{
+			name: "clauses in GAQL order whatever the call order",
+			b: (&Builder{}).
+				Limit(5).
+				OrderBy("campaign.name", false).
+				Where("campaign.status = 'ENABLED'").
+				From("campaign").
+				Select("campaign.id", "campaign.name").
+				WhereIf(true, "metrics.clicks > 0"),
+			want: "SELECT campaign.id, campaign.name\nFROM campaign\nWHERE campaign.status = 'ENABLED'\n  AND metrics.clicks > 0\nORDER BY campaign.name\nLIMIT 5",
+		},
+		{
+			name: "From replaces the resource",
+			b:    Select("campaign.id").From("ad_group").From("campaign"),
+			want: "SELECT campaign.id\nFROM campaign",
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.b.String(); got != tt.want {
+			t.Errorf("%s:\ngot:\n%s\nwant:\n%s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBuilderStringIsRepeatable(t *testing.T) {
+	b := Select("campaign.id").From("campaign").Where("campaign.id = 1").OrderBy("campaign.id", true)
+	if first, second := b.String(), b.String(); first != second {
+		t.Errorf("String changed between calls:\n%s\n---\n%s", first, second)
+	}
+}
@@ -0,0 +1,75 @@
+package output
+
+import "strconv"
+
+// ansiReverse marks table cells that changed since the previous refresh.
+const ansiReverse = "\x1b[7m"
+
+var (
+	// highlightChanges makes PrintTable mark cells that differ from the
+	// previous table it printed. Set for --watch.
+	highlightChanges bool
+
+	// previousCells holds the cells of the previous table, keyed by row and
+	// column; see cellKeys.
+	previousCells map[string]string
+)
+
+// SetHighlightChanges makes each table highlight the cells that changed
+// since the one before it, as in a refreshing --watch view.
+func SetHighlightChanges(enabled bool) {
+	highlightChanges = enabled
+	previousCells = nil
+}
+
+// cellKeys returns a key per cell that survives reordering: rows are
+// identified by their first cell (campaign, ad group…), columns by header.
+func cellKeys(headers []string, rows [][]string) [][]string {
+	seen := make(map[string]int)
+	keys := make([][]string, len(rows))
+	for r, row := range rows {
+		id := ""
+		if len(row) > 0 {
+			id = stripANSI(row[0])
+		}
+		seen[id]++
+		if n := seen[id]; n > 1 {
+			id += "#" + strconv.Itoa(n)
+		}
+		keys[r] = make([]string, len(row))
+		for i := range row {
+			if i < len(headers) {
+				keys[r][i] = id + "\x00" + headers[i]
+			}
+		}
+	}
+	return keys
+}
+
+// markChanges highlights the cells of shown whose value in rows differs
+// from the previous table, and remembers rows for the next call. New rows
+// are not highlighted, nor is anything on the first call.
+func markChanges(headers []string, rows, shown [][]string) [][]string {
+	keys := cellKeys(headers, rows)
+	current := make(map[string]string)
+	marked := make([][]string, len(shown))
+	for r, row := range shown {
+		marked[r] = row
+		copied := false
+		for i, cell := range row {
+			value := stripANSI(rows[r][i])
+			current[keys[r][i]] = value
+			old, known := previousCells[keys[r][i]]
+			if !known || old == value || !colorEnabled() {
+				continue
+			}
+			if !copied {
+				marked[r] = append([]string(nil), row...)
+				copied = true
+			}
+			marked[r][i] = ansiReverse + stripANSI(cell) + ansiReset
+		}
+	}
+	previousCells = current
+	return marked
+}
@@ -0,0 +1,108 @@
+package output
+
+import (
+	"os"
+	"regexp"
+	"strings"
+)
+
+// noColor disables ANSI colors. Set from --no-color.
+var noColor bool
+
+// SetNoColor disables colored table output.
+func SetNoColor(disabled bool) {
+	noColor = disabled
+}
+
+// colorEnabled reports whether table cells may be colored: only for plain
+// tables on a terminal, and never with --no-color, NO_COLOR (https://no-color.org)
+// or TERM=dumb.
+func colorEnabled() bool {
+	if noColor || csvOutput || markdownOutput || os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return toTerminal()
+}
+
+const (
+	ansiReset  = "\x1b[0m"
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+	ansiDim    = "\x1b[2m"
+)
+
+// statusColors maps statuses, in upper case, to their color.
+var statusColors = map[string]string{
+	"ENABLED":               ansiGreen,
+	"APPROVED":              ansiGreen,
+	"ELIGIBLE":              ansiGreen,
+	"ACTIVE":                ansiGreen,
+	"OPEN":                  ansiGreen,
+	"PAUSED":                ansiYellow,
+	"PENDING":               ansiYellow,
+	"UNDER_REVIEW":          ansiYellow,
+	"APPROVED_LIMITED":      ansiYellow,
+	"AREA_OF_INTEREST_ONLY": ansiYellow,
+	"LIMITED":               ansiYellow,
+	"LEARNING":              ansiYellow,
+	"REMOVED":               ansiRed,
+	"DISAPPROVED":           ansiRed,
+	"CANCELLED":             ansiRed,
+	"CANCELED":              ansiRed,
+	"REFUSED":               ansiRed,
+	"HALTED":                ansiRed,
+	"CLOSED":                ansiRed,
+	"SUSPENDED":             ansiRed,
+	"NOT_ELIGIBLE":          ansiRed,
+	"MISCONFIGURED":         ansiRed,
+	"ENDED":                 ansiRed,
+}
+
+// Colorize colors a status for table output: ENABLED green, PAUSED yellow,
+// REMOVED or DISAPPROVED red. Unknown statuses, and any status when color
+// is off, are returned unchanged.
+func Colorize(status string) string {
+	if !colorEnabled() {
+		return status
+	}
+	if c, ok := statusColors[strings.ToUpper(status)]; ok {
+		return c + status + ansiReset
+	}
+	return status
+}
+
+// Dim fades a table cell, e.g. one of a removed entity. Colors inside the
+// cell are kept. The cell is returned unchanged when color is off.
+func Dim(cell string) string {
+	if !colorEnabled() || cell == "" {
+		return cell
+	}
+	return ansiDim + strings.ReplaceAll(cell, ansiReset, ansiReset+ansiDim) + ansiReset
+}
+
+// ColorizeDelta colors a signed change, e.g. "+12.5%" green and "-3" red,
+// for comparison tables. Zero and unsigned values are returned unchanged.
+func ColorizeDelta(delta string) string {
+	if !colorEnabled() {
+		return delta
+	}
+	switch {
+	case strings.HasPrefix(delta, "+") && strings.Trim(delta, "+0.,%") != "":
+		return ansiGreen + delta + ansiReset
+	case strings.HasPrefix(delta, "-") && strings.Trim(delta, "-0.,%") != "":
+		return ansiRed + delta + ansiReset
+	}
+	return delta
+}
+
+// ansiEscape matches the SGR sequences Colorize emits.
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// stripANSI removes color sequences, e.g. from cells written as CSV.
+func stripANSI(s string) string {
+	if !strings.Contains(s, "\x1b") {
+		return s
+	}
+	return ansiEscape.ReplaceAllString(s, "")
+}
@@ -0,0 +1,128 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// useTerminal makes output look like it goes to a terminal, or not, with
+// color settings cleared, for one test.
+func useTerminal(t *testing.T, terminal bool) {
+	t.Helper()
+	savedCheck, savedNoColor, savedCSV, savedMarkdown := stdoutIsTerminal, noColor, csvOutput, markdownOutput
+	t.Cleanup(func() {
+		stdoutIsTerminal, noColor, csvOutput, markdownOutput = savedCheck, savedNoColor, savedCSV, savedMarkdown
+	})
+	stdoutIsTerminal = func() bool { return terminal }
+	noColor, csvOutput, markdownOutput = false, false, false
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("TERM", "xterm-256color")
+}
+
+func TestColorize(t *testing.T) {
+	useTerminal(t, true)
+	tests := []struct {
+		status, want string
+	}{
+		{"ENABLED", ansiGreen + "ENABLED" + ansiReset},
+		{"enabled", ansiGreen + "enabled" + ansiReset},
+		{"PAUSED", ansiYellow + "PAUSED" + ansiReset},
+		{"REMOVED", ansiRed + "REMOVED" + ansiReset},
+		{"CANCELED", ansiRed + "CANCELED" + ansiReset},
+		{"UNKNOWN", "UNKNOWN"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Colorize(tt.status); got != tt.want {
+			t.Errorf("Colorize(%q) = %q, want %q", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestDim(t *testing.T) {
+	useTerminal(t, true)
+	tests := []struct {
+		cell, want string
+	}{
+		{"Old campaign", ansiDim + "Old campaign" + ansiReset},
+		{"", ""},
+		// A reset inside the cell would end the dimming: it is dimmed again.
+		{Colorize("REMOVED"), ansiDim + ansiRed + "REMOVED" + ansiReset + ansiDim + ansiReset},
+	}
+	for _, tt := range tests {
+		if got := Dim(tt.cell); got != tt.want {
+			t.Errorf("Dim(%q) = %q, want %q", tt.cell, got, tt.want)
+		}
+	}
+}
+
+func TestColorizeDelta(t *testing.T) {
+	useTerminal(t, true)
+	tests := []struct {
+		delta, want string
+	}{
+		{"+12.5%", ansiGreen + "+12.5%" + ansiReset},
+		{"+3", ansiGreen + "+3" + ansiReset},
+		{"+1,234", ansiGreen + "+1,234" + ansiReset},
+		{"-3", ansiRed + "-3" + ansiReset},
+		{"-0.5%", ansiRed + "-0.5%" + ansiReset},
+		{"+0", "+0"},
+		{"+0.0%", "+0.0%"},
+		{"-0.00", "-0.00"},
+		{"0", "0"},
+		{"12", "12"},
+		{"-", "-"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := ColorizeDelta(tt.delta); got != tt.want {
+			t.Errorf("ColorizeDelta(%q) = %q, want %q", tt.delta, got, tt.want)
+		}
+	}
+}
+
+func TestColorOff(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T)
+	}{
+		{"not a terminal", func(t *testing.T) { stdoutIsTerminal = func() bool { return false } }},
+		{"NO_COLOR", func(t *testing.T) { t.Setenv("NO_COLOR", "1") }},
+		{"TERM=dumb", func(t *testing.T) { t.Setenv("TERM", "dumb") }},
+		{"--no-color", func(t *testing.T) { SetNoColor(true) }},
+		{"--csv", func(t *testing.T) { SetCSV(true) }},
+		{"--markdown", func(t *testing.T) { markdownOutput = true }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useTerminal(t, true)
+			tt.setup(t)
+			for _, got := range []string{Colorize("ENABLED"), Dim("Old campaign"), ColorizeDelta("+12%"), ColorizeDelta("-3")} {
+				if strings.Contains(got, "\x1b") {
+					t.Errorf("colored with color off: %q", got)
+				}
+			}
+		})
+	}
+}
+
+func TestCSVStripsColor(t *testing.T) {
+	useTerminal(t, true)
+	cells := [][]string{{"1", Colorize("ENABLED"), Dim("Old"), ColorizeDelta("+5%")}}
+	if !strings.Contains(cells[0][1], "\x1b") {
+		t.Fatal("cells are not colored on a terminal")
+	}
+
+	var buf bytes.Buffer
+	saved := out
+	t.Cleanup(func() { out = saved })
+	out = &buf
+	SetCSV(true)
+	if err := PrintTable([]string{"ID", "STATUS", "NAME", "CHANGE"}, cells); err != nil {
+		t.Fatal(err)
+	}
+	if want := "ID,STATUS,NAME,CHANGE\n1,ENABLED,Old,+5%\n"; buf.String() != want {
+		t.Errorf("CSV:\n%q\nwant:\n%q", buf.String(), want)
+	}
+}
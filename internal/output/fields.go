@@ -0,0 +1,247 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// fieldPaths restricts output to these fields. Set from --fields.
+var fieldPaths []string
+
+// SetFields restricts JSON output to the given dot-paths (e.g. "campaign.id")
+// and table/CSV output to the matching columns, in that order. Empty entries
+// are ignored; no fields means everything is printed.
+func SetFields(fields []string) {
+	fieldPaths = nil
+	for _, f := range fields {
+		if f = strings.TrimSpace(f); f != "" {
+			fieldPaths = append(fieldPaths, f)
+		}
+	}
+}
+
+// normalizeField folds case and separators so "cost_micros", "costMicros"
+// and "COST MICROS" compare equal.
+func normalizeField(s string) string {
+	s = strings.ToLower(s)
+	return strings.NewReplacer("_", "", "-", "", " ", "").Replace(s)
+}
+
+// Project keeps only the --fields paths in v: in each element when v encodes
+// an array (a list of rows), otherwise in v itself. Without --fields it
+// returns v unchanged.
+func Project(v any) (any, error) {
+	if len(fieldPaths) == 0 {
+		return v, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var raws []json.RawMessage
+	single := json.Unmarshal(data, &raws) != nil
+	if single {
+		raws = []json.RawMessage{data}
+	}
+	rows := make([]map[string]any, len(raws))
+	for i, raw := range raws {
+		if err := json.Unmarshal(raw, &rows[i]); err != nil {
+			return nil, fmt.Errorf("--fields needs object rows: %w", err)
+		}
+	}
+	// Resolve the fields against every row first, so an unknown field fails
+	// before anything is printed and a row missing one gets the same keys.
+	p := NewProjection()
+	for _, row := range rows {
+		p.resolve(row)
+	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	out := make([]any, len(rows))
+	for i, row := range rows {
+		out[i] = p.project(row)
+	}
+	if single {
+		return out[0], nil
+	}
+	return out, nil
+}
+
+// Projection projects rows to --fields one at a time, for output written as
+// it streams in. A field missing from a row is null in that row; Err reports
+// the fields no row had once all rows are written.
+type Projection struct {
+	keys      [][]string      // key path of each field, from the first row that has it
+	available map[string]bool // leaf paths of the rows, for the unknown field error
+	rows      int
+}
+
+// NewProjection returns a Projection for the current --fields.
+func NewProjection() *Projection {
+	return &Projection{keys: make([][]string, len(fieldPaths)), available: map[string]bool{}}
+}
+
+// Row returns a copy of the JSON object encoding v with only the --fields
+// paths, keeping their nesting. Without --fields it returns v unchanged.
+func (p *Projection) Row(v any) (any, error) {
+	if len(fieldPaths) == 0 {
+		return v, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var row map[string]any
+	if err := json.Unmarshal(data, &row); err != nil {
+		return nil, fmt.Errorf("--fields needs object rows: %w", err)
+	}
+	p.resolve(row)
+	return p.project(row), nil
+}
+
+// Err reports the first --fields path that none of the rows had. It is nil
+// when there were no rows.
+func (p *Projection) Err() error {
+	if p.rows == 0 {
+		return nil
+	}
+	for i, keys := range p.keys {
+		if keys == nil {
+			available := make([]string, 0, len(p.available))
+			for path := range p.available {
+				available = append(available, path)
+			}
+			sort.Strings(available)
+			return fmt.Errorf("unknown field %q; available: %s", fieldPaths[i], strings.Join(available, ", "))
+		}
+	}
+	return nil
+}
+
+// resolve records the key paths row has for the fields not yet resolved.
+func (p *Projection) resolve(row map[string]any) {
+	p.rows++
+	for _, path := range leafPaths(row, "") {
+		p.available[path] = true
+	}
+	for i, path := range fieldPaths {
+		if p.keys[i] == nil {
+			p.keys[i], _ = lookupPath(row, path)
+		}
+	}
+}
+
+// project returns a copy of row with only fieldPaths. Path segments match
+// keys by normalizeField, so GAQL-style snake_case paths work on the API's
+// camelCase JSON.
+func (p *Projection) project(row map[string]any) map[string]any {
+	out := map[string]any{}
+	for i, path := range fieldPaths {
+		keys, value := lookupPath(row, path)
+		if keys == nil {
+			keys = p.keys[i]
+			if keys == nil {
+				keys = strings.Split(path, ".")
+			}
+		}
+		dst := out
+		for _, key := range keys[:len(keys)-1] {
+			next, ok := dst[key].(map[string]any)
+			if !ok {
+				next = map[string]any{}
+				dst[key] = next
+			}
+			dst = next
+		}
+		dst[keys[len(keys)-1]] = value
+	}
+	return out
+}
+
+// lookupPath returns the keys of row matching the dot-path and the value
+// there, or nil keys when row does not have it.
+func lookupPath(row map[string]any, path string) ([]string, any) {
+	var keys []string
+	node := any(row)
+	for _, seg := range strings.Split(path, ".") {
+		obj, ok := node.(map[string]any)
+		if !ok {
+			return nil, nil
+		}
+		key, found := lookupKey(obj, seg)
+		if !found {
+			return nil, nil
+		}
+		keys = append(keys, key)
+		node = obj[key]
+	}
+	return keys, node
+}
+
+// lookupKey finds the key of obj matching seg exactly or by normalizeField.
+func lookupKey(obj map[string]any, seg string) (string, bool) {
+	if _, ok := obj[seg]; ok {
+		return seg, true
+	}
+	want := normalizeField(seg)
+	for k := range obj {
+		if normalizeField(k) == want {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// leafPaths lists the dot-paths of the non-object values in obj, sorted.
+func leafPaths(obj map[string]any, prefix string) []string {
+	var paths []string
+	for k, v := range obj {
+		if child, ok := v.(map[string]any); ok && len(child) > 0 {
+			paths = append(paths, leafPaths(child, prefix+k+".")...)
+			continue
+		}
+		paths = append(paths, prefix+k)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// selectColumns returns headers and rows reduced to fieldPaths, in that
+// order. A field matches a header by name ("DAILY BUDGET", "daily_budget")
+// or by its last path segment ("metrics.clicks" matches "CLICKS").
+func selectColumns(headers []string, rows [][]string) ([]string, [][]string, error) {
+	index := make([]int, len(fieldPaths))
+	for i, f := range fieldPaths {
+		index[i] = -1
+		last := f[strings.LastIndex(f, ".")+1:]
+		for j, h := range headers {
+			if normalizeField(h) == normalizeField(f) {
+				index[i] = j
+				break
+			}
+			if index[i] < 0 && normalizeField(h) == normalizeField(last) {
+				index[i] = j
+			}
+		}
+		if index[i] < 0 {
+			return nil, nil, fmt.Errorf("unknown field %q; available: %s", f, strings.Join(headers, ", "))
+		}
+	}
+	outHeaders := make([]string, len(index))
+	for i, j := range index {
+		outHeaders[i] = headers[j]
+	}
+	outRows := make([][]string, len(rows))
+	for r, row := range rows {
+		outRows[r] = make([]string, len(index))
+		for i, j := range index {
+			if j < len(row) {
+				outRows[r][i] = row[j]
+			}
+		}
+	}
+	return outHeaders, outRows, nil
+}
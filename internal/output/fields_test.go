@@ -0,0 +1,114 @@
+package output
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func useFields(t *testing.T, fields ...string) {
+	t.Helper()
+	SetFields(fields)
+	t.Cleanup(func() { SetFields(nil) })
+}
+
+func TestProject(t *testing.T) {
+	rows := []map[string]any{
+		{"campaign": map[string]any{"id": "1", "name": "Brand"}, "metrics": map[string]any{"clicks": "10", "costMicros": "500"}},
+		{"campaign": map[string]any{"id": "2", "name": "Search"}, "metrics": map[string]any{"clicks": "4"}},
+	}
+	tests := []struct {
+		name    string
+		v       any
+		fields  []string
+		want    string
+		wantErr string
+	}{
+		{
+			name:   "field in every row",
+			v:      rows,
+			fields: []string{"campaign.id", "metrics.clicks"},
+			want:   `[{"campaign":{"id":"1"},"metrics":{"clicks":"10"}},{"campaign":{"id":"2"},"metrics":{"clicks":"4"}}]`,
+		},
+		{
+			name:   "field in one row only",
+			v:      rows,
+			fields: []string{"campaign.id", "metrics.cost_micros"},
+			want:   `[{"campaign":{"id":"1"},"metrics":{"costMicros":"500"}},{"campaign":{"id":"2"},"metrics":{"costMicros":null}}]`,
+		},
+		{
+			name:   "field missing from the first row",
+			v:      []map[string]any{rows[1], rows[0]},
+			fields: []string{"metrics.cost_micros"},
+			want:   `[{"metrics":{"costMicros":null}},{"metrics":{"costMicros":"500"}}]`,
+		},
+		{
+			name:    "field in no row",
+			v:       rows,
+			fields:  []string{"campaign.status"},
+			wantErr: `unknown field "campaign.status"; available: campaign.id, campaign.name, metrics.clicks, metrics.costMicros`,
+		},
+		{
+			name:   "single object",
+			v:      rows[0],
+			fields: []string{"CAMPAIGN.NAME"},
+			want:   `{"campaign":{"name":"Brand"}}`,
+		},
+		{
+			name:   "no rows",
+			v:      []map[string]any{},
+			fields: []string{"campaign.status"},
+			want:   `[]`,
+		},
+		{
+			name:    "not objects",
+			v:       []string{"a"},
+			fields:  []string{"campaign.id"},
+			wantErr: "--fields needs object rows",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useFields(t, tt.fields...)
+			got, err := Project(tt.v)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tt.want {
+				t.Errorf("Project = %s, want %s", b, tt.want)
+			}
+		})
+	}
+}
+
+func TestProjectionStream(t *testing.T) {
+	useFields(t, "campaign.id", "metrics.conversions")
+	p := NewProjection()
+	got, err := p.Row(map[string]any{"campaign": map[string]any{"id": "1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := json.Marshal(got); string(b) != `{"campaign":{"id":"1"},"metrics":{"conversions":null}}` {
+		t.Errorf("Row = %s", b)
+	}
+	if err := p.Err(); err == nil || !strings.Contains(err.Error(), `unknown field "metrics.conversions"`) {
+		t.Errorf("Err = %v, want metrics.conversions unknown", err)
+	}
+
+	if _, err := p.Row(map[string]any{"campaign": map[string]any{"id": "2"}, "metrics": map[string]any{"conversions": 3}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Err(); err != nil {
+		t.Errorf("Err = %v after a row with the field", err)
+	}
+}
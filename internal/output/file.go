@@ -0,0 +1,83 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/mattn/go-isatty"
+)
+
+var (
+	// out receives the command's result: stdout, or the temp file of --output.
+	out io.Writer = os.Stdout
+
+	// outFile is the --output temp file, renamed to outPath by Finish.
+	outFile *os.File
+	outPath string
+
+	// rowsWritten counts result rows for Finish's summary.
+	rowsWritten int
+)
+
+// Writer returns where results are written: stdout, or the --output file.
+func Writer() io.Writer {
+	return out
+}
+
+// AddRows counts n rows written directly to Writer, e.g. by streaming.
+func AddRows(n int) {
+	rowsWritten += n
+}
+
+// SetFile sends results to path instead of stdout. They go to a temp file in
+// the same directory until Finish renames it, so a failed command never
+// leaves a truncated file behind.
+func SetFile(path string) error {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating output file: %w", err)
+	}
+	out, outFile, outPath = f, f, path
+	return nil
+}
+
+// Finish completes --output: on success the temp file replaces the output
+// file and the number of rows written is returned; otherwise it is removed.
+// Without --output it does nothing.
+func Finish(success bool) (int, error) {
+	if outFile == nil {
+		return 0, nil
+	}
+	f := outFile
+	out, outFile = os.Stdout, nil
+	closeErr := f.Close()
+	if !success || closeErr != nil {
+		os.Remove(f.Name())
+		return 0, closeErr
+	}
+	if err := os.Chmod(f.Name(), 0o644); err != nil {
+		os.Remove(f.Name())
+		return 0, err
+	}
+	if err := os.Rename(f.Name(), outPath); err != nil {
+		os.Remove(f.Name())
+		return 0, fmt.Errorf("writing %s: %w", outPath, err)
+	}
+	return rowsWritten, nil
+}
+
+// toTerminal reports whether results go to an interactive terminal.
+func toTerminal() bool {
+	if outFile != nil {
+		return false
+	}
+	return stdoutIsTerminal()
+}
+
+// stdoutIsTerminal reports whether stdout is an interactive terminal. Tests
+// replace it to check terminal output.
+var stdoutIsTerminal = func() bool {
+	return isatty.IsTerminal(os.Stdout.Fd()) || isatty.IsCygwinTerminal(os.Stdout.Fd())
+}
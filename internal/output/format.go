@@ -0,0 +1,116 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"text/template"
+)
+
+// rowTemplate renders each result row. Set from --format.
+var rowTemplate *template.Template
+
+// templateFuncs are the helpers available in --format templates.
+var templateFuncs = template.FuncMap{
+	// micros renders a micros amount as currency: {{micros .Metrics.CostMicros}}.
+	"micros": func(v any) (string, error) {
+		n, err := toFloat(v)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%.2f", n/1_000_000), nil
+	},
+	// pct renders a 0–1 fraction as a percentage: {{pct .Metrics.Ctr}}.
+	"pct": func(v any) (string, error) {
+		n, err := toFloat(v)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%.2f%%", n*100), nil
+	},
+	// trunc shortens a string to n characters: {{trunc 20 .Campaign.Name}}.
+	"trunc": func(n int, s string) string {
+		return Truncate(s, n)
+	},
+}
+
+// toFloat converts a numeric template argument, including named integer
+// types such as api.Int64.
+func toFloat(v any) (float64, error) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	}
+	return 0, fmt.Errorf("expected a number, got %T", v)
+}
+
+// SetFormat parses the --format template, applied to each result row.
+func SetFormat(format string) error {
+	if format == "" {
+		rowTemplate = nil
+		return nil
+	}
+	t, err := template.New("format").Funcs(templateFuncs).Option("missingkey=error").Parse(format)
+	if err != nil {
+		return fmt.Errorf("invalid --format template: %w", err)
+	}
+	rowTemplate = t
+	return nil
+}
+
+// HasFormat reports whether --format is set.
+func HasFormat() bool {
+	return rowTemplate != nil
+}
+
+// ExecFormat renders one row with the --format template, ending it with a
+// newline when the template does not.
+func ExecFormat(w io.Writer, row any) error {
+	// Raw API rows are decoded so templates can address their keys.
+	if raw, ok := row.(json.RawMessage); ok {
+		var decoded any
+		if err := json.Unmarshal(raw, &decoded); err != nil {
+			return fmt.Errorf("--format: %w", err)
+		}
+		row = decoded
+	}
+	var buf bytes.Buffer
+	if err := rowTemplate.Execute(&buf, row); err != nil {
+		return fmt.Errorf("--format: %w", err)
+	}
+	if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// printFormatted renders each element of v (or v itself when it is not a
+// slice) with the --format template. Nothing is written unless every row
+// renders, so a missing field fails before any output.
+func printFormatted(v any) error {
+	var buf bytes.Buffer
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		for i := 0; i < rv.Len(); i++ {
+			if err := ExecFormat(&buf, rv.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		rowsWritten += rv.Len()
+	} else {
+		if err := ExecFormat(&buf, v); err != nil {
+			return err
+		}
+		rowsWritten++
+	}
+	_, err := buf.WriteTo(out)
+	return err
+}
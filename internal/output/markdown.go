@@ -0,0 +1,73 @@
+package output
+
+import (
+	"bufio"
+	"strings"
+)
+
+var (
+	// markdownOutput makes PrintTable and PrintKeyValue write GitHub-flavored
+	// markdown tables. Set from --markdown.
+	markdownOutput bool
+
+	// commandHeader is the invocation printed in a code block above the first
+	// markdown table; empty with --no-command-header.
+	commandHeader string
+
+	// markdownTables counts tables written, to separate consecutive ones.
+	markdownTables int
+)
+
+// SetMarkdown switches table output to markdown, headed by command unless
+// it is empty.
+func SetMarkdown(enabled bool, command string) {
+	markdownOutput = enabled
+	commandHeader = command
+}
+
+// IsMarkdown returns true when tables are written as markdown (--markdown).
+func IsMarkdown() bool {
+	return markdownOutput
+}
+
+// printMarkdown writes headers and rows as a markdown table, with the
+// columns marked in right right-aligned.
+func printMarkdown(headers []string, rows [][]string, right []bool) error {
+	rowsWritten += len(rows)
+	w := bufio.NewWriter(out)
+	if markdownTables > 0 {
+		// A blank line keeps the next table from continuing this one.
+		w.WriteString("\n")
+	}
+	markdownTables++
+	if commandHeader != "" {
+		w.WriteString("```\n" + commandHeader + "\n```\n\n")
+		// One header per run, even when a command prints several tables.
+		commandHeader = ""
+	}
+	writeMarkdownRow(w, headers)
+	separators := make([]string, len(headers))
+	for i := range headers {
+		separators[i] = "---"
+		if i < len(right) && right[i] {
+			separators[i] = "---:"
+		}
+	}
+	writeMarkdownRow(w, separators)
+	for _, row := range rows {
+		writeMarkdownRow(w, row)
+	}
+	return w.Flush()
+}
+
+// markdownEscaper keeps cell text from breaking the table: pipes are
+// escaped and line breaks become spaces.
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ")
+
+func writeMarkdownRow(w *bufio.Writer, cells []string) {
+	w.WriteString("|")
+	for _, cell := range cells {
+		w.WriteString(" " + markdownEscaper.Replace(stripANSI(cell)) + " |")
+	}
+	w.WriteString("\n")
+}
@@ -1,21 +1,83 @@
 package output
 
 import (
+	"bufio"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"os"
+	"reflect"
+	"regexp"
 	"strings"
-	"text/tabwriter"
 	"time"
 
-	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 )
 
+// csvOutput makes PrintTable and PrintKeyValue write CSV. Set from --csv.
+var csvOutput bool
+
+// SetCSV switches table output to CSV.
+func SetCSV(enabled bool) {
+	csvOutput = enabled
+}
+
+// IsCSV returns true when tables are written as CSV (--csv).
+func IsCSV() bool {
+	return csvOutput
+}
+
+// jsonlOutput makes PrintJSON write one compact object per line. Set from
+// --jsonl.
+var jsonlOutput bool
+
+// SetJSONL switches JSON output to JSON lines.
+func SetJSONL(enabled bool) {
+	jsonlOutput = enabled
+}
+
+// IsJSONL returns true when JSON is written as one object per line (--jsonl).
+func IsJSONL() bool {
+	return jsonlOutput
+}
+
+// quiet makes list commands print only IDs. Set from --quiet.
+var quiet bool
+
+// SetQuiet switches list commands to printing IDs only.
+func SetQuiet(enabled bool) {
+	quiet = enabled
+}
+
+// IsQuiet returns true when list commands print only IDs (-q/--quiet).
+func IsQuiet() bool {
+	return quiet
+}
+
+// PrintIDs writes one ID per line, for -q/--quiet. No IDs print nothing.
+func PrintIDs(ids []string) error {
+	rowsWritten += len(ids)
+	w := bufio.NewWriter(out)
+	for _, id := range ids {
+		w.WriteString(id)
+		w.WriteByte('\n')
+	}
+	return w.Flush()
+}
+
 // IsJSON returns true when output should be JSON:
-// stdout is not a TTY (piped) OR --json/--pretty flag is set.
+// stdout is not a TTY (piped or --output) OR --json/--pretty flag is set.
+// --csv and --markdown win over the piped-stdout default. --format also
+// takes the JSON path, since that is where commands hand over their typed
+// rows.
 func IsJSON(cmd *cobra.Command) bool {
-	if !isatty.IsTerminal(os.Stdout.Fd()) && !isatty.IsCygwinTerminal(os.Stdout.Fd()) {
+	if rowTemplate != nil || jsonlOutput {
+		return true
+	}
+	if csvOutput || markdownOutput {
+		return false
+	}
+	if !toTerminal() {
 		return true
 	}
 	j, _ := cmd.Flags().GetBool("json")
@@ -28,62 +90,260 @@ func IsPretty(cmd *cobra.Command) bool {
 	pretty, _ := cmd.Flags().GetBool("pretty")
 	if !pretty {
 		isJSON, _ := cmd.Flags().GetBool("json")
-		if isJSON && isatty.IsTerminal(os.Stdout.Fd()) {
+		if isJSON && toTerminal() {
 			return true
 		}
 	}
 	return pretty
 }
 
-// PrintJSON encodes v as JSON to stdout.
+// PrintJSON encodes v as JSON to stdout, projected to --fields if set. With
+// --format each row of v is rendered with the template instead, and with
+// --jsonl each row is written as one line.
 func PrintJSON(v any, pretty bool) error {
-	enc := json.NewEncoder(os.Stdout)
+	if rowTemplate != nil {
+		return printFormatted(v)
+	}
+	v, err := Project(v)
+	if err != nil {
+		return err
+	}
+	if jsonlOutput {
+		return printJSONL(v)
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice {
+		rowsWritten += rv.Len()
+	} else {
+		rowsWritten++
+	}
+	if jsonMeta != nil {
+		if v, err = withMeta(v, jsonMeta()); err != nil {
+			return err
+		}
+	}
+	enc := json.NewEncoder(out)
 	if pretty {
 		enc.SetIndent("", "  ")
 	}
 	return enc.Encode(v)
 }
 
-// PrintTable writes a tab-aligned table to stdout.
-func PrintTable(headers []string, rows [][]string) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
-	defer w.Flush()
-	for i, h := range headers {
-		if i > 0 {
-			fmt.Fprint(w, "\t")
+// jsonMeta returns the "_meta" object added to JSON output, or is nil for
+// none. Set from --meta.
+var jsonMeta func() any
+
+// SetJSONMeta makes PrintJSON add the value of fn under a "_meta" key; nil
+// turns it off. JSON lines and --format output are left alone.
+func SetJSONMeta(fn func() any) {
+	jsonMeta = fn
+}
+
+// withMeta adds meta to v under "_meta": as the first key of an object, or
+// next to an array wrapped as {"data": [...]}.
+func withMeta(v, meta any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	m, err := json.Marshal(meta)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > 0 && b[0] == '{' {
+		obj := append([]byte(`{"_meta":`), m...)
+		if string(b) != "{}" {
+			obj = append(obj, ',')
+		}
+		return json.RawMessage(append(obj, b[1:]...)), nil
+	}
+	return struct {
+		Meta json.RawMessage `json:"_meta"`
+		Data json.RawMessage `json:"data"`
+	}{m, b}, nil
+}
+
+// printJSONL writes each element of v (or v itself when it is not a slice)
+// as one compact JSON line.
+func printJSONL(v any) error {
+	enc := json.NewEncoder(out)
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		rowsWritten++
+		return enc.Encode(v)
+	}
+	for i := 0; i < rv.Len(); i++ {
+		if err := enc.Encode(rv.Index(i).Interface()); err != nil {
+			return err
 		}
-		fmt.Fprint(w, h)
+		rowsWritten++
 	}
-	fmt.Fprintln(w)
+	return nil
+}
+
+// PrintJSONLSummary writes the --jsonl-summary trailer, e.g.
+// {"summary":{"rows":42,"ok":true}}, so a consumer can tell a complete
+// stream from one cut short by an error.
+func PrintJSONLSummary(cmdErr error) error {
+	summary := struct {
+		Rows  int    `json:"rows"`
+		OK    bool   `json:"ok"`
+		Error string `json:"error,omitempty"`
+	}{Rows: rowsWritten, OK: cmdErr == nil}
+	if cmdErr != nil {
+		summary.Error = cmdErr.Error()
+	}
+	return json.NewEncoder(out).Encode(map[string]any{"summary": summary})
+}
+
+// PrintTable writes a tab-aligned table to stdout, or CSV with --csv, or a
+// markdown table with --markdown. With --fields only the selected columns
+// are printed.
+func PrintTable(headers []string, rows [][]string) error {
+	if len(fieldPaths) > 0 {
+		var err error
+		if headers, rows, err = selectColumns(headers, rows); err != nil {
+			return err
+		}
+	}
+	if csvOutput {
+		return PrintCSV(headers, rows)
+	}
+	right := make([]bool, len(headers))
+	for i, h := range headers {
+		right[i] = isNumericColumn(h, rows, i)
+	}
+	if markdownOutput {
+		return printMarkdown(headers, rows, right)
+	}
+	rowsWritten += len(rows)
+	shown := fitColumns(headers, rows, right)
+	if highlightChanges {
+		shown = markChanges(headers, rows, shown)
+	}
+	return writeAligned(append([][]string{headers}, shown...), right)
+}
+
+// numericCell matches table numbers: counts, percentages, ratios and
+// amounts with a currency symbol or code ("$1,234.50", "1.234,50 €",
+// "5.00 EUR"), optionally followed by a "!" flag.
+var numericCell = regexp.MustCompile(`^[-+]?(\pL{0,3}\pS|\pL{1,3} ?)?[0-9][0-9,.]*(%|x)?( ?\pS| \pL{2,3}\.?)?( !)?$`)
+
+// isNumericColumn reports whether column i holds numbers, to right-align
+// it. Empty and "-" cells are ignored. ID columns are identifiers, not
+// quantities, and stay left-aligned.
+func isNumericColumn(header string, rows [][]string, i int) bool {
+	if header == "ID" || strings.HasSuffix(header, " ID") {
+		return false
+	}
+	found := false
 	for _, row := range rows {
-		for i, cell := range row {
-			if i > 0 {
-				fmt.Fprint(w, "\t")
+		if i >= len(row) {
+			continue
+		}
+		cell := stripANSI(row[i])
+		if cell == "" || cell == "-" {
+			continue
+		}
+		if !numericCell.MatchString(cell) {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+// writeAligned writes lines of cells in columns two spaces apart, padding
+// the columns marked in right on the left. Widths are measured on screen,
+// ignoring color, so colored, non-ASCII and wide cells line up.
+func writeAligned(lines [][]string, right []bool) error {
+	var widths []int
+	for _, line := range lines {
+		for i, cell := range line {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], displayWidth(cell))
+		}
+	}
+	w := bufio.NewWriter(out)
+	for _, line := range lines {
+		for i, cell := range line {
+			pad := strings.Repeat(" ", widths[i]-displayWidth(cell))
+			if i < len(right) && right[i] {
+				w.WriteString(pad)
+				w.WriteString(cell)
+			} else {
+				w.WriteString(cell)
+				if i < len(line)-1 {
+					w.WriteString(pad)
+				}
+			}
+			if i < len(line)-1 {
+				w.WriteString("  ")
 			}
-			fmt.Fprint(w, cell)
 		}
-		fmt.Fprintln(w)
+		w.WriteByte('\n')
 	}
+	return w.Flush()
 }
 
-// PrintKeyValue prints a two-column key-value table.
-func PrintKeyValue(rows [][]string) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
-	defer w.Flush()
-	for _, row := range rows {
-		if len(row) == 2 {
-			fmt.Fprintf(w, "%s\t%s\n", row[0], row[1])
+// PrintCSV writes headers and rows to stdout as RFC 4180 CSV.
+func PrintCSV(headers []string, rows [][]string) error {
+	rowsWritten += len(rows)
+	w := csv.NewWriter(out)
+	if err := w.Write(headers); err != nil {
+		return err
+	}
+	plain := make([][]string, len(rows))
+	for i, row := range rows {
+		plain[i] = make([]string, len(row))
+		for j, cell := range row {
+			plain[i][j] = stripANSI(cell)
 		}
 	}
+	if err := w.WriteAll(plain); err != nil {
+		return fmt.Errorf("writing CSV: %w", err)
+	}
+	return nil
 }
 
-// Truncate shortens a string to maxLen characters, adding "…" if truncated.
-func Truncate(s string, maxLen int) string {
-	runes := []rune(s)
-	if len(runes) <= maxLen {
-		return s
+// PrintKeyValue prints a two-column key-value table, or FIELD,VALUE CSV
+// with --csv. With --fields only the selected keys are printed.
+func PrintKeyValue(rows [][]string) error {
+	if len(fieldPaths) > 0 {
+		keys := make([]string, len(rows))
+		for i, row := range rows {
+			keys[i] = row[0]
+		}
+		selected, _, err := selectColumns(keys, nil)
+		if err != nil {
+			return err
+		}
+		var kept [][]string
+		for _, key := range selected {
+			for _, row := range rows {
+				if row[0] == key {
+					kept = append(kept, row)
+					break
+				}
+			}
+		}
+		rows = kept
+	}
+	if csvOutput {
+		return PrintCSV([]string{"FIELD", "VALUE"}, rows)
+	}
+	if markdownOutput {
+		return printMarkdown([]string{"FIELD", "VALUE"}, rows, nil)
+	}
+	rowsWritten += len(rows)
+	var lines [][]string
+	for _, row := range rows {
+		if len(row) == 2 {
+			lines = append(lines, row)
+		}
 	}
-	return string(runes[:maxLen-1]) + "…"
+	return writeAligned(lines, nil)
 }
 
 // FormatTime formats an ISO-8601 timestamp to "YYYY-MM-DD HH:MM" or returns "-".
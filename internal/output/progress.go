@@ -0,0 +1,78 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/mattn/go-isatty"
+)
+
+// progressDelay keeps quick fetches from flashing a spinner.
+const progressDelay = 500 * time.Millisecond
+
+var spinnerFrames = []rune("⠋⠙⠹⠸⠼⠴⠦⠧⠇⠏")
+
+// progress draws a spinner and a status line on stderr while data is
+// fetched.
+type progress struct {
+	mu      sync.Mutex
+	status  string
+	started time.Time
+	running bool // the drawing goroutine is alive
+	shown   bool // a status line is on screen
+}
+
+// NewProgress returns a function that shows fetch progress on stderr, as
+// taken by api.Client.SetProgress, or nil when no one is watching: stderr
+// or stdout is not a terminal (e.g. piped JSON) or --quiet is set.
+func NewProgress() func(status string) {
+	if quiet || !toTerminal() || !isatty.IsTerminal(os.Stderr.Fd()) {
+		return nil
+	}
+	p := &progress{}
+	return p.update
+}
+
+// update sets the status shown next to the spinner; "" clears the line.
+func (p *progress) update(status string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if status == "" {
+		p.status = ""
+		if p.shown {
+			fmt.Fprint(os.Stderr, "\r\x1b[K")
+			p.shown = false
+		}
+		return
+	}
+	if p.status == "" {
+		p.started = time.Now()
+	}
+	p.status = status
+	if !p.running {
+		p.running = true
+		go p.run()
+	}
+}
+
+// run redraws the status line until the status is cleared.
+func (p *progress) run() {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for frame := 0; ; frame++ {
+		<-ticker.C
+		p.mu.Lock()
+		if p.status == "" {
+			p.running = false
+			p.mu.Unlock()
+			return
+		}
+		if time.Since(p.started) >= progressDelay {
+			fmt.Fprintf(os.Stderr, "\r%c %s\x1b[K", spinnerFrames[frame%len(spinnerFrames)], p.status)
+			p.shown = true
+		}
+		p.mu.Unlock()
+	}
+}
@@ -0,0 +1,204 @@
+package output
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/term"
+)
+
+// wide disables fitting tables to the terminal. Set from --wide.
+var wide bool
+
+// SetWide turns off truncation of table cells.
+func SetWide(enabled bool) {
+	wide = enabled
+}
+
+// minFlexWidth is the narrowest a text column is shrunk to, unless its
+// header is wider.
+const minFlexWidth = 8
+
+// terminalWidth returns the width of the terminal results go to, or 0 when
+// they don't go to one. COLUMNS overrides the detected width.
+func terminalWidth() int {
+	if !toTerminal() {
+		return 0
+	}
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	w, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return 0
+	}
+	return w
+}
+
+// fitColumns truncates cells of the widest text columns until the table
+// fits the terminal. Numeric (right-aligned) and ID columns are never cut.
+// With --wide, or off a terminal, rows are returned unchanged.
+func fitColumns(headers []string, rows [][]string, right []bool) [][]string {
+	limit := terminalWidth()
+	if wide || limit == 0 {
+		return rows
+	}
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = displayWidth(h)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if i < len(widths) {
+				widths[i] = max(widths[i], displayWidth(cell))
+			}
+		}
+	}
+	natural := append([]int(nil), widths...)
+	total := 2 * (len(widths) - 1)
+	for _, w := range widths {
+		total += w
+	}
+	for total > limit {
+		widest := -1
+		for i, h := range headers {
+			if right[i] || h == "ID" || strings.HasSuffix(h, " ID") {
+				continue
+			}
+			if widths[i] <= max(minFlexWidth, displayWidth(h)) {
+				continue
+			}
+			if widest < 0 || widths[i] > widths[widest] {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			break
+		}
+		widths[widest]--
+		total--
+	}
+
+	fitted := make([][]string, len(rows))
+	for r, row := range rows {
+		fitted[r] = row
+		copied := false
+		for i, cell := range row {
+			if i >= len(widths) || widths[i] == natural[i] || displayWidth(cell) <= widths[i] {
+				continue
+			}
+			if !copied {
+				fitted[r] = append([]string(nil), row...)
+				copied = true
+			}
+			fitted[r][i] = Truncate(stripANSI(cell), widths[i])
+		}
+	}
+	return fitted
+}
+
+// displayWidth is the number of terminal columns s occupies, ignoring color.
+func displayWidth(s string) int {
+	n := 0
+	for _, g := range graphemes(stripANSI(s)) {
+		n += clusterWidth(g)
+	}
+	return n
+}
+
+// graphemes splits s into user-perceived characters, approximating Unicode
+// grapheme clusters: a base character with its combining marks, variation
+// selectors and skin-tone modifiers, emoji joined by ZWJ, and flag pairs.
+func graphemes(s string) []string {
+	runes := []rune(s)
+	var clusters []string
+	for i := 0; i < len(runes); {
+		j := i + 1
+		for j < len(runes) {
+			switch {
+			case extendsCluster(runes[j]), runes[j-1] == '\u200d':
+				j++
+				continue
+			case j == i+1 && isRegionalIndicator(runes[i]) && isRegionalIndicator(runes[j]):
+				j++
+				continue
+			}
+			break
+		}
+		clusters = append(clusters, string(runes[i:j]))
+		i = j
+	}
+	return clusters
+}
+
+// extendsCluster reports whether r attaches to the character before it.
+func extendsCluster(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me) ||
+		r == '\u200d' ||
+		(r >= 0xfe00 && r <= 0xfe0f) ||
+		(r >= 0x1f3fb && r <= 0x1f3ff)
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
+
+// clusterWidth is the number of terminal columns a grapheme cluster
+// occupies: two for wide characters, emoji and flags, otherwise one.
+func clusterWidth(g string) int {
+	runes := []rune(g)
+	if len(runes) == 0 {
+		return 0
+	}
+	if len(runes) > 1 && (isRegionalIndicator(runes[0]) || strings.ContainsRune(g, '\ufe0f')) {
+		return 2
+	}
+	if extendsCluster(runes[0]) {
+		return 0
+	}
+	return runeWidth(runes[0])
+}
+
+// runeWidth is the number of terminal columns r occupies: two for East
+// Asian wide characters and emoji, otherwise one.
+func runeWidth(r rune) int {
+	switch {
+	case r >= 0x1100 && r <= 0x115f,
+		r >= 0x2e80 && r <= 0xa4cf && r != 0x303f,
+		r >= 0xac00 && r <= 0xd7a3,
+		r >= 0xf900 && r <= 0xfaff,
+		r >= 0xfe30 && r <= 0xfe4f,
+		r >= 0xff00 && r <= 0xff60,
+		r >= 0xffe0 && r <= 0xffe6,
+		r >= 0x1f300 && r <= 0x1f64f,
+		r >= 0x1f900 && r <= 0x1f9ff,
+		r >= 0x20000 && r <= 0x3fffd:
+		return 2
+	}
+	return 1
+}
+
+// Truncate shortens s to at most maxLen terminal columns, adding "…" if
+// truncated. It cuts between whole characters (grapheme clusters), so
+// accented, CJK and emoji text is never split mid-character.
+func Truncate(s string, maxLen int) string {
+	if displayWidth(s) <= maxLen {
+		return s
+	}
+	if maxLen < 1 {
+		return ""
+	}
+	var b strings.Builder
+	width := 0
+	for _, g := range graphemes(s) {
+		w := clusterWidth(g)
+		if width+w > maxLen-1 {
+			break
+		}
+		b.WriteString(g)
+		width += w
+	}
+	return b.String() + "…"
+}
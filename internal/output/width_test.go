@@ -0,0 +1,95 @@
+package output
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want int
+	}{
+		{"empty", "", 0},
+		{"ASCII", "Running shoes", 13},
+		{"accented, precomposed", "café", 4},
+		{"combining acute", "café", 4},
+		{"several combining marks", "ạ́b", 2},
+		{"CJK", "日本語", 6},
+		{"Hangul", "한국어", 6},
+		{"fullwidth Latin", "ＡＢ", 4},
+		{"mixed CJK and ASCII", "Tokyo 東京", 10},
+		{"emoji", "🎉", 2},
+		{"emoji with skin tone", "👍🏽", 2},
+		{"emoji presentation selector", "❤️", 2},
+		{"ZWJ family", "👨‍👩‍👧", 2},
+		{"ZWJ sequence in text", "team 👩‍💻 ok", 10},
+		{"flag", "🇫🇷", 2},
+		{"two flags", "🇫🇷🇩🇪", 4},
+		{"colored", "\x1b[31mENABLED\x1b[0m", 7},
+	}
+	for _, tt := range tests {
+		if got := displayWidth(tt.s); got != tt.want {
+			t.Errorf("%s: displayWidth(%q) = %d, want %d", tt.name, tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestGraphemes(t *testing.T) {
+	tests := []struct {
+		s    string
+		want []string
+	}{
+		{"abc", []string{"a", "b", "c"}},
+		{"ét́", []string{"é", "t́"}},
+		{"👨‍👩‍👧!", []string{"👨‍👩‍👧", "!"}},
+		{"👍🏽👍", []string{"👍🏽", "👍"}},
+		{"🇫🇷🇩🇪🇮", []string{"🇫🇷", "🇩🇪", "🇮"}},
+		{"❤️x", []string{"❤️", "x"}},
+	}
+	for _, tt := range tests {
+		if got := graphemes(tt.s); !slices.Equal(got, tt.want) {
+			t.Errorf("graphemes(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name   string
+		s      string
+		maxLen int
+		want   string
+	}{
+		{"fits", "hello", 5, "hello"},
+		{"ASCII", "hello world", 5, "hell…"},
+		{"zero width", "abc", 0, ""},
+		{"only the ellipsis", "ab", 1, "…"},
+		{"CJK on a boundary", "日本語テキスト", 5, "日本…"},
+		{"CJK leaves a column free", "日本語", 4, "日…"},
+		{"CJK fits", "日本語", 6, "日本語"},
+		{"combining marks stay with their letter", "éééé", 3, "éé…"},
+		{"emoji", "🎉🎉🎉", 4, "🎉…"},
+		{"skin tone kept whole", "👍🏽👍🏽", 3, "👍🏽…"},
+		{"ZWJ sequence kept whole", "👨‍👩‍👧👨‍👩‍👧", 3, "👨‍👩‍👧…"},
+		{"ZWJ sequence dropped whole", "ab👨‍👩‍👧", 3, "ab…"},
+		{"flags kept in pairs", "🇫🇷🇩🇪🇮🇹", 5, "🇫🇷🇩🇪…"},
+		{"mixed", "Tokyo 東京 store", 9, "Tokyo 東…"},
+	}
+	for _, tt := range tests {
+		got := Truncate(tt.s, tt.maxLen)
+		if got != tt.want {
+			t.Errorf("%s: Truncate(%q, %d) = %q, want %q", tt.name, tt.s, tt.maxLen, got, tt.want)
+		}
+		if w := displayWidth(got); w > tt.maxLen {
+			t.Errorf("%s: Truncate(%q, %d) is %d columns wide", tt.name, tt.s, tt.maxLen, w)
+		}
+		// The kept text is whole clusters of s.
+		kept := graphemes(strings.TrimSuffix(got, "…"))
+		if all := graphemes(tt.s); len(kept) > len(all) || !slices.Equal(kept, all[:len(kept)]) {
+			t.Errorf("%s: Truncate(%q, %d) = %q splits a character", tt.name, tt.s, tt.maxLen, got)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// Package version reports the gads-cli build version.
+package version
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
+
+// Version, Commit and Date are set at build time:
+//
+//	go build -ldflags "-X github.com/the20100/gads-cli/internal/version.Version=v1.2.3 \
+//	  -X github.com/the20100/gads-cli/internal/version.Commit=$(git rev-parse HEAD) \
+//	  -X github.com/the20100/gads-cli/internal/version.Date=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	Version = "dev"
+	Commit  = ""
+	Date    = ""
+)
+
+// String returns Version, or for untagged builds the VCS revision recorded by
+// the Go toolchain (e.g. "dev+3f2a1b9c0d1e").
+func String() string {
+	if Version != "dev" {
+		return Version
+	}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return Version
+	}
+	if v := info.Main.Version; v != "" && v != "(devel)" {
+		return v
+	}
+	if rev := buildSetting("vcs.revision"); len(rev) >= 12 {
+		return Version + "+" + rev[:12]
+	}
+	return Version
+}
+
+// CommitHash returns Commit, or the VCS revision recorded by the Go
+// toolchain, with "-dirty" for a modified tree; "" when unknown.
+func CommitHash() string {
+	if Commit != "" {
+		return Commit
+	}
+	rev := buildSetting("vcs.revision")
+	if rev != "" && buildSetting("vcs.modified") == "true" {
+		rev += "-dirty"
+	}
+	return rev
+}
+
+// BuildDate returns Date, or the commit time recorded by the Go toolchain;
+// "" when unknown.
+func BuildDate() string {
+	if Date != "" {
+		return Date
+	}
+	return buildSetting("vcs.time")
+}
+
+func buildSetting(key string) string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	for _, s := range info.Settings {
+		if s.Key == key {
+			return s.Value
+		}
+	}
+	return ""
+}
+
+// UserAgent returns the User-Agent sent with API requests, e.g.
+// "gads-cli/v1.2.3 (linux/amd64)".
+func UserAgent() string {
+	return fmt.Sprintf("gads-cli/%s (%s/%s)", String(), runtime.GOOS, runtime.GOARCH)
+}
+
+// Newer reports whether release tag latest (e.g. "v1.3.0") is a newer
+// semantic version than current. It is false when either is not a version,
+// such as a dev build. A pre-release is older than its release.
+func Newer(latest, current string) bool {
+	l, lok := parse(latest)
+	c, cok := parse(current)
+	if !lok || !cok {
+		return false
+	}
+	for i := 0; i < 3; i++ {
+		if l.parts[i] != c.parts[i] {
+			return l.parts[i] > c.parts[i]
+		}
+	}
+	return c.pre != "" && (l.pre == "" || l.pre > c.pre)
+}
+
+type semver struct {
+	parts [3]int
+	pre   string
+}
+
+// parse reads "v1.2.3", "1.2" or "v1.2.3-rc.1"; build metadata is ignored.
+func parse(v string) (semver, bool) {
+	v = strings.TrimPrefix(v, "v")
+	v, _, _ = strings.Cut(v, "+")
+	v, pre, _ := strings.Cut(v, "-")
+	fields := strings.Split(v, ".")
+	if len(fields) == 0 || len(fields) > 3 {
+		return semver{}, false
+	}
+	s := semver{pre: pre}
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 {
+			return semver{}, false
+		}
+		s.parts[i] = n
+	}
+	return s, true
+}
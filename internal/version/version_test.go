@@ -0,0 +1,36 @@
+package version
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// setVersion sets Version for one test.
+func setVersion(t *testing.T, v string) {
+	t.Helper()
+	saved := Version
+	t.Cleanup(func() { Version = saved })
+	Version = v
+}
+
+func TestUserAgent(t *testing.T) {
+	setVersion(t, "v1.2.3")
+	want := "gads-cli/v1.2.3 (" + runtime.GOOS + "/" + runtime.GOARCH + ")"
+	if got := UserAgent(); got != want {
+		t.Errorf("UserAgent() = %q, want %q", got, want)
+	}
+}
+
+func TestUserAgentDevBuild(t *testing.T) {
+	setVersion(t, "dev")
+	ua := UserAgent()
+	if !strings.HasPrefix(ua, "gads-cli/dev") || !strings.HasSuffix(ua, " ("+runtime.GOOS+"/"+runtime.GOARCH+")") {
+		t.Errorf("UserAgent() = %q", ua)
+	}
+	// A product token may not contain spaces or separators.
+	product, _, _ := strings.Cut(ua, " ")
+	if strings.ContainsAny(product, "()<>@,;:\\\"[]?={}\t") {
+		t.Errorf("product token %q is not a valid token", product)
+	}
+}